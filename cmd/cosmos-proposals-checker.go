@@ -4,6 +4,9 @@ import (
 	"main/pkg"
 	"main/pkg/fs"
 	"main/pkg/logger"
+	"main/pkg/report"
+	"os"
+	"time"
 
 	"github.com/spf13/cobra"
 )
@@ -18,6 +21,34 @@ func ExecuteMain(configPath string) {
 	app.Start()
 }
 
+func ExecuteBackfill(configPath string) {
+	filesystem := &fs.OsFS{}
+	app := pkg.NewApp(configPath, filesystem, version)
+	app.Backfill()
+}
+
+func ExecuteCheck(configPath string, format string, dryRun bool) int {
+	if err := report.ValidatePrintFormat(format); err != nil {
+		logger.GetDefaultLogger().Panic().Err(err).Msg("Invalid output format!")
+	}
+
+	filesystem := &fs.OsFS{}
+	app := pkg.NewAppWithLogsOutput(configPath, filesystem, version, os.Stderr)
+	generatedReport := app.Check(dryRun)
+
+	timezone, _ := time.LoadLocation(app.Config.Timezone)
+	printer := report.NewPrinter(app.Logger, timezone, os.Stdout)
+	if err := printer.Print(generatedReport, format); err != nil {
+		app.Logger.Panic().Err(err).Msg("Could not print the report")
+	}
+
+	if generatedReport.HasNotVotedEntries() {
+		return 1
+	}
+
+	return 0
+}
+
 func ExecuteValidateConfig(configPath string) {
 	filesystem := &fs.OsFS{}
 
@@ -38,7 +69,11 @@ func ExecuteValidateConfig(configPath string) {
 }
 
 func main() {
-	var ConfigPath string
+	var (
+		ConfigPath  string
+		CheckFormat string
+		CheckDryRun bool
+	)
 
 	rootCmd := &cobra.Command{
 		Use:     "cosmos-proposals-checker --config [config path]",
@@ -58,6 +93,26 @@ func main() {
 		},
 	}
 
+	backfillCmd := &cobra.Command{
+		Use:     "backfill --config [config path]",
+		Long:    "Stores the current proposals and votes state in the database without sending any alerts.",
+		Version: version,
+		Run: func(cmd *cobra.Command, args []string) {
+			ExecuteBackfill(ConfigPath)
+		},
+	}
+
+	checkCmd := &cobra.Command{
+		Use:     "check --config [config path] [--format text|json] [--dry-run]",
+		Long:    "Checks for proposal votes once, prints the results and exits with a non-zero code if any wallet hasn't voted.",
+		Version: version,
+		Run: func(cmd *cobra.Command, args []string) {
+			if exitCode := ExecuteCheck(ConfigPath, CheckFormat, CheckDryRun); exitCode != 0 {
+				os.Exit(exitCode)
+			}
+		},
+	}
+
 	rootCmd.PersistentFlags().StringVar(&ConfigPath, "config", "", "Config file path")
 	_ = rootCmd.MarkPersistentFlagRequired("config")
 
@@ -65,6 +120,16 @@ func main() {
 	_ = validateConfigCmd.MarkPersistentFlagRequired("config")
 	rootCmd.AddCommand(validateConfigCmd)
 
+	backfillCmd.PersistentFlags().StringVar(&ConfigPath, "config", "", "Config file path")
+	_ = backfillCmd.MarkPersistentFlagRequired("config")
+	rootCmd.AddCommand(backfillCmd)
+
+	checkCmd.PersistentFlags().StringVar(&ConfigPath, "config", "", "Config file path")
+	checkCmd.PersistentFlags().StringVar(&CheckFormat, "format", report.PrintFormatText, "Output format: text or json")
+	checkCmd.PersistentFlags().BoolVar(&CheckDryRun, "dry-run", false, "Do not send anything or write to the database")
+	_ = checkCmd.MarkPersistentFlagRequired("config")
+	rootCmd.AddCommand(checkCmd)
+
 	if err := rootCmd.Execute(); err != nil {
 		logger.GetDefaultLogger().Panic().Err(err).Msg("Could not start application")
 	}
@@ -82,3 +82,63 @@ func TestStartConfigProvided(t *testing.T) {
 	os.Args = []string{"cmd", "--config", "../assets/config-invalid.toml"}
 	main()
 }
+
+//nolint:paralleltest // disabled
+func TestBackfillNoConfigProvided(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			require.Fail(t, "Expected to have a panic here!")
+		}
+	}()
+
+	os.Args = []string{"cmd", "backfill"}
+	main()
+}
+
+//nolint:paralleltest // disabled
+func TestBackfillInvalidConfig(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			require.Fail(t, "Expected to have a panic here!")
+		}
+	}()
+
+	os.Args = []string{"cmd", "backfill", "--config", "../assets/config-invalid.toml"}
+	main()
+}
+
+//nolint:paralleltest // disabled
+func TestCheckNoConfigProvided(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			require.Fail(t, "Expected to have a panic here!")
+		}
+	}()
+
+	os.Args = []string{"cmd", "check"}
+	main()
+}
+
+//nolint:paralleltest // disabled
+func TestCheckInvalidFormat(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			require.Fail(t, "Expected to have a panic here!")
+		}
+	}()
+
+	os.Args = []string{"cmd", "check", "--config", "../assets/config-valid.toml", "--format", "xml"}
+	main()
+}
+
+//nolint:paralleltest // disabled
+func TestCheckInvalidConfig(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			require.Fail(t, "Expected to have a panic here!")
+		}
+	}()
+
+	os.Args = []string{"cmd", "check", "--config", "../assets/config-invalid.toml", "--dry-run"}
+	main()
+}
@@ -2,5 +2,5 @@ package migrations
 
 import "embed"
 
-//go:embed **.sql
+//go:embed sqlite/*.sql postgres/*.sql
 var EmbedFS embed.FS
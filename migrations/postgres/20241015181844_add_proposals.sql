@@ -0,0 +1,13 @@
+-- +goose Up
+CREATE TABLE proposals (
+    chain TEXT NOT NULL,
+    id TEXT NOT NULL,
+    title TEXT NOT NULL,
+    description TEXT NOT NULL,
+    status TEXT NOT NULL,
+    end_time TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (chain, id)
+);
+
+-- +goose Down
+DROP TABLE proposals;
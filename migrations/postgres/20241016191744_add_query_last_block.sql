@@ -0,0 +1,10 @@
+-- +goose Up
+CREATE TABLE query_last_block (
+    chain TEXT NOT NULL,
+    query TEXT NOT NULL,
+    height BIGINT NOT NULL,
+    PRIMARY KEY (chain, query)
+);
+
+-- +goose Down
+DROP TABLE query_last_block;
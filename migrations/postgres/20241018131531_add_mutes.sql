@@ -0,0 +1,14 @@
+-- +goose Up
+CREATE TABLE mutes (
+    chain TEXT,
+    proposal_id TEXT,
+    expires TIMESTAMPTZ NOT NULL,
+    comment TEXT
+);
+
+-- chain and proposal_id can be NULL, and NULLs are distinct in a regular unique index,
+-- so this one makes mutes with empty labels conflict as well.
+CREATE UNIQUE INDEX mutes_chain_proposal_id ON mutes (COALESCE(chain, ''), COALESCE(proposal_id, ''));
+
+-- +goose Down
+DROP TABLE mutes;
@@ -0,0 +1,10 @@
+-- +goose Up
+ALTER TABLE mutes ADD COLUMN destination TEXT;
+DROP INDEX mutes_chain_proposal_id;
+CREATE UNIQUE INDEX mutes_labels ON mutes (COALESCE(chain, ''), COALESCE(proposal_id, ''), COALESCE(destination, ''));
+
+-- +goose Down
+DROP INDEX mutes_labels;
+DELETE FROM mutes WHERE destination IS NOT NULL;
+ALTER TABLE mutes DROP COLUMN destination;
+CREATE UNIQUE INDEX mutes_chain_proposal_id ON mutes (COALESCE(chain, ''), COALESCE(proposal_id, ''));
@@ -0,0 +1,27 @@
+-- +goose Up
+ALTER TABLE mutes ADD COLUMN author TEXT;
+ALTER TABLE mutes ADD COLUMN source TEXT;
+ALTER TABLE mutes ADD COLUMN created_at TIMESTAMPTZ;
+
+-- Deleted and expired mutes are moved here, so it's possible to find out
+-- why notifications were not sent at some point.
+CREATE TABLE mutes_history (
+    chain TEXT,
+    proposal_id TEXT,
+    destination TEXT,
+    expires TIMESTAMPTZ NOT NULL,
+    comment TEXT,
+    author TEXT,
+    source TEXT,
+    created_at TIMESTAMPTZ,
+    reason TEXT NOT NULL,
+    removed_at TIMESTAMPTZ NOT NULL,
+    removed_by TEXT
+);
+CREATE INDEX mutes_history_removed_at ON mutes_history (removed_at);
+
+-- +goose Down
+DROP TABLE mutes_history;
+ALTER TABLE mutes DROP COLUMN created_at;
+ALTER TABLE mutes DROP COLUMN source;
+ALTER TABLE mutes DROP COLUMN author;
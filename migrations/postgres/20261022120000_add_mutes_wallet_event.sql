@@ -0,0 +1,17 @@
+-- +goose Up
+ALTER TABLE mutes ADD COLUMN wallet TEXT;
+ALTER TABLE mutes ADD COLUMN event TEXT;
+ALTER TABLE mutes_history ADD COLUMN wallet TEXT;
+ALTER TABLE mutes_history ADD COLUMN event TEXT;
+DROP INDEX mutes_labels;
+CREATE UNIQUE INDEX mutes_labels ON mutes (COALESCE(chain, ''), COALESCE(proposal_id, ''), COALESCE(wallet, ''), COALESCE(event, ''), COALESCE(destination, ''));
+
+-- +goose Down
+DROP INDEX mutes_labels;
+DELETE FROM mutes WHERE wallet IS NOT NULL OR event IS NOT NULL;
+DELETE FROM mutes_history WHERE wallet IS NOT NULL OR event IS NOT NULL;
+ALTER TABLE mutes_history DROP COLUMN event;
+ALTER TABLE mutes_history DROP COLUMN wallet;
+ALTER TABLE mutes DROP COLUMN event;
+ALTER TABLE mutes DROP COLUMN wallet;
+CREATE UNIQUE INDEX mutes_labels ON mutes (COALESCE(chain, ''), COALESCE(proposal_id, ''), COALESCE(destination, ''));
@@ -0,0 +1,13 @@
+-- +goose Up
+ALTER TABLE mutes ADD COLUMN title_pattern TEXT;
+ALTER TABLE mutes_history ADD COLUMN title_pattern TEXT;
+DROP INDEX mutes_labels;
+CREATE UNIQUE INDEX mutes_labels ON mutes (COALESCE(chain, ''), COALESCE(proposal_id, ''), COALESCE(wallet, ''), COALESCE(event, ''), COALESCE(title_pattern, ''), COALESCE(destination, ''));
+
+-- +goose Down
+DROP INDEX mutes_labels;
+DELETE FROM mutes WHERE title_pattern IS NOT NULL;
+DELETE FROM mutes_history WHERE title_pattern IS NOT NULL;
+ALTER TABLE mutes_history DROP COLUMN title_pattern;
+ALTER TABLE mutes DROP COLUMN title_pattern;
+CREATE UNIQUE INDEX mutes_labels ON mutes (COALESCE(chain, ''), COALESCE(proposal_id, ''), COALESCE(wallet, ''), COALESCE(event, ''), COALESCE(destination, ''));
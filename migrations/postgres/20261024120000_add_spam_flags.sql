@@ -0,0 +1,14 @@
+-- +goose Up
+-- Reasons are stored separated by newlines.
+CREATE TABLE spam_flags (
+    chain TEXT NOT NULL,
+    proposal_id TEXT NOT NULL,
+    reasons TEXT NOT NULL,
+    flagged BOOLEAN NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL,
+    updated_by TEXT,
+    PRIMARY KEY (chain, proposal_id)
+);
+
+-- +goose Down
+DROP TABLE spam_flags;
@@ -0,0 +1,21 @@
+-- +goose Up
+-- Report entries are stored here before sending, and deleted once delivered,
+-- so failed sends can be retried.
+CREATE TABLE outbox (
+    id BIGSERIAL PRIMARY KEY,
+    reporter TEXT NOT NULL,
+    event TEXT NOT NULL,
+    chain TEXT,
+    proposal_id TEXT,
+    wallet TEXT,
+    payload TEXT NOT NULL,
+    status TEXT NOT NULL,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT,
+    next_attempt_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL
+);
+CREATE INDEX outbox_status_next_attempt_at ON outbox (status, next_attempt_at);
+
+-- +goose Down
+DROP TABLE outbox;
@@ -0,0 +1,9 @@
+-- +goose Up
+CREATE TABLE dashboards (
+    destination TEXT NOT NULL PRIMARY KEY,
+    message_id TEXT NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL
+);
+
+-- +goose Down
+DROP TABLE dashboards;
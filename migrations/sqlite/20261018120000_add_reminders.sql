@@ -0,0 +1,12 @@
+-- +goose Up
+CREATE TABLE reminders (
+    chain TEXT NOT NULL,
+    proposal_id TEXT NOT NULL,
+    wallet TEXT NOT NULL,
+    reminder TEXT NOT NULL,
+    sent_at TIMESTAMP NOT NULL,
+    PRIMARY KEY (chain, proposal_id, wallet, reminder)
+);
+
+-- +goose Down
+DROP TABLE reminders;
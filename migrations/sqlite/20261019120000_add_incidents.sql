@@ -0,0 +1,13 @@
+-- +goose Up
+CREATE TABLE incidents (
+    reporter TEXT NOT NULL,
+    chain TEXT NOT NULL,
+    proposal_id TEXT NOT NULL,
+    wallet TEXT NOT NULL,
+    dedup_key TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    PRIMARY KEY (reporter, dedup_key)
+);
+
+-- +goose Down
+DROP TABLE incidents;
@@ -0,0 +1,27 @@
+-- +goose Up
+-- SQLite can't change the primary key of an existing table, so it's recreated.
+-- NULLs are distinct in a primary key, so the unique index below is used instead,
+-- making mutes with empty labels conflict as well.
+CREATE TABLE mutes_new (
+    chain TEXT,
+    proposal_id TEXT,
+    destination TEXT,
+    expires TIMESTAMP NOT NULL,
+    comment TEXT
+);
+INSERT INTO mutes_new (chain, proposal_id, expires, comment) SELECT chain, proposal_id, expires, comment FROM mutes;
+DROP TABLE mutes;
+ALTER TABLE mutes_new RENAME TO mutes;
+CREATE UNIQUE INDEX mutes_labels ON mutes (COALESCE(chain, ''), COALESCE(proposal_id, ''), COALESCE(destination, ''));
+
+-- +goose Down
+CREATE TABLE mutes_old (
+    chain TEXT,
+    proposal_id TEXT,
+    expires TIMESTAMP NOT NULL,
+    comment TEXT,
+    PRIMARY KEY (chain, proposal_id)
+);
+INSERT INTO mutes_old (chain, proposal_id, expires, comment) SELECT chain, proposal_id, expires, comment FROM mutes WHERE destination IS NULL;
+DROP TABLE mutes;
+ALTER TABLE mutes_old RENAME TO mutes;
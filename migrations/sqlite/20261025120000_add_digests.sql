@@ -0,0 +1,16 @@
+-- +goose Up
+-- Only the last sent digest is stored.
+CREATE TABLE digests (
+    sent_at TIMESTAMP NOT NULL
+);
+CREATE TABLE digest_votes (
+    chain TEXT NOT NULL,
+    proposal_id TEXT NOT NULL,
+    wallet TEXT NOT NULL,
+    vote TEXT NOT NULL,
+    PRIMARY KEY (chain, proposal_id, wallet)
+);
+
+-- +goose Down
+DROP TABLE digest_votes;
+DROP TABLE digests;
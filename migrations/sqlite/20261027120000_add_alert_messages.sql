@@ -0,0 +1,14 @@
+-- +goose Up
+CREATE TABLE alert_messages (
+    destination TEXT NOT NULL,
+    chain TEXT NOT NULL,
+    proposal_id TEXT NOT NULL,
+    wallet TEXT NOT NULL,
+    message_id TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL,
+    PRIMARY KEY (destination, chain, proposal_id, wallet)
+);
+
+-- +goose Down
+DROP TABLE alert_messages;
@@ -0,0 +1,5 @@
+-- +goose Up
+ALTER TABLE proposals ADD COLUMN expedited BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- +goose Down
+ALTER TABLE proposals DROP COLUMN expedited;
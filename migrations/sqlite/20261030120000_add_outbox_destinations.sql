@@ -0,0 +1,6 @@
+-- +goose Up
+-- Chats or channels the entry failed to be sent to, so only these are retried.
+ALTER TABLE outbox ADD COLUMN destinations TEXT;
+
+-- +goose Down
+ALTER TABLE outbox DROP COLUMN destinations;
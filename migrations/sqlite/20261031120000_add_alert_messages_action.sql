@@ -0,0 +1,6 @@
+-- +goose Up
+-- Action taken from the alert buttons, like acknowledging it, kept when the message is edited.
+ALTER TABLE alert_messages ADD COLUMN action TEXT;
+
+-- +goose Down
+ALTER TABLE alert_messages DROP COLUMN action;
@@ -0,0 +1,97 @@
+package alerts
+
+import (
+	"errors"
+	databasePkg "main/pkg/database"
+	"main/pkg/events"
+	"main/pkg/report/entry"
+	"main/pkg/types"
+	"time"
+
+	"github.com/guregu/null/v5"
+	"github.com/rs/zerolog"
+)
+
+// Manager stores the messages sent for wallet-related alerts, so the reporters
+// can edit them with the newer state instead of sending new messages.
+type Manager struct {
+	Database         databasePkg.Database
+	Logger           zerolog.Logger
+	RemindersEnabled bool
+}
+
+func NewManager(
+	logger *zerolog.Logger,
+	database databasePkg.Database,
+	reminders []types.Duration,
+) *Manager {
+	return &Manager{
+		Database:         database,
+		Logger:           logger.With().Str("component", "alerts_manager").Logger(),
+		RemindersEnabled: len(reminders) > 0,
+	}
+}
+
+// ShouldEdit returns whether the entry that is due to be sent should edit the message
+// sent before instead of sending a new one. With reminders, the due "wallet hasn't voted"
+// alerts are reminders, so they are sent as new messages to notify about them,
+// and the messages are only edited between the reminders.
+func (m *Manager) ShouldEdit(reportEntry entry.ReportEntry) bool {
+	_, isNotVoted := reportEntry.(events.NotVotedEvent)
+	return !isNotVoted || !m.RemindersEnabled
+}
+
+func (m *Manager) Get(destination string, reportEntry entry.ReportEntryNotError) (*types.AlertMessage, error) {
+	return m.Database.GetAlertMessage(
+		destination,
+		reportEntry.GetChain().Name,
+		reportEntry.GetProposal().ID,
+		reportEntry.GetWallet().Address,
+	)
+}
+
+// Save stores the message sent for the entry, or updates the stored one if it was edited,
+// keeping the action taken from its buttons so the next edits still show it.
+func (m *Manager) Save(
+	destination string,
+	reportEntry entry.ReportEntryNotError,
+	messageID string,
+	action null.String,
+) error {
+	now := time.Now()
+
+	return m.Database.UpsertAlertMessage(&types.AlertMessage{
+		Destination: destination,
+		Chain:       reportEntry.GetChain().Name,
+		ProposalID:  reportEntry.GetProposal().ID,
+		Wallet:      reportEntry.GetWallet().Address,
+		MessageID:   messageID,
+		Action:      action,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	})
+}
+
+// SetAction stores the action taken from the alert buttons, like acknowledging it.
+func (m *Manager) SetAction(destination, messageID, action string) error {
+	return m.Database.SetAlertMessageAction(destination, messageID, action)
+}
+
+func (m *Manager) Delete(message *types.AlertMessage) error {
+	return m.Database.DeleteAlertMessage(message)
+}
+
+// DeleteForProposal removes the messages for a proposal that has finished voting,
+// as there would be no newer alerts to edit them with. Only the messages sent to the given
+// destinations are removed, so other reporters can still edit theirs if they send the entry later.
+func (m *Manager) DeleteForProposal(destinations []string, chain, proposalID string) error {
+	errs := make([]error, 0)
+
+	for _, destination := range destinations {
+		if err := m.Database.DeleteProposalAlertMessages(destination, chain, proposalID); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,86 @@
+package alerts
+
+import (
+	"errors"
+	databasePkg "main/pkg/database"
+	"main/pkg/events"
+	loggerPkg "main/pkg/logger"
+	"main/pkg/types"
+	"testing"
+	"time"
+
+	"github.com/guregu/null/v5"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAlertsManagerSaveAndGet(t *testing.T) {
+	t.Parallel()
+
+	database := &databasePkg.StubDatabase{}
+	manager := NewManager(loggerPkg.GetNopLogger(), database, []types.Duration{})
+
+	chain := &types.Chain{Name: "chain"}
+	proposal := types.Proposal{ID: "proposal"}
+	notVoted := events.NotVotedEvent{Chain: chain, Wallet: &types.Wallet{Address: "wallet"}, Proposal: proposal}
+	voted := events.VotedEvent{Chain: chain, Wallet: &types.Wallet{Address: "wallet"}, Proposal: proposal}
+
+	message, err := manager.Get("telegram:1", notVoted)
+	require.NoError(t, err)
+	require.Nil(t, message)
+
+	require.NoError(t, manager.Save("telegram:1", notVoted, "123", null.String{}))
+
+	// the same wallet and proposal share the message, whatever the event is
+	message, err = manager.Get("telegram:1", voted)
+	require.NoError(t, err)
+	require.NotNil(t, message)
+	require.Equal(t, "123", message.MessageID)
+	require.Equal(t, "wallet", message.Wallet)
+	require.False(t, message.Action.Valid)
+
+	// the action is kept when the message is updated after an edit
+	require.NoError(t, manager.SetAction("telegram:1", "123", "Acknowledged"))
+	message, err = manager.Get("telegram:1", voted)
+	require.NoError(t, err)
+	require.Equal(t, "Acknowledged", message.Action.String)
+	require.NoError(t, manager.Save("telegram:1", voted, "123", message.Action))
+	message, err = manager.Get("telegram:1", voted)
+	require.NoError(t, err)
+	require.Equal(t, "Acknowledged", message.Action.String)
+
+	message, err = manager.Get("telegram:2", voted)
+	require.NoError(t, err)
+	require.Nil(t, message)
+
+	require.NoError(t, manager.Save("telegram:2", voted, "456", null.String{}))
+	require.NoError(t, manager.Delete(&types.AlertMessage{
+		Destination: "telegram:2",
+		Chain:       "chain",
+		ProposalID:  "proposal",
+		Wallet:      "wallet",
+	}))
+	require.Len(t, database.AlertMessages, 1)
+
+	// only the messages sent to the given destinations are deleted
+	require.NoError(t, manager.Save("discord:1", voted, "789", null.String{}))
+	require.NoError(t, manager.DeleteForProposal([]string{"telegram:1", "telegram:2"}, "chain", "proposal"))
+	require.Len(t, database.AlertMessages, 1)
+	require.Equal(t, "discord:1", database.AlertMessages[0].Destination)
+
+	database.DeleteAlertMessageError = errors.New("custom error")
+	require.Error(t, manager.DeleteForProposal([]string{"discord:1"}, "chain", "proposal"))
+}
+
+func TestAlertsManagerShouldEdit(t *testing.T) {
+	t.Parallel()
+
+	database := &databasePkg.StubDatabase{}
+	manager := NewManager(loggerPkg.GetNopLogger(), database, []types.Duration{})
+	require.True(t, manager.ShouldEdit(events.NotVotedEvent{}))
+	require.True(t, manager.ShouldEdit(events.VotedEvent{}))
+
+	// with reminders, the due "wallet hasn't voted" alerts are sent as new messages
+	manager = NewManager(loggerPkg.GetNopLogger(), database, []types.Duration{{Duration: time.Hour}})
+	require.False(t, manager.ShouldEdit(events.NotVotedEvent{}))
+	require.True(t, manager.ShouldEdit(events.VotedEvent{}))
+}
@@ -0,0 +1,31 @@
+package api
+
+import (
+	"net/url"
+)
+
+type Filters struct {
+	Chain    string
+	Proposal string
+	Wallet   string
+}
+
+func NewFilters(query url.Values) Filters {
+	return Filters{
+		Chain:    query.Get("chain"),
+		Proposal: query.Get("proposal"),
+		Wallet:   query.Get("wallet"),
+	}
+}
+
+func (f Filters) MatchesChain(chain string) bool {
+	return f.Chain == "" || f.Chain == chain
+}
+
+func (f Filters) MatchesProposal(proposal string) bool {
+	return f.Proposal == "" || f.Proposal == proposal
+}
+
+func (f Filters) MatchesWallet(wallet string) bool {
+	return f.Wallet == "" || f.Wallet == wallet
+}
@@ -0,0 +1,316 @@
+package api
+
+import (
+	"main/pkg/state"
+	"main/pkg/types"
+	"main/pkg/utils"
+	"sort"
+	"time"
+
+	"cosmossdk.io/math"
+)
+
+type ErrorResponse struct {
+	Error string `json:"error"`
+}
+
+type ChainResponse struct {
+	Name       string `json:"name"`
+	PrettyName string `json:"pretty_name"`
+}
+
+type ProposalResponse struct {
+	ID          string    `json:"id"`
+	Title       string    `json:"title"`
+	Description string    `json:"description"`
+	Status      string    `json:"status"`
+	EndTime     time.Time `json:"end_time"`
+}
+
+type VoteOptionResponse struct {
+	Option string  `json:"option"`
+	Weight float64 `json:"weight"`
+}
+
+type WalletVoteResponse struct {
+	Address string               `json:"address"`
+	Alias   string               `json:"alias,omitempty"`
+	Voted   bool                 `json:"voted"`
+	Vote    string               `json:"vote,omitempty"`
+	Options []VoteOptionResponse `json:"options,omitempty"`
+	Error   string               `json:"error,omitempty"`
+}
+
+type ProposalVotesResponse struct {
+	Proposal ProposalResponse     `json:"proposal"`
+	Votes    []WalletVoteResponse `json:"votes"`
+}
+
+type ChainProposalsResponse struct {
+	Chain     ChainResponse           `json:"chain"`
+	Error     string                  `json:"error,omitempty"`
+	Proposals []ProposalVotesResponse `json:"proposals"`
+}
+
+type ProposalsResponse struct {
+	RenderTime time.Time                `json:"render_time"`
+	Chains     []ChainProposalsResponse `json:"chains"`
+}
+
+type TallyOptionResponse struct {
+	Option  string `json:"option"`
+	Voted   string `json:"voted"`
+	Percent string `json:"percent,omitempty"`
+}
+
+type ProposalTallyResponse struct {
+	Proposal         ProposalResponse      `json:"proposal"`
+	Tally            []TallyOptionResponse `json:"tally"`
+	TotalVotingPower string                `json:"total_voting_power"`
+	Quorum           string                `json:"quorum,omitempty"`
+	NotVoted         string                `json:"not_voted,omitempty"`
+}
+
+type ChainTallyResponse struct {
+	Chain     ChainResponse           `json:"chain"`
+	Proposals []ProposalTallyResponse `json:"proposals"`
+}
+
+type TallyResponse struct {
+	RenderTime time.Time            `json:"render_time"`
+	Chains     []ChainTallyResponse `json:"chains"`
+}
+
+type ParamResponse struct {
+	Description string `json:"description"`
+	Value       string `json:"value"`
+}
+
+type ChainParamsResponse struct {
+	Chain  ChainResponse   `json:"chain"`
+	Params []ParamResponse `json:"params"`
+}
+
+type ParamsResponse struct {
+	Chains []ChainParamsResponse `json:"chains"`
+}
+
+type MuteResponse struct {
+	Chain        string     `json:"chain,omitempty"`
+	ProposalID   string     `json:"proposal_id,omitempty"`
+	Wallet       string     `json:"wallet,omitempty"`
+	Event        string     `json:"event,omitempty"`
+	TitlePattern string     `json:"title_pattern,omitempty"`
+	Expires      time.Time  `json:"expires"`
+	Comment      string     `json:"comment"`
+	Author       string     `json:"author,omitempty"`
+	Source       string     `json:"source,omitempty"`
+	CreatedAt    *time.Time `json:"created_at,omitempty"`
+}
+
+type MutesResponse struct {
+	Mutes []MuteResponse `json:"mutes"`
+}
+
+func NewChainResponse(chain *types.Chain) ChainResponse {
+	return ChainResponse{
+		Name:       chain.Name,
+		PrettyName: chain.GetName(),
+	}
+}
+
+func NewProposalResponse(proposal types.Proposal) ProposalResponse {
+	return ProposalResponse{
+		ID:          proposal.ID,
+		Title:       proposal.Title,
+		Description: proposal.Description,
+		Status:      string(proposal.Status),
+		EndTime:     proposal.EndTime,
+	}
+}
+
+func NewWalletVoteResponse(vote state.RenderedWalletVote) WalletVoteResponse {
+	response := WalletVoteResponse{
+		Address: vote.Wallet.Address,
+		Alias:   vote.Wallet.Alias,
+		Voted:   vote.HasVoted(),
+	}
+
+	if vote.IsError() {
+		response.Error = vote.Error.Error()
+	} else if vote.Vote != nil {
+		response.Vote = vote.Vote.ResolveVote()
+		response.Options = utils.Map(vote.Vote.Options, func(o types.VoteOption) VoteOptionResponse {
+			return VoteOptionResponse{Option: o.Option, Weight: o.Weight}
+		})
+	}
+
+	return response
+}
+
+func NewProposalsResponse(renderedState state.RenderedState, filters Filters) ProposalsResponse {
+	chains := make([]ChainProposalsResponse, 0)
+
+	for _, chainInfo := range renderedState.ChainInfos {
+		if !filters.MatchesChain(chainInfo.Chain.Name) {
+			continue
+		}
+
+		chainResponse := ChainProposalsResponse{
+			Chain:     NewChainResponse(chainInfo.Chain),
+			Proposals: make([]ProposalVotesResponse, 0),
+		}
+
+		if chainInfo.HasProposalsError() {
+			chainResponse.Error = chainInfo.ProposalsError.Error()
+		}
+
+		for _, proposalVotes := range chainInfo.ProposalVotes {
+			if !filters.MatchesProposal(proposalVotes.Proposal.ID) {
+				continue
+			}
+
+			votes := make([]WalletVoteResponse, 0)
+			for _, vote := range proposalVotes.Votes {
+				if filters.MatchesWallet(vote.Wallet.Address) {
+					votes = append(votes, NewWalletVoteResponse(vote))
+				}
+			}
+
+			chainResponse.Proposals = append(chainResponse.Proposals, ProposalVotesResponse{
+				Proposal: NewProposalResponse(proposalVotes.Proposal),
+				Votes:    votes,
+			})
+		}
+
+		chains = append(chains, chainResponse)
+	}
+
+	return ProposalsResponse{
+		RenderTime: renderedState.RenderTime,
+		Chains:     chains,
+	}
+}
+
+func NewProposalTallyResponse(tallyInfo types.TallyInfo) ProposalTallyResponse {
+	response := ProposalTallyResponse{
+		Proposal:         NewProposalResponse(tallyInfo.Proposal),
+		Tally:            make([]TallyOptionResponse, len(tallyInfo.Tally)),
+		TotalVotingPower: serializeDec(tallyInfo.TotalVotingPower),
+	}
+
+	totalVoted := tallyInfo.Tally.GetTotalVoted()
+
+	for index, option := range tallyInfo.Tally {
+		response.Tally[index] = TallyOptionResponse{
+			Option: option.Option,
+			Voted:  serializeDec(option.Voted),
+		}
+
+		if !totalVoted.IsZero() && !option.Voted.IsNil() {
+			response.Tally[index].Percent = tallyInfo.Tally.GetVoted(option)
+		}
+	}
+
+	if !tallyInfo.TotalVotingPower.IsNil() && tallyInfo.TotalVotingPower.IsPositive() {
+		response.Quorum = tallyInfo.GetQuorum()
+		response.NotVoted = tallyInfo.GetNotVoted()
+	}
+
+	return response
+}
+
+func NewTallyResponse(tallies types.ChainsTallyInfos, filters Filters) TallyResponse {
+	chains := make([]ChainTallyResponse, 0)
+
+	for _, chainTallyInfos := range tallies.ChainsTallyInfos {
+		if !filters.MatchesChain(chainTallyInfos.Chain.Name) {
+			continue
+		}
+
+		proposals := make([]ProposalTallyResponse, 0)
+		for _, tallyInfo := range chainTallyInfos.TallyInfos {
+			if filters.MatchesProposal(tallyInfo.Proposal.ID) {
+				proposals = append(proposals, NewProposalTallyResponse(tallyInfo))
+			}
+		}
+
+		chains = append(chains, ChainTallyResponse{
+			Chain:     NewChainResponse(chainTallyInfos.Chain),
+			Proposals: proposals,
+		})
+	}
+
+	sort.Slice(chains, func(i, j int) bool {
+		return chains[i].Chain.Name < chains[j].Chain.Name
+	})
+
+	return TallyResponse{
+		RenderTime: tallies.RenderTime,
+		Chains:     chains,
+	}
+}
+
+func NewParamsResponse(params map[string]types.ChainWithVotingParams, filters Filters) ParamsResponse {
+	chains := make([]ChainParamsResponse, 0)
+
+	for _, chainParams := range params {
+		if !filters.MatchesChain(chainParams.Chain.Name) {
+			continue
+		}
+
+		chains = append(chains, ChainParamsResponse{
+			Chain: NewChainResponse(chainParams.Chain),
+			Params: utils.Map(chainParams.Params, func(p types.ChainParam) ParamResponse {
+				return ParamResponse{Description: p.GetDescription(), Value: p.Serialize()}
+			}),
+		})
+	}
+
+	sort.Slice(chains, func(i, j int) bool {
+		return chains[i].Chain.Name < chains[j].Chain.Name
+	})
+
+	return ParamsResponse{Chains: chains}
+}
+
+func NewMutesResponse(mutes []*types.Mute, filters Filters) MutesResponse {
+	response := MutesResponse{Mutes: make([]MuteResponse, 0)}
+
+	for _, mute := range mutes {
+		if mute.IsExpired() {
+			continue
+		}
+
+		if !mute.Chain.IsZero() && !filters.MatchesChain(mute.Chain.String) {
+			continue
+		}
+
+		if !mute.ProposalID.IsZero() && !filters.MatchesProposal(mute.ProposalID.String) {
+			continue
+		}
+
+		response.Mutes = append(response.Mutes, MuteResponse{
+			Chain:        mute.Chain.String,
+			ProposalID:   mute.ProposalID.String,
+			Wallet:       mute.Wallet.String,
+			Event:        mute.Event.String,
+			TitlePattern: mute.TitlePattern.String,
+			Expires:      mute.Expires,
+			Comment:      mute.Comment,
+			Author:       mute.Author.String,
+			Source:       mute.Source.String,
+			CreatedAt:    mute.CreatedAt.Ptr(),
+		})
+	}
+
+	return response
+}
+
+func serializeDec(dec math.LegacyDec) string {
+	if dec.IsNil() {
+		return "0"
+	}
+
+	return dec.String()
+}
@@ -0,0 +1,76 @@
+package api
+
+import (
+	"errors"
+	"main/pkg/state"
+	"main/pkg/types"
+	"testing"
+
+	"cosmossdk.io/math"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewProposalTallyResponse(t *testing.T) {
+	t.Parallel()
+
+	response := NewProposalTallyResponse(types.TallyInfo{
+		Proposal: types.Proposal{ID: "1"},
+		Tally: types.Tally{
+			{Option: "Yes", Voted: math.LegacyNewDec(30)},
+			{Option: "No", Voted: math.LegacyNewDec(10)},
+		},
+		TotalVotingPower: math.LegacyNewDec(100),
+	})
+
+	assert.Equal(t, "1", response.Proposal.ID)
+	assert.Equal(t, []TallyOptionResponse{
+		{Option: "Yes", Voted: "30.000000000000000000", Percent: "75.00%"},
+		{Option: "No", Voted: "10.000000000000000000", Percent: "25.00%"},
+	}, response.Tally)
+	assert.Equal(t, "100.000000000000000000", response.TotalVotingPower)
+	assert.Equal(t, "40.00%", response.Quorum)
+	assert.Equal(t, "60.00%", response.NotVoted)
+}
+
+func TestNewProposalTallyResponseEmpty(t *testing.T) {
+	t.Parallel()
+
+	response := NewProposalTallyResponse(types.TallyInfo{
+		Proposal: types.Proposal{ID: "1"},
+		Tally:    types.Tally{{Option: "Yes", Voted: math.LegacyNewDec(0)}},
+	})
+
+	assert.Equal(t, []TallyOptionResponse{{Option: "Yes", Voted: "0.000000000000000000"}}, response.Tally)
+	assert.Equal(t, "0", response.TotalVotingPower)
+	assert.Empty(t, response.Quorum)
+	assert.Empty(t, response.NotVoted)
+}
+
+func TestNewWalletVoteResponse(t *testing.T) {
+	t.Parallel()
+
+	wallet := &types.Wallet{Address: "wallet", Alias: "alias"}
+
+	weighted := NewWalletVoteResponse(state.RenderedWalletVote{
+		Wallet: wallet,
+		Vote: &types.Vote{Options: types.VoteOptions{
+			{Option: "Yes", Weight: 0.7},
+			{Option: "No", Weight: 0.3},
+		}},
+	})
+	assert.True(t, weighted.Voted)
+	assert.Equal(t, "Yes 70% / No 30%", weighted.Vote)
+	assert.Len(t, weighted.Options, 2)
+	assert.Equal(t, "alias", weighted.Alias)
+
+	notVoted := NewWalletVoteResponse(state.RenderedWalletVote{Wallet: wallet})
+	assert.False(t, notVoted.Voted)
+	assert.Empty(t, notVoted.Vote)
+
+	withError := NewWalletVoteResponse(state.RenderedWalletVote{
+		Wallet: wallet,
+		Error:  &types.QueryError{QueryError: errors.New("error")},
+	})
+	assert.False(t, withError.Voted)
+	assert.Equal(t, "error", withError.Error)
+}
@@ -0,0 +1,173 @@
+package api
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"main/pkg/data"
+	mutes "main/pkg/mutes"
+	statePkg "main/pkg/state"
+	"main/pkg/types"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type Server struct {
+	Config         types.APIConfig
+	Logger         zerolog.Logger
+	StateGenerator *statePkg.Generator
+	DataManager    *data.Manager
+	MutesManager   *mutes.Manager
+	Tracer         trace.Tracer
+}
+
+func NewServer(
+	config types.APIConfig,
+	logger *zerolog.Logger,
+	stateGenerator *statePkg.Generator,
+	dataManager *data.Manager,
+	mutesManager *mutes.Manager,
+	tracer trace.Tracer,
+) *Server {
+	return &Server{
+		Config:         config,
+		Logger:         logger.With().Str("component", "api").Logger(),
+		StateGenerator: stateGenerator,
+		DataManager:    dataManager,
+		MutesManager:   mutesManager,
+		Tracer:         tracer,
+	}
+}
+
+func (s *Server) Start() {
+	if !s.Config.Enabled.Bool {
+		s.Logger.Info().Msg("API not enabled")
+		return
+	}
+
+	s.Logger.Info().
+		Str("addr", s.Config.ListenAddr).
+		Bool("auth", s.Config.Token != "").
+		Msg("API listening")
+
+	server := &http.Server{
+		Addr:              s.Config.ListenAddr,
+		Handler:           s.Handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	if err := server.ListenAndServe(); err != nil {
+		s.Logger.Panic().
+			Err(err).
+			Str("addr", s.Config.ListenAddr).
+			Msg("Cannot start API")
+	}
+}
+
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/proposals", s.HandleProposals)
+	mux.HandleFunc("/tally", s.HandleTally)
+	mux.HandleFunc("/params", s.HandleParams)
+	mux.HandleFunc("/mutes", s.HandleMutes)
+
+	return s.Middleware(mux)
+}
+
+func (s *Server) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.Logger.Debug().
+			Str("method", r.Method).
+			Str("url", r.URL.String()).
+			Msg("Got API request")
+
+		if r.Method != http.MethodGet {
+			s.WriteError(w, http.StatusMethodNotAllowed, "method not allowed")
+			return
+		}
+
+		if s.Config.Token != "" && !s.IsAuthorized(r) {
+			s.WriteError(w, http.StatusUnauthorized, "unauthorized")
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (s *Server) IsAuthorized(r *http.Request) bool {
+	token, found := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !found {
+		return false
+	}
+
+	return subtle.ConstantTimeCompare([]byte(token), []byte(s.Config.Token)) == 1
+}
+
+func (s *Server) HandleProposals(w http.ResponseWriter, r *http.Request) {
+	ctx, span := s.Tracer.Start(r.Context(), "API proposals query")
+	defer span.End()
+
+	state := s.StateGenerator.GetState(statePkg.NewState(), ctx)
+	renderedState := state.ToRenderedState()
+
+	s.WriteJSON(w, http.StatusOK, NewProposalsResponse(renderedState, NewFilters(r.URL.Query())))
+}
+
+func (s *Server) HandleTally(w http.ResponseWriter, r *http.Request) {
+	ctx, span := s.Tracer.Start(r.Context(), "API tally query")
+	defer span.End()
+
+	tallies, err := s.DataManager.GetTallies(ctx)
+	if err != nil {
+		s.Logger.Error().Err(err).Msg("Error getting tallies info")
+		s.WriteError(w, http.StatusInternalServerError, "error getting tallies info: "+err.Error())
+		return
+	}
+
+	s.WriteJSON(w, http.StatusOK, NewTallyResponse(tallies, NewFilters(r.URL.Query())))
+}
+
+func (s *Server) HandleParams(w http.ResponseWriter, r *http.Request) {
+	ctx, span := s.Tracer.Start(r.Context(), "API params query")
+	defer span.End()
+
+	params, err := s.DataManager.GetParams(ctx)
+	if err != nil {
+		s.Logger.Error().Err(err).Msg("Error getting chain params")
+		s.WriteError(w, http.StatusInternalServerError, "error getting chain params: "+err.Error())
+		return
+	}
+
+	s.WriteJSON(w, http.StatusOK, NewParamsResponse(params, NewFilters(r.URL.Query())))
+}
+
+func (s *Server) HandleMutes(w http.ResponseWriter, r *http.Request) {
+	_, span := s.Tracer.Start(r.Context(), "API mutes query")
+	defer span.End()
+
+	mutesList, err := s.MutesManager.GetAllMutes()
+	if err != nil {
+		s.Logger.Error().Err(err).Msg("Error fetching mutes")
+		s.WriteError(w, http.StatusInternalServerError, "error fetching mutes: "+err.Error())
+		return
+	}
+
+	s.WriteJSON(w, http.StatusOK, NewMutesResponse(mutesList, NewFilters(r.URL.Query())))
+}
+
+func (s *Server) WriteError(w http.ResponseWriter, status int, message string) {
+	s.WriteJSON(w, status, ErrorResponse{Error: message})
+}
+
+func (s *Server) WriteJSON(w http.ResponseWriter, status int, response interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		s.Logger.Error().Err(err).Msg("Error writing API response")
+	}
+}
@@ -0,0 +1,270 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"main/pkg/data"
+	databasePkg "main/pkg/database"
+	"main/pkg/fetchers"
+	"main/pkg/logger"
+	"main/pkg/metrics"
+	mutes "main/pkg/mutes"
+	"main/pkg/state"
+	"main/pkg/tracing"
+	"main/pkg/types"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/guregu/null/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func getTestServer(
+	config types.APIConfig,
+	fetcher fetchers.Fetcher,
+	db *databasePkg.StubDatabase,
+) *Server {
+	log := logger.GetNopLogger()
+	tracer := tracing.InitNoopTracer()
+	metricsManager := metrics.NewManager(log, types.MetricsConfig{})
+	chains := types.Chains{{Name: "chain", Wallets: []*types.Wallet{{Address: "wallet"}}}}
+
+	stateGenerator := state.NewStateGenerator(log, metricsManager, tracer, chains)
+	stateGenerator.Fetchers = map[string]fetchers.Fetcher{"chain": fetcher}
+
+	dataManager := data.NewManager(log, chains, metricsManager, tracer)
+	dataManager.Fetchers = []fetchers.Fetcher{fetcher}
+
+	mutesManager := mutes.NewMutesManager(log, db)
+
+	return NewServer(config, log, stateGenerator, dataManager, mutesManager, tracer)
+}
+
+func doRequest(t *testing.T, server *Server, method, url string, token string, target interface{}) int {
+	t.Helper()
+
+	request := httptest.NewRequest(method, url, nil)
+	if token != "" {
+		request.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	recorder := httptest.NewRecorder()
+	server.Handler().ServeHTTP(recorder, request)
+
+	assert.Equal(t, "application/json", recorder.Header().Get("Content-Type"))
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), target))
+
+	return recorder.Code
+}
+
+func TestServerStartDisabled(t *testing.T) {
+	t.Parallel()
+
+	server := getTestServer(types.APIConfig{}, &fetchers.TestFetcher{}, &databasePkg.StubDatabase{})
+	server.Start()
+}
+
+func TestServerStartFailed(t *testing.T) {
+	t.Parallel()
+
+	defer func() {
+		if r := recover(); r == nil {
+			assert.Fail(t, "Expected to have a panic here!")
+		}
+	}()
+
+	server := getTestServer(types.APIConfig{}, &fetchers.TestFetcher{}, &databasePkg.StubDatabase{})
+	server.Config = types.APIConfig{Enabled: null.BoolFrom(true), ListenAddr: "invalid"}
+	server.Logger = *logger.GetDefaultLogger()
+	server.Start()
+}
+
+func TestServerMethodNotAllowed(t *testing.T) {
+	t.Parallel()
+
+	server := getTestServer(types.APIConfig{}, &fetchers.TestFetcher{}, &databasePkg.StubDatabase{})
+
+	var response ErrorResponse
+	code := doRequest(t, server, http.MethodPost, "/proposals", "", &response)
+	assert.Equal(t, http.StatusMethodNotAllowed, code)
+	assert.Equal(t, "method not allowed", response.Error)
+}
+
+func TestServerUnauthorized(t *testing.T) {
+	t.Parallel()
+
+	server := getTestServer(types.APIConfig{Token: "token"}, &fetchers.TestFetcher{}, &databasePkg.StubDatabase{})
+
+	var response ErrorResponse
+	code := doRequest(t, server, http.MethodGet, "/mutes", "", &response)
+	assert.Equal(t, http.StatusUnauthorized, code)
+
+	code = doRequest(t, server, http.MethodGet, "/mutes", "wrong", &response)
+	assert.Equal(t, http.StatusUnauthorized, code)
+}
+
+func TestServerAuthorized(t *testing.T) {
+	t.Parallel()
+
+	server := getTestServer(types.APIConfig{Token: "token"}, &fetchers.TestFetcher{}, &databasePkg.StubDatabase{})
+
+	var response MutesResponse
+	code := doRequest(t, server, http.MethodGet, "/mutes", "token", &response)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Empty(t, response.Mutes)
+}
+
+func TestServerProposals(t *testing.T) {
+	t.Parallel()
+
+	server := getTestServer(types.APIConfig{}, &fetchers.TestFetcher{WithVote: true}, &databasePkg.StubDatabase{})
+
+	var response ProposalsResponse
+	code := doRequest(t, server, http.MethodGet, "/proposals", "", &response)
+	assert.Equal(t, http.StatusOK, code)
+	require.Len(t, response.Chains, 1)
+	assert.Equal(t, "chain", response.Chains[0].Chain.Name)
+	require.Len(t, response.Chains[0].Proposals, 1)
+	assert.Equal(t, "1", response.Chains[0].Proposals[0].Proposal.ID)
+	require.Len(t, response.Chains[0].Proposals[0].Votes, 1)
+	assert.Equal(t, "wallet", response.Chains[0].Proposals[0].Votes[0].Address)
+	assert.True(t, response.Chains[0].Proposals[0].Votes[0].Voted)
+}
+
+func TestServerProposalsFiltered(t *testing.T) {
+	t.Parallel()
+
+	server := getTestServer(types.APIConfig{}, &fetchers.TestFetcher{}, &databasePkg.StubDatabase{})
+
+	var response ProposalsResponse
+	code := doRequest(t, server, http.MethodGet, "/proposals?chain=another", "", &response)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Empty(t, response.Chains)
+
+	code = doRequest(t, server, http.MethodGet, "/proposals?chain=chain&proposal=2", "", &response)
+	assert.Equal(t, http.StatusOK, code)
+	require.Len(t, response.Chains, 1)
+	assert.Empty(t, response.Chains[0].Proposals)
+
+	code = doRequest(t, server, http.MethodGet, "/proposals?wallet=another", "", &response)
+	assert.Equal(t, http.StatusOK, code)
+	require.Len(t, response.Chains, 1)
+	require.Len(t, response.Chains[0].Proposals, 1)
+	assert.Empty(t, response.Chains[0].Proposals[0].Votes)
+}
+
+func TestServerProposalsError(t *testing.T) {
+	t.Parallel()
+
+	server := getTestServer(types.APIConfig{}, &fetchers.TestFetcher{WithProposalsError: true}, &databasePkg.StubDatabase{})
+
+	var response ProposalsResponse
+	code := doRequest(t, server, http.MethodGet, "/proposals", "", &response)
+	assert.Equal(t, http.StatusOK, code)
+	require.Len(t, response.Chains, 1)
+	assert.Equal(t, "error", response.Chains[0].Error)
+}
+
+func TestServerTallyError(t *testing.T) {
+	t.Parallel()
+
+	server := getTestServer(types.APIConfig{}, &fetchers.TestFetcher{WithTallyError: true}, &databasePkg.StubDatabase{})
+
+	var response ErrorResponse
+	code := doRequest(t, server, http.MethodGet, "/tally", "", &response)
+	assert.Equal(t, http.StatusInternalServerError, code)
+	assert.NotEmpty(t, response.Error)
+}
+
+func TestServerTallyOk(t *testing.T) {
+	t.Parallel()
+
+	server := getTestServer(types.APIConfig{}, &fetchers.TestFetcher{WithTallyNotEmpty: true}, &databasePkg.StubDatabase{})
+
+	var response TallyResponse
+	code := doRequest(t, server, http.MethodGet, "/tally", "", &response)
+	assert.Equal(t, http.StatusOK, code)
+	require.Len(t, response.Chains, 1)
+	require.Len(t, response.Chains[0].Proposals, 1)
+	assert.Equal(t, "id", response.Chains[0].Proposals[0].Proposal.ID)
+
+	code = doRequest(t, server, http.MethodGet, "/tally?proposal=another", "", &response)
+	assert.Equal(t, http.StatusOK, code)
+	require.Len(t, response.Chains, 1)
+	assert.Empty(t, response.Chains[0].Proposals)
+}
+
+func TestServerParamsError(t *testing.T) {
+	t.Parallel()
+
+	server := getTestServer(types.APIConfig{}, &fetchers.TestFetcher{WithParamsError: true}, &databasePkg.StubDatabase{})
+
+	var response ErrorResponse
+	code := doRequest(t, server, http.MethodGet, "/params", "", &response)
+	assert.Equal(t, http.StatusInternalServerError, code)
+	assert.NotEmpty(t, response.Error)
+}
+
+func TestServerParamsOk(t *testing.T) {
+	t.Parallel()
+
+	server := getTestServer(types.APIConfig{}, &fetchers.TestFetcher{}, &databasePkg.StubDatabase{})
+
+	var response ParamsResponse
+	code := doRequest(t, server, http.MethodGet, "/params", "", &response)
+	assert.Equal(t, http.StatusOK, code)
+	require.Len(t, response.Chains, 1)
+	assert.Equal(t, []ParamResponse{{Description: "param", Value: "Yes"}}, response.Chains[0].Params)
+
+	code = doRequest(t, server, http.MethodGet, "/params?chain=another", "", &response)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Empty(t, response.Chains)
+}
+
+func TestServerMutesError(t *testing.T) {
+	t.Parallel()
+
+	server := getTestServer(
+		types.APIConfig{},
+		&fetchers.TestFetcher{},
+		&databasePkg.StubDatabase{GetAllMutesError: errors.New("error")},
+	)
+
+	var response ErrorResponse
+	code := doRequest(t, server, http.MethodGet, "/mutes", "", &response)
+	assert.Equal(t, http.StatusInternalServerError, code)
+	assert.NotEmpty(t, response.Error)
+}
+
+func TestServerMutesOk(t *testing.T) {
+	t.Parallel()
+
+	server := getTestServer(types.APIConfig{}, &fetchers.TestFetcher{}, &databasePkg.StubDatabase{
+		Mutes: []*types.Mute{
+			{
+				Chain:   null.StringFrom("chain"),
+				Expires: time.Now().Add(time.Hour),
+				Author:  null.StringFrom("@user"),
+				Source:  null.StringFrom(types.MuteSourceTelegram),
+			},
+			{Chain: null.StringFrom("another"), Expires: time.Now().Add(time.Hour)},
+			{ProposalID: null.StringFrom("1"), Expires: time.Now().Add(time.Hour)},
+			{Expires: time.Now().Add(-time.Hour)},
+		},
+	})
+
+	var response MutesResponse
+	code := doRequest(t, server, http.MethodGet, "/mutes", "", &response)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Len(t, response.Mutes, 3)
+
+	code = doRequest(t, server, http.MethodGet, "/mutes?chain=chain&proposal=2", "", &response)
+	assert.Equal(t, http.StatusOK, code)
+	require.Len(t, response.Mutes, 1)
+	assert.Equal(t, "chain", response.Mutes[0].Chain)
+	assert.Equal(t, "@user", response.Mutes[0].Author)
+	assert.Equal(t, types.MuteSourceTelegram, response.Mutes[0].Source)
+}
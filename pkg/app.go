@@ -2,19 +2,34 @@ package pkg
 
 import (
 	"context"
+	"io"
+	"main/pkg/alerts"
+	"main/pkg/api"
+	"main/pkg/dashboard"
 	"main/pkg/data"
 	databasePkg "main/pkg/database"
+	"main/pkg/digest"
 	"main/pkg/fs"
 	"main/pkg/logger"
+	"main/pkg/metrics"
 	mutes "main/pkg/mutes"
+	"main/pkg/outbox"
+	"main/pkg/reminders"
 	"main/pkg/report"
+	"main/pkg/report/entry"
 	reportersPkg "main/pkg/reporters"
 	"main/pkg/reporters/discord"
+	"main/pkg/reporters/opsgenie"
 	"main/pkg/reporters/pagerduty"
+	"main/pkg/reporters/slack"
 	"main/pkg/reporters/telegram"
+	"main/pkg/reporters/webhook"
+	"main/pkg/routes"
+	"main/pkg/spam"
 	"main/pkg/state"
 	"main/pkg/tracing"
 	"main/pkg/types"
+	"os"
 	"time"
 
 	"github.com/robfig/cron/v3"
@@ -29,44 +44,97 @@ type App struct {
 	ReportGenerator  *report.Generator
 	StateGenerator   *state.Generator
 	ReportDispatcher *report.Dispatcher
+	MetricsManager   *metrics.Manager
+	MutesManager     *mutes.Manager
+	DigestManager    *digest.Manager
+	APIServer        *api.Server
 	Database         databasePkg.Database
 	StopChannel      chan bool
 }
 
 func NewApp(configPath string, filesystem fs.FS, version string) *App {
+	return NewAppWithLogsOutput(configPath, filesystem, version, os.Stdout)
+}
+
+func NewAppWithLogsOutput(
+	configPath string,
+	filesystem fs.FS,
+	version string,
+	logsOutput io.Writer,
+) *App {
+	defaultLogger := logger.GetDefaultLoggerWithOutput(logsOutput)
+
 	config, err := GetConfig(filesystem, configPath)
 	if err != nil {
-		logger.GetDefaultLogger().Panic().Err(err).Msg("Could not load config")
+		defaultLogger.Panic().Err(err).Msg("Could not load config")
 	}
 
 	if err = config.Validate(); err != nil {
-		logger.GetDefaultLogger().Panic().Err(err).Msg("Provided config is invalid!")
+		defaultLogger.Panic().Err(err).Msg("Provided config is invalid!")
 	}
 
 	if warnings := config.DisplayWarnings(); len(warnings) > 0 {
-		config.LogWarnings(logger.GetDefaultLogger(), warnings)
+		config.LogWarnings(defaultLogger, warnings)
 	} else {
-		logger.GetDefaultLogger().Info().Msg("Provided config is valid.")
+		defaultLogger.Info().Msg("Provided config is valid.")
 	}
 
 	tracer := tracing.InitTracer(config.TracingConfig, version)
-	log := logger.GetLogger(config.LogConfig)
+	log := logger.GetLoggerWithOutput(config.LogConfig, logsOutput)
 
-	database := databasePkg.NewSqliteDatabase(log, config.DatabaseConfig)
+	database := databasePkg.NewDatabase(log, config.DatabaseConfig)
 
+	metricsManager := metrics.NewManager(log, config.MetricsConfig)
 	mutesManager := mutes.NewMutesManager(log, database)
-	stateGenerator := state.NewStateGenerator(log, tracer, config.Chains)
-	dataManager := data.NewManager(log, config.Chains, tracer)
+	remindersManager := reminders.NewManager(log, database, config.Reminders)
+	routesManager := routes.NewManager(log, config.Routes)
+	spamManager := spam.NewManager(log, config.SpamConfig, database)
+	outboxManager := outbox.NewManager(log, config.OutboxConfig, database)
+	alertsManager := alerts.NewManager(log, database, config.Reminders)
+	dashboardManager := dashboard.NewManager(log, database)
+	stateGenerator := state.NewStateGenerator(log, metricsManager, tracer, config.Chains)
+	dataManager := data.NewManager(log, config.Chains, metricsManager, tracer)
+	digestManager := digest.NewManager(log, stateGenerator, database, tracer)
 
-	generator := report.NewReportNewGenerator(log, config.Chains, database, tracer)
+	generator := report.NewReportNewGenerator(log, config.Chains, database, metricsManager, spamManager, tracer)
 
 	timeZone, _ := time.LoadLocation(config.Timezone)
 
+	pagerDutyReporter := pagerduty.NewPagerDutyReporter(
+		config.PagerDutyConfig,
+		config.Chains,
+		database,
+		mutesManager,
+		log,
+		tracer,
+	)
+	mutesManager.OnMuteAdded(pagerDutyReporter.HandleMuteAdded)
+
 	reporters := []reportersPkg.Reporter{
-		pagerduty.NewPagerDutyReporter(config.PagerDutyConfig, log, tracer),
+		pagerDutyReporter,
+		opsgenie.NewOpsgenieReporter(config.OpsgenieConfig, log, tracer),
+		webhook.NewReporter(config.WebhookConfig, log, tracer),
 		telegram.NewTelegramReporter(
 			config.TelegramConfig,
 			mutesManager,
+			routesManager,
+			spamManager,
+			outboxManager,
+			alertsManager,
+			dashboardManager,
+			stateGenerator,
+			dataManager,
+			log,
+			version,
+			timeZone,
+			tracer,
+		),
+		slack.NewSlackReporter(
+			config.SlackConfig,
+			mutesManager,
+			routesManager,
+			spamManager,
+			outboxManager,
 			stateGenerator,
 			dataManager,
 			log,
@@ -79,6 +147,11 @@ func NewApp(configPath string, filesystem fs.FS, version string) *App {
 			version,
 			log,
 			mutesManager,
+			routesManager,
+			spamManager,
+			outboxManager,
+			alertsManager,
+			dashboardManager,
 			dataManager,
 			stateGenerator,
 			timeZone,
@@ -86,7 +159,25 @@ func NewApp(configPath string, filesystem fs.FS, version string) *App {
 		),
 	}
 
-	reportDispatcher := report.NewDispatcher(log, mutesManager, reporters, tracer)
+	apiServer := api.NewServer(
+		config.APIConfig,
+		log,
+		stateGenerator,
+		dataManager,
+		mutesManager,
+		tracer,
+	)
+
+	reportDispatcher := report.NewDispatcher(
+		log,
+		mutesManager,
+		remindersManager,
+		routesManager,
+		metricsManager,
+		outboxManager,
+		reporters,
+		tracer,
+	)
 
 	return &App{
 		Tracer:           tracer,
@@ -95,6 +186,10 @@ func NewApp(configPath string, filesystem fs.FS, version string) *App {
 		ReportGenerator:  generator,
 		StateGenerator:   stateGenerator,
 		ReportDispatcher: reportDispatcher,
+		MetricsManager:   metricsManager,
+		MutesManager:     mutesManager,
+		DigestManager:    digestManager,
+		APIServer:        apiServer,
 		Database:         database,
 		StopChannel:      make(chan bool),
 	}
@@ -108,12 +203,34 @@ func (a *App) Start() {
 		a.Logger.Panic().Err(err).Msg("Error initializing reporters")
 	}
 
+	go a.MetricsManager.Start()
+	go a.APIServer.Start()
+
 	c := cron.New()
-	if _, err := c.AddFunc(a.Config.Interval, a.Report); err != nil {
-		a.Logger.Panic().Err(err).Msg("Error processing cron pattern")
+
+	if a.Config.DigestConfig.Enabled.Bool && a.Config.DigestConfig.DisableAlerts.Bool {
+		a.Logger.Info().Msg("Alerts are disabled, only sending the digest")
+	} else {
+		if _, err := c.AddFunc(a.Config.Interval, a.Report); err != nil {
+			a.Logger.Panic().Err(err).Msg("Error processing cron pattern")
+		}
+		a.Logger.Info().Str("interval", a.Config.Interval).Msg("Scheduled proposals reporting")
+	}
+
+	if a.Config.DigestConfig.Enabled.Bool {
+		if _, err := c.AddFunc(a.Config.DigestConfig.Interval, a.SendDigest); err != nil {
+			a.Logger.Panic().Err(err).Msg("Error processing digest cron pattern")
+		}
+		a.Logger.Info().Str("interval", a.Config.DigestConfig.Interval).Msg("Scheduled digest reporting")
 	}
+
+	if a.Config.OutboxConfig.Enabled.Bool {
+		if _, err := c.AddFunc(a.Config.OutboxConfig.RetryInterval, a.RetryUndelivered); err != nil {
+			a.Logger.Panic().Err(err).Msg("Error processing outbox retry cron pattern")
+		}
+	}
+
 	c.Start()
-	a.Logger.Info().Str("interval", a.Config.Interval).Msg("Scheduled proposals reporting")
 
 	<-a.StopChannel
 	a.Logger.Info().Msg("Shutting down...")
@@ -128,6 +245,110 @@ func (a *App) Report() {
 	ctx, span := a.Tracer.Start(context.Background(), "report")
 	defer span.End()
 
+	a.MutesManager.ArchiveExpiredMutes()
+
 	generatedReport := a.ReportGenerator.GenerateReport(ctx)
 	a.ReportDispatcher.SendReport(generatedReport, ctx)
+
+	if a.ReportDispatcher.HasDashboards() {
+		a.UpdateDashboards(ctx)
+	}
+}
+
+// UpdateDashboards re-renders the pinned dashboard messages with the proposals
+// currently in voting.
+func (a *App) UpdateDashboards(ctx context.Context) {
+	currentState := a.StateGenerator.GetState(state.NewState(), ctx)
+	a.ReportDispatcher.UpdateDashboards(currentState.ToRenderedState(), ctx)
+}
+
+// SendDigest sends the digest of proposals in voting and of the changes
+// since the previous digest to all reporters.
+func (a *App) SendDigest() {
+	ctx, span := a.Tracer.Start(context.Background(), "digest")
+	defer span.End()
+
+	digestEvent, snapshot, err := a.DigestManager.GenerateDigest(ctx)
+	if err != nil {
+		a.Logger.Error().Err(err).Msg("Error generating digest, not sending it")
+		return
+	}
+
+	a.ReportDispatcher.SendReport(reportersPkg.Report{
+		Entries: []entry.ReportEntry{digestEvent},
+	}, ctx)
+
+	if err := a.DigestManager.SaveSnapshot(snapshot); err != nil {
+		a.Logger.Error().Err(err).Msg("Error saving digest")
+	}
+}
+
+// RetryUndelivered retries sending the report entries that failed to be sent before.
+func (a *App) RetryUndelivered() {
+	ctx, span := a.Tracer.Start(context.Background(), "retry")
+	defer span.End()
+
+	a.ReportDispatcher.RetryUndelivered(ctx)
+}
+
+// Backfill stores the current state of all chains (proposals, votes and last block heights)
+// in the database without sending anything, so the next run would only report
+// the changes that happened after that. Useful when starting with an empty database
+// or adding a new chain, as otherwise each of the already cast votes would be reported.
+func (a *App) Backfill() {
+	ctx, span := a.Tracer.Start(context.Background(), "backfill")
+	defer span.End()
+
+	a.Database.Init()
+	a.Database.Migrate()
+
+	a.Logger.Info().Msg("Backfilling the database, this might take a while...")
+
+	generatedReport := a.ReportGenerator.GenerateReport(ctx)
+
+	a.Logger.Info().
+		Int("entries", len(generatedReport.Entries)).
+		Msg("Backfilled the database, not sending any of the generated report entries.")
+}
+
+// Check generates the report once and sends it to all reporters. With dryRun set,
+// nothing is sent or persisted in the database, and the report is only returned.
+func (a *App) Check(dryRun bool) reportersPkg.Report {
+	ctx, span := a.Tracer.Start(context.Background(), "check")
+	defer span.End()
+
+	if dryRun {
+		a.Database = databasePkg.NewReadOnlyDatabase(a.Database)
+		a.ReportGenerator.Database = databasePkg.NewReadOnlyDatabase(a.ReportGenerator.Database)
+		a.ReportGenerator.SpamManager.Database = databasePkg.NewReadOnlyDatabase(a.ReportGenerator.SpamManager.Database)
+	}
+
+	a.Database.Init()
+
+	// dry runs do not change the database schema, but cannot work with an outdated one
+	if dryRun {
+		if hasPending, err := a.Database.HasPendingMigrations(); err != nil {
+			a.Logger.Panic().Err(err).Msg("Could not check database migrations, run without --dry-run to apply them")
+		} else if hasPending {
+			a.Logger.Panic().Msg("Database migrations are not applied, run without --dry-run to apply them")
+		}
+	} else {
+		a.Database.Migrate()
+	}
+
+	generatedReport := a.ReportGenerator.GenerateReport(ctx)
+
+	if dryRun {
+		a.Logger.Info().
+			Int("entries", len(generatedReport.Entries)).
+			Msg("Dry run, not sending the report.")
+		return generatedReport
+	}
+
+	if err := a.ReportDispatcher.Init(); err != nil {
+		a.Logger.Panic().Err(err).Msg("Error initializing reporters")
+	}
+
+	a.ReportDispatcher.SendReport(generatedReport, ctx)
+	return generatedReport
 }
@@ -1,12 +1,19 @@
 package pkg
 
 import (
+	"errors"
 	databasePkg "main/pkg/database"
+	"main/pkg/events"
+	fetchersPkg "main/pkg/fetchers"
 	"main/pkg/fs"
+	"main/pkg/report/entry"
 	reportersPkg "main/pkg/reporters"
+	"main/pkg/types"
+	"slices"
 	"sync"
 	"testing"
 
+	"github.com/guregu/null/v5"
 	"github.com/jarcoal/httpmock"
 
 	"github.com/stretchr/testify/require"
@@ -58,6 +65,19 @@ func TestAppCreateConfigValid(t *testing.T) {
 	require.NotNil(t, app)
 }
 
+func TestAppReporterNamesCanBeRouted(t *testing.T) {
+	t.Parallel()
+
+	filesystem := &fs.TestFS{}
+
+	app := NewApp("config-valid.toml", filesystem, "1.2.3")
+	require.NotNil(t, app)
+
+	for _, reporter := range app.ReportDispatcher.Reporters {
+		require.Contains(t, types.RouteReporters, reporter.Name())
+	}
+}
+
 func TestAppStartReporterFailedToInit(t *testing.T) {
 	t.Parallel()
 
@@ -126,5 +146,216 @@ func TestAppReport(t *testing.T) {
 	app := NewApp("config-valid.toml", filesystem, "1.2.3")
 	app.Database = &databasePkg.StubDatabase{}
 	app.ReportGenerator.Database = &databasePkg.StubDatabase{}
+	app.MutesManager.Database = &databasePkg.StubDatabase{}
+	app.ReportDispatcher.OutboxManager.Database = &databasePkg.StubDatabase{}
 	app.Report()
 }
+
+//nolint:paralleltest // disabled due to httpmock usage
+func TestAppSendDigest(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	filesystem := &fs.TestFS{}
+	reporter := &reportersPkg.TestReporter{}
+	db := &databasePkg.StubDatabase{}
+	app := NewApp("config-valid.toml", filesystem, "1.2.3")
+	app.ReportDispatcher.Reporters = []reportersPkg.Reporter{reporter}
+	app.Database = db
+	app.MutesManager.Database = db
+	app.ReportDispatcher.OutboxManager.Database = db
+	app.DigestManager.Database = db
+	app.SendDigest()
+
+	require.Len(t, reporter.SentEntries, 1)
+	require.Equal(t, "digest", reporter.SentEntries[0].Name())
+	require.NotNil(t, db.DigestSnapshot)
+}
+
+//nolint:paralleltest // disabled due to httpmock usage
+func TestAppSendDigestFailedToGenerate(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	filesystem := &fs.TestFS{}
+	reporter := &reportersPkg.TestReporter{}
+	db := &databasePkg.StubDatabase{GetDigestError: errors.New("custom error")}
+	app := NewApp("config-valid.toml", filesystem, "1.2.3")
+	app.ReportDispatcher.Reporters = []reportersPkg.Reporter{reporter}
+	app.Database = db
+	app.MutesManager.Database = db
+	app.ReportDispatcher.OutboxManager.Database = db
+	app.DigestManager.Database = db
+	app.SendDigest()
+
+	require.Empty(t, reporter.SentEntries)
+	require.Nil(t, db.DigestSnapshot)
+}
+
+//nolint:paralleltest // disabled due to httpmock usage
+func TestAppRetryUndelivered(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	filesystem := &fs.TestFS{}
+	reporter := &reportersPkg.TestReporter{}
+	db := &databasePkg.StubDatabase{
+		Outbox: []*types.OutboxEntry{{
+			ID:       1,
+			Reporter: reporter.Name(),
+			Event:    "finished_voting",
+			Payload:  `{"Chain":{"Name":"chain"},"Proposal":{"ID":"proposal"}}`,
+			Status:   types.OutboxStatusPending,
+		}},
+	}
+	app := NewApp("config-valid.toml", filesystem, "1.2.3")
+	app.ReportDispatcher.Reporters = []reportersPkg.Reporter{reporter}
+	app.Database = db
+	app.MutesManager.Database = db
+	app.ReportDispatcher.OutboxManager.Database = db
+	app.RetryUndelivered()
+
+	require.Len(t, reporter.SentEntries, 1)
+	require.Empty(t, db.Outbox)
+}
+
+//nolint:paralleltest // disabled due to httpmock usage
+func TestAppBackfill(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	filesystem := &fs.TestFS{}
+	reporter := &reportersPkg.TestReporter{}
+	db := &databasePkg.StubDatabase{}
+	app := NewApp("config-valid.toml", filesystem, "1.2.3")
+	app.ReportDispatcher.Reporters = []reportersPkg.Reporter{reporter}
+	app.Database = db
+	app.ReportGenerator.Database = db
+	app.ReportGenerator.SpamManager.Database = db
+	app.MutesManager.Database = db
+	app.ReportDispatcher.RemindersManager.Database = db
+	app.ReportDispatcher.OutboxManager.Database = db
+	app.ReportGenerator.Fetchers = map[string]fetchersPkg.Fetcher{
+		"bitsong": &fetchersPkg.TestFetcher{WithVote: true},
+	}
+	app.Backfill()
+
+	require.Empty(t, reporter.SentEntries)
+	require.Contains(t, db.Proposals["bitsong"], "1")
+	require.Contains(t, db.Votes["bitsong"]["1"], "bitsong14rvn7anf22e00vj5x3al4w50ns78s7n4t8yxcy")
+	require.Equal(t, int64(123), db.LastBlockHeight["bitsong"]["proposals"])
+	require.Equal(
+		t,
+		int64(456),
+		db.LastBlockHeight["bitsong"]["proposal_1_vote_bitsong14rvn7anf22e00vj5x3al4w50ns78s7n4t8yxcy"],
+	)
+
+	// the votes cast before backfilling are not reported
+	app.Report()
+	require.Empty(t, reporter.SentEntries)
+}
+
+//nolint:paralleltest // disabled due to httpmock usage
+func TestAppCheckDryRun(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	filesystem := &fs.TestFS{}
+	reporter := &reportersPkg.TestReporter{}
+	db := &databasePkg.StubDatabase{}
+	app := NewApp("config-valid.toml", filesystem, "1.2.3")
+	app.ReportDispatcher.Reporters = []reportersPkg.Reporter{reporter}
+	app.Database = db
+	app.ReportGenerator.Database = db
+
+	generatedReport := app.Check(true)
+	require.NotEmpty(t, generatedReport.Entries)
+	require.Empty(t, reporter.SentEntries)
+	require.Empty(t, db.LastBlockHeight)
+}
+
+//nolint:paralleltest // disabled due to httpmock usage
+func TestAppCheckDryRunPendingMigrations(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	defer func() {
+		if r := recover(); r == nil {
+			require.Fail(t, "Expected to have a panic here!")
+		}
+	}()
+
+	filesystem := &fs.TestFS{}
+	db := &databasePkg.StubDatabase{PendingMigrations: true}
+	app := NewApp("config-valid.toml", filesystem, "1.2.3")
+	app.Database = db
+	app.ReportGenerator.Database = db
+
+	app.Check(true)
+}
+
+//nolint:paralleltest // disabled due to httpmock usage
+func TestAppCheckDryRunSpam(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	filesystem := &fs.TestFS{}
+	db := &databasePkg.StubDatabase{}
+	app := NewApp("config-valid.toml", filesystem, "1.2.3")
+	app.Database = db
+	app.ReportGenerator.Database = db
+	app.ReportGenerator.SpamManager.Database = db
+	app.ReportGenerator.SpamManager.Config = types.SpamConfig{
+		Enabled:  null.BoolFrom(true),
+		Keywords: []string{"airdrop"},
+	}
+	app.ReportGenerator.Fetchers = map[string]fetchersPkg.Fetcher{
+		"bitsong": &fetchersPkg.TestFetcher{WithSpamProposals: true},
+	}
+
+	generatedReport := app.Check(true)
+	require.True(t, slices.ContainsFunc(generatedReport.Entries, func(e entry.ReportEntry) bool {
+		_, ok := e.(events.SuspectedSpamEvent)
+		return ok
+	}))
+	require.Empty(t, db.SpamFlags)
+}
+
+//nolint:paralleltest // disabled due to httpmock usage
+func TestAppCheckReporterFailedToInit(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	defer func() {
+		if r := recover(); r == nil {
+			require.Fail(t, "Expected to have a panic here!")
+		}
+	}()
+
+	filesystem := &fs.TestFS{}
+	app := NewApp("config-valid.toml", filesystem, "1.2.3")
+	app.ReportDispatcher.Reporters = []reportersPkg.Reporter{&reportersPkg.TestReporter{WithInitFail: true}}
+	app.Database = &databasePkg.StubDatabase{}
+	app.ReportGenerator.Database = &databasePkg.StubDatabase{}
+
+	app.Check(false)
+}
+
+//nolint:paralleltest // disabled due to httpmock usage
+func TestAppCheckOk(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	filesystem := &fs.TestFS{}
+	reporter := &reportersPkg.TestReporter{}
+	app := NewApp("config-valid.toml", filesystem, "1.2.3")
+	app.ReportDispatcher.Reporters = []reportersPkg.Reporter{reporter}
+	app.Database = &databasePkg.StubDatabase{}
+	app.ReportGenerator.Database = &databasePkg.StubDatabase{}
+	app.MutesManager.Database = &databasePkg.StubDatabase{}
+	app.ReportDispatcher.OutboxManager.Database = &databasePkg.StubDatabase{}
+
+	generatedReport := app.Check(false)
+	require.NotEmpty(t, generatedReport.Entries)
+	require.Len(t, reporter.SentEntries, len(generatedReport.Entries))
+}
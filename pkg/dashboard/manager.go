@@ -0,0 +1,35 @@
+package dashboard
+
+import (
+	databasePkg "main/pkg/database"
+	"main/pkg/types"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// Manager stores the pinned dashboard messages, so the reporters can edit them
+// after every report run instead of sending new ones.
+type Manager struct {
+	Database databasePkg.Database
+	Logger   zerolog.Logger
+}
+
+func NewManager(logger *zerolog.Logger, database databasePkg.Database) *Manager {
+	return &Manager{
+		Database: database,
+		Logger:   logger.With().Str("component", "dashboard_manager").Logger(),
+	}
+}
+
+func (m *Manager) Get(destination string) (*types.DashboardMessage, error) {
+	return m.Database.GetDashboardMessage(destination)
+}
+
+func (m *Manager) Save(destination string, messageID string) error {
+	return m.Database.UpsertDashboardMessage(&types.DashboardMessage{
+		Destination: destination,
+		MessageID:   messageID,
+		UpdatedAt:   time.Now(),
+	})
+}
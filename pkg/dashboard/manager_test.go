@@ -0,0 +1,29 @@
+package dashboard
+
+import (
+	databasePkg "main/pkg/database"
+	loggerPkg "main/pkg/logger"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDashboardManagerSaveAndGet(t *testing.T) {
+	t.Parallel()
+
+	database := &databasePkg.StubDatabase{}
+	manager := NewManager(loggerPkg.GetNopLogger(), database)
+
+	message, err := manager.Get("telegram:1")
+	require.NoError(t, err)
+	require.Nil(t, message)
+
+	require.NoError(t, manager.Save("telegram:1", "123"))
+	require.NoError(t, manager.Save("telegram:1", "456"))
+
+	message, err = manager.Get("telegram:1")
+	require.NoError(t, err)
+	require.NotNil(t, message)
+	require.Equal(t, "456", message.MessageID)
+	require.Len(t, database.Dashboards, 1)
+}
@@ -4,7 +4,9 @@ import (
 	"context"
 	"fmt"
 	fetchersPkg "main/pkg/fetchers"
+	"main/pkg/metrics"
 	"main/pkg/types"
+	"main/pkg/utils"
 	"sync"
 	"time"
 
@@ -20,11 +22,16 @@ type Manager struct {
 	Tracer   trace.Tracer
 }
 
-func NewManager(logger *zerolog.Logger, chains types.Chains, tracer trace.Tracer) *Manager {
+func NewManager(
+	logger *zerolog.Logger,
+	chains types.Chains,
+	metricsManager *metrics.Manager,
+	tracer trace.Tracer,
+) *Manager {
 	fetchers := make([]fetchersPkg.Fetcher, len(chains))
 
 	for index, chain := range chains {
-		fetchers[index] = fetchersPkg.GetFetcher(chain, logger, tracer)
+		fetchers[index] = fetchersPkg.GetFetcher(chain, logger, metricsManager, tracer)
 	}
 
 	return &Manager{
@@ -35,7 +42,9 @@ func NewManager(logger *zerolog.Logger, chains types.Chains, tracer trace.Tracer
 	}
 }
 
-func (m *Manager) GetTallies(ctx context.Context) (types.ChainsTallyInfos, error) {
+// GetTallies fetches tallies for the chains with the given names, or for all chains
+// if no names are provided.
+func (m *Manager) GetTallies(ctx context.Context, chainNames ...string) (types.ChainsTallyInfos, error) {
 	childCtx, span := m.Tracer.Start(ctx, "Fetching tallies")
 	defer span.End()
 
@@ -49,6 +58,10 @@ func (m *Manager) GetTallies(ctx context.Context) (types.ChainsTallyInfos, error
 	}
 
 	for index, chain := range m.Chains {
+		if len(chainNames) > 0 && !utils.Contains(chainNames, chain.Name) {
+			continue
+		}
+
 		fetcher := m.Fetchers[index]
 
 		wg.Add(1)
@@ -79,7 +92,12 @@ func (m *Manager) GetTallies(ctx context.Context) (types.ChainsTallyInfos, error
 	return tallies, nil
 }
 
-func (m *Manager) GetParams(ctx context.Context) (map[string]types.ChainWithVotingParams, error) {
+// GetParams fetches params for the chains with the given names, or for all chains
+// if no names are provided.
+func (m *Manager) GetParams(
+	ctx context.Context,
+	chainNames ...string,
+) (map[string]types.ChainWithVotingParams, error) {
 	childCtx, span := m.Tracer.Start(ctx, "Fetching params...")
 	defer span.End()
 
@@ -89,7 +107,11 @@ func (m *Manager) GetParams(ctx context.Context) (map[string]types.ChainWithVoti
 	params := make(map[string]types.ChainWithVotingParams)
 	errors := make([]error, 0)
 
-	for index := range m.Chains {
+	for index, chain := range m.Chains {
+		if len(chainNames) > 0 && !utils.Contains(chainNames, chain.Name) {
+			continue
+		}
+
 		fetcher := m.Fetchers[index]
 
 		wg.Add(1)
@@ -4,6 +4,7 @@ import (
 	"context"
 	fetchersPkg "main/pkg/fetchers"
 	"main/pkg/logger"
+	"main/pkg/metrics"
 	"main/pkg/tracing"
 	"main/pkg/types"
 	"testing"
@@ -19,7 +20,7 @@ func TestDataManagerNew(t *testing.T) {
 	log := logger.GetNopLogger()
 	dataManager := NewManager(log, types.Chains{
 		{Name: "chain"},
-	}, tracing.InitNoopTracer())
+	}, metrics.NewManager(log, types.MetricsConfig{}), tracing.InitNoopTracer())
 
 	assert.NotNil(t, dataManager)
 }
@@ -103,3 +104,41 @@ func TestDataManagerGetParamsOk(t *testing.T) {
 	require.NoError(t, err)
 	assert.NotEmpty(t, params)
 }
+
+func TestDataManagerGetTallyFilteredByChain(t *testing.T) {
+	t.Parallel()
+
+	log := logger.GetNopLogger()
+	dataManager := &Manager{
+		Logger: *log,
+		Chains: types.Chains{{Name: "chain"}, {Name: "another"}},
+		Fetchers: []fetchersPkg.Fetcher{
+			&fetchersPkg.TestFetcher{WithTallyNotEmpty: true},
+			&fetchersPkg.TestFetcher{WithTallyError: true},
+		},
+		Tracer: tracing.InitNoopTracer(),
+	}
+
+	tallies, err := dataManager.GetTallies(context.Background(), "chain")
+	require.NoError(t, err)
+	assert.NotEmpty(t, tallies.ChainsTallyInfos)
+}
+
+func TestDataManagerGetParamsFilteredByChain(t *testing.T) {
+	t.Parallel()
+
+	log := logger.GetNopLogger()
+	dataManager := &Manager{
+		Logger: *log,
+		Chains: types.Chains{{Name: "chain"}, {Name: "another"}},
+		Fetchers: []fetchersPkg.Fetcher{
+			&fetchersPkg.TestFetcher{},
+			&fetchersPkg.TestFetcher{WithParamsError: true},
+		},
+		Tracer: tracing.InitNoopTracer(),
+	}
+
+	params, err := dataManager.GetParams(context.Background(), "chain")
+	require.NoError(t, err)
+	assert.Len(t, params, 1)
+}
@@ -2,13 +2,21 @@ package database
 
 import (
 	"context"
+	"database/sql"
+	migrationsPkg "main/migrations"
 	"main/pkg/types"
+	"strings"
+	"time"
+
+	"github.com/pressly/goose/v3"
+	"github.com/rs/zerolog"
 )
 
 type Database interface {
 	Init()
 	Migrate()
 	Rollback()
+	HasPendingMigrations() (bool, error)
 	UpsertProposal(chain *types.Chain, proposal types.Proposal) error
 	GetProposal(chain *types.Chain, proposalID string) (*types.Proposal, error)
 	GetVote(chain *types.Chain, proposal types.Proposal, wallet *types.Wallet) (*types.Vote, error)
@@ -22,7 +30,99 @@ type Database interface {
 	GetLastBlockHeight(chain *types.Chain, storableKey string) (int64, error)
 	UpsertLastBlockHeight(chain *types.Chain, storableKey string, height int64) error
 	UpsertMute(mute *types.Mute) error
-	DeleteMute(mute *types.Mute) (bool, error)
+	DeleteMute(mute *types.Mute, removedBy string) (bool, error)
 	GetAllMutes() ([]*types.Mute, error)
-	IsMuted(chain, proposalID string) (bool, error)
+	ArchiveExpiredMutes() (int64, error)
+	GetMutesHistory(limit int) ([]*types.MuteHistoryEntry, error)
+	IsMuted(query types.MuteQuery) (bool, error)
+	GetSentReminders(chain *types.Chain, proposal types.Proposal, wallet *types.Wallet) ([]string, error)
+	InsertSentReminders(
+		chain *types.Chain,
+		proposal types.Proposal,
+		wallet *types.Wallet,
+		reminders []string,
+	) error
+	InsertIncident(incident *types.Incident) error
+	GetIncidents(reporter string) ([]*types.Incident, error)
+	DeleteIncident(reporter, dedupKey string) error
+	GetSpamFlag(chain, proposalID string) (*types.SpamFlag, error)
+	UpsertSpamFlag(flag *types.SpamFlag) error
+	GetDigestSnapshot() (*types.DigestSnapshot, error)
+	SaveDigestSnapshot(snapshot *types.DigestSnapshot) error
+	InsertOutboxEntry(entry *types.OutboxEntry) error
+	UpdateOutboxEntry(entry *types.OutboxEntry) error
+	DeleteOutboxEntry(id int64) error
+	GetDueOutboxEntries(now time.Time) ([]*types.OutboxEntry, error)
+	GetUndeliveredOutboxEntries() ([]*types.OutboxEntry, error)
+	GetAlertMessage(destination, chain, proposalID, wallet string) (*types.AlertMessage, error)
+	UpsertAlertMessage(message *types.AlertMessage) error
+	SetAlertMessageAction(destination, messageID, action string) error
+	DeleteAlertMessage(message *types.AlertMessage) error
+	DeleteProposalAlertMessages(destination, chain, proposalID string) error
+	GetDashboardMessage(destination string) (*types.DashboardMessage, error)
+	UpsertDashboardMessage(message *types.DashboardMessage) error
+}
+
+func NewDatabase(logger *zerolog.Logger, config types.DatabaseConfig) Database {
+	if config.Type == types.DatabaseTypePostgres {
+		return NewPostgresDatabase(logger, config)
+	}
+
+	return NewSqliteDatabase(logger, config)
+}
+
+// hasPendingMigrations returns whether any of the migrations from the directory is not applied,
+// without applying them or creating the migrations table, unlike goose does.
+func hasPendingMigrations(client *sql.DB, dir string) (bool, error) {
+	goose.SetBaseFS(migrationsPkg.EmbedFS)
+
+	migrations, err := goose.CollectMigrations(dir, 0, goose.MaxVersion)
+	if err != nil {
+		return false, err
+	}
+
+	rows, err := client.Query("SELECT version_id, is_applied FROM goose_db_version ORDER BY id")
+	if err != nil {
+		return false, err
+	}
+	defer rows.Close()
+
+	// the latest row for each version tells whether it's applied or rolled back
+	applied := make(map[int64]bool)
+	for rows.Next() {
+		var (
+			version   int64
+			isApplied bool
+		)
+
+		if err := rows.Scan(&version, &isApplied); err != nil {
+			return false, err
+		}
+
+		applied[version] = isApplied
+	}
+
+	if err := rows.Err(); err != nil {
+		return false, err
+	}
+
+	for _, migration := range migrations {
+		if !applied[migration.Version] {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+type Logger struct {
+	Logger zerolog.Logger
+}
+
+func (l *Logger) Printf(format string, v ...interface{}) {
+	l.Logger.Debug().Msgf(strings.TrimSpace(format), v...)
+}
+
+func (l *Logger) Fatalf(format string, v ...interface{}) {
+	l.Logger.Panic().Msgf(strings.TrimSpace(format), v...)
 }
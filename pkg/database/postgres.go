@@ -0,0 +1,1003 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	migrationsPkg "main/migrations"
+	"main/pkg/types"
+	"strings"
+	"time"
+
+	"github.com/guregu/null/v5"
+	_ "github.com/lib/pq"
+	"github.com/pressly/goose/v3"
+
+	"github.com/rs/zerolog"
+)
+
+type PostgresDatabase struct {
+	logger         zerolog.Logger
+	config         types.DatabaseConfig
+	client         *sql.DB
+	databaseLogger goose.Logger
+}
+
+func NewPostgresDatabase(
+	logger *zerolog.Logger,
+	config types.DatabaseConfig,
+) *PostgresDatabase {
+	return &PostgresDatabase{
+		logger: logger.With().Str("component", "database").Logger(),
+		config: config,
+		databaseLogger: &Logger{
+			Logger: logger.With().Str("component", "migrations").Logger(),
+		},
+	}
+}
+
+func (d *PostgresDatabase) Init() {
+	db, err := sql.Open("postgres", d.config.URL)
+
+	if err != nil {
+		d.logger.Panic().Err(err).Msg("Could not open PostgreSQL database")
+	}
+
+	var version string
+	if versionErr := db.QueryRow("SELECT version()").Scan(&version); versionErr != nil {
+		d.logger.Panic().Err(versionErr).Msg("Could not query PostgreSQL database")
+	}
+
+	d.logger.Info().
+		Str("version", version).
+		Msg("PostgreSQL database connected")
+
+	d.client = db
+}
+
+func (d *PostgresDatabase) Migrate() {
+	goose.SetBaseFS(migrationsPkg.EmbedFS)
+	goose.SetLogger(d.databaseLogger)
+
+	_ = goose.SetDialect("postgres")
+
+	if err := goose.Up(d.client, "postgres"); err != nil {
+		d.logger.Panic().Err(err).Msg("Could not apply migrations")
+	}
+}
+
+func (d *PostgresDatabase) HasPendingMigrations() (bool, error) {
+	return hasPendingMigrations(d.client, "postgres")
+}
+
+func (d *PostgresDatabase) Rollback() {
+	goose.SetBaseFS(migrationsPkg.EmbedFS)
+	goose.SetLogger(d.databaseLogger)
+
+	_ = goose.SetDialect("postgres")
+
+	if err := goose.Reset(d.client, "postgres"); err != nil {
+		if errors.Is(err, goose.ErrNoCurrentVersion) {
+			d.logger.Info().Err(err).Msg("No migrations are applied, cannot rollback")
+		} else {
+			d.logger.Panic().Err(err).Msg("Could not rollback migrations")
+		}
+	}
+}
+
+func (d *PostgresDatabase) UpsertProposal(
+	chain *types.Chain,
+	proposal types.Proposal,
+) error {
+	_, err := d.client.Exec(
+		"INSERT INTO proposals (chain, id, title, description, status, end_time, expedited) VALUES ($1, $2, $3, $4, $5, $6, $7) ON CONFLICT (chain, id) DO UPDATE SET title = $3, description = $4, status = $5, end_time = $6, expedited = $7",
+		chain.Name,
+		proposal.ID,
+		proposal.Title,
+		proposal.Description,
+		proposal.Status,
+		proposal.EndTime,
+		proposal.Expedited,
+	)
+	if err != nil {
+		d.logger.Error().Err(err).Msg("Could not upsert proposal")
+		return err
+	}
+
+	return nil
+}
+
+func (d *PostgresDatabase) GetProposal(chain *types.Chain, proposalID string) (*types.Proposal, error) {
+	proposal := &types.Proposal{}
+	row := d.client.QueryRow(
+		"SELECT id, title, description, status, end_time, expedited FROM proposals WHERE chain = $1 AND id = $2 LIMIT 1",
+		chain.Name,
+		proposalID,
+	)
+
+	err := row.Scan(
+		&proposal.ID,
+		&proposal.Title,
+		&proposal.Description,
+		&proposal.Status,
+		&proposal.EndTime,
+		&proposal.Expedited,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil //nolint:nilnil
+		}
+
+		d.logger.Error().Err(err).Msg("Error getting proposal")
+		return nil, err
+	}
+
+	return proposal, nil
+}
+
+func (d *PostgresDatabase) GetVote(
+	chain *types.Chain,
+	proposal types.Proposal,
+	wallet *types.Wallet,
+) (*types.Vote, error) {
+	rows, err := d.client.Query(
+		"SELECT vote_option, vote_weight FROM votes WHERE chain = $1 AND proposal_id = $2 AND wallet = $3",
+		chain.Name,
+		proposal.ID,
+		wallet.Address,
+	)
+	if err != nil {
+		d.logger.Error().Err(err).Msg("Error getting vote")
+		return nil, err
+	}
+	defer func() {
+		_ = rows.Close()
+		_ = rows.Err()
+	}()
+
+	vote := &types.Vote{
+		ProposalID: proposal.ID,
+		Voter:      wallet.Address,
+		Options:    make(types.VoteOptions, 0),
+	}
+
+	for rows.Next() {
+		voteOption := types.VoteOption{}
+
+		scanErr := rows.Scan(&voteOption.Option, &voteOption.Weight)
+		if scanErr != nil {
+			d.logger.Error().Err(scanErr).Msg("Error getting vote")
+			return nil, scanErr
+		}
+
+		vote.Options = append(vote.Options, voteOption)
+	}
+
+	if len(vote.Options) == 0 {
+		return nil, nil //nolint:nilnil
+	}
+
+	return vote, nil
+}
+
+func (d *PostgresDatabase) UpsertVote(
+	chain *types.Chain,
+	proposal types.Proposal,
+	wallet *types.Wallet,
+	vote *types.Vote,
+	ctx context.Context,
+) error {
+	tx, err := d.client.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	defer tx.Rollback() //nolint:errcheck
+
+	if _, deleteErr := tx.Exec(
+		"DELETE FROM votes WHERE chain = $1 AND proposal_id = $2 AND wallet = $3",
+		chain.Name,
+		proposal.ID,
+		wallet.Address,
+	); deleteErr != nil {
+		d.logger.Error().Err(deleteErr).Msg("Error deleting votes")
+		return deleteErr
+	}
+
+	for _, option := range vote.Options {
+		if _, insertErr := tx.Exec(
+			"INSERT INTO votes (chain, proposal_id, wallet, vote_option, vote_weight) VALUES ($1, $2, $3, $4, $5)",
+			chain.Name,
+			proposal.ID,
+			wallet.Address,
+			option.Option,
+			option.Weight,
+		); insertErr != nil {
+			d.logger.Error().Err(insertErr).Msg("Error inserting votes")
+			return insertErr
+		}
+	}
+
+	if commitErr := tx.Commit(); commitErr != nil {
+		d.logger.Error().Err(commitErr).Msg("Error committing votes")
+		return commitErr
+	}
+
+	return nil
+}
+
+func (d *PostgresDatabase) GetLastBlockHeight(
+	chain *types.Chain,
+	storableKey string,
+) (int64, error) {
+	row := d.client.QueryRow(
+		"SELECT height FROM query_last_block WHERE chain = $1 AND query = $2 LIMIT 1",
+		chain.Name,
+		storableKey,
+	)
+
+	var lastBlockHeight int64 = 0
+
+	if err := row.Scan(&lastBlockHeight); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, nil
+		}
+
+		d.logger.Error().Err(err).Msg("Error getting last block height")
+		return 0, err
+	}
+
+	return lastBlockHeight, nil
+}
+
+func (d *PostgresDatabase) UpsertLastBlockHeight(
+	chain *types.Chain,
+	storableKey string,
+	height int64,
+) error {
+	_, err := d.client.Exec(
+		"INSERT INTO query_last_block (chain, query, height) VALUES ($1, $2, $3) ON CONFLICT (chain, query) DO UPDATE SET height = $3",
+		chain.Name,
+		storableKey,
+		height,
+	)
+	if err != nil {
+		d.logger.Error().Err(err).Msg("Could not upsert last block height")
+		return err
+	}
+
+	return nil
+}
+
+func (d *PostgresDatabase) UpsertMute(mute *types.Mute) error {
+	_, err := d.client.Exec(
+		"INSERT INTO mutes (chain, proposal_id, wallet, event, title_pattern, destination, expires, comment, author, source, created_at) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11) ON CONFLICT (COALESCE(chain, ''), COALESCE(proposal_id, ''), COALESCE(wallet, ''), COALESCE(event, ''), COALESCE(title_pattern, ''), COALESCE(destination, '')) DO UPDATE SET expires = $7, comment = $8, author = $9, source = $10, created_at = $11",
+		mute.Chain,
+		mute.ProposalID,
+		mute.Wallet,
+		mute.Event,
+		mute.TitlePattern,
+		mute.Destination,
+		mute.Expires,
+		mute.Comment,
+		mute.Author,
+		mute.Source,
+		mute.CreatedAt,
+	)
+	if err != nil {
+		d.logger.Error().Err(err).Msg("Could not upsert mute")
+		return err
+	}
+
+	return nil
+}
+
+func (d *PostgresDatabase) GetAllMutes() ([]*types.Mute, error) {
+	mutes := make([]*types.Mute, 0)
+
+	rows, err := d.client.Query("SELECT chain, proposal_id, wallet, event, title_pattern, destination, expires, comment, author, source, created_at FROM mutes")
+	if err != nil {
+		d.logger.Error().Err(err).Msg("Error getting all mutes")
+		return mutes, err
+	}
+	defer func() {
+		_ = rows.Close()
+		_ = rows.Err()
+	}()
+
+	for rows.Next() {
+		mute := &types.Mute{}
+
+		err = rows.Scan(
+			&mute.Chain,
+			&mute.ProposalID,
+			&mute.Wallet,
+			&mute.Event,
+			&mute.TitlePattern,
+			&mute.Destination,
+			&mute.Expires,
+			&mute.Comment,
+			&mute.Author,
+			&mute.Source,
+			&mute.CreatedAt,
+		)
+		if err != nil {
+			d.logger.Error().Err(err).Msg("Error getting mute")
+			return mutes, err
+		}
+
+		mutes = append(mutes, mute)
+	}
+
+	return mutes, nil
+}
+
+// DeleteMute deletes the mute and moves it to the mutes history.
+func (d *PostgresDatabase) DeleteMute(mute *types.Mute, removedBy string) (bool, error) {
+	tx, err := d.client.Begin()
+	if err != nil {
+		d.logger.Error().Err(err).Msg("Could not begin transaction on deleting mute")
+		return false, err
+	}
+
+	defer tx.Rollback() //nolint:errcheck
+
+	if _, err := tx.Exec(
+		"INSERT INTO mutes_history (chain, proposal_id, wallet, event, title_pattern, destination, expires, comment, author, source, created_at, reason, removed_at, removed_by) SELECT chain, proposal_id, wallet, event, title_pattern, destination, expires, comment, author, source, created_at, $1::TEXT, $2::TIMESTAMPTZ, $3::TEXT FROM mutes WHERE COALESCE(chain, '') = $4 AND COALESCE(proposal_id, '') = $5 AND COALESCE(wallet, '') = $6 AND COALESCE(event, '') = $7 AND COALESCE(title_pattern, '') = $8 AND COALESCE(destination, '') = $9",
+		types.MuteRemovedReasonDeleted,
+		time.Now(),
+		null.NewString(removedBy, removedBy != ""),
+		mute.Chain.String,
+		mute.ProposalID.String,
+		mute.Wallet.String,
+		mute.Event.String,
+		mute.TitlePattern.String,
+		mute.Destination.String,
+	); err != nil {
+		d.logger.Error().Err(err).Msg("Could not move mute to history")
+		return false, err
+	}
+
+	result, err := tx.Exec(
+		"DELETE FROM mutes WHERE COALESCE(chain, '') = $1 AND COALESCE(proposal_id, '') = $2 AND COALESCE(wallet, '') = $3 AND COALESCE(event, '') = $4 AND COALESCE(title_pattern, '') = $5 AND COALESCE(destination, '') = $6",
+		mute.Chain.String,
+		mute.ProposalID.String,
+		mute.Wallet.String,
+		mute.Event.String,
+		mute.TitlePattern.String,
+		mute.Destination.String,
+	)
+	if err != nil {
+		d.logger.Error().Err(err).Msg("Could not delete mute")
+		return false, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		d.logger.Error().Err(err).Msg("Could not get affected rows on deleting mute")
+		return false, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		d.logger.Error().Err(err).Msg("Could not commit transaction on deleting mute")
+		return false, err
+	}
+
+	return rowsAffected > 0, nil
+}
+
+// ArchiveExpiredMutes moves the mutes that have expired to the mutes history,
+// returning how many were moved.
+func (d *PostgresDatabase) ArchiveExpiredMutes() (int64, error) {
+	tx, err := d.client.Begin()
+	if err != nil {
+		d.logger.Error().Err(err).Msg("Could not begin transaction on archiving expired mutes")
+		return 0, err
+	}
+
+	defer tx.Rollback() //nolint:errcheck
+
+	now := time.Now()
+
+	if _, err := tx.Exec(
+		"INSERT INTO mutes_history (chain, proposal_id, wallet, event, title_pattern, destination, expires, comment, author, source, created_at, reason, removed_at) SELECT chain, proposal_id, wallet, event, title_pattern, destination, expires, comment, author, source, created_at, $1::TEXT, expires FROM mutes WHERE expires < $2",
+		types.MuteRemovedReasonExpired,
+		now,
+	); err != nil {
+		d.logger.Error().Err(err).Msg("Could not move expired mutes to history")
+		return 0, err
+	}
+
+	result, err := tx.Exec("DELETE FROM mutes WHERE expires < $1", now)
+	if err != nil {
+		d.logger.Error().Err(err).Msg("Could not delete expired mutes")
+		return 0, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		d.logger.Error().Err(err).Msg("Could not get affected rows on archiving expired mutes")
+		return 0, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		d.logger.Error().Err(err).Msg("Could not commit transaction on archiving expired mutes")
+		return 0, err
+	}
+
+	return rowsAffected, nil
+}
+
+func (d *PostgresDatabase) GetMutesHistory(limit int) ([]*types.MuteHistoryEntry, error) {
+	entries := make([]*types.MuteHistoryEntry, 0)
+
+	rows, err := d.client.Query(
+		"SELECT chain, proposal_id, wallet, event, title_pattern, destination, expires, comment, author, source, created_at, reason, removed_at, removed_by FROM mutes_history ORDER BY removed_at DESC LIMIT $1",
+		limit,
+	)
+	if err != nil {
+		d.logger.Error().Err(err).Msg("Error getting mutes history")
+		return entries, err
+	}
+	defer func() {
+		_ = rows.Close()
+		_ = rows.Err()
+	}()
+
+	for rows.Next() {
+		entry := &types.MuteHistoryEntry{}
+
+		err = rows.Scan(
+			&entry.Chain,
+			&entry.ProposalID,
+			&entry.Wallet,
+			&entry.Event,
+			&entry.TitlePattern,
+			&entry.Destination,
+			&entry.Expires,
+			&entry.Comment,
+			&entry.Author,
+			&entry.Source,
+			&entry.CreatedAt,
+			&entry.Reason,
+			&entry.RemovedAt,
+			&entry.RemovedBy,
+		)
+		if err != nil {
+			d.logger.Error().Err(err).Msg("Error getting mutes history entry")
+			return entries, err
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+// IsMuted only checks mutes without a title pattern, as these are matched in mutes.Manager.
+func (d *PostgresDatabase) IsMuted(query types.MuteQuery) (bool, error) {
+	row := d.client.QueryRow(
+		"SELECT COUNT(*) FROM mutes WHERE (chain IS NULL OR chain = $1) AND (proposal_id IS NULL OR proposal_id = $2) AND (wallet IS NULL OR wallet = $3 OR wallet = $4) AND (event IS NULL OR event = $5) AND (destination IS NULL OR destination = $6) AND title_pattern IS NULL AND expires >= NOW()",
+		query.Chain,
+		query.ProposalID,
+		query.WalletAddress,
+		query.WalletAlias,
+		query.Event,
+		query.Destination,
+	)
+
+	if err := row.Err(); err != nil {
+		d.logger.Error().Err(err).Msg("Could not check if entry was muted")
+		return false, err
+	}
+
+	count := 0
+
+	if err := row.Scan(&count); err != nil {
+		d.logger.Error().Err(err).Msg("Could not scan to check entry was muted")
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+func (d *PostgresDatabase) GetSentReminders(
+	chain *types.Chain,
+	proposal types.Proposal,
+	wallet *types.Wallet,
+) ([]string, error) {
+	reminders := make([]string, 0)
+
+	rows, err := d.client.Query(
+		"SELECT reminder FROM reminders WHERE chain = $1 AND proposal_id = $2 AND wallet = $3",
+		chain.Name,
+		proposal.ID,
+		wallet.Address,
+	)
+	if err != nil {
+		d.logger.Error().Err(err).Msg("Error getting sent reminders")
+		return reminders, err
+	}
+	defer func() {
+		_ = rows.Close()
+		_ = rows.Err()
+	}()
+
+	for rows.Next() {
+		var reminder string
+
+		if scanErr := rows.Scan(&reminder); scanErr != nil {
+			d.logger.Error().Err(scanErr).Msg("Error getting sent reminder")
+			return reminders, scanErr
+		}
+
+		reminders = append(reminders, reminder)
+	}
+
+	return reminders, nil
+}
+
+func (d *PostgresDatabase) InsertSentReminders(
+	chain *types.Chain,
+	proposal types.Proposal,
+	wallet *types.Wallet,
+	reminders []string,
+) error {
+	for _, reminder := range reminders {
+		if _, err := d.client.Exec(
+			"INSERT INTO reminders (chain, proposal_id, wallet, reminder, sent_at) VALUES ($1, $2, $3, $4, $5) ON CONFLICT DO NOTHING",
+			chain.Name,
+			proposal.ID,
+			wallet.Address,
+			reminder,
+			time.Now(),
+		); err != nil {
+			d.logger.Error().Err(err).Msg("Could not insert sent reminder")
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (d *PostgresDatabase) InsertIncident(incident *types.Incident) error {
+	_, err := d.client.Exec(
+		"INSERT INTO incidents (reporter, chain, proposal_id, wallet, dedup_key, created_at) VALUES ($1, $2, $3, $4, $5, $6) ON CONFLICT DO NOTHING",
+		incident.Reporter,
+		incident.Chain,
+		incident.ProposalID,
+		incident.Wallet,
+		incident.DedupKey,
+		incident.CreatedAt,
+	)
+	if err != nil {
+		d.logger.Error().Err(err).Msg("Could not insert incident")
+		return err
+	}
+
+	return nil
+}
+
+func (d *PostgresDatabase) GetIncidents(reporter string) ([]*types.Incident, error) {
+	incidents := make([]*types.Incident, 0)
+
+	rows, err := d.client.Query(
+		"SELECT reporter, chain, proposal_id, wallet, dedup_key, created_at FROM incidents WHERE reporter = $1",
+		reporter,
+	)
+	if err != nil {
+		d.logger.Error().Err(err).Msg("Error getting incidents")
+		return incidents, err
+	}
+	defer func() {
+		_ = rows.Close()
+		_ = rows.Err()
+	}()
+
+	for rows.Next() {
+		incident := &types.Incident{}
+
+		if scanErr := rows.Scan(
+			&incident.Reporter,
+			&incident.Chain,
+			&incident.ProposalID,
+			&incident.Wallet,
+			&incident.DedupKey,
+			&incident.CreatedAt,
+		); scanErr != nil {
+			d.logger.Error().Err(scanErr).Msg("Error getting incident")
+			return incidents, scanErr
+		}
+
+		incidents = append(incidents, incident)
+	}
+
+	return incidents, nil
+}
+
+func (d *PostgresDatabase) DeleteIncident(reporter, dedupKey string) error {
+	_, err := d.client.Exec(
+		"DELETE FROM incidents WHERE reporter = $1 AND dedup_key = $2",
+		reporter,
+		dedupKey,
+	)
+	if err != nil {
+		d.logger.Error().Err(err).Msg("Could not delete incident")
+		return err
+	}
+
+	return nil
+}
+
+func (d *PostgresDatabase) GetSpamFlag(chain, proposalID string) (*types.SpamFlag, error) {
+	flag := &types.SpamFlag{}
+	reasons := ""
+
+	row := d.client.QueryRow(
+		"SELECT chain, proposal_id, reasons, flagged, updated_at, updated_by FROM spam_flags WHERE chain = $1 AND proposal_id = $2",
+		chain,
+		proposalID,
+	)
+
+	if err := row.Scan(
+		&flag.Chain,
+		&flag.ProposalID,
+		&reasons,
+		&flag.Flagged,
+		&flag.UpdatedAt,
+		&flag.UpdatedBy,
+	); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil //nolint:nilnil
+		}
+
+		d.logger.Error().Err(err).Msg("Error getting spam flag")
+		return nil, err
+	}
+
+	flag.Reasons = strings.Split(reasons, "\n")
+	return flag, nil
+}
+
+func (d *PostgresDatabase) UpsertSpamFlag(flag *types.SpamFlag) error {
+	_, err := d.client.Exec(
+		"INSERT INTO spam_flags (chain, proposal_id, reasons, flagged, updated_at, updated_by) VALUES ($1, $2, $3, $4, $5, $6) ON CONFLICT (chain, proposal_id) DO UPDATE SET reasons = $3, flagged = $4, updated_at = $5, updated_by = $6",
+		flag.Chain,
+		flag.ProposalID,
+		strings.Join(flag.Reasons, "\n"),
+		flag.Flagged,
+		flag.UpdatedAt,
+		flag.UpdatedBy,
+	)
+	if err != nil {
+		d.logger.Error().Err(err).Msg("Could not upsert spam flag")
+		return err
+	}
+
+	return nil
+}
+
+func (d *PostgresDatabase) GetDigestSnapshot() (*types.DigestSnapshot, error) {
+	snapshot := &types.DigestSnapshot{
+		Votes: make([]types.DigestSnapshotVote, 0),
+	}
+
+	row := d.client.QueryRow("SELECT sent_at FROM digests LIMIT 1")
+	if err := row.Scan(&snapshot.SentAt); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil //nolint:nilnil
+		}
+
+		d.logger.Error().Err(err).Msg("Error getting digest")
+		return nil, err
+	}
+
+	rows, err := d.client.Query("SELECT chain, proposal_id, wallet, vote FROM digest_votes")
+	if err != nil {
+		d.logger.Error().Err(err).Msg("Error getting digest votes")
+		return nil, err
+	}
+	defer func() {
+		_ = rows.Close()
+		_ = rows.Err()
+	}()
+
+	for rows.Next() {
+		vote := types.DigestSnapshotVote{}
+
+		if scanErr := rows.Scan(&vote.Chain, &vote.ProposalID, &vote.Wallet, &vote.Vote); scanErr != nil {
+			d.logger.Error().Err(scanErr).Msg("Error getting digest vote")
+			return nil, scanErr
+		}
+
+		snapshot.Votes = append(snapshot.Votes, vote)
+	}
+
+	return snapshot, nil
+}
+
+func (d *PostgresDatabase) SaveDigestSnapshot(snapshot *types.DigestSnapshot) error {
+	tx, err := d.client.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer tx.Rollback() //nolint:errcheck
+
+	for _, query := range []string{"DELETE FROM digests", "DELETE FROM digest_votes"} {
+		if _, deleteErr := tx.Exec(query); deleteErr != nil {
+			d.logger.Error().Err(deleteErr).Msg("Error deleting previous digest")
+			return deleteErr
+		}
+	}
+
+	if _, insertErr := tx.Exec("INSERT INTO digests (sent_at) VALUES ($1)", snapshot.SentAt); insertErr != nil {
+		d.logger.Error().Err(insertErr).Msg("Error inserting digest")
+		return insertErr
+	}
+
+	for _, vote := range snapshot.Votes {
+		if _, insertErr := tx.Exec(
+			"INSERT INTO digest_votes (chain, proposal_id, wallet, vote) VALUES ($1, $2, $3, $4)",
+			vote.Chain,
+			vote.ProposalID,
+			vote.Wallet,
+			vote.Vote,
+		); insertErr != nil {
+			d.logger.Error().Err(insertErr).Msg("Error inserting digest vote")
+			return insertErr
+		}
+	}
+
+	if commitErr := tx.Commit(); commitErr != nil {
+		d.logger.Error().Err(commitErr).Msg("Error committing digest")
+		return commitErr
+	}
+
+	return nil
+}
+
+func (d *PostgresDatabase) InsertOutboxEntry(entry *types.OutboxEntry) error {
+	row := d.client.QueryRow(
+		"INSERT INTO outbox (reporter, event, chain, proposal_id, wallet, payload, destinations, status, attempts, last_error, next_attempt_at, created_at) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12) RETURNING id",
+		entry.Reporter,
+		entry.Event,
+		entry.Chain,
+		entry.ProposalID,
+		entry.Wallet,
+		entry.Payload,
+		entry.Destinations,
+		entry.Status,
+		entry.Attempts,
+		entry.LastError,
+		entry.NextAttemptAt,
+		entry.CreatedAt,
+	)
+
+	if err := row.Scan(&entry.ID); err != nil {
+		d.logger.Error().Err(err).Msg("Could not insert outbox entry")
+		return err
+	}
+
+	return nil
+}
+
+func (d *PostgresDatabase) UpdateOutboxEntry(entry *types.OutboxEntry) error {
+	_, err := d.client.Exec(
+		"UPDATE outbox SET destinations = $1, status = $2, attempts = $3, last_error = $4, next_attempt_at = $5 WHERE id = $6",
+		entry.Destinations,
+		entry.Status,
+		entry.Attempts,
+		entry.LastError,
+		entry.NextAttemptAt,
+		entry.ID,
+	)
+	if err != nil {
+		d.logger.Error().Err(err).Msg("Could not update outbox entry")
+		return err
+	}
+
+	return nil
+}
+
+func (d *PostgresDatabase) DeleteOutboxEntry(id int64) error {
+	if _, err := d.client.Exec("DELETE FROM outbox WHERE id = $1", id); err != nil {
+		d.logger.Error().Err(err).Msg("Could not delete outbox entry")
+		return err
+	}
+
+	return nil
+}
+
+func (d *PostgresDatabase) GetDueOutboxEntries(now time.Time) ([]*types.OutboxEntry, error) {
+	return d.getOutboxEntries(
+		"SELECT id, reporter, event, chain, proposal_id, wallet, payload, destinations, status, attempts, last_error, next_attempt_at, created_at FROM outbox WHERE status = $1 AND next_attempt_at <= $2 ORDER BY id",
+		types.OutboxStatusPending,
+		now,
+	)
+}
+
+func (d *PostgresDatabase) GetUndeliveredOutboxEntries() ([]*types.OutboxEntry, error) {
+	return d.getOutboxEntries(
+		"SELECT id, reporter, event, chain, proposal_id, wallet, payload, destinations, status, attempts, last_error, next_attempt_at, created_at FROM outbox ORDER BY id",
+	)
+}
+
+func (d *PostgresDatabase) getOutboxEntries(query string, args ...interface{}) ([]*types.OutboxEntry, error) {
+	entries := make([]*types.OutboxEntry, 0)
+
+	rows, err := d.client.Query(query, args...)
+	if err != nil {
+		d.logger.Error().Err(err).Msg("Error getting outbox entries")
+		return entries, err
+	}
+	defer func() {
+		_ = rows.Close()
+		_ = rows.Err()
+	}()
+
+	for rows.Next() {
+		entry := &types.OutboxEntry{}
+
+		if scanErr := rows.Scan(
+			&entry.ID,
+			&entry.Reporter,
+			&entry.Event,
+			&entry.Chain,
+			&entry.ProposalID,
+			&entry.Wallet,
+			&entry.Payload,
+			&entry.Destinations,
+			&entry.Status,
+			&entry.Attempts,
+			&entry.LastError,
+			&entry.NextAttemptAt,
+			&entry.CreatedAt,
+		); scanErr != nil {
+			d.logger.Error().Err(scanErr).Msg("Error getting outbox entry")
+			return entries, scanErr
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+func (d *PostgresDatabase) GetAlertMessage(destination, chain, proposalID, wallet string) (*types.AlertMessage, error) {
+	message := &types.AlertMessage{}
+
+	row := d.client.QueryRow(
+		"SELECT destination, chain, proposal_id, wallet, message_id, action, created_at, updated_at FROM alert_messages WHERE destination = $1 AND chain = $2 AND proposal_id = $3 AND wallet = $4",
+		destination,
+		chain,
+		proposalID,
+		wallet,
+	)
+
+	if err := row.Scan(
+		&message.Destination,
+		&message.Chain,
+		&message.ProposalID,
+		&message.Wallet,
+		&message.MessageID,
+		&message.Action,
+		&message.CreatedAt,
+		&message.UpdatedAt,
+	); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil //nolint:nilnil
+		}
+
+		d.logger.Error().Err(err).Msg("Error getting alert message")
+		return nil, err
+	}
+
+	return message, nil
+}
+
+func (d *PostgresDatabase) UpsertAlertMessage(message *types.AlertMessage) error {
+	_, err := d.client.Exec(
+		"INSERT INTO alert_messages (destination, chain, proposal_id, wallet, message_id, action, created_at, updated_at) VALUES ($1, $2, $3, $4, $5, $6, $7, $8) ON CONFLICT (destination, chain, proposal_id, wallet) DO UPDATE SET message_id = $5, action = $6, updated_at = $8",
+		message.Destination,
+		message.Chain,
+		message.ProposalID,
+		message.Wallet,
+		message.MessageID,
+		message.Action,
+		message.CreatedAt,
+		message.UpdatedAt,
+	)
+	if err != nil {
+		d.logger.Error().Err(err).Msg("Could not upsert alert message")
+		return err
+	}
+
+	return nil
+}
+
+func (d *PostgresDatabase) SetAlertMessageAction(destination, messageID, action string) error {
+	_, err := d.client.Exec(
+		"UPDATE alert_messages SET action = $1 WHERE destination = $2 AND message_id = $3",
+		action,
+		destination,
+		messageID,
+	)
+	if err != nil {
+		d.logger.Error().Err(err).Msg("Could not set alert message action")
+		return err
+	}
+
+	return nil
+}
+
+func (d *PostgresDatabase) DeleteAlertMessage(message *types.AlertMessage) error {
+	_, err := d.client.Exec(
+		"DELETE FROM alert_messages WHERE destination = $1 AND chain = $2 AND proposal_id = $3 AND wallet = $4",
+		message.Destination,
+		message.Chain,
+		message.ProposalID,
+		message.Wallet,
+	)
+	if err != nil {
+		d.logger.Error().Err(err).Msg("Could not delete alert message")
+		return err
+	}
+
+	return nil
+}
+
+func (d *PostgresDatabase) DeleteProposalAlertMessages(destination, chain, proposalID string) error {
+	_, err := d.client.Exec(
+		"DELETE FROM alert_messages WHERE destination = $1 AND chain = $2 AND proposal_id = $3",
+		destination,
+		chain,
+		proposalID,
+	)
+	if err != nil {
+		d.logger.Error().Err(err).Msg("Could not delete proposal alert messages")
+		return err
+	}
+
+	return nil
+}
+
+func (d *PostgresDatabase) GetDashboardMessage(destination string) (*types.DashboardMessage, error) {
+	message := &types.DashboardMessage{}
+
+	row := d.client.QueryRow(
+		"SELECT destination, message_id, updated_at FROM dashboards WHERE destination = $1",
+		destination,
+	)
+
+	if err := row.Scan(&message.Destination, &message.MessageID, &message.UpdatedAt); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil //nolint:nilnil
+		}
+
+		d.logger.Error().Err(err).Msg("Error getting dashboard message")
+		return nil, err
+	}
+
+	return message, nil
+}
+
+func (d *PostgresDatabase) UpsertDashboardMessage(message *types.DashboardMessage) error {
+	_, err := d.client.Exec(
+		"INSERT INTO dashboards (destination, message_id, updated_at) VALUES ($1, $2, $3) ON CONFLICT (destination) DO UPDATE SET message_id = $2, updated_at = $3",
+		message.Destination,
+		message.MessageID,
+		message.UpdatedAt,
+	)
+	if err != nil {
+		d.logger.Error().Err(err).Msg("Could not upsert dashboard message")
+		return err
+	}
+
+	return nil
+}
+
+func (d *PostgresDatabase) Destroy() error {
+	d.Rollback()
+	return d.client.Close()
+}
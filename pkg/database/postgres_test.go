@@ -0,0 +1,65 @@
+package database
+
+import (
+	"testing"
+)
+
+//nolint:paralleltest
+func TestPostgresMigrations(t *testing.T) {
+	testDatabaseMigrations(t, getPostgresTestDatabase(t))
+}
+
+//nolint:paralleltest
+func TestPostgresProposal(t *testing.T) {
+	testDatabaseProposal(t, getPostgresTestDatabase(t))
+}
+
+//nolint:paralleltest
+func TestPostgresVote(t *testing.T) {
+	testDatabaseVote(t, getPostgresTestDatabase(t))
+}
+
+//nolint:paralleltest
+func TestPostgresLastBlockHeight(t *testing.T) {
+	testDatabaseLastBlockHeight(t, getPostgresTestDatabase(t))
+}
+
+//nolint:paralleltest
+func TestPostgresMutes(t *testing.T) {
+	testDatabaseMutes(t, getPostgresTestDatabase(t))
+}
+
+//nolint:paralleltest
+func TestPostgresReminders(t *testing.T) {
+	testDatabaseReminders(t, getPostgresTestDatabase(t))
+}
+
+//nolint:paralleltest
+func TestPostgresIncidents(t *testing.T) {
+	testDatabaseIncidents(t, getPostgresTestDatabase(t))
+}
+
+//nolint:paralleltest
+func TestPostgresSpamFlags(t *testing.T) {
+	testDatabaseSpamFlags(t, getPostgresTestDatabase(t))
+}
+
+//nolint:paralleltest
+func TestPostgresDigest(t *testing.T) {
+	testDatabaseDigest(t, getPostgresTestDatabase(t))
+}
+
+//nolint:paralleltest
+func TestPostgresOutbox(t *testing.T) {
+	testDatabaseOutbox(t, getPostgresTestDatabase(t))
+}
+
+//nolint:paralleltest
+func TestPostgresAlertMessages(t *testing.T) {
+	testDatabaseAlertMessages(t, getPostgresTestDatabase(t))
+}
+
+//nolint:paralleltest
+func TestPostgresDashboards(t *testing.T) {
+	testDatabaseDashboards(t, getPostgresTestDatabase(t))
+}
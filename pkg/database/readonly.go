@@ -0,0 +1,110 @@
+package database
+
+import (
+	"context"
+	"main/pkg/types"
+)
+
+// ReadOnlyDatabase wraps another database, passing reads through to it
+// and ignoring all writes. Used for dry runs.
+type ReadOnlyDatabase struct {
+	Database
+}
+
+func NewReadOnlyDatabase(database Database) *ReadOnlyDatabase {
+	return &ReadOnlyDatabase{Database: database}
+}
+
+// Migrate does nothing, as applying migrations changes the database schema.
+func (d *ReadOnlyDatabase) Migrate() {
+}
+
+func (d *ReadOnlyDatabase) Rollback() {
+}
+
+func (d *ReadOnlyDatabase) UpsertProposal(chain *types.Chain, proposal types.Proposal) error {
+	return nil
+}
+
+func (d *ReadOnlyDatabase) UpsertVote(
+	chain *types.Chain,
+	proposal types.Proposal,
+	wallet *types.Wallet,
+	vote *types.Vote,
+	ctx context.Context,
+) error {
+	return nil
+}
+
+func (d *ReadOnlyDatabase) UpsertLastBlockHeight(chain *types.Chain, storableKey string, height int64) error {
+	return nil
+}
+
+func (d *ReadOnlyDatabase) UpsertMute(mute *types.Mute) error {
+	return nil
+}
+
+func (d *ReadOnlyDatabase) DeleteMute(mute *types.Mute, removedBy string) (bool, error) {
+	return false, nil
+}
+
+func (d *ReadOnlyDatabase) ArchiveExpiredMutes() (int64, error) {
+	return 0, nil
+}
+
+func (d *ReadOnlyDatabase) InsertSentReminders(
+	chain *types.Chain,
+	proposal types.Proposal,
+	wallet *types.Wallet,
+	reminders []string,
+) error {
+	return nil
+}
+
+func (d *ReadOnlyDatabase) InsertIncident(incident *types.Incident) error {
+	return nil
+}
+
+func (d *ReadOnlyDatabase) DeleteIncident(reporter, dedupKey string) error {
+	return nil
+}
+
+func (d *ReadOnlyDatabase) UpsertSpamFlag(flag *types.SpamFlag) error {
+	return nil
+}
+
+func (d *ReadOnlyDatabase) SaveDigestSnapshot(snapshot *types.DigestSnapshot) error {
+	return nil
+}
+
+func (d *ReadOnlyDatabase) InsertOutboxEntry(entry *types.OutboxEntry) error {
+	return nil
+}
+
+func (d *ReadOnlyDatabase) UpdateOutboxEntry(entry *types.OutboxEntry) error {
+	return nil
+}
+
+func (d *ReadOnlyDatabase) DeleteOutboxEntry(id int64) error {
+	return nil
+}
+
+func (d *ReadOnlyDatabase) UpsertAlertMessage(message *types.AlertMessage) error {
+	return nil
+}
+
+func (d *ReadOnlyDatabase) SetAlertMessageAction(destination, messageID, action string) error {
+	return nil
+}
+
+func (d *ReadOnlyDatabase) DeleteAlertMessage(message *types.AlertMessage) error {
+	return nil
+}
+
+func (d *ReadOnlyDatabase) DeleteProposalAlertMessages(destination, chain, proposalID string) error {
+	return nil
+}
+
+func (d *ReadOnlyDatabase) UpsertDashboardMessage(message *types.DashboardMessage) error {
+	return nil
+}
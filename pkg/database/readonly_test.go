@@ -0,0 +1,78 @@
+package database
+
+import (
+	"context"
+	"main/pkg/types"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadOnlyDatabaseIgnoresWrites(t *testing.T) {
+	t.Parallel()
+
+	stub := &StubDatabase{}
+	db := NewReadOnlyDatabase(stub)
+	db.Init()
+	db.Migrate()
+	db.Rollback()
+
+	chain := &types.Chain{Name: "chain"}
+	proposal := types.Proposal{ID: "proposal"}
+	wallet := &types.Wallet{Address: "wallet"}
+
+	require.NoError(t, db.UpsertProposal(chain, proposal))
+	require.NoError(t, db.UpsertVote(chain, proposal, wallet, &types.Vote{}, context.Background()))
+	require.NoError(t, db.UpsertLastBlockHeight(chain, "key", 123))
+	require.NoError(t, db.UpsertMute(&types.Mute{}))
+	require.NoError(t, db.InsertSentReminders(chain, proposal, wallet, []string{"initial"}))
+	require.NoError(t, db.InsertIncident(&types.Incident{Reporter: "reporter", DedupKey: "key"}))
+	require.NoError(t, db.DeleteIncident("reporter", "key"))
+	require.NoError(t, db.UpsertSpamFlag(&types.SpamFlag{Chain: "chain", ProposalID: "proposal"}))
+	require.NoError(t, db.SaveDigestSnapshot(&types.DigestSnapshot{}))
+	require.NoError(t, db.InsertOutboxEntry(&types.OutboxEntry{}))
+	require.NoError(t, db.UpdateOutboxEntry(&types.OutboxEntry{}))
+	require.NoError(t, db.DeleteOutboxEntry(1))
+	require.NoError(t, db.UpsertAlertMessage(&types.AlertMessage{}))
+	require.NoError(t, db.DeleteAlertMessage(&types.AlertMessage{}))
+	require.NoError(t, db.DeleteProposalAlertMessages("telegram:1", "chain", "proposal"))
+	require.NoError(t, db.UpsertDashboardMessage(&types.DashboardMessage{}))
+
+	deleted, err := db.DeleteMute(&types.Mute{}, "user")
+	require.NoError(t, err)
+	assert.False(t, deleted)
+
+	proposalFromDB, err := db.GetProposal(chain, "proposal")
+	require.NoError(t, err)
+	assert.Nil(t, proposalFromDB)
+
+	voteFromDB, err := db.GetVote(chain, proposal, wallet)
+	require.NoError(t, err)
+	assert.Nil(t, voteFromDB)
+
+	height, err := db.GetLastBlockHeight(chain, "key")
+	require.NoError(t, err)
+	assert.Zero(t, height)
+
+	mutes, err := db.GetAllMutes()
+	require.NoError(t, err)
+	assert.Empty(t, mutes)
+
+	reminders, err := db.GetSentReminders(chain, proposal, wallet)
+	require.NoError(t, err)
+	assert.Empty(t, reminders)
+
+	incidents, err := db.GetIncidents("reporter")
+	require.NoError(t, err)
+	assert.Empty(t, incidents)
+
+	assert.Empty(t, stub.Proposals)
+	assert.Empty(t, stub.Votes)
+	assert.Empty(t, stub.LastBlockHeight)
+	assert.Empty(t, stub.Reminders)
+	assert.Empty(t, stub.Incidents)
+	assert.Empty(t, stub.SpamFlags)
+	assert.Nil(t, stub.DigestSnapshot)
+	assert.Empty(t, stub.Outbox)
+}
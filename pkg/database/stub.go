@@ -3,24 +3,59 @@ package database
 import (
 	"context"
 	"main/pkg/types"
+	"sort"
+	"time"
+
+	"github.com/guregu/null/v5"
 )
 
 type StubDatabase struct {
-	LastHeightQueryErrors map[string]map[string]error
-	LastHeightWriteError  error
-	GetProposalError      error
-	UpsertProposalError   error
-	GetVoteError          error
-	UpsertVoteError       error
-	IsMutedError          error
-	UpsertMuteError       error
-	DeleteMuteError       error
-	GetAllMutesError      error
+	LastHeightQueryErrors   map[string]map[string]error
+	LastHeightWriteError    error
+	GetProposalError        error
+	UpsertProposalError     error
+	GetVoteError            error
+	UpsertVoteError         error
+	IsMutedError            error
+	UpsertMuteError         error
+	DeleteMuteError         error
+	GetAllMutesError        error
+	ArchiveMutesError       error
+	GetMutesHistoryError    error
+	GetRemindersError       error
+	InsertRemindersError    error
+	InsertIncidentError     error
+	GetIncidentsError       error
+	DeleteIncidentError     error
+	GetSpamFlagError        error
+	UpsertSpamFlagError     error
+	GetDigestError          error
+	SaveDigestError         error
+	InsertOutboxError       error
+	UpdateOutboxError       error
+	DeleteOutboxError       error
+	GetOutboxError          error
+	GetAlertMessageError    error
+	UpsertAlertMessageError error
+	DeleteAlertMessageError error
+	GetDashboardError       error
+	UpsertDashboardError    error
+
+	HasPendingMigrationsError error
+	PendingMigrations         bool
 
 	Proposals       map[string]map[string]*types.Proposal
 	Votes           map[string]map[string]map[string]*types.Vote
 	LastBlockHeight map[string]map[string]int64
 	Mutes           []*types.Mute
+	MutesHistory    []*types.MuteHistoryEntry
+	Reminders       map[string]map[string]map[string][]string
+	Incidents       []*types.Incident
+	SpamFlags       []*types.SpamFlag
+	DigestSnapshot  *types.DigestSnapshot
+	Outbox          []*types.OutboxEntry
+	AlertMessages   []*types.AlertMessage
+	Dashboards      []*types.DashboardMessage
 }
 
 func (d *StubDatabase) Init() {
@@ -35,6 +70,14 @@ func (d *StubDatabase) Rollback() {
 
 }
 
+func (d *StubDatabase) HasPendingMigrations() (bool, error) {
+	if d.HasPendingMigrationsError != nil {
+		return false, d.HasPendingMigrationsError
+	}
+
+	return d.PendingMigrations, nil
+}
+
 func (d *StubDatabase) UpsertProposal(
 	chain *types.Chain,
 	proposal types.Proposal,
@@ -171,6 +214,9 @@ func (d *StubDatabase) UpsertMute(mute *types.Mute) error {
 		if otherMute.LabelsEqual(mute) {
 			otherMute.Expires = mute.Expires
 			otherMute.Comment = mute.Comment
+			otherMute.Author = mute.Author
+			otherMute.Source = mute.Source
+			otherMute.CreatedAt = mute.CreatedAt
 			return nil
 		}
 	}
@@ -192,7 +238,7 @@ func (d *StubDatabase) GetAllMutes() ([]*types.Mute, error) {
 	return d.Mutes, nil
 }
 
-func (d *StubDatabase) DeleteMute(mute *types.Mute) (bool, error) {
+func (d *StubDatabase) DeleteMute(mute *types.Mute, removedBy string) (bool, error) {
 	if d.DeleteMuteError != nil {
 		return false, d.DeleteMuteError
 	}
@@ -204,6 +250,12 @@ func (d *StubDatabase) DeleteMute(mute *types.Mute) (bool, error) {
 	for index, otherMute := range d.Mutes {
 		if otherMute.LabelsEqual(mute) {
 			d.Mutes = append(d.Mutes[:index], d.Mutes[index+1:]...)
+			d.MutesHistory = append(d.MutesHistory, &types.MuteHistoryEntry{
+				Mute:      *otherMute,
+				Reason:    types.MuteRemovedReasonDeleted,
+				RemovedAt: time.Now(),
+				RemovedBy: null.NewString(removedBy, removedBy != ""),
+			})
 			return true, nil
 		}
 	}
@@ -211,7 +263,52 @@ func (d *StubDatabase) DeleteMute(mute *types.Mute) (bool, error) {
 	return false, nil
 }
 
-func (d *StubDatabase) IsMuted(chain, proposalID string) (bool, error) {
+func (d *StubDatabase) ArchiveExpiredMutes() (int64, error) {
+	if d.ArchiveMutesError != nil {
+		return 0, d.ArchiveMutesError
+	}
+
+	active := make([]*types.Mute, 0, len(d.Mutes))
+
+	for _, mute := range d.Mutes {
+		if !mute.IsExpired() {
+			active = append(active, mute)
+			continue
+		}
+
+		d.MutesHistory = append(d.MutesHistory, &types.MuteHistoryEntry{
+			Mute:      *mute,
+			Reason:    types.MuteRemovedReasonExpired,
+			RemovedAt: mute.Expires,
+		})
+	}
+
+	archived := int64(len(d.Mutes) - len(active))
+	d.Mutes = active
+
+	return archived, nil
+}
+
+func (d *StubDatabase) GetMutesHistory(limit int) ([]*types.MuteHistoryEntry, error) {
+	if d.GetMutesHistoryError != nil {
+		return []*types.MuteHistoryEntry{}, d.GetMutesHistoryError
+	}
+
+	entries := make([]*types.MuteHistoryEntry, len(d.MutesHistory))
+	copy(entries, d.MutesHistory)
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].RemovedAt.After(entries[j].RemovedAt)
+	})
+
+	if len(entries) > limit {
+		entries = entries[:limit]
+	}
+
+	return entries, nil
+}
+
+func (d *StubDatabase) IsMuted(query types.MuteQuery) (bool, error) {
 	if d.IsMutedError != nil {
 		return false, d.IsMutedError
 	}
@@ -220,11 +317,337 @@ func (d *StubDatabase) IsMuted(chain, proposalID string) (bool, error) {
 		return false, nil
 	}
 
+	// same as in the real databases, title pattern mutes are matched in mutes.Manager
 	for _, mute := range d.Mutes {
-		if mute.Matches(chain, proposalID) {
+		if mute.TitlePattern.IsZero() && mute.Matches(query) && mute.MatchesDestination(query.Destination) {
 			return true, nil
 		}
 	}
 
 	return false, nil
 }
+
+func (d *StubDatabase) GetSentReminders(
+	chain *types.Chain,
+	proposal types.Proposal,
+	wallet *types.Wallet,
+) ([]string, error) {
+	if d.GetRemindersError != nil {
+		return []string{}, d.GetRemindersError
+	}
+
+	chainReminders, ok := d.Reminders[chain.Name]
+	if !ok {
+		return []string{}, nil
+	}
+
+	proposalReminders, ok := chainReminders[proposal.ID]
+	if !ok {
+		return []string{}, nil
+	}
+
+	return proposalReminders[wallet.Address], nil
+}
+
+func (d *StubDatabase) InsertSentReminders(
+	chain *types.Chain,
+	proposal types.Proposal,
+	wallet *types.Wallet,
+	reminders []string,
+) error {
+	if d.InsertRemindersError != nil {
+		return d.InsertRemindersError
+	}
+
+	if d.Reminders == nil {
+		d.Reminders = make(map[string]map[string]map[string][]string)
+	}
+
+	if _, ok := d.Reminders[chain.Name]; !ok {
+		d.Reminders[chain.Name] = make(map[string]map[string][]string)
+	}
+
+	if _, ok := d.Reminders[chain.Name][proposal.ID]; !ok {
+		d.Reminders[chain.Name][proposal.ID] = make(map[string][]string)
+	}
+
+	d.Reminders[chain.Name][proposal.ID][wallet.Address] = append(
+		d.Reminders[chain.Name][proposal.ID][wallet.Address],
+		reminders...,
+	)
+	return nil
+}
+
+func (d *StubDatabase) InsertIncident(incident *types.Incident) error {
+	if d.InsertIncidentError != nil {
+		return d.InsertIncidentError
+	}
+
+	for _, otherIncident := range d.Incidents {
+		if otherIncident.Reporter == incident.Reporter && otherIncident.DedupKey == incident.DedupKey {
+			return nil
+		}
+	}
+
+	d.Incidents = append(d.Incidents, incident)
+	return nil
+}
+
+func (d *StubDatabase) GetIncidents(reporter string) ([]*types.Incident, error) {
+	incidents := make([]*types.Incident, 0)
+
+	if d.GetIncidentsError != nil {
+		return incidents, d.GetIncidentsError
+	}
+
+	for _, incident := range d.Incidents {
+		if incident.Reporter == reporter {
+			incidents = append(incidents, incident)
+		}
+	}
+
+	return incidents, nil
+}
+
+func (d *StubDatabase) DeleteIncident(reporter, dedupKey string) error {
+	if d.DeleteIncidentError != nil {
+		return d.DeleteIncidentError
+	}
+
+	for index, incident := range d.Incidents {
+		if incident.Reporter == reporter && incident.DedupKey == dedupKey {
+			d.Incidents = append(d.Incidents[:index], d.Incidents[index+1:]...)
+			return nil
+		}
+	}
+
+	return nil
+}
+
+func (d *StubDatabase) GetSpamFlag(chain, proposalID string) (*types.SpamFlag, error) {
+	if d.GetSpamFlagError != nil {
+		return nil, d.GetSpamFlagError
+	}
+
+	for _, flag := range d.SpamFlags {
+		if flag.Chain == chain && flag.ProposalID == proposalID {
+			return flag, nil
+		}
+	}
+
+	return nil, nil //nolint:nilnil
+}
+
+func (d *StubDatabase) UpsertSpamFlag(flag *types.SpamFlag) error {
+	if d.UpsertSpamFlagError != nil {
+		return d.UpsertSpamFlagError
+	}
+
+	for index, otherFlag := range d.SpamFlags {
+		if otherFlag.Chain == flag.Chain && otherFlag.ProposalID == flag.ProposalID {
+			d.SpamFlags[index] = flag
+			return nil
+		}
+	}
+
+	d.SpamFlags = append(d.SpamFlags, flag)
+	return nil
+}
+
+func (d *StubDatabase) GetDigestSnapshot() (*types.DigestSnapshot, error) {
+	if d.GetDigestError != nil {
+		return nil, d.GetDigestError
+	}
+
+	return d.DigestSnapshot, nil
+}
+
+func (d *StubDatabase) SaveDigestSnapshot(snapshot *types.DigestSnapshot) error {
+	if d.SaveDigestError != nil {
+		return d.SaveDigestError
+	}
+
+	d.DigestSnapshot = snapshot
+	return nil
+}
+
+func (d *StubDatabase) InsertOutboxEntry(entry *types.OutboxEntry) error {
+	if d.InsertOutboxError != nil {
+		return d.InsertOutboxError
+	}
+
+	entry.ID = 1
+	if len(d.Outbox) > 0 {
+		entry.ID = d.Outbox[len(d.Outbox)-1].ID + 1
+	}
+
+	d.Outbox = append(d.Outbox, entry)
+	return nil
+}
+
+func (d *StubDatabase) UpdateOutboxEntry(entry *types.OutboxEntry) error {
+	if d.UpdateOutboxError != nil {
+		return d.UpdateOutboxError
+	}
+
+	for index, otherEntry := range d.Outbox {
+		if otherEntry.ID == entry.ID {
+			d.Outbox[index] = entry
+		}
+	}
+
+	return nil
+}
+
+func (d *StubDatabase) DeleteOutboxEntry(id int64) error {
+	if d.DeleteOutboxError != nil {
+		return d.DeleteOutboxError
+	}
+
+	for index, entry := range d.Outbox {
+		if entry.ID == id {
+			d.Outbox = append(d.Outbox[:index], d.Outbox[index+1:]...)
+			return nil
+		}
+	}
+
+	return nil
+}
+
+func (d *StubDatabase) GetDueOutboxEntries(now time.Time) ([]*types.OutboxEntry, error) {
+	entries := make([]*types.OutboxEntry, 0)
+
+	if d.GetOutboxError != nil {
+		return entries, d.GetOutboxError
+	}
+
+	for _, entry := range d.Outbox {
+		if entry.Status == types.OutboxStatusPending && !entry.NextAttemptAt.After(now) {
+			entries = append(entries, entry)
+		}
+	}
+
+	return entries, nil
+}
+
+func (d *StubDatabase) GetUndeliveredOutboxEntries() ([]*types.OutboxEntry, error) {
+	if d.GetOutboxError != nil {
+		return []*types.OutboxEntry{}, d.GetOutboxError
+	}
+
+	return append([]*types.OutboxEntry{}, d.Outbox...), nil
+}
+
+func (d *StubDatabase) GetAlertMessage(destination, chain, proposalID, wallet string) (*types.AlertMessage, error) {
+	if d.GetAlertMessageError != nil {
+		return nil, d.GetAlertMessageError
+	}
+
+	for _, message := range d.AlertMessages {
+		if message.Destination == destination &&
+			message.Chain == chain &&
+			message.ProposalID == proposalID &&
+			message.Wallet == wallet {
+			return message, nil
+		}
+	}
+
+	return nil, nil //nolint:nilnil
+}
+
+func (d *StubDatabase) UpsertAlertMessage(message *types.AlertMessage) error {
+	if d.UpsertAlertMessageError != nil {
+		return d.UpsertAlertMessageError
+	}
+
+	for index, otherMessage := range d.AlertMessages {
+		if otherMessage.Destination == message.Destination &&
+			otherMessage.Chain == message.Chain &&
+			otherMessage.ProposalID == message.ProposalID &&
+			otherMessage.Wallet == message.Wallet {
+			d.AlertMessages[index] = message
+			return nil
+		}
+	}
+
+	d.AlertMessages = append(d.AlertMessages, message)
+	return nil
+}
+
+func (d *StubDatabase) SetAlertMessageAction(destination, messageID, action string) error {
+	if d.UpsertAlertMessageError != nil {
+		return d.UpsertAlertMessageError
+	}
+
+	for _, message := range d.AlertMessages {
+		if message.Destination == destination && message.MessageID == messageID {
+			message.Action = null.StringFrom(action)
+		}
+	}
+
+	return nil
+}
+
+func (d *StubDatabase) DeleteAlertMessage(message *types.AlertMessage) error {
+	if d.DeleteAlertMessageError != nil {
+		return d.DeleteAlertMessageError
+	}
+
+	for index, otherMessage := range d.AlertMessages {
+		if otherMessage.Destination == message.Destination &&
+			otherMessage.Chain == message.Chain &&
+			otherMessage.ProposalID == message.ProposalID &&
+			otherMessage.Wallet == message.Wallet {
+			d.AlertMessages = append(d.AlertMessages[:index], d.AlertMessages[index+1:]...)
+			return nil
+		}
+	}
+
+	return nil
+}
+
+func (d *StubDatabase) DeleteProposalAlertMessages(destination, chain, proposalID string) error {
+	if d.DeleteAlertMessageError != nil {
+		return d.DeleteAlertMessageError
+	}
+
+	messages := make([]*types.AlertMessage, 0, len(d.AlertMessages))
+	for _, message := range d.AlertMessages {
+		if message.Destination != destination || message.Chain != chain || message.ProposalID != proposalID {
+			messages = append(messages, message)
+		}
+	}
+
+	d.AlertMessages = messages
+	return nil
+}
+
+func (d *StubDatabase) GetDashboardMessage(destination string) (*types.DashboardMessage, error) {
+	if d.GetDashboardError != nil {
+		return nil, d.GetDashboardError
+	}
+
+	for _, message := range d.Dashboards {
+		if message.Destination == destination {
+			return message, nil
+		}
+	}
+
+	return nil, nil //nolint:nilnil
+}
+
+func (d *StubDatabase) UpsertDashboardMessage(message *types.DashboardMessage) error {
+	if d.UpsertDashboardError != nil {
+		return d.UpsertDashboardError
+	}
+
+	for index, otherMessage := range d.Dashboards {
+		if otherMessage.Destination == message.Destination {
+			d.Dashboards[index] = message
+			return nil
+		}
+	}
+
+	d.Dashboards = append(d.Dashboards, message)
+	return nil
+}
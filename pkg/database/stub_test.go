@@ -13,8 +13,8 @@ func TestStubVarious(t *testing.T) {
 	db := &StubDatabase{}
 	db.Migrate()
 	db.Rollback()
-	_, _ = db.IsMuted("chain", "proposal")
-	_, _ = db.DeleteMute(&types.Mute{})
+	_, _ = db.IsMuted(types.MuteQuery{Chain: "chain", ProposalID: "proposal"})
+	_, _ = db.DeleteMute(&types.Mute{}, "user")
 	_ = db.UpsertVote(
 		&types.Chain{Name: "chain"},
 		types.Proposal{ID: "proposal1"},
@@ -0,0 +1,161 @@
+package digest
+
+import (
+	"context"
+	databasePkg "main/pkg/database"
+	"main/pkg/events"
+	statePkg "main/pkg/state"
+	"main/pkg/types"
+	"sort"
+	"time"
+
+	"github.com/rs/zerolog"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type Manager struct {
+	Logger         zerolog.Logger
+	StateGenerator *statePkg.Generator
+	Database       databasePkg.Database
+	Tracer         trace.Tracer
+}
+
+func NewManager(
+	logger *zerolog.Logger,
+	stateGenerator *statePkg.Generator,
+	database databasePkg.Database,
+	tracer trace.Tracer,
+) *Manager {
+	return &Manager{
+		Logger:         logger.With().Str("component", "digest_manager").Logger(),
+		StateGenerator: stateGenerator,
+		Database:       database,
+		Tracer:         tracer,
+	}
+}
+
+// GenerateDigest returns the digest along with the snapshot to save once it's sent,
+// so the next digest only includes the changes since this one.
+func (m *Manager) GenerateDigest(ctx context.Context) (events.DigestEvent, *types.DigestSnapshot, error) {
+	childCtx, span := m.Tracer.Start(ctx, "Generating digest")
+	defer span.End()
+
+	previous, err := m.Database.GetDigestSnapshot()
+	if err != nil {
+		m.Logger.Error().Err(err).Msg("Error getting previous digest")
+		span.RecordError(err)
+		return events.DigestEvent{}, nil, err
+	}
+
+	state := m.StateGenerator.GetState(statePkg.NewState(), childCtx)
+	now := time.Now()
+
+	digest := events.DigestEvent{
+		State:             state.ToRenderedState(),
+		NewVotes:          make([]events.DigestVote, 0),
+		FinishedProposals: make([]events.DigestProposal, 0),
+	}
+	snapshot := &types.DigestSnapshot{
+		SentAt: now,
+		Votes:  make([]types.DigestSnapshotVote, 0),
+	}
+
+	if previous != nil {
+		digest.Since = previous.SentAt
+	}
+
+	for _, chain := range m.StateGenerator.Chains {
+		chainInfo, ok := state.ChainInfos[chain.Name]
+		if !ok {
+			continue
+		}
+
+		// proposals are unknown, so keeping the votes from the previous digest
+		// to not report them as new ones the next time
+		if chainInfo.HasProposalsError() {
+			if previous != nil {
+				for _, vote := range previous.Votes {
+					if vote.Chain == chain.Name {
+						snapshot.Votes = append(snapshot.Votes, vote)
+					}
+				}
+			}
+
+			continue
+		}
+
+		for _, proposalID := range GetSortedProposalsIDs(chainInfo) {
+			proposalVotes := chainInfo.ProposalVotes[proposalID]
+			proposal := proposalVotes.Proposal
+
+			if !proposal.IsInVoting() {
+				if previous != nil && proposal.EndTime.After(previous.SentAt) && !proposal.EndTime.After(now) {
+					digest.FinishedProposals = append(digest.FinishedProposals, events.DigestProposal{
+						Chain:    chain,
+						Proposal: proposal,
+					})
+				}
+
+				continue
+			}
+
+			for _, wallet := range chain.Wallets {
+				vote, found := proposalVotes.Votes[wallet.Address]
+				previousVote, hasPreviousVote := "", false
+				if previous != nil {
+					previousVote, hasPreviousVote = previous.GetVote(chain.Name, proposal.ID, wallet.Address)
+				}
+
+				if !found || vote.IsError() {
+					if hasPreviousVote {
+						snapshot.Votes = append(snapshot.Votes, types.DigestSnapshotVote{
+							Chain:      chain.Name,
+							ProposalID: proposal.ID,
+							Wallet:     wallet.Address,
+							Vote:       previousVote,
+						})
+					}
+
+					continue
+				}
+
+				if !vote.HasVoted() {
+					continue
+				}
+
+				resolvedVote := vote.Vote.ResolveVote()
+				snapshot.Votes = append(snapshot.Votes, types.DigestSnapshotVote{
+					Chain:      chain.Name,
+					ProposalID: proposal.ID,
+					Wallet:     wallet.Address,
+					Vote:       resolvedVote,
+				})
+
+				if previous != nil && (!hasPreviousVote || previousVote != resolvedVote) {
+					digest.NewVotes = append(digest.NewVotes, events.DigestVote{
+						Chain:    chain,
+						Proposal: proposal,
+						Wallet:   wallet,
+						Vote:     vote.Vote,
+					})
+				}
+			}
+		}
+	}
+
+	return digest, snapshot, nil
+}
+
+func (m *Manager) SaveSnapshot(snapshot *types.DigestSnapshot) error {
+	return m.Database.SaveDigestSnapshot(snapshot)
+}
+
+func GetSortedProposalsIDs(chainInfo *statePkg.ChainInfo) []string {
+	ids := make([]string, 0, len(chainInfo.ProposalVotes))
+	for id := range chainInfo.ProposalVotes {
+		ids = append(ids, id)
+	}
+
+	sort.Strings(ids)
+	return ids
+}
@@ -0,0 +1,187 @@
+package digest
+
+import (
+	"context"
+	"errors"
+	databasePkg "main/pkg/database"
+	"main/pkg/fetchers"
+	loggerPkg "main/pkg/logger"
+	"main/pkg/metrics"
+	statePkg "main/pkg/state"
+	"main/pkg/tracing"
+	"main/pkg/types"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func getTestManager(database databasePkg.Database, fetcher fetchers.Fetcher) *Manager {
+	logger := loggerPkg.GetNopLogger()
+	chains := types.Chains{{
+		Name:    "chain",
+		Wallets: []*types.Wallet{{Address: "me"}},
+	}}
+
+	return &Manager{
+		Logger:   *logger,
+		Database: database,
+		Tracer:   tracing.InitNoopTracer(),
+		StateGenerator: &statePkg.Generator{
+			Logger:   *logger,
+			Chains:   chains,
+			Fetchers: map[string]fetchers.Fetcher{"chain": fetcher},
+			Tracer:   tracing.InitNoopTracer(),
+		},
+	}
+}
+
+func TestDigestManagerNew(t *testing.T) {
+	t.Parallel()
+
+	logger := loggerPkg.GetNopLogger()
+	metricsManager := metrics.NewManager(logger, types.MetricsConfig{})
+	tracer := tracing.InitNoopTracer()
+	stateGenerator := statePkg.NewStateGenerator(logger, metricsManager, tracer, types.Chains{})
+
+	manager := NewManager(logger, stateGenerator, &databasePkg.StubDatabase{}, tracer)
+	require.NotNil(t, manager)
+}
+
+func TestDigestManagerGetPreviousError(t *testing.T) {
+	t.Parallel()
+
+	manager := getTestManager(
+		&databasePkg.StubDatabase{GetDigestError: errors.New("custom error")},
+		&fetchers.TestFetcher{},
+	)
+
+	_, _, err := manager.GenerateDigest(context.Background())
+	require.ErrorContains(t, err, "custom error")
+}
+
+func TestDigestManagerFirstDigest(t *testing.T) {
+	t.Parallel()
+
+	database := &databasePkg.StubDatabase{}
+	manager := getTestManager(database, &fetchers.TestFetcher{WithVote: true})
+
+	digest, snapshot, err := manager.GenerateDigest(context.Background())
+	require.NoError(t, err)
+	require.True(t, digest.IsFirst())
+	require.Len(t, digest.State.ChainInfos, 1)
+	require.Empty(t, digest.NewVotes)
+	require.Empty(t, digest.FinishedProposals)
+
+	require.Len(t, snapshot.Votes, 1)
+	require.Equal(t, types.DigestSnapshotVote{
+		Chain:      "chain",
+		ProposalID: "1",
+		Wallet:     "me",
+		Vote:       snapshot.Votes[0].Vote,
+	}, snapshot.Votes[0])
+
+	require.NoError(t, manager.SaveSnapshot(snapshot))
+	require.Equal(t, snapshot, database.DigestSnapshot)
+}
+
+func TestDigestManagerNewVotes(t *testing.T) {
+	t.Parallel()
+
+	since := time.Now().Add(-24 * time.Hour)
+	database := &databasePkg.StubDatabase{
+		DigestSnapshot: &types.DigestSnapshot{SentAt: since},
+	}
+	manager := getTestManager(database, &fetchers.TestFetcher{WithVote: true})
+
+	digest, snapshot, err := manager.GenerateDigest(context.Background())
+	require.NoError(t, err)
+	require.False(t, digest.IsFirst())
+	require.Equal(t, since, digest.Since)
+	require.Len(t, digest.NewVotes, 1)
+	require.Equal(t, "me", digest.NewVotes[0].Wallet.Address)
+	require.Len(t, snapshot.Votes, 1)
+
+	// the same vote is not reported twice
+	database.DigestSnapshot = snapshot
+
+	digest, _, err = manager.GenerateDigest(context.Background())
+	require.NoError(t, err)
+	require.Empty(t, digest.NewVotes)
+}
+
+func TestDigestManagerNotVoted(t *testing.T) {
+	t.Parallel()
+
+	database := &databasePkg.StubDatabase{
+		DigestSnapshot: &types.DigestSnapshot{SentAt: time.Now().Add(-24 * time.Hour)},
+	}
+	manager := getTestManager(database, &fetchers.TestFetcher{})
+
+	digest, snapshot, err := manager.GenerateDigest(context.Background())
+	require.NoError(t, err)
+	require.Empty(t, digest.NewVotes)
+	require.Empty(t, snapshot.Votes)
+	require.Len(t, digest.State.ChainInfos, 1)
+	require.Len(t, digest.State.ChainInfos[0].ProposalVotes, 1)
+	require.False(t, digest.State.ChainInfos[0].ProposalVotes[0].Votes[0].HasVoted())
+}
+
+func TestDigestManagerVoteError(t *testing.T) {
+	t.Parallel()
+
+	previousVote := types.DigestSnapshotVote{Chain: "chain", ProposalID: "1", Wallet: "me", Vote: "Yes"}
+	database := &databasePkg.StubDatabase{
+		DigestSnapshot: &types.DigestSnapshot{
+			SentAt: time.Now().Add(-24 * time.Hour),
+			Votes:  []types.DigestSnapshotVote{previousVote},
+		},
+	}
+	manager := getTestManager(database, &fetchers.TestFetcher{WithVoteError: true})
+
+	digest, snapshot, err := manager.GenerateDigest(context.Background())
+	require.NoError(t, err)
+	require.Empty(t, digest.NewVotes)
+	require.Equal(t, []types.DigestSnapshotVote{previousVote}, snapshot.Votes)
+}
+
+func TestDigestManagerProposalsError(t *testing.T) {
+	t.Parallel()
+
+	previousVote := types.DigestSnapshotVote{Chain: "chain", ProposalID: "1", Wallet: "me", Vote: "Yes"}
+	database := &databasePkg.StubDatabase{
+		DigestSnapshot: &types.DigestSnapshot{
+			SentAt: time.Now().Add(-24 * time.Hour),
+			Votes:  []types.DigestSnapshotVote{previousVote},
+		},
+	}
+	manager := getTestManager(database, &fetchers.TestFetcher{WithProposalsError: true})
+
+	digest, snapshot, err := manager.GenerateDigest(context.Background())
+	require.NoError(t, err)
+	require.Len(t, digest.State.ChainInfos, 1)
+	require.True(t, digest.State.ChainInfos[0].HasProposalsError())
+	require.Equal(t, []types.DigestSnapshotVote{previousVote}, snapshot.Votes)
+}
+
+func TestDigestManagerFinishedProposals(t *testing.T) {
+	t.Parallel()
+
+	database := &databasePkg.StubDatabase{
+		DigestSnapshot: &types.DigestSnapshot{SentAt: time.Now().Add(-24 * time.Hour)},
+	}
+	manager := getTestManager(database, &fetchers.TestFetcher{WithPassedProposals: true})
+
+	digest, _, err := manager.GenerateDigest(context.Background())
+	require.NoError(t, err)
+	require.Empty(t, digest.State.ChainInfos)
+	require.Len(t, digest.FinishedProposals, 1)
+	require.Equal(t, "1", digest.FinishedProposals[0].Proposal.ID)
+
+	// finished before the previous digest
+	database.DigestSnapshot = &types.DigestSnapshot{SentAt: time.Now()}
+
+	digest, _, err = manager.GenerateDigest(context.Background())
+	require.NoError(t, err)
+	require.Empty(t, digest.FinishedProposals)
+}
@@ -0,0 +1,67 @@
+package events
+
+import (
+	"main/pkg/state"
+	"main/pkg/types"
+	"time"
+)
+
+// DigestEvent is sent on its own schedule, listing the proposals in voting
+// and what has changed since the previous digest.
+type DigestEvent struct {
+	State             state.RenderedState
+	Since             time.Time
+	NewVotes          []DigestVote
+	FinishedProposals []DigestProposal
+}
+
+type DigestVote struct {
+	Chain    *types.Chain
+	Proposal types.Proposal
+	Wallet   *types.Wallet
+	Vote     *types.Vote
+}
+
+type DigestProposal struct {
+	Chain    *types.Chain
+	Proposal types.Proposal
+}
+
+func (e DigestEvent) Name() string {
+	return "digest"
+}
+
+func (e DigestEvent) IsAlert() bool {
+	return false
+}
+
+// IsFirst returns whether there was no digest sent before this one,
+// so the changes since the previous one are unknown.
+func (e DigestEvent) IsFirst() bool {
+	return e.Since.IsZero()
+}
+
+// Filter returns the digest with only the chains and wallets the chat or channel
+// with this filter receives notifications about.
+func (e DigestEvent) Filter(filter *types.DestinationFilter) DigestEvent {
+	newVotes := make([]DigestVote, 0, len(e.NewVotes))
+	for _, vote := range e.NewVotes {
+		if filter.Matches(vote.Chain, vote.Wallet) {
+			newVotes = append(newVotes, vote)
+		}
+	}
+
+	finishedProposals := make([]DigestProposal, 0, len(e.FinishedProposals))
+	for _, proposal := range e.FinishedProposals {
+		if filter.MatchesChain(proposal.Chain) {
+			finishedProposals = append(finishedProposals, proposal)
+		}
+	}
+
+	return DigestEvent{
+		State:             e.State.Filter(filter),
+		Since:             e.Since,
+		NewVotes:          newVotes,
+		FinishedProposals: finishedProposals,
+	}
+}
@@ -1,8 +1,11 @@
 package events
 
 import (
+	"main/pkg/report/entry"
+	"main/pkg/state"
 	"main/pkg/types"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -23,6 +26,97 @@ func TestFinishedVotingEvent(t *testing.T) {
 	assert.False(t, event.IsAlert())
 }
 
+func TestSuspectedSpamEvent(t *testing.T) {
+	t.Parallel()
+
+	event := SuspectedSpamEvent{}
+	assert.Equal(t, "suspected_spam", event.Name())
+	assert.False(t, event.IsAlert())
+}
+
+func TestProposalEnteredVotingEvent(t *testing.T) {
+	t.Parallel()
+
+	event := ProposalEnteredVotingEvent{}
+	assert.Equal(t, "proposal_entered_voting", event.Name())
+	assert.False(t, event.IsAlert())
+}
+
+func TestProposalStatusChangedEvent(t *testing.T) {
+	t.Parallel()
+
+	event := ProposalStatusChangedEvent{
+		Proposal:       types.Proposal{Status: types.ProposalStatusPassed},
+		OldStatus:      types.ProposalStatusVoting,
+		OldIsExpedited: true,
+	}
+	assert.Equal(t, "proposal_status_changed", event.Name())
+	assert.False(t, event.IsAlert())
+	assert.True(t, event.IsStatusChanged())
+	assert.True(t, event.IsNoLongerExpedited())
+
+	event.Proposal.Expedited = true
+	event.OldStatus = types.ProposalStatusPassed
+	assert.False(t, event.IsStatusChanged())
+	assert.False(t, event.IsNoLongerExpedited())
+}
+
+func TestDigestEvent(t *testing.T) {
+	t.Parallel()
+
+	event := DigestEvent{}
+	assert.Equal(t, "digest", event.Name())
+	assert.False(t, event.IsAlert())
+	assert.True(t, event.IsFirst())
+
+	event = DigestEvent{Since: time.Now()}
+	assert.False(t, event.IsFirst())
+}
+
+func TestDigestEventFilter(t *testing.T) {
+	t.Parallel()
+
+	chain := &types.Chain{Name: "chain"}
+	otherChain := &types.Chain{Name: "other-chain"}
+	wallet := &types.Wallet{Address: "wallet"}
+	otherWallet := &types.Wallet{Address: "other-wallet"}
+	since := time.Now()
+
+	event := DigestEvent{
+		State: state.RenderedState{ChainInfos: []state.RenderedChainInfo{
+			{Chain: chain, ProposalVotes: []state.RenderedProposalVotes{{
+				Proposal: types.Proposal{ID: "1"},
+				Votes:    []state.RenderedWalletVote{{Wallet: wallet}, {Wallet: otherWallet}},
+			}}},
+			{Chain: otherChain},
+		}},
+		Since: since,
+		NewVotes: []DigestVote{
+			{Chain: chain, Wallet: wallet},
+			{Chain: chain, Wallet: otherWallet},
+			{Chain: otherChain, Wallet: wallet},
+		},
+		FinishedProposals: []DigestProposal{
+			{Chain: chain, Proposal: types.Proposal{ID: "2"}},
+			{Chain: otherChain, Proposal: types.Proposal{ID: "3"}},
+		},
+	}
+
+	assert.Equal(t, event.NewVotes, event.Filter(&types.DestinationFilter{}).NewVotes)
+
+	filtered := event.Filter(&types.DestinationFilter{Chains: []string{"chain"}, Wallets: []string{"wallet"}})
+	assert.Equal(t, since, filtered.Since)
+	assert.Equal(t, []DigestVote{{Chain: chain, Wallet: wallet}}, filtered.NewVotes)
+	assert.Equal(t, []DigestProposal{{Chain: chain, Proposal: types.Proposal{ID: "2"}}}, filtered.FinishedProposals)
+	assert.Len(t, filtered.State.ChainInfos, 1)
+	assert.Equal(t, "chain", filtered.State.ChainInfos[0].Chain.Name)
+	assert.Equal(
+		t,
+		[]state.RenderedWalletVote{{Wallet: wallet}},
+		filtered.State.ChainInfos[0].ProposalVotes[0].Votes,
+	)
+}
+
 func TestProposalsQueryErrorEvent(t *testing.T) {
 	t.Parallel()
 
@@ -90,3 +184,116 @@ func TestRevotedEvent(t *testing.T) {
 	assert.Equal(t, "proposal", event.GetProposal().ID)
 	assert.Equal(t, "wallet", event.GetWallet().Address)
 }
+
+func TestGetEntryLabels(t *testing.T) {
+	t.Parallel()
+
+	chain := &types.Chain{Name: "chain"}
+	wallet := &types.Wallet{Address: "wallet"}
+	proposal := types.Proposal{ID: "proposal"}
+
+	entryChain, entryWallet, entryProposal := GetEntryLabels(NotVotedEvent{
+		Chain:    chain,
+		Wallet:   wallet,
+		Proposal: proposal,
+	})
+	assert.Equal(t, chain, entryChain)
+	assert.Equal(t, wallet, entryWallet)
+	assert.Equal(t, "proposal", entryProposal.ID)
+
+	entryChain, entryWallet, entryProposal = GetEntryLabels(FinishedVotingEvent{Chain: chain, Proposal: proposal})
+	assert.Equal(t, chain, entryChain)
+	assert.Nil(t, entryWallet)
+	assert.Equal(t, "proposal", entryProposal.ID)
+
+	entryChain, entryWallet, entryProposal = GetEntryLabels(SuspectedSpamEvent{Chain: chain, Proposal: proposal})
+	assert.Equal(t, chain, entryChain)
+	assert.Nil(t, entryWallet)
+	assert.Equal(t, "proposal", entryProposal.ID)
+
+	entryChain, entryWallet, entryProposal = GetEntryLabels(ProposalEnteredVotingEvent{Chain: chain, Proposal: proposal})
+	assert.Equal(t, chain, entryChain)
+	assert.Nil(t, entryWallet)
+	assert.Equal(t, "proposal", entryProposal.ID)
+
+	entryChain, entryWallet, entryProposal = GetEntryLabels(ProposalStatusChangedEvent{Chain: chain, Proposal: proposal})
+	assert.Equal(t, chain, entryChain)
+	assert.Nil(t, entryWallet)
+	assert.Equal(t, "proposal", entryProposal.ID)
+
+	entryChain, entryWallet, entryProposal = GetEntryLabels(VoteQueryError{Chain: chain, Proposal: proposal})
+	assert.Equal(t, chain, entryChain)
+	assert.Nil(t, entryWallet)
+	assert.Equal(t, "proposal", entryProposal.ID)
+
+	entryChain, entryWallet, entryProposal = GetEntryLabels(ProposalsQueryErrorEvent{Chain: chain})
+	assert.Equal(t, chain, entryChain)
+	assert.Nil(t, entryWallet)
+	assert.Nil(t, entryProposal)
+
+	entryChain, entryWallet, entryProposal = GetEntryLabels(GenericErrorEvent{Chain: chain})
+	assert.Equal(t, chain, entryChain)
+	assert.Nil(t, entryWallet)
+	assert.Nil(t, entryProposal)
+
+	entryChain, entryWallet, entryProposal = GetEntryLabels(GroupedEvent{Chain: chain})
+	assert.Equal(t, chain, entryChain)
+	assert.Nil(t, entryWallet)
+	assert.Nil(t, entryProposal)
+
+	entryChain, entryWallet, entryProposal = GetEntryLabels(NotExistingEvent{})
+	assert.Nil(t, entryChain)
+	assert.Nil(t, entryWallet)
+	assert.Nil(t, entryProposal)
+}
+
+func TestGroupedEvent(t *testing.T) {
+	t.Parallel()
+
+	event := GroupedEvent{}
+	assert.Equal(t, "grouped", event.Name())
+	assert.False(t, event.IsAlert())
+
+	event = GroupedEvent{Entries: []entry.ReportEntry{VotedEvent{}, NotVotedEvent{}}}
+	assert.True(t, event.IsAlert())
+
+	filtered := event.Filter(func(reportEntry entry.ReportEntry) bool {
+		return reportEntry.Name() == "voted"
+	})
+	assert.Equal(t, []entry.ReportEntry{VotedEvent{}}, filtered.Entries)
+
+	filtered = event.Filter(func(reportEntry entry.ReportEntry) bool {
+		return false
+	})
+	assert.Empty(t, filtered.Entries)
+	assert.False(t, filtered.IsAlert())
+}
+
+func TestGroupEntries(t *testing.T) {
+	t.Parallel()
+
+	chain1 := &types.Chain{Name: "chain1"}
+	chain2 := &types.Chain{Name: "chain2"}
+	wallet1 := &types.Wallet{Address: "wallet1"}
+	wallet2 := &types.Wallet{Address: "wallet2"}
+
+	grouped := GroupEntries([]entry.ReportEntry{
+		FinishedVotingEvent{Chain: chain1},
+		NotVotedEvent{Chain: chain1, Wallet: wallet1, Proposal: types.Proposal{ID: "1"}},
+		NotVotedEvent{Chain: chain2, Wallet: wallet1, Proposal: types.Proposal{ID: "1"}},
+		VotedEvent{Chain: chain1, Wallet: wallet2, Proposal: types.Proposal{ID: "1"}},
+		RevotedEvent{Chain: chain1, Wallet: wallet1, Proposal: types.Proposal{ID: "2"}},
+		GenericErrorEvent{Chain: chain2},
+	})
+
+	assert.Equal(t, []entry.ReportEntry{
+		FinishedVotingEvent{Chain: chain1},
+		GroupedEvent{Chain: chain1, Entries: []entry.ReportEntry{
+			NotVotedEvent{Chain: chain1, Wallet: wallet1, Proposal: types.Proposal{ID: "1"}},
+			VotedEvent{Chain: chain1, Wallet: wallet2, Proposal: types.Proposal{ID: "1"}},
+			RevotedEvent{Chain: chain1, Wallet: wallet1, Proposal: types.Proposal{ID: "2"}},
+		}},
+		NotVotedEvent{Chain: chain2, Wallet: wallet1, Proposal: types.Proposal{ID: "1"}},
+		GenericErrorEvent{Chain: chain2},
+	}, grouped)
+}
@@ -1,6 +1,8 @@
 package events
 
 import (
+	"encoding/json"
+	"errors"
 	types "main/pkg/types"
 )
 
@@ -16,3 +18,29 @@ func (e GenericErrorEvent) Name() string {
 func (e GenericErrorEvent) IsAlert() bool {
 	return false
 }
+
+type genericErrorEventJSON struct {
+	Chain *types.Chain
+	Error string
+}
+
+// MarshalJSON only keeps the error message, as an arbitrary error cannot be unmarshalled back.
+func (e GenericErrorEvent) MarshalJSON() ([]byte, error) {
+	serialized := genericErrorEventJSON{Chain: e.Chain}
+	if e.Error != nil {
+		serialized.Error = e.Error.Error()
+	}
+
+	return json.Marshal(serialized)
+}
+
+func (e *GenericErrorEvent) UnmarshalJSON(data []byte) error {
+	var serialized genericErrorEventJSON
+	if err := json.Unmarshal(data, &serialized); err != nil {
+		return err
+	}
+
+	e.Chain = serialized.Chain
+	e.Error = errors.New(serialized.Error)
+	return nil
+}
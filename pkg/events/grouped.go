@@ -0,0 +1,137 @@
+package events
+
+import (
+	"encoding/json"
+	"main/pkg/report/entry"
+	"main/pkg/types"
+)
+
+// GroupedEvent combines the wallet-related alerts for a single chain, so they are
+// sent as one message instead of one message per wallet and proposal.
+type GroupedEvent struct {
+	Chain   *types.Chain
+	Entries []entry.ReportEntry
+}
+
+type groupedEventJSON struct {
+	Chain   *types.Chain
+	Entries []groupedEventEntryJSON
+}
+
+type groupedEventEntryJSON struct {
+	Name    string
+	Payload string
+}
+
+func (e GroupedEvent) Name() string {
+	return "grouped"
+}
+
+func (e GroupedEvent) IsAlert() bool {
+	for _, reportEntry := range e.Entries {
+		if reportEntry.IsAlert() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Filter returns the event with only the entries matching the predicate.
+func (e GroupedEvent) Filter(predicate func(entry.ReportEntry) bool) GroupedEvent {
+	entries := make([]entry.ReportEntry, 0, len(e.Entries))
+	for _, reportEntry := range e.Entries {
+		if predicate(reportEntry) {
+			entries = append(entries, reportEntry)
+		}
+	}
+
+	return GroupedEvent{Chain: e.Chain, Entries: entries}
+}
+
+// CanBeGrouped returns whether the entry can be put into a GroupedEvent,
+// which is true for the entries related to a wallet voting on a proposal.
+func CanBeGrouped(reportEntry entry.ReportEntry) bool {
+	switch reportEntry.(type) {
+	case NotVotedEvent, VotedEvent, RevotedEvent:
+		return true
+	default:
+		return false
+	}
+}
+
+// GroupEntries puts all the entries that can be grouped into one GroupedEvent per chain,
+// keeping the order of the entries. Chains with a single such entry are not grouped.
+func GroupEntries(entries []entry.ReportEntry) []entry.ReportEntry {
+	groupable := make(map[string][]entry.ReportEntry)
+	for _, reportEntry := range entries {
+		if !CanBeGrouped(reportEntry) {
+			continue
+		}
+
+		chain, _, _ := GetEntryLabels(reportEntry)
+		groupable[chain.Name] = append(groupable[chain.Name], reportEntry)
+	}
+
+	grouped := make([]entry.ReportEntry, 0, len(entries))
+	added := make(map[string]bool)
+
+	for _, reportEntry := range entries {
+		if !CanBeGrouped(reportEntry) {
+			grouped = append(grouped, reportEntry)
+			continue
+		}
+
+		chain, _, _ := GetEntryLabels(reportEntry)
+		chainEntries := groupable[chain.Name]
+
+		if len(chainEntries) == 1 {
+			grouped = append(grouped, reportEntry)
+			continue
+		}
+
+		if added[chain.Name] {
+			continue
+		}
+
+		grouped = append(grouped, GroupedEvent{Chain: chain, Entries: chainEntries})
+		added[chain.Name] = true
+	}
+
+	return grouped
+}
+
+func (e GroupedEvent) MarshalJSON() ([]byte, error) {
+	entries := make([]groupedEventEntryJSON, len(e.Entries))
+	for index, reportEntry := range e.Entries {
+		payload, err := MarshalEntry(reportEntry)
+		if err != nil {
+			return nil, err
+		}
+
+		entries[index] = groupedEventEntryJSON{Name: reportEntry.Name(), Payload: payload}
+	}
+
+	return json.Marshal(groupedEventJSON{Chain: e.Chain, Entries: entries})
+}
+
+func (e *GroupedEvent) UnmarshalJSON(data []byte) error {
+	var event groupedEventJSON
+	if err := json.Unmarshal(data, &event); err != nil {
+		return err
+	}
+
+	entries := make([]entry.ReportEntry, len(event.Entries))
+	for index, serializedEntry := range event.Entries {
+		reportEntry, err := UnmarshalEntry(serializedEntry.Name, serializedEntry.Payload)
+		if err != nil {
+			return err
+		}
+
+		entries[index] = reportEntry
+	}
+
+	e.Chain = event.Chain
+	e.Entries = entries
+	return nil
+}
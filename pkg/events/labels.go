@@ -0,0 +1,34 @@
+package events
+
+import (
+	"main/pkg/report/entry"
+	"main/pkg/types"
+)
+
+// GetEntryLabels returns the chain, wallet and proposal the entry is related to,
+// each of them can be nil if the entry is not related to it.
+func GetEntryLabels(reportEntry entry.ReportEntry) (*types.Chain, *types.Wallet, *types.Proposal) {
+	switch e := reportEntry.(type) {
+	case entry.ReportEntryNotError:
+		proposal := e.GetProposal()
+		return e.GetChain(), e.GetWallet(), &proposal
+	case FinishedVotingEvent:
+		return e.Chain, nil, &e.Proposal
+	case ProposalEnteredVotingEvent:
+		return e.Chain, nil, &e.Proposal
+	case ProposalStatusChangedEvent:
+		return e.Chain, nil, &e.Proposal
+	case SuspectedSpamEvent:
+		return e.Chain, nil, &e.Proposal
+	case VoteQueryError:
+		return e.Chain, nil, &e.Proposal
+	case ProposalsQueryErrorEvent:
+		return e.Chain, nil, nil
+	case GenericErrorEvent:
+		return e.Chain, nil, nil
+	case GroupedEvent:
+		return e.Chain, nil, nil
+	default:
+		return nil, nil, nil
+	}
+}
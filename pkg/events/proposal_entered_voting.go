@@ -0,0 +1,22 @@
+package events
+
+import (
+	"main/pkg/types"
+)
+
+// ProposalEnteredVotingEvent is sent once when a proposal enters the voting period,
+// either right after being submitted or after collecting enough deposit.
+// Wallets are the wallets monitored on this chain, that are expected to vote.
+type ProposalEnteredVotingEvent struct {
+	Chain    *types.Chain
+	Proposal types.Proposal
+	Wallets  []*types.Wallet
+}
+
+func (e ProposalEnteredVotingEvent) Name() string {
+	return "proposal_entered_voting"
+}
+
+func (e ProposalEnteredVotingEvent) IsAlert() bool {
+	return false
+}
@@ -0,0 +1,31 @@
+package events
+
+import (
+	"main/pkg/types"
+)
+
+// ProposalStatusChangedEvent is sent when a known proposal changes its status, like when
+// it enters voting or when voting is finished, or when an expedited proposal has not reached
+// the expedited threshold and was converted to a regular one.
+type ProposalStatusChangedEvent struct {
+	Chain          *types.Chain
+	Proposal       types.Proposal
+	OldStatus      types.ProposalStatus
+	OldIsExpedited bool
+}
+
+func (e ProposalStatusChangedEvent) Name() string {
+	return "proposal_status_changed"
+}
+
+func (e ProposalStatusChangedEvent) IsAlert() bool {
+	return false
+}
+
+func (e ProposalStatusChangedEvent) IsStatusChanged() bool {
+	return e.OldStatus != e.Proposal.Status
+}
+
+func (e ProposalStatusChangedEvent) IsNoLongerExpedited() bool {
+	return e.OldIsExpedited && !e.Proposal.Expedited
+}
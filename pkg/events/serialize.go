@@ -0,0 +1,58 @@
+package events
+
+import (
+	"encoding/json"
+	"fmt"
+	"main/pkg/report/entry"
+)
+
+// MarshalEntry serializes a report entry, so it can be stored and sent later.
+func MarshalEntry(reportEntry entry.ReportEntry) (string, error) {
+	serialized, err := json.Marshal(reportEntry)
+	if err != nil {
+		return "", err
+	}
+
+	return string(serialized), nil
+}
+
+// UnmarshalEntry restores a report entry serialized with MarshalEntry by its name.
+func UnmarshalEntry(name string, payload string) (entry.ReportEntry, error) {
+	switch name {
+	case NotVotedEvent{}.Name():
+		return unmarshalEntry[NotVotedEvent](payload)
+	case VotedEvent{}.Name():
+		return unmarshalEntry[VotedEvent](payload)
+	case RevotedEvent{}.Name():
+		return unmarshalEntry[RevotedEvent](payload)
+	case FinishedVotingEvent{}.Name():
+		return unmarshalEntry[FinishedVotingEvent](payload)
+	case ProposalEnteredVotingEvent{}.Name():
+		return unmarshalEntry[ProposalEnteredVotingEvent](payload)
+	case ProposalStatusChangedEvent{}.Name():
+		return unmarshalEntry[ProposalStatusChangedEvent](payload)
+	case SuspectedSpamEvent{}.Name():
+		return unmarshalEntry[SuspectedSpamEvent](payload)
+	case DigestEvent{}.Name():
+		return unmarshalEntry[DigestEvent](payload)
+	case GroupedEvent{}.Name():
+		return unmarshalEntry[GroupedEvent](payload)
+	case GenericErrorEvent{}.Name():
+		return unmarshalEntry[GenericErrorEvent](payload)
+	case ProposalsQueryErrorEvent{}.Name():
+		return unmarshalEntry[ProposalsQueryErrorEvent](payload)
+	case VoteQueryError{}.Name():
+		return unmarshalEntry[VoteQueryError](payload)
+	default:
+		return nil, fmt.Errorf("unknown event: %s", name)
+	}
+}
+
+func unmarshalEntry[T entry.ReportEntry](payload string) (entry.ReportEntry, error) {
+	var reportEntry T
+	if err := json.Unmarshal([]byte(payload), &reportEntry); err != nil {
+		return nil, err
+	}
+
+	return reportEntry, nil
+}
@@ -0,0 +1,96 @@
+package events
+
+import (
+	"errors"
+	"main/pkg/report/entry"
+	"main/pkg/state"
+	"main/pkg/types"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarshalUnmarshalEntry(t *testing.T) {
+	t.Parallel()
+
+	chain := &types.Chain{Name: "chain", PrettyName: "Chain"}
+	wallet := &types.Wallet{Address: "wallet", Alias: "alias"}
+	proposal := types.Proposal{
+		ID:      "proposal",
+		Title:   "title",
+		EndTime: time.Unix(1700000000, 0).UTC(),
+		Status:  types.ProposalStatusVoting,
+	}
+	vote := &types.Vote{Options: types.VoteOptions{{Option: "Yes", Weight: 1}}}
+	queryError := &types.QueryError{QueryError: errors.New("query error")}
+
+	entries := []entry.ReportEntry{
+		NotVotedEvent{Chain: chain, Wallet: wallet, Proposal: proposal, RenderTime: time.Unix(1700000000, 0).UTC()},
+		VotedEvent{Chain: chain, Wallet: wallet, Proposal: proposal, Vote: vote},
+		RevotedEvent{Chain: chain, Wallet: wallet, Proposal: proposal, Vote: vote, OldVote: vote},
+		FinishedVotingEvent{Chain: chain, Proposal: proposal},
+		ProposalEnteredVotingEvent{Chain: chain, Proposal: proposal, Wallets: []*types.Wallet{wallet}},
+		ProposalStatusChangedEvent{Chain: chain, Proposal: proposal, OldStatus: types.ProposalStatusDeposit},
+		SuspectedSpamEvent{Chain: chain, Proposal: proposal, Reasons: []string{"reason"}},
+		DigestEvent{
+			Since: time.Unix(1700000000, 0).UTC(),
+			State: state.RenderedState{
+				ChainInfos: []state.RenderedChainInfo{{
+					Chain: chain,
+					ProposalVotes: []state.RenderedProposalVotes{{
+						Proposal: proposal,
+						Votes: []state.RenderedWalletVote{
+							{Wallet: wallet, Vote: vote},
+							{Wallet: wallet, Error: queryError},
+						},
+					}},
+				}},
+			},
+			NewVotes:          []DigestVote{{Chain: chain, Proposal: proposal, Wallet: wallet, Vote: vote}},
+			FinishedProposals: []DigestProposal{{Chain: chain, Proposal: proposal}},
+		},
+		GroupedEvent{Chain: chain, Entries: []entry.ReportEntry{
+			NotVotedEvent{Chain: chain, Wallet: wallet, Proposal: proposal, RenderTime: time.Unix(1700000000, 0).UTC()},
+			VotedEvent{Chain: chain, Wallet: wallet, Proposal: proposal, Vote: vote},
+		}},
+		GenericErrorEvent{Chain: chain, Error: errors.New("generic error")},
+		ProposalsQueryErrorEvent{Chain: chain, Error: queryError},
+		VoteQueryError{Chain: chain, Proposal: proposal, Error: queryError},
+	}
+
+	for _, reportEntry := range entries {
+		t.Run(reportEntry.Name(), func(t *testing.T) {
+			payload, err := MarshalEntry(reportEntry)
+			require.NoError(t, err)
+
+			unmarshalled, err := UnmarshalEntry(reportEntry.Name(), payload)
+			require.NoError(t, err)
+			require.Equal(t, reportEntry, unmarshalled)
+		})
+	}
+}
+
+func TestUnmarshalEntryUnknown(t *testing.T) {
+	t.Parallel()
+
+	_, err := UnmarshalEntry("unknown", "{}")
+	require.ErrorContains(t, err, "unknown event")
+}
+
+func TestUnmarshalEntryInvalid(t *testing.T) {
+	t.Parallel()
+
+	_, err := UnmarshalEntry("not_voted", "invalid")
+	require.Error(t, err)
+}
+
+func TestUnmarshalGroupedEntryInvalid(t *testing.T) {
+	t.Parallel()
+
+	_, err := UnmarshalEntry("grouped", "invalid")
+	require.Error(t, err)
+
+	_, err = UnmarshalEntry("grouped", `{"Entries":[{"Name":"unknown","Payload":"{}"}]}`)
+	require.ErrorContains(t, err, "unknown event")
+}
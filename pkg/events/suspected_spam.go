@@ -0,0 +1,21 @@
+package events
+
+import (
+	"main/pkg/types"
+)
+
+// SuspectedSpamEvent is sent once when a proposal in voting is flagged as spam,
+// after that no alerts about wallets not voting on it are sent, unless it's un-flagged.
+type SuspectedSpamEvent struct {
+	Chain    *types.Chain
+	Proposal types.Proposal
+	Reasons  []string
+}
+
+func (e SuspectedSpamEvent) Name() string {
+	return "suspected_spam"
+}
+
+func (e SuspectedSpamEvent) IsAlert() bool {
+	return false
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"main/pkg/fetchers/cosmos/responses"
 	"main/pkg/http"
+	"main/pkg/metrics"
 	"main/pkg/types"
 
 	"go.opentelemetry.io/otel/trace"
@@ -21,12 +22,17 @@ type RPC struct {
 	PaginationLimit int
 }
 
-func NewRPC(chainConfig *types.Chain, logger *zerolog.Logger, tracer trace.Tracer) *RPC {
+func NewRPC(
+	chainConfig *types.Chain,
+	logger *zerolog.Logger,
+	metricsManager *metrics.Manager,
+	tracer trace.Tracer,
+) *RPC {
 	return &RPC{
 		ChainConfig:     chainConfig,
 		ProposalsType:   chainConfig.ProposalsType,
 		Logger:          logger.With().Str("component", "rpc").Logger(),
-		Client:          http.NewClient(chainConfig.Name, chainConfig.LCDEndpoints, logger, tracer),
+		Client:          http.NewClient(chainConfig.Name, chainConfig.LCDEndpoints, logger, metricsManager, tracer),
 		PaginationLimit: PaginationLimit,
 	}
 }
@@ -39,6 +45,16 @@ func (rpc *RPC) GetAllProposals(prevHeight int64, ctx context.Context) ([]types.
 	return rpc.GetAllV1beta1Proposals(prevHeight, ctx)
 }
 
+// GetGovVersion returns the gov module version used in LCD queries paths,
+// falling back to v1beta1 as the older one if nothing is specified.
+func (rpc *RPC) GetGovVersion() string {
+	if rpc.ProposalsType == "v1" {
+		return "v1"
+	}
+
+	return "v1beta1"
+}
+
 func (rpc *RPC) GetStakingPool(ctx context.Context) (*responses.PoolRPCResponse, *types.QueryError) {
 	url := "/cosmos/staking/v1beta1/pool"
 
@@ -8,7 +8,7 @@ import (
 )
 
 func (rpc *RPC) GetGovParams(paramsType string, ctx context.Context) (*responses.ParamsResponse, *types.QueryError) {
-	url := "/cosmos/gov/v1beta1/params/" + paramsType
+	url := "/cosmos/gov/" + rpc.GetGovVersion() + "/params/" + paramsType
 
 	var params responses.ParamsResponse
 	if errs := rpc.Client.Get(url, &params, ctx); len(errs) > 0 {
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"main/assets"
 	loggerPkg "main/pkg/logger"
+	"main/pkg/metrics"
 	"main/pkg/tracing"
 	"main/pkg/types"
 	"testing"
@@ -23,9 +24,10 @@ func TestParamsFailAll(t *testing.T) {
 		LCDEndpoints: []string{"https://example.com"},
 	}
 	logger := loggerPkg.GetNopLogger()
+	metricsManager := metrics.NewManager(logger, types.MetricsConfig{})
 	tracer := tracing.InitNoopTracer()
 
-	fetcher := NewRPC(config, logger, tracer)
+	fetcher := NewRPC(config, logger, metricsManager, tracer)
 
 	httpmock.RegisterResponder(
 		"GET",
@@ -63,9 +65,10 @@ func TestParamsFailOk(t *testing.T) {
 		LCDEndpoints: []string{"https://example.com"},
 	}
 	logger := loggerPkg.GetNopLogger()
+	metricsManager := metrics.NewManager(logger, types.MetricsConfig{})
 	tracer := tracing.InitNoopTracer()
 
-	fetcher := NewRPC(config, logger, tracer)
+	fetcher := NewRPC(config, logger, metricsManager, tracer)
 
 	httpmock.RegisterResponder(
 		"GET",
@@ -99,3 +102,48 @@ func TestParamsFailOk(t *testing.T) {
 	assert.Equal(t, "50.00%", params.Params[4].Serialize())
 	assert.Equal(t, "33.40%", params.Params[5].Serialize())
 }
+
+//nolint:paralleltest // disabled due to httpmock usage
+func TestParamsV1Ok(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	config := &types.Chain{
+		Name:          "chain",
+		LCDEndpoints:  []string{"https://example.com"},
+		ProposalsType: "v1",
+	}
+	logger := loggerPkg.GetNopLogger()
+	metricsManager := metrics.NewManager(logger, types.MetricsConfig{})
+	tracer := tracing.InitNoopTracer()
+
+	fetcher := NewRPC(config, logger, metricsManager, tracer)
+
+	httpmock.RegisterResponder(
+		"GET",
+		"https://example.com/cosmos/gov/v1/params/deposit",
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("params_deposit.json")),
+	)
+
+	httpmock.RegisterResponder(
+		"GET",
+		"https://example.com/cosmos/gov/v1/params/voting",
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("params_voting.json")),
+	)
+
+	httpmock.RegisterResponder(
+		"GET",
+		"https://example.com/cosmos/gov/v1/params/tallying",
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("params_tallying.json")),
+	)
+
+	params, errs := fetcher.GetChainParams(
+		context.Background(),
+	)
+
+	assert.Empty(t, errs)
+	assert.NotNil(t, params)
+	assert.Len(t, params.Params, 6)
+	assert.Equal(t, "14 days", params.Params[0].Serialize())
+	assert.Equal(t, "40.00%", params.Params[3].Serialize())
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"main/assets"
 	loggerPkg "main/pkg/logger"
+	"main/pkg/metrics"
 	"main/pkg/tracing"
 	"main/pkg/types"
 	"testing"
@@ -24,9 +25,10 @@ func TestProposalsV1Fail(t *testing.T) {
 		ProposalsType: "v1",
 	}
 	logger := loggerPkg.GetNopLogger()
+	metricsManager := metrics.NewManager(logger, types.MetricsConfig{})
 	tracer := tracing.InitNoopTracer()
 
-	fetcher := NewRPC(config, logger, tracer)
+	fetcher := NewRPC(config, logger, metricsManager, tracer)
 
 	httpmock.RegisterResponder(
 		"GET",
@@ -59,9 +61,10 @@ func TestProposalsV1LcdError(t *testing.T) {
 		ProposalsType: "v1",
 	}
 	logger := loggerPkg.GetNopLogger()
+	metricsManager := metrics.NewManager(logger, types.MetricsConfig{})
 	tracer := tracing.InitNoopTracer()
 
-	fetcher := NewRPC(config, logger, tracer)
+	fetcher := NewRPC(config, logger, metricsManager, tracer)
 
 	httpmock.RegisterResponder(
 		"GET",
@@ -91,9 +94,10 @@ func TestProposalsV1Success(t *testing.T) {
 		ProposalsType: "v1",
 	}
 	logger := loggerPkg.GetNopLogger()
+	metricsManager := metrics.NewManager(logger, types.MetricsConfig{})
 	tracer := tracing.InitNoopTracer()
 
-	fetcher := NewRPC(config, logger, tracer)
+	fetcher := NewRPC(config, logger, metricsManager, tracer)
 	fetcher.PaginationLimit = 100
 
 	httpmock.RegisterResponder(
@@ -135,9 +139,10 @@ func TestProposalsV1SuccessKujira(t *testing.T) {
 		ProposalsType: "v1",
 	}
 	logger := loggerPkg.GetNopLogger()
+	metricsManager := metrics.NewManager(logger, types.MetricsConfig{})
 	tracer := tracing.InitNoopTracer()
 
-	fetcher := NewRPC(config, logger, tracer)
+	fetcher := NewRPC(config, logger, metricsManager, tracer)
 
 	httpmock.RegisterResponder(
 		"GET",
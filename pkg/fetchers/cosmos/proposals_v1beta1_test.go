@@ -5,6 +5,7 @@ import (
 	"errors"
 	"main/assets"
 	loggerPkg "main/pkg/logger"
+	"main/pkg/metrics"
 	"main/pkg/tracing"
 	"main/pkg/types"
 	"testing"
@@ -23,9 +24,10 @@ func TestProposalsV1beta1Fail(t *testing.T) {
 		LCDEndpoints: []string{"https://example.com"},
 	}
 	logger := loggerPkg.GetNopLogger()
+	metricsManager := metrics.NewManager(logger, types.MetricsConfig{})
 	tracer := tracing.InitNoopTracer()
 
-	fetcher := NewRPC(config, logger, tracer)
+	fetcher := NewRPC(config, logger, metricsManager, tracer)
 
 	httpmock.RegisterResponder(
 		"GET",
@@ -57,9 +59,10 @@ func TestProposalsV1beta1LcdError(t *testing.T) {
 		LCDEndpoints: []string{"https://example.com"},
 	}
 	logger := loggerPkg.GetNopLogger()
+	metricsManager := metrics.NewManager(logger, types.MetricsConfig{})
 	tracer := tracing.InitNoopTracer()
 
-	fetcher := NewRPC(config, logger, tracer)
+	fetcher := NewRPC(config, logger, metricsManager, tracer)
 
 	httpmock.RegisterResponder(
 		"GET",
@@ -88,9 +91,10 @@ func TestProposalsV1beta1Success(t *testing.T) {
 		LCDEndpoints: []string{"https://example.com"},
 	}
 	logger := loggerPkg.GetNopLogger()
+	metricsManager := metrics.NewManager(logger, types.MetricsConfig{})
 	tracer := tracing.InitNoopTracer()
 
-	fetcher := NewRPC(config, logger, tracer)
+	fetcher := NewRPC(config, logger, metricsManager, tracer)
 	fetcher.PaginationLimit = 20
 
 	httpmock.RegisterResponder(
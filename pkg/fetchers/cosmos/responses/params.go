@@ -27,6 +27,15 @@ type Amount struct {
 	Amount string `json:"amount"`
 }
 
+func ToAmounts(amounts []Amount) []types.Amount {
+	return utils.Map(amounts, func(amount Amount) types.Amount {
+		return types.Amount{
+			Denom:  amount.Denom,
+			Amount: amount.Amount,
+		}
+	})
+}
+
 type TallyParams struct {
 	Quorum        math.LegacyDec `json:"quorum"`
 	Threshold     math.LegacyDec `json:"threshold"`
@@ -40,13 +49,8 @@ func (params ParamsResponse) ToParams(chain *types.Chain) (*types.ChainWithVotin
 			types.DurationParam{Description: "Voting period", Value: params.VotingParams.VotingPeriod.Duration},
 			types.DurationParam{Description: "Max deposit period", Value: params.DepositParams.MaxDepositPeriod.Duration},
 			types.AmountsParam{
-				Description: "Min deposit amount",
-				Value: utils.Map(params.DepositParams.MinDepositAmount, func(amount Amount) types.Amount {
-					return types.Amount{
-						Denom:  amount.Denom,
-						Amount: amount.Amount,
-					}
-				}),
+				Description: types.MinDepositParamDescription,
+				Value:       ToAmounts(params.DepositParams.MinDepositAmount),
 			},
 			types.PercentParam{Description: "Quorum", Value: params.TallyParams.Quorum.MustFloat64()},
 			types.PercentParam{Description: "Threshold", Value: params.TallyParams.Threshold.MustFloat64()},
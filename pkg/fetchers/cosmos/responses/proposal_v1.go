@@ -18,6 +18,9 @@ type V1Proposal struct {
 	Status        string              `json:"status"`
 	VotingEndTime time.Time           `json:"voting_end_time"`
 	Messages      []V1ProposalMessage `json:"messages"`
+	TotalDeposit  []Amount            `json:"total_deposit"`
+	Proposer      string              `json:"proposer"`
+	Expedited     bool                `json:"expedited"`
 
 	Title   string `json:"title"`
 	Summary string `json:"summary"`
@@ -52,6 +55,9 @@ func (p V1Proposal) ToProposal() types.Proposal {
 		Description: description,
 		EndTime:     p.VotingEndTime,
 		Status:      ParseProposalStatus(p.Status),
+		Expedited:   p.Expedited,
+		Proposer:    p.Proposer,
+		Deposit:     ToAmounts(p.TotalDeposit),
 	}
 }
 
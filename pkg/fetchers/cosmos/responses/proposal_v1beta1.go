@@ -12,6 +12,7 @@ type V1beta1Proposal struct {
 	Status        string           `json:"status"`
 	Content       *ProposalContent `json:"content"`
 	VotingEndTime time.Time        `json:"voting_end_time"`
+	TotalDeposit  []Amount         `json:"total_deposit"`
 }
 
 type ProposalContent struct {
@@ -32,5 +33,6 @@ func (p V1beta1Proposal) ToProposal() types.Proposal {
 		Description: p.Content.Description,
 		EndTime:     p.VotingEndTime,
 		Status:      ParseProposalStatus(p.Status),
+		Deposit:     ToAmounts(p.TotalDeposit),
 	}
 }
@@ -27,3 +27,27 @@ func (t Tally) ToTally() *types.Tally {
 		{Option: "No with veto", Voted: t.NoWithVeto},
 	}
 }
+
+// cosmos/gov/v1/proposals/:id/tally
+
+type V1TallyRPCResponse struct {
+	Code    int64    `json:"code"`
+	Message string   `json:"message"`
+	Tally   *V1Tally `json:"tally"`
+}
+
+type V1Tally struct {
+	YesCount        math.LegacyDec `json:"yes_count"`
+	NoCount         math.LegacyDec `json:"no_count"`
+	NoWithVetoCount math.LegacyDec `json:"no_with_veto_count"`
+	AbstainCount    math.LegacyDec `json:"abstain_count"`
+}
+
+func (t V1Tally) ToTally() *types.Tally {
+	return &types.Tally{
+		{Option: "Yes", Voted: t.YesCount},
+		{Option: "No", Voted: t.NoCount},
+		{Option: "Abstain", Voted: t.AbstainCount},
+		{Option: "No with veto", Voted: t.NoWithVetoCount},
+	}
+}
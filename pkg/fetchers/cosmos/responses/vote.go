@@ -7,6 +7,7 @@ import (
 )
 
 // cosmos/gov/v1beta1/proposals/:id/votes/:wallet
+// cosmos/gov/v1/proposals/:id/votes/:wallet
 
 type Vote struct {
 	ProposalID string       `json:"proposal_id"`
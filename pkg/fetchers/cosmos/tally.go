@@ -12,6 +12,14 @@ import (
 )
 
 func (rpc *RPC) GetTally(proposal string, ctx context.Context) (*types.Tally, *types.QueryError) {
+	if rpc.ProposalsType == "v1" {
+		return rpc.GetV1Tally(proposal, ctx)
+	}
+
+	return rpc.GetV1beta1Tally(proposal, ctx)
+}
+
+func (rpc *RPC) GetV1beta1Tally(proposal string, ctx context.Context) (*types.Tally, *types.QueryError) {
 	url := fmt.Sprintf(
 		"/cosmos/gov/v1beta1/proposals/%s/tally",
 		proposal,
@@ -28,6 +36,23 @@ func (rpc *RPC) GetTally(proposal string, ctx context.Context) (*types.Tally, *t
 	return tally.Tally.ToTally(), nil
 }
 
+func (rpc *RPC) GetV1Tally(proposal string, ctx context.Context) (*types.Tally, *types.QueryError) {
+	url := fmt.Sprintf(
+		"/cosmos/gov/v1/proposals/%s/tally",
+		proposal,
+	)
+
+	var tally responses.V1TallyRPCResponse
+	if errs := rpc.Client.Get(url, &tally, ctx); len(errs) > 0 {
+		return nil, &types.QueryError{
+			QueryError: nil,
+			NodeErrors: errs,
+		}
+	}
+
+	return tally.Tally.ToTally(), nil
+}
+
 func (rpc *RPC) GetTallies(ctx context.Context) (types.ChainTallyInfos, error) {
 	var wg sync.WaitGroup
 	var mutex sync.Mutex
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"main/assets"
 	loggerPkg "main/pkg/logger"
+	"main/pkg/metrics"
 	"main/pkg/tracing"
 	"main/pkg/types"
 	"testing"
@@ -23,9 +24,10 @@ func TestTallyAllFail(t *testing.T) {
 		LCDEndpoints: []string{"https://example.com"},
 	}
 	logger := loggerPkg.GetNopLogger()
+	metricsManager := metrics.NewManager(logger, types.MetricsConfig{})
 	tracer := tracing.InitNoopTracer()
 
-	fetcher := NewRPC(config, logger, tracer)
+	fetcher := NewRPC(config, logger, metricsManager, tracer)
 
 	httpmock.RegisterResponder(
 		"GET",
@@ -59,9 +61,10 @@ func TestTallyTallyError(t *testing.T) {
 		ProposalsType: "v1",
 	}
 	logger := loggerPkg.GetNopLogger()
+	metricsManager := metrics.NewManager(logger, types.MetricsConfig{})
 	tracer := tracing.InitNoopTracer()
 
-	fetcher := NewRPC(config, logger, tracer)
+	fetcher := NewRPC(config, logger, metricsManager, tracer)
 	fetcher.PaginationLimit = 100
 
 	httpmock.RegisterResponder(
@@ -90,7 +93,7 @@ func TestTallyTallyError(t *testing.T) {
 
 	httpmock.RegisterResponder(
 		"GET",
-		"https://example.com/cosmos/gov/v1beta1/proposals/936/tally",
+		"https://example.com/cosmos/gov/v1/proposals/936/tally",
 		httpmock.NewErrorResponder(errors.New("custom error")),
 	)
 
@@ -114,9 +117,10 @@ func TestTallyAllOk(t *testing.T) {
 		ProposalsType: "v1",
 	}
 	logger := loggerPkg.GetNopLogger()
+	metricsManager := metrics.NewManager(logger, types.MetricsConfig{})
 	tracer := tracing.InitNoopTracer()
 
-	fetcher := NewRPC(config, logger, tracer)
+	fetcher := NewRPC(config, logger, metricsManager, tracer)
 	fetcher.PaginationLimit = 100
 
 	httpmock.RegisterResponder(
@@ -145,8 +149,8 @@ func TestTallyAllOk(t *testing.T) {
 
 	httpmock.RegisterResponder(
 		"GET",
-		"https://example.com/cosmos/gov/v1beta1/proposals/936/tally",
-		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("tally.json")),
+		"https://example.com/cosmos/gov/v1/proposals/936/tally",
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("tally-v1.json")),
 	)
 
 	tallies, err := fetcher.GetTallies(
@@ -157,3 +161,93 @@ func TestTallyAllOk(t *testing.T) {
 	require.NotEmpty(t, tallies.TallyInfos)
 	require.NotNil(t, tallies.Chain)
 }
+
+//nolint:paralleltest // disabled due to httpmock usage
+func TestTallyV1beta1Ok(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	config := &types.Chain{
+		Name:          "chain",
+		LCDEndpoints:  []string{"https://example.com"},
+		ProposalsType: "v1beta1",
+	}
+	logger := loggerPkg.GetNopLogger()
+	metricsManager := metrics.NewManager(logger, types.MetricsConfig{})
+	tracer := tracing.InitNoopTracer()
+
+	fetcher := NewRPC(config, logger, metricsManager, tracer)
+
+	httpmock.RegisterResponder(
+		"GET",
+		"https://example.com/cosmos/gov/v1beta1/proposals/936/tally",
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("tally.json")),
+	)
+
+	tally, err := fetcher.GetTally("936", context.Background())
+
+	require.Nil(t, err)
+	require.NotNil(t, tally)
+	require.Len(t, *tally, 4)
+	require.Equal(t, "Yes", (*tally)[0].Option)
+	require.Equal(t, "52008408706.000000000000000000", (*tally)[0].Voted.String())
+}
+
+//nolint:paralleltest // disabled due to httpmock usage
+func TestTallyV1Ok(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	config := &types.Chain{
+		Name:          "chain",
+		LCDEndpoints:  []string{"https://example.com"},
+		ProposalsType: "v1",
+	}
+	logger := loggerPkg.GetNopLogger()
+	metricsManager := metrics.NewManager(logger, types.MetricsConfig{})
+	tracer := tracing.InitNoopTracer()
+
+	fetcher := NewRPC(config, logger, metricsManager, tracer)
+
+	httpmock.RegisterResponder(
+		"GET",
+		"https://example.com/cosmos/gov/v1/proposals/936/tally",
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("tally-v1.json")),
+	)
+
+	tally, err := fetcher.GetTally("936", context.Background())
+
+	require.Nil(t, err)
+	require.NotNil(t, tally)
+	require.Len(t, *tally, 4)
+	require.Equal(t, "No with veto", (*tally)[3].Option)
+	require.Equal(t, "64525405153526.000000000000000000", (*tally)[3].Voted.String())
+}
+
+//nolint:paralleltest // disabled due to httpmock usage
+func TestTallyV1Fail(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	config := &types.Chain{
+		Name:          "chain",
+		LCDEndpoints:  []string{"https://example.com"},
+		ProposalsType: "v1",
+	}
+	logger := loggerPkg.GetNopLogger()
+	metricsManager := metrics.NewManager(logger, types.MetricsConfig{})
+	tracer := tracing.InitNoopTracer()
+
+	fetcher := NewRPC(config, logger, metricsManager, tracer)
+
+	httpmock.RegisterResponder(
+		"GET",
+		"https://example.com/cosmos/gov/v1/proposals/936/tally",
+		httpmock.NewErrorResponder(errors.New("custom error")),
+	)
+
+	tally, err := fetcher.GetTally("936", context.Background())
+
+	require.Error(t, err)
+	require.Nil(t, tally)
+}
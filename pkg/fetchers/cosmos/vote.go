@@ -16,7 +16,8 @@ func (rpc *RPC) GetVote(
 	ctx context.Context,
 ) (*types.Vote, int64, *types.QueryError) {
 	url := fmt.Sprintf(
-		"/cosmos/gov/v1beta1/proposals/%s/votes/%s",
+		"/cosmos/gov/%s/proposals/%s/votes/%s",
+		rpc.GetGovVersion(),
 		proposal,
 		voter,
 	)
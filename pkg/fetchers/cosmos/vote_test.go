@@ -5,6 +5,7 @@ import (
 	"errors"
 	"main/assets"
 	loggerPkg "main/pkg/logger"
+	"main/pkg/metrics"
 	"main/pkg/tracing"
 	"main/pkg/types"
 	"testing"
@@ -24,9 +25,10 @@ func TestVoteFail(t *testing.T) {
 		LCDEndpoints: []string{"https://example.com"},
 	}
 	logger := loggerPkg.GetNopLogger()
+	metricsManager := metrics.NewManager(logger, types.MetricsConfig{})
 	tracer := tracing.InitNoopTracer()
 
-	fetcher := NewRPC(config, logger, tracer)
+	fetcher := NewRPC(config, logger, metricsManager, tracer)
 
 	httpmock.RegisterResponder(
 		"GET",
@@ -57,9 +59,10 @@ func TestVoteNotFound(t *testing.T) {
 		LCDEndpoints: []string{"https://example.com"},
 	}
 	logger := loggerPkg.GetNopLogger()
+	metricsManager := metrics.NewManager(logger, types.MetricsConfig{})
 	tracer := tracing.InitNoopTracer()
 
-	fetcher := NewRPC(config, logger, tracer)
+	fetcher := NewRPC(config, logger, metricsManager, tracer)
 
 	httpmock.RegisterResponder(
 		"GET",
@@ -89,9 +92,10 @@ func TestVoteLcdError(t *testing.T) {
 		LCDEndpoints: []string{"https://example.com"},
 	}
 	logger := loggerPkg.GetNopLogger()
+	metricsManager := metrics.NewManager(logger, types.MetricsConfig{})
 	tracer := tracing.InitNoopTracer()
 
-	fetcher := NewRPC(config, logger, tracer)
+	fetcher := NewRPC(config, logger, metricsManager, tracer)
 
 	httpmock.RegisterResponder(
 		"GET",
@@ -122,9 +126,10 @@ func TestVoteOk(t *testing.T) {
 		LCDEndpoints: []string{"https://example.com"},
 	}
 	logger := loggerPkg.GetNopLogger()
+	metricsManager := metrics.NewManager(logger, types.MetricsConfig{})
 	tracer := tracing.InitNoopTracer()
 
-	fetcher := NewRPC(config, logger, tracer)
+	fetcher := NewRPC(config, logger, metricsManager, tracer)
 
 	httpmock.RegisterResponder(
 		"GET",
@@ -157,9 +162,10 @@ func TestVoteOkUnknown(t *testing.T) {
 		LCDEndpoints: []string{"https://example.com"},
 	}
 	logger := loggerPkg.GetNopLogger()
+	metricsManager := metrics.NewManager(logger, types.MetricsConfig{})
 	tracer := tracing.InitNoopTracer()
 
-	fetcher := NewRPC(config, logger, tracer)
+	fetcher := NewRPC(config, logger, metricsManager, tracer)
 
 	httpmock.RegisterResponder(
 		"GET",
@@ -192,9 +198,10 @@ func TestVoteOkOld(t *testing.T) {
 		LCDEndpoints: []string{"https://example.com"},
 	}
 	logger := loggerPkg.GetNopLogger()
+	metricsManager := metrics.NewManager(logger, types.MetricsConfig{})
 	tracer := tracing.InitNoopTracer()
 
-	fetcher := NewRPC(config, logger, tracer)
+	fetcher := NewRPC(config, logger, metricsManager, tracer)
 
 	httpmock.RegisterResponder(
 		"GET",
@@ -227,9 +234,10 @@ func TestVoteOkOldUnknown(t *testing.T) {
 		LCDEndpoints: []string{"https://example.com"},
 	}
 	logger := loggerPkg.GetNopLogger()
+	metricsManager := metrics.NewManager(logger, types.MetricsConfig{})
 	tracer := tracing.InitNoopTracer()
 
-	fetcher := NewRPC(config, logger, tracer)
+	fetcher := NewRPC(config, logger, metricsManager, tracer)
 
 	httpmock.RegisterResponder(
 		"GET",
@@ -251,3 +259,76 @@ func TestVoteOkOldUnknown(t *testing.T) {
 		{Option: "test", Weight: 1},
 	}, vote.Options)
 }
+
+//nolint:paralleltest // disabled due to httpmock usage
+func TestVoteV1OkWeighted(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	config := &types.Chain{
+		Name:          "chain",
+		LCDEndpoints:  []string{"https://example.com"},
+		ProposalsType: "v1",
+	}
+	logger := loggerPkg.GetNopLogger()
+	metricsManager := metrics.NewManager(logger, types.MetricsConfig{})
+	tracer := tracing.InitNoopTracer()
+
+	fetcher := NewRPC(config, logger, metricsManager, tracer)
+
+	httpmock.RegisterResponder(
+		"GET",
+		"https://example.com/cosmos/gov/v1/proposals/936/votes/cosmos1xqz9pemz5e5zycaa89kys5aw6m8rhgsvtp9lt2",
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("vote-v1-weighted.json")),
+	)
+
+	vote, height, err := fetcher.GetVote(
+		"936",
+		"cosmos1xqz9pemz5e5zycaa89kys5aw6m8rhgsvtp9lt2",
+		0,
+		context.Background(),
+	)
+
+	require.Nil(t, err)
+	require.Zero(t, height)
+	require.NotNil(t, vote)
+	require.Equal(t, types.VoteOptions{
+		{Option: "👌Yes", Weight: 0.7},
+		{Option: "🤷Abstain", Weight: 0.3},
+	}, vote.Options)
+	require.Equal(t, "👌Yes 70% / 🤷Abstain 30%", vote.ResolveVote())
+}
+
+//nolint:paralleltest // disabled due to httpmock usage
+func TestVoteV1NotFound(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	config := &types.Chain{
+		Name:          "chain",
+		LCDEndpoints:  []string{"https://example.com"},
+		ProposalsType: "v1",
+	}
+	logger := loggerPkg.GetNopLogger()
+	metricsManager := metrics.NewManager(logger, types.MetricsConfig{})
+	tracer := tracing.InitNoopTracer()
+
+	fetcher := NewRPC(config, logger, metricsManager, tracer)
+
+	httpmock.RegisterResponder(
+		"GET",
+		"https://example.com/cosmos/gov/v1/proposals/936/votes/cosmos1xqz9pemz5e5zycaa89kys5aw6m8rhgsvtp9lt2",
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("vote-not-found.json")),
+	)
+
+	vote, height, err := fetcher.GetVote(
+		"936",
+		"cosmos1xqz9pemz5e5zycaa89kys5aw6m8rhgsvtp9lt2",
+		0,
+		context.Background(),
+	)
+
+	require.Nil(t, err)
+	require.Zero(t, height)
+	require.Nil(t, vote)
+}
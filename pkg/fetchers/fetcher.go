@@ -4,6 +4,7 @@ import (
 	"context"
 	"main/pkg/fetchers/cosmos"
 	"main/pkg/fetchers/neutron"
+	"main/pkg/metrics"
 	"main/pkg/types"
 
 	"go.opentelemetry.io/otel/trace"
@@ -22,11 +23,12 @@ type Fetcher interface {
 func GetFetcher(
 	chainConfig *types.Chain,
 	logger *zerolog.Logger,
+	metricsManager *metrics.Manager,
 	tracer trace.Tracer,
 ) Fetcher {
 	if chainConfig.Type == "neutron" {
-		return neutron.NewFetcher(chainConfig, logger, tracer)
+		return neutron.NewFetcher(chainConfig, logger, metricsManager, tracer)
 	}
 
-	return cosmos.NewRPC(chainConfig, logger, tracer)
+	return cosmos.NewRPC(chainConfig, logger, metricsManager, tracer)
 }
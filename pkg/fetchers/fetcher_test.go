@@ -4,6 +4,7 @@ import (
 	"main/pkg/fetchers/cosmos"
 	"main/pkg/fetchers/neutron"
 	loggerPkg "main/pkg/logger"
+	"main/pkg/metrics"
 	"main/pkg/tracing"
 	"main/pkg/types"
 	"testing"
@@ -15,11 +16,12 @@ func TestGetFetcher(t *testing.T) {
 	t.Parallel()
 
 	logger := loggerPkg.GetNopLogger()
+	metricsManager := metrics.NewManager(logger, types.MetricsConfig{})
 	tracer := tracing.InitNoopTracer()
 
-	neutronFetcher := GetFetcher(&types.Chain{Type: "neutron"}, logger, tracer)
+	neutronFetcher := GetFetcher(&types.Chain{Type: "neutron"}, logger, metricsManager, tracer)
 	assert.IsType(t, &neutron.Fetcher{}, neutronFetcher)
 
-	cosmosFetcher := GetFetcher(&types.Chain{}, logger, tracer)
+	cosmosFetcher := GetFetcher(&types.Chain{}, logger, metricsManager, tracer)
 	assert.IsType(t, &cosmos.RPC{}, cosmosFetcher)
 }
@@ -5,6 +5,7 @@ import (
 	"encoding/base64"
 	"fmt"
 	"main/pkg/http"
+	"main/pkg/metrics"
 	"main/pkg/types"
 	"main/pkg/utils"
 
@@ -22,12 +23,13 @@ type Fetcher struct {
 func NewFetcher(
 	chainConfig *types.Chain,
 	logger *zerolog.Logger,
+	metricsManager *metrics.Manager,
 	tracer trace.Tracer,
 ) *Fetcher {
 	return &Fetcher{
 		ChainConfig: chainConfig,
 		Logger:      logger.With().Str("component", "neutron_fetcher").Logger(),
-		Client:      http.NewClient(chainConfig.Name, chainConfig.LCDEndpoints, logger, tracer),
+		Client:      http.NewClient(chainConfig.Name, chainConfig.LCDEndpoints, logger, metricsManager, tracer),
 	}
 }
 
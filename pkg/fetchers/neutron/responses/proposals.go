@@ -15,6 +15,7 @@ type ProposalWithID struct {
 type Proposal struct {
 	Title       string `json:"title"`
 	Description string `json:"description"`
+	Proposer    string `json:"proposer"`
 	Expiration  struct {
 		AtTime int64 `json:"at_time,string"`
 	} `json:"expiration"`
@@ -51,6 +52,7 @@ func (p ProposalWithID) ToProposal() types.Proposal {
 		Description: p.Proposal.Description,
 		EndTime:     time.Unix(0, p.Proposal.Expiration.AtTime),
 		Status:      ParseProposalStatus(p.Proposal.Status),
+		Proposer:    p.Proposal.Proposer,
 	}
 }
 
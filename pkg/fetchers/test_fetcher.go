@@ -4,11 +4,13 @@ import (
 	"context"
 	"errors"
 	"main/pkg/types"
+	"time"
 )
 
 type TestFetcher struct {
 	WithPassedProposals bool
 	WithProposalsError  bool
+	WithSpamProposals   bool
 	WithVote            bool
 	WithVoteError       bool
 	WithTallyError      bool
@@ -27,10 +29,21 @@ func (f *TestFetcher) GetAllProposals(
 	}
 
 	if f.WithPassedProposals {
+		return []types.Proposal{
+			{
+				ID:      "1",
+				Status:  types.ProposalStatusPassed,
+				EndTime: time.Now().Add(-time.Hour),
+			},
+		}, 123, nil
+	}
+
+	if f.WithSpamProposals {
 		return []types.Proposal{
 			{
 				ID:     "1",
-				Status: types.ProposalStatusPassed,
+				Title:  "Claim your airdrop at https://scam.example.com",
+				Status: types.ProposalStatusVoting,
 			},
 		}, 123, nil
 	}
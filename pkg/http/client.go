@@ -3,6 +3,7 @@ package http
 import (
 	"context"
 	"encoding/json"
+	"main/pkg/metrics"
 	"main/pkg/types"
 	"net/http"
 	"time"
@@ -14,24 +15,29 @@ import (
 )
 
 type Client struct {
-	Hosts  []string
-	Logger zerolog.Logger
-	Tracer trace.Tracer
+	ChainName      string
+	Hosts          []string
+	Logger         zerolog.Logger
+	MetricsManager *metrics.Manager
+	Tracer         trace.Tracer
 }
 
 func NewClient(
 	chainName string,
 	hosts []string,
 	logger *zerolog.Logger,
+	metricsManager *metrics.Manager,
 	tracer trace.Tracer,
 ) *Client {
 	return &Client{
-		Hosts: hosts,
+		ChainName: chainName,
+		Hosts:     hosts,
 		Logger: logger.With().
 			Str("component", "http").
 			Str("chain", chainName).
 			Logger(),
-		Tracer: tracer,
+		MetricsManager: metricsManager,
+		Tracer:         tracer,
 	}
 }
 
@@ -59,12 +65,14 @@ func (client *Client) GetWithPredicate(
 		fullURL := lcd + url
 		client.Logger.Trace().Str("url", fullURL).Msg("Trying making request to LCD")
 
+		start := time.Now()
 		header, err := client.GetFull(
 			fullURL,
 			target,
 			predicate,
 			childCtx,
 		)
+		client.MetricsManager.LogNodeQuery(client.ChainName, lcd, err == nil, time.Since(start))
 
 		if err == nil {
 			return nil, header
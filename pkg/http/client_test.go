@@ -5,6 +5,7 @@ import (
 	"main/assets"
 	"main/pkg/constants"
 	loggerPkg "main/pkg/logger"
+	"main/pkg/metrics"
 	"main/pkg/tracing"
 	"main/pkg/types"
 	"net/http"
@@ -18,8 +19,9 @@ func TestHttpClientErrorCreating(t *testing.T) {
 	t.Parallel()
 
 	logger := loggerPkg.GetNopLogger()
+	metricsManager := metrics.NewManager(logger, types.MetricsConfig{})
 	tracer := tracing.InitNoopTracer()
-	client := NewClient("chain", []string{"https://example.com"}, logger, tracer)
+	client := NewClient("chain", []string{"https://example.com"}, logger, metricsManager, tracer)
 	_, err := client.GetFull("://", nil, types.HTTPPredicateCheckHeightAfter(100), nil)
 	require.Error(t, err)
 	require.ErrorContains(t, err, "missing protocol scheme")
@@ -36,8 +38,9 @@ func TestHttpClientQueryFail(t *testing.T) {
 		httpmock.NewErrorResponder(errors.New("custom error")),
 	)
 	logger := loggerPkg.GetNopLogger()
+	metricsManager := metrics.NewManager(logger, types.MetricsConfig{})
 	tracer := tracing.InitNoopTracer()
-	client := NewClient("chain", []string{"https://example.com"}, logger, tracer)
+	client := NewClient("chain", []string{"https://example.com"}, logger, metricsManager, tracer)
 
 	var response interface{}
 	_, err := client.GetFull("https://example.com", &response, types.HTTPPredicateCheckHeightAfter(100), nil)
@@ -58,8 +61,9 @@ func TestHttpClientPredicateFail(t *testing.T) {
 		}),
 	)
 	logger := loggerPkg.GetNopLogger()
+	metricsManager := metrics.NewManager(logger, types.MetricsConfig{})
 	tracer := tracing.InitNoopTracer()
-	client := NewClient("chain", []string{"https://example.com"}, logger, tracer)
+	client := NewClient("chain", []string{"https://example.com"}, logger, metricsManager, tracer)
 
 	var response interface{}
 	_, err := client.GetFull("https://example.com", &response, types.HTTPPredicateCheckHeightAfter(100), nil)
@@ -78,8 +82,9 @@ func TestHttpClientJsonParseFail(t *testing.T) {
 		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("invalid-json.json")),
 	)
 	logger := loggerPkg.GetNopLogger()
+	metricsManager := metrics.NewManager(logger, types.MetricsConfig{})
 	tracer := tracing.InitNoopTracer()
-	client := NewClient("chain", []string{"https://example.com"}, logger, tracer)
+	client := NewClient("chain", []string{"https://example.com"}, logger, metricsManager, tracer)
 
 	var response interface{}
 	_, err := client.GetFull("https://example.com", &response, types.HTTPPredicateAlwaysPass(), nil)
@@ -98,8 +103,9 @@ func TestHttpClientOk(t *testing.T) {
 		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("tally.json")),
 	)
 	logger := loggerPkg.GetNopLogger()
+	metricsManager := metrics.NewManager(logger, types.MetricsConfig{})
 	tracer := tracing.InitNoopTracer()
-	client := NewClient("chain", []string{"https://example.com"}, logger, tracer)
+	client := NewClient("chain", []string{"https://example.com"}, logger, metricsManager, tracer)
 
 	var response interface{}
 	_, err := client.GetFull("https://example.com", &response, types.HTTPPredicateAlwaysPass(), nil)
@@ -117,8 +123,9 @@ func TestHttpClientGetMultipleFail(t *testing.T) {
 		httpmock.NewErrorResponder(errors.New("custom error")),
 	)
 	logger := loggerPkg.GetNopLogger()
+	metricsManager := metrics.NewManager(logger, types.MetricsConfig{})
 	tracer := tracing.InitNoopTracer()
-	client := NewClient("chain", []string{"https://example.com"}, logger, tracer)
+	client := NewClient("chain", []string{"https://example.com"}, logger, metricsManager, tracer)
 
 	var response interface{}
 	errs := client.Get("/", &response, nil)
@@ -139,8 +146,9 @@ func TestHttpClientGetMultipleOk(t *testing.T) {
 		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("tally.json")),
 	)
 	logger := loggerPkg.GetNopLogger()
+	metricsManager := metrics.NewManager(logger, types.MetricsConfig{})
 	tracer := tracing.InitNoopTracer()
-	client := NewClient("chain", []string{"https://example.com"}, logger, tracer)
+	client := NewClient("chain", []string{"https://example.com"}, logger, metricsManager, tracer)
 
 	var response interface{}
 	errs := client.Get("/", &response, nil)
@@ -46,3 +46,28 @@ func TestLoadConfigValidToml(t *testing.T) {
 	require.Equal(t, "https://mintscan.io/bitsong/proposals/%s", firstChain.Explorer.ProposalLinkPattern)
 	require.Equal(t, "https://mintscan.io/bitsong/account/%s", firstChain.Explorer.WalletLinkPattern)
 }
+
+func TestLoadConfigDestinations(t *testing.T) {
+	t.Parallel()
+
+	filesystem := &fs.TestFS{}
+
+	config, err := GetConfig(filesystem, "config-destinations.toml")
+	require.NoError(t, err)
+	require.NoError(t, config.Validate())
+
+	chats := config.TelegramConfig.GetChats()
+	require.Len(t, chats, 3)
+	assert.Equal(t, "telegram:123", chats[0].Destination())
+	assert.Equal(t, "telegram:-100456:7", chats[1].Destination())
+	assert.Equal(t, []string{"bitsong"}, chats[1].Chains)
+	assert.True(t, chats[1].HasOwnMutes())
+	assert.Equal(t, []string{"Validator wallet"}, chats[2].Wallets)
+	assert.Equal(t, "global", chats[2].MuteScope)
+
+	channels := config.DiscordConfig.GetChannels()
+	require.Len(t, channels, 2)
+	assert.Equal(t, "discord:123", channels[0].Destination())
+	assert.Equal(t, []string{"bitsong"}, channels[1].Chains)
+	assert.False(t, channels[1].HasOwnMutes())
+}
@@ -1,6 +1,7 @@
 package logger
 
 import (
+	"io"
 	"main/pkg/types"
 	"os"
 
@@ -8,7 +9,11 @@ import (
 )
 
 func GetDefaultLogger() *zerolog.Logger {
-	log := zerolog.New(zerolog.ConsoleWriter{Out: os.Stdout}).With().Timestamp().Logger()
+	return GetDefaultLoggerWithOutput(os.Stdout)
+}
+
+func GetDefaultLoggerWithOutput(output io.Writer) *zerolog.Logger {
+	log := zerolog.New(zerolog.ConsoleWriter{Out: output}).With().Timestamp().Logger()
 	return &log
 }
 
@@ -18,10 +23,14 @@ func GetNopLogger() *zerolog.Logger {
 }
 
 func GetLogger(config types.LogConfig) *zerolog.Logger {
-	log := zerolog.New(zerolog.ConsoleWriter{Out: os.Stdout}).With().Timestamp().Logger()
+	return GetLoggerWithOutput(config, os.Stdout)
+}
+
+func GetLoggerWithOutput(config types.LogConfig, output io.Writer) *zerolog.Logger {
+	log := zerolog.New(zerolog.ConsoleWriter{Out: output}).With().Timestamp().Logger()
 
 	if config.JSONOutput {
-		log = zerolog.New(os.Stdout).With().Timestamp().Logger()
+		log = zerolog.New(output).With().Timestamp().Logger()
 	}
 
 	logLevel, err := zerolog.ParseLevel(config.LogLevel)
@@ -1,6 +1,7 @@
 package logger_test
 
 import (
+	"bytes"
 	loggerPkg "main/pkg/logger"
 	"main/pkg/types"
 	"testing"
@@ -47,3 +48,13 @@ func TestGetLoggerNop(t *testing.T) {
 	logger := loggerPkg.GetNopLogger()
 	require.NotNil(t, logger)
 }
+
+func TestGetLoggerWithOutput(t *testing.T) {
+	t.Parallel()
+
+	var buffer bytes.Buffer
+
+	logger := loggerPkg.GetLoggerWithOutput(types.LogConfig{LogLevel: "info", JSONOutput: true}, &buffer)
+	logger.Warn().Msg("test")
+	require.Contains(t, buffer.String(), "test")
+}
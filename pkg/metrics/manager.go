@@ -0,0 +1,159 @@
+package metrics
+
+import (
+	"main/pkg/types"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/rs/zerolog"
+)
+
+const MetricsPrefix = "cosmos_proposals_checker_"
+
+type Manager struct {
+	Logger   zerolog.Logger
+	Config   types.MetricsConfig
+	Registry *prometheus.Registry
+
+	proposalsInVotingGauge         *prometheus.GaugeVec
+	notVotedTimeLeftGauge          *prometheus.GaugeVec
+	nodeQueriesCounter             *prometheus.CounterVec
+	nodeQueryDurationHistogram     *prometheus.HistogramVec
+	reportGenerationDurationGauge  prometheus.Gauge
+	reportGenerationTimestampGauge prometheus.Gauge
+	reporterEntriesCounter         *prometheus.CounterVec
+}
+
+func NewManager(logger *zerolog.Logger, config types.MetricsConfig) *Manager {
+	registry := prometheus.NewRegistry()
+
+	manager := &Manager{
+		Logger:   logger.With().Str("component", "metrics").Logger(),
+		Config:   config,
+		Registry: registry,
+
+		proposalsInVotingGauge: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: MetricsPrefix + "proposals_in_voting",
+			Help: "Amount of proposals in voting period per chain",
+		}, []string{"chain"}),
+		notVotedTimeLeftGauge: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: MetricsPrefix + "not_voted_seconds_left",
+			Help: "Seconds left until the voting ends for each wallet/proposal pair the wallet hasn't voted on",
+		}, []string{"chain", "proposal", "wallet", "alias"}),
+		nodeQueriesCounter: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: MetricsPrefix + "node_queries_total",
+			Help: "Amount of queries done to LCD nodes",
+		}, []string{"chain", "node", "status"}),
+		nodeQueryDurationHistogram: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    MetricsPrefix + "node_query_duration_seconds",
+			Help:    "LCD nodes queries duration in seconds",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"chain", "node"}),
+		reportGenerationDurationGauge: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: MetricsPrefix + "report_generation_duration_seconds",
+			Help: "Last report generation duration in seconds",
+		}),
+		reportGenerationTimestampGauge: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: MetricsPrefix + "report_generation_timestamp",
+			Help: "Last report generation time as a unix timestamp",
+		}),
+		reporterEntriesCounter: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: MetricsPrefix + "reporter_entries_total",
+			Help: "Amount of report entries sent by each reporter",
+		}, []string{"reporter", "type", "status"}),
+	}
+
+	registry.MustRegister(
+		manager.proposalsInVotingGauge,
+		manager.notVotedTimeLeftGauge,
+		manager.nodeQueriesCounter,
+		manager.nodeQueryDurationHistogram,
+		manager.reportGenerationDurationGauge,
+		manager.reportGenerationTimestampGauge,
+		manager.reporterEntriesCounter,
+	)
+
+	return manager
+}
+
+func (m *Manager) Start() {
+	if !m.Config.Enabled.Bool {
+		m.Logger.Info().Msg("Metrics not enabled")
+		return
+	}
+
+	m.Logger.Info().
+		Str("addr", m.Config.ListenAddr).
+		Msg("Metrics handler listening")
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.HandlerFor(m.Registry, promhttp.HandlerOpts{}))
+
+	server := &http.Server{
+		Addr:              m.Config.ListenAddr,
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	if err := server.ListenAndServe(); err != nil {
+		m.Logger.Panic().
+			Err(err).
+			Str("addr", m.Config.ListenAddr).
+			Msg("Cannot start metrics handler")
+	}
+}
+
+func (m *Manager) SetProposalsInVoting(chain string, count int) {
+	m.proposalsInVotingGauge.
+		With(prometheus.Labels{"chain": chain}).
+		Set(float64(count))
+}
+
+// ResetNotVoted removes all the not voted wallets on a chain, so the wallets
+// that have voted since the last report or the proposals that are not in voting anymore
+// won't be exported.
+func (m *Manager) ResetNotVoted(chain string) {
+	m.notVotedTimeLeftGauge.DeletePartialMatch(prometheus.Labels{"chain": chain})
+}
+
+func (m *Manager) SetNotVoted(chain string, proposal types.Proposal, wallet *types.Wallet, timeLeft time.Duration) {
+	m.notVotedTimeLeftGauge.
+		With(prometheus.Labels{
+			"chain":    chain,
+			"proposal": proposal.ID,
+			"wallet":   wallet.Address,
+			"alias":    wallet.Alias,
+		}).
+		Set(timeLeft.Seconds())
+}
+
+func (m *Manager) LogNodeQuery(chain, node string, success bool, duration time.Duration) {
+	m.nodeQueriesCounter.
+		With(prometheus.Labels{"chain": chain, "node": node, "status": getStatus(success)}).
+		Inc()
+
+	m.nodeQueryDurationHistogram.
+		With(prometheus.Labels{"chain": chain, "node": node}).
+		Observe(duration.Seconds())
+}
+
+func (m *Manager) LogReportGeneration(duration time.Duration) {
+	m.reportGenerationDurationGauge.Set(duration.Seconds())
+	m.reportGenerationTimestampGauge.SetToCurrentTime()
+}
+
+func (m *Manager) LogReporterEntrySent(reporter, entryType string, success bool) {
+	m.reporterEntriesCounter.
+		With(prometheus.Labels{"reporter": reporter, "type": entryType, "status": getStatus(success)}).
+		Inc()
+}
+
+func getStatus(success bool) string {
+	if success {
+		return "success"
+	}
+
+	return "failure"
+}
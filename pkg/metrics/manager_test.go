@@ -0,0 +1,101 @@
+package metrics
+
+import (
+	"main/pkg/logger"
+	"main/pkg/types"
+	"testing"
+	"time"
+
+	"github.com/guregu/null/v5"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetricsManagerStartDisabled(t *testing.T) {
+	t.Parallel()
+
+	manager := NewManager(logger.GetNopLogger(), types.MetricsConfig{})
+	manager.Start()
+}
+
+func TestMetricsManagerStartFailed(t *testing.T) {
+	t.Parallel()
+
+	defer func() {
+		if r := recover(); r == nil {
+			assert.Fail(t, "Expected to have a panic here!")
+		}
+	}()
+
+	manager := NewManager(logger.GetDefaultLogger(), types.MetricsConfig{
+		Enabled:    null.BoolFrom(true),
+		ListenAddr: "invalid",
+	})
+	manager.Start()
+}
+
+func TestMetricsManagerProposalsInVoting(t *testing.T) {
+	t.Parallel()
+
+	manager := NewManager(logger.GetNopLogger(), types.MetricsConfig{})
+	manager.SetProposalsInVoting("chain", 3)
+
+	assert.InDelta(t, 3, testutil.ToFloat64(manager.proposalsInVotingGauge.WithLabelValues("chain")), 0.001)
+}
+
+func TestMetricsManagerNotVoted(t *testing.T) {
+	t.Parallel()
+
+	manager := NewManager(logger.GetNopLogger(), types.MetricsConfig{})
+	manager.SetNotVoted("chain", types.Proposal{ID: "1"}, &types.Wallet{Address: "wallet"}, time.Minute)
+	manager.SetNotVoted("chain", types.Proposal{ID: "2"}, &types.Wallet{Address: "wallet"}, time.Hour)
+	manager.SetNotVoted("chain2", types.Proposal{ID: "1"}, &types.Wallet{Address: "wallet"}, time.Hour)
+
+	assert.Equal(t, 3, testutil.CollectAndCount(manager.notVotedTimeLeftGauge))
+	assert.InDelta(
+		t,
+		60,
+		testutil.ToFloat64(manager.notVotedTimeLeftGauge.WithLabelValues("chain", "1", "wallet", "")),
+		0.001,
+	)
+
+	manager.ResetNotVoted("chain")
+	assert.Equal(t, 1, testutil.CollectAndCount(manager.notVotedTimeLeftGauge))
+}
+
+func TestMetricsManagerNodeQueries(t *testing.T) {
+	t.Parallel()
+
+	manager := NewManager(logger.GetNopLogger(), types.MetricsConfig{})
+	manager.LogNodeQuery("chain", "node", true, time.Second)
+	manager.LogNodeQuery("chain", "node", true, time.Second)
+	manager.LogNodeQuery("chain", "node", false, time.Second)
+
+	assert.InDelta(t, 2, testutil.ToFloat64(manager.nodeQueriesCounter.WithLabelValues("chain", "node", "success")), 0.001)
+	assert.InDelta(t, 1, testutil.ToFloat64(manager.nodeQueriesCounter.WithLabelValues("chain", "node", "failure")), 0.001)
+	assert.Equal(t, 1, testutil.CollectAndCount(manager.nodeQueryDurationHistogram))
+}
+
+func TestMetricsManagerReportGeneration(t *testing.T) {
+	t.Parallel()
+
+	manager := NewManager(logger.GetNopLogger(), types.MetricsConfig{})
+	manager.LogReportGeneration(2 * time.Second)
+
+	assert.InDelta(t, 2, testutil.ToFloat64(manager.reportGenerationDurationGauge), 0.001)
+	assert.Positive(t, testutil.ToFloat64(manager.reportGenerationTimestampGauge))
+}
+
+func TestMetricsManagerReporterEntrySent(t *testing.T) {
+	t.Parallel()
+
+	manager := NewManager(logger.GetNopLogger(), types.MetricsConfig{})
+	manager.LogReporterEntrySent("telegram-reporter", "not_voted", false)
+
+	assert.InDelta(
+		t,
+		1,
+		testutil.ToFloat64(manager.reporterEntriesCounter.WithLabelValues("telegram-reporter", "not_voted", "failure")),
+		0.001,
+	)
+}
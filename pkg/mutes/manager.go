@@ -1,16 +1,35 @@
 package mutesmanager
 
 import (
+	"errors"
+	"fmt"
 	databasePkg "main/pkg/database"
+	"main/pkg/events"
 	"main/pkg/report/entry"
 	"main/pkg/types"
+	"sync"
+	"time"
 
+	"github.com/guregu/null/v5"
 	"github.com/rs/zerolog"
 )
 
+// MutesHistoryLimit is how many entries the mutes history commands display.
+const MutesHistoryLimit = 20
+
+type MuteAddedHandler func(mute *types.Mute)
+
 type Manager struct {
-	Database databasePkg.Database
-	Logger   zerolog.Logger
+	Database          databasePkg.Database
+	Logger            zerolog.Logger
+	MuteAddedHandlers []MuteAddedHandler
+
+	// the mutes matched by title pattern are loaded once per report instead of once
+	// per entry, reporter and destination, see CacheMutes
+	cacheMutex   sync.Mutex
+	cacheUsers   int
+	cachedMutes  []*types.Mute
+	mutesAreRead bool
 }
 
 func NewMutesManager(logger *zerolog.Logger, database databasePkg.Database) *Manager {
@@ -20,16 +39,130 @@ func NewMutesManager(logger *zerolog.Logger, database databasePkg.Database) *Man
 	}
 }
 
+// IsEntryMuted returns whether the entry is muted by a mute applying to everything.
 func (m *Manager) IsEntryMuted(reportEntry entry.ReportEntry) (bool, error) {
-	entryConverted, ok := reportEntry.(entry.ReportEntryNotError)
-	if !ok {
+	return m.IsEntryMutedForDestination(reportEntry, "")
+}
+
+// IsEntryMutedForDestination returns whether the entry is muted either by a mute
+// applying to everything, or by a mute applying to the given destination only.
+// Errors can be muted as well, for example by a mute on their event type.
+func (m *Manager) IsEntryMutedForDestination(reportEntry entry.ReportEntry, destination string) (bool, error) {
+	query := GetEntryMuteQuery(reportEntry, destination)
+
+	if muted, err := m.Database.IsMuted(query); err != nil || muted {
+		return muted, err
+	}
+
+	return m.IsMutedByTitlePattern(query)
+}
+
+// IsMutedByTitlePattern checks the mutes matching the proposal title by a regular expression.
+// These are matched here and not in the database, as databases do not support
+// regular expressions the same way.
+func (m *Manager) IsMutedByTitlePattern(query types.MuteQuery) (bool, error) {
+	if query.ProposalTitle == "" {
 		return false, nil
 	}
 
-	chain := entryConverted.GetChain()
-	proposal := entryConverted.GetProposal()
+	mutes, err := m.GetMutesForMatching()
+	if err != nil {
+		return false, err
+	}
+
+	for _, mute := range mutes {
+		if mute.TitlePattern.IsZero() || mute.IsExpired() {
+			continue
+		}
+
+		if mute.Matches(query) && mute.MatchesDestination(query.Destination) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// CacheMutes makes the mutes be loaded from the database only once until StopCachingMutes
+// is called, so checking the entries of a report does not query all the mutes for each of them.
+// The cached mutes are reloaded when a mute is added or deleted in the meantime.
+func (m *Manager) CacheMutes() {
+	m.cacheMutex.Lock()
+	defer m.cacheMutex.Unlock()
+
+	m.cacheUsers++
+}
+
+// StopCachingMutes drops the cached mutes once nothing uses them anymore.
+func (m *Manager) StopCachingMutes() {
+	m.cacheMutex.Lock()
+	defer m.cacheMutex.Unlock()
+
+	if m.cacheUsers > 0 {
+		m.cacheUsers--
+	}
+
+	if m.cacheUsers == 0 {
+		m.resetCachedMutes()
+	}
+}
+
+// GetMutesForMatching returns all the mutes, taking them from the cache if mutes are cached.
+func (m *Manager) GetMutesForMatching() ([]*types.Mute, error) {
+	m.cacheMutex.Lock()
+	defer m.cacheMutex.Unlock()
+
+	if m.mutesAreRead {
+		return m.cachedMutes, nil
+	}
+
+	mutes, err := m.Database.GetAllMutes()
+	if err != nil {
+		return nil, err
+	}
+
+	if m.cacheUsers > 0 {
+		m.cachedMutes = mutes
+		m.mutesAreRead = true
+	}
+
+	return mutes, nil
+}
+
+func (m *Manager) InvalidateCachedMutes() {
+	m.cacheMutex.Lock()
+	defer m.cacheMutex.Unlock()
+
+	m.resetCachedMutes()
+}
+
+func (m *Manager) resetCachedMutes() {
+	m.cachedMutes = nil
+	m.mutesAreRead = false
+}
+
+func GetEntryMuteQuery(reportEntry entry.ReportEntry, destination string) types.MuteQuery {
+	query := types.MuteQuery{
+		Event:       reportEntry.Name(),
+		Destination: destination,
+	}
+
+	chain, wallet, proposal := events.GetEntryLabels(reportEntry)
+	if chain != nil {
+		query.Chain = chain.Name
+	}
+
+	if wallet != nil {
+		query.WalletAddress = wallet.Address
+		query.WalletAlias = wallet.Alias
+	}
+
+	if proposal != nil {
+		query.ProposalID = proposal.ID
+		query.ProposalTitle = proposal.Title
+	}
 
-	return m.Database.IsMuted(chain.Name, proposal.ID)
+	return query
 }
 
 func (m *Manager) GetAllMutes() ([]*types.Mute, error) {
@@ -37,9 +170,94 @@ func (m *Manager) GetAllMutes() ([]*types.Mute, error) {
 }
 
 func (m *Manager) AddMute(mute *types.Mute) error {
-	return m.Database.UpsertMute(mute)
+	if !mute.CreatedAt.Valid {
+		mute.CreatedAt = null.TimeFrom(time.Now())
+	}
+
+	// mutes until the voting ends are added without the expiration time,
+	// as it is taken from the stored proposal
+	if mute.Expires.IsZero() {
+		endTime, err := m.GetVotingEndTime(mute)
+		if err != nil {
+			return err
+		}
+
+		mute.Expires = endTime
+	}
+
+	if err := m.Database.UpsertMute(mute); err != nil {
+		return err
+	}
+
+	m.InvalidateCachedMutes()
+
+	for _, handler := range m.MuteAddedHandlers {
+		handler(mute)
+	}
+
+	return nil
+}
+
+// GetVotingEndTime returns when the voting ends for the mute's proposal.
+func (m *Manager) GetVotingEndTime(mute *types.Mute) (time.Time, error) {
+	if mute.Chain.IsZero() || mute.ProposalID.IsZero() {
+		return time.Time{}, errors.New("both chain and proposal are required to mute until the voting ends")
+	}
+
+	proposal, err := m.Database.GetProposal(&types.Chain{Name: mute.Chain.String}, mute.ProposalID.String)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	if proposal == nil {
+		return time.Time{}, fmt.Errorf(
+			"proposal %s on chain %s is not found",
+			mute.ProposalID.String,
+			mute.Chain.String,
+		)
+	}
+
+	if proposal.EndTime.Before(time.Now()) {
+		return time.Time{}, fmt.Errorf(
+			"voting for proposal %s on chain %s has already ended",
+			mute.ProposalID.String,
+			mute.Chain.String,
+		)
+	}
+
+	return proposal.EndTime, nil
+}
+
+// OnMuteAdded registers a handler called after each successfully added mute,
+// for components that need to react on it, like resolving open alerts.
+func (m *Manager) OnMuteAdded(handler MuteAddedHandler) {
+	m.MuteAddedHandlers = append(m.MuteAddedHandlers, handler)
+}
+
+// DeleteMute deletes the mute, keeping it in the mutes history along with who deleted it.
+func (m *Manager) DeleteMute(mute *types.Mute, removedBy string) (bool, error) {
+	deleted, err := m.Database.DeleteMute(mute, removedBy)
+	if deleted {
+		m.InvalidateCachedMutes()
+	}
+
+	return deleted, err
+}
+
+// ArchiveExpiredMutes moves expired mutes to the mutes history.
+func (m *Manager) ArchiveExpiredMutes() {
+	archived, err := m.Database.ArchiveExpiredMutes()
+	if err != nil {
+		m.Logger.Error().Err(err).Msg("Error archiving expired mutes")
+		return
+	}
+
+	if archived > 0 {
+		m.Logger.Info().Int64("count", archived).Msg("Archived expired mutes")
+	}
 }
 
-func (m *Manager) DeleteMute(mute *types.Mute) (bool, error) {
-	return m.Database.DeleteMute(mute)
+// GetMutesHistory returns the most recently deleted or expired mutes.
+func (m *Manager) GetMutesHistory() ([]*types.MuteHistoryEntry, error) {
+	return m.Database.GetMutesHistory(MutesHistoryLimit)
 }
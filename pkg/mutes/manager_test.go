@@ -1,6 +1,7 @@
 package mutesmanager
 
 import (
+	"errors"
 	databasePkg "main/pkg/database"
 	"main/pkg/events"
 	"main/pkg/logger"
@@ -49,7 +50,7 @@ func TestMuteManagerAddAndDeleteMuteIsMuted(t *testing.T) {
 
 	deleted, err := manager.DeleteMute(&types.Mute{
 		Chain: null.StringFrom("chain"),
-	})
+	}, "user")
 	assert.True(t, deleted)
 	require.NoError(t, err)
 
@@ -69,24 +70,108 @@ func TestMuteManagerAddAndDeleteMuteIsMuted(t *testing.T) {
 
 	deleted2, err2 := manager.DeleteMute(&types.Mute{
 		Chain: null.StringFrom("chain"),
-	})
+	}, "user")
 	assert.False(t, deleted2)
 	require.NoError(t, err2)
 }
 
-func TestMuteManagerIsNotAlert(t *testing.T) {
+func TestMuteManagerIsMutedForDestination(t *testing.T) {
 	t.Parallel()
 
 	log := logger.GetNopLogger()
 	db := &databasePkg.StubDatabase{}
 	manager := NewMutesManager(log, db)
 
-	muted, err := manager.IsEntryMuted(events.ProposalsQueryErrorEvent{
-		Chain: &types.Chain{Name: "chain"},
+	err := manager.AddMute(&types.Mute{
+		Chain:       null.StringFrom("chain"),
+		Destination: null.StringFrom("telegram:123"),
+		Expires:     time.Now().Add(time.Hour),
 	})
+	require.NoError(t, err)
+
+	event := events.VotedEvent{Chain: &types.Chain{Name: "chain"}}
 
+	muted1, err1 := manager.IsEntryMuted(event)
+	require.NoError(t, err1)
+	assert.False(t, muted1)
+
+	muted2, err2 := manager.IsEntryMutedForDestination(event, "telegram:123")
+	require.NoError(t, err2)
+	assert.True(t, muted2)
+
+	muted3, err3 := manager.IsEntryMutedForDestination(event, "telegram:456")
+	require.NoError(t, err3)
+	assert.False(t, muted3)
+}
+
+func TestMuteManagerErrorIsMuted(t *testing.T) {
+	t.Parallel()
+
+	log := logger.GetNopLogger()
+	db := &databasePkg.StubDatabase{}
+	manager := NewMutesManager(log, db)
+
+	event := events.ProposalsQueryErrorEvent{
+		Chain: &types.Chain{Name: "chain"},
+		Error: &types.QueryError{QueryError: errors.New("error")},
+	}
+
+	muted, err := manager.IsEntryMuted(event)
 	require.NoError(t, err)
 	assert.False(t, muted)
+
+	err = manager.AddMute(&types.Mute{
+		Event:   null.StringFrom("proposals_query_error"),
+		Expires: time.Now().Add(time.Hour),
+	})
+	require.NoError(t, err)
+
+	muted2, err := manager.IsEntryMuted(event)
+	require.NoError(t, err)
+	assert.True(t, muted2)
+
+	muted3, err := manager.IsEntryMuted(events.GenericErrorEvent{Error: errors.New("error")})
+	require.NoError(t, err)
+	assert.False(t, muted3)
+}
+
+func TestMuteManagerWalletIsMuted(t *testing.T) {
+	t.Parallel()
+
+	log := logger.GetNopLogger()
+	db := &databasePkg.StubDatabase{}
+	manager := NewMutesManager(log, db)
+
+	err := manager.AddMute(&types.Mute{
+		Wallet:  null.StringFrom("retired"),
+		Expires: time.Now().Add(time.Hour),
+	})
+	require.NoError(t, err)
+
+	muted, err := manager.IsEntryMuted(events.NotVotedEvent{
+		Chain:    &types.Chain{Name: "chain"},
+		Wallet:   &types.Wallet{Address: "address", Alias: "retired"},
+		Proposal: types.Proposal{ID: "1"},
+	})
+	require.NoError(t, err)
+	assert.True(t, muted)
+
+	muted2, err := manager.IsEntryMuted(events.NotVotedEvent{
+		Chain:    &types.Chain{Name: "chain"},
+		Wallet:   &types.Wallet{Address: "address2"},
+		Proposal: types.Proposal{ID: "1"},
+	})
+	require.NoError(t, err)
+	assert.False(t, muted2)
+
+	// errors are not related to any wallet, so wallet mutes do not apply to them
+	muted3, err := manager.IsEntryMuted(events.VoteQueryError{
+		Chain:    &types.Chain{Name: "chain"},
+		Proposal: types.Proposal{ID: "1"},
+		Error:    &types.QueryError{QueryError: errors.New("error")},
+	})
+	require.NoError(t, err)
+	assert.False(t, muted3)
 }
 
 func TestMuteManagerGetAllMutes(t *testing.T) {
@@ -110,3 +195,243 @@ func TestMuteManagerGetAllMutes(t *testing.T) {
 	assert.NotEmpty(t, allMutes2)
 	require.NoError(t, err2)
 }
+
+func TestMuteManagerOnMuteAdded(t *testing.T) {
+	t.Parallel()
+
+	log := logger.GetNopLogger()
+	db := &databasePkg.StubDatabase{}
+	manager := NewMutesManager(log, db)
+
+	handledMutes := make([]*types.Mute, 0)
+	manager.OnMuteAdded(func(mute *types.Mute) {
+		handledMutes = append(handledMutes, mute)
+	})
+
+	mute := &types.Mute{
+		Chain:   null.StringFrom("chain"),
+		Expires: time.Now().Add(time.Hour),
+	}
+
+	err := manager.AddMute(mute)
+	require.NoError(t, err)
+	require.Equal(t, []*types.Mute{mute}, handledMutes)
+}
+
+func TestMuteManagerOnMuteAddedError(t *testing.T) {
+	t.Parallel()
+
+	log := logger.GetNopLogger()
+	db := &databasePkg.StubDatabase{UpsertMuteError: errors.New("custom error")}
+	manager := NewMutesManager(log, db)
+
+	handled := false
+	manager.OnMuteAdded(func(mute *types.Mute) {
+		handled = true
+	})
+
+	err := manager.AddMute(&types.Mute{Expires: time.Now().Add(time.Hour)})
+	require.Error(t, err)
+	require.False(t, handled)
+}
+
+func TestMuteManagerArchiveExpiredMutes(t *testing.T) {
+	t.Parallel()
+
+	log := logger.GetNopLogger()
+	db := &databasePkg.StubDatabase{}
+	manager := NewMutesManager(log, db)
+
+	err := manager.AddMute(&types.Mute{
+		Chain:   null.StringFrom("chain"),
+		Expires: time.Now().Add(-time.Hour),
+	})
+	require.NoError(t, err)
+	require.True(t, db.Mutes[0].CreatedAt.Valid)
+
+	err = manager.AddMute(&types.Mute{
+		Chain:   null.StringFrom("chain2"),
+		Expires: time.Now().Add(time.Hour),
+	})
+	require.NoError(t, err)
+
+	deleted, err := manager.DeleteMute(&types.Mute{Chain: null.StringFrom("chain2")}, "user")
+	require.True(t, deleted)
+	require.NoError(t, err)
+
+	manager.ArchiveExpiredMutes()
+	require.Empty(t, db.Mutes)
+
+	history, err := manager.GetMutesHistory()
+	require.NoError(t, err)
+	require.Len(t, history, 2)
+	assert.Equal(t, types.MuteRemovedReasonDeleted, history[0].Reason)
+	assert.Equal(t, "user", history[0].RemovedBy.String)
+	assert.Equal(t, types.MuteRemovedReasonExpired, history[1].Reason)
+}
+
+func TestMuteManagerArchiveExpiredMutesError(t *testing.T) {
+	t.Parallel()
+
+	log := logger.GetNopLogger()
+	db := &databasePkg.StubDatabase{ArchiveMutesError: errors.New("custom error")}
+	manager := NewMutesManager(log, db)
+
+	manager.ArchiveExpiredMutes()
+
+	history, err := manager.GetMutesHistory()
+	require.NoError(t, err)
+	require.Empty(t, history)
+}
+
+func TestMuteManagerTitlePatternIsMuted(t *testing.T) {
+	t.Parallel()
+
+	log := logger.GetNopLogger()
+	db := &databasePkg.StubDatabase{}
+	manager := NewMutesManager(log, db)
+
+	err := manager.AddMute(&types.Mute{
+		TitlePattern: null.StringFrom("(?i)airdrop"),
+		Destination:  null.StringFrom("telegram:123"),
+		Expires:      time.Now().Add(time.Hour),
+	})
+	require.NoError(t, err)
+
+	spam := events.NotVotedEvent{
+		Chain:    &types.Chain{Name: "chain"},
+		Wallet:   &types.Wallet{Address: "address"},
+		Proposal: types.Proposal{ID: "1", Title: "Claim your AIRDROP"},
+	}
+
+	muted, err := manager.IsEntryMutedForDestination(spam, "telegram:123")
+	require.NoError(t, err)
+	assert.True(t, muted)
+
+	muted2, err := manager.IsEntryMuted(spam)
+	require.NoError(t, err)
+	assert.False(t, muted2)
+
+	muted3, err := manager.IsEntryMutedForDestination(events.NotVotedEvent{
+		Chain:    &types.Chain{Name: "chain"},
+		Wallet:   &types.Wallet{Address: "address"},
+		Proposal: types.Proposal{ID: "2", Title: "Software upgrade"},
+	}, "telegram:123")
+	require.NoError(t, err)
+	assert.False(t, muted3)
+}
+
+func TestMuteManagerTitlePatternError(t *testing.T) {
+	t.Parallel()
+
+	log := logger.GetNopLogger()
+	db := &databasePkg.StubDatabase{GetAllMutesError: errors.New("custom error")}
+	manager := NewMutesManager(log, db)
+
+	_, err := manager.IsEntryMuted(events.NotVotedEvent{
+		Chain:    &types.Chain{Name: "chain"},
+		Wallet:   &types.Wallet{Address: "address"},
+		Proposal: types.Proposal{ID: "1", Title: "Title"},
+	})
+	require.Error(t, err)
+}
+
+func TestMuteManagerTitlePatternCached(t *testing.T) {
+	t.Parallel()
+
+	log := logger.GetNopLogger()
+	db := &databasePkg.StubDatabase{}
+	manager := NewMutesManager(log, db)
+
+	err := manager.AddMute(&types.Mute{
+		TitlePattern: null.StringFrom("(?i)airdrop"),
+		Expires:      time.Now().Add(time.Hour),
+	})
+	require.NoError(t, err)
+
+	spam := events.NotVotedEvent{
+		Chain:    &types.Chain{Name: "chain"},
+		Wallet:   &types.Wallet{Address: "address"},
+		Proposal: types.Proposal{ID: "1", Title: "Claim your AIRDROP"},
+	}
+
+	manager.CacheMutes()
+
+	muted, err := manager.IsEntryMuted(spam)
+	require.NoError(t, err)
+	assert.True(t, muted)
+
+	// the mutes are taken from the cache, not from the database
+	db.GetAllMutesError = errors.New("custom error")
+	muted, err = manager.IsEntryMuted(spam)
+	require.NoError(t, err)
+	assert.True(t, muted)
+
+	// deleting a mute drops the cache
+	deleted, err := manager.DeleteMute(db.Mutes[0], "user")
+	require.NoError(t, err)
+	require.True(t, deleted)
+	_, err = manager.IsEntryMuted(spam)
+	require.Error(t, err)
+
+	db.GetAllMutesError = nil
+	muted, err = manager.IsEntryMuted(spam)
+	require.NoError(t, err)
+	assert.False(t, muted)
+
+	// adding a mute drops the cache as well
+	err = manager.AddMute(&types.Mute{
+		TitlePattern: null.StringFrom("(?i)airdrop"),
+		Expires:      time.Now().Add(time.Hour),
+	})
+	require.NoError(t, err)
+	muted, err = manager.IsEntryMuted(spam)
+	require.NoError(t, err)
+	assert.True(t, muted)
+
+	// once caching is stopped, the mutes are loaded from the database on each check
+	manager.StopCachingMutes()
+	db.GetAllMutesError = errors.New("custom error")
+	_, err = manager.IsEntryMuted(spam)
+	require.Error(t, err)
+}
+
+func TestMuteManagerAddMuteUntilVotingEnds(t *testing.T) {
+	t.Parallel()
+
+	log := logger.GetNopLogger()
+	endTime := time.Now().Add(time.Hour)
+	db := &databasePkg.StubDatabase{
+		Proposals: map[string]map[string]*types.Proposal{
+			"chain": {
+				"1": {ID: "1", EndTime: endTime},
+				"2": {ID: "2", EndTime: time.Now().Add(-time.Hour)},
+			},
+		},
+	}
+	manager := NewMutesManager(log, db)
+
+	mute := &types.Mute{Chain: null.StringFrom("chain"), ProposalID: null.StringFrom("1")}
+	require.NoError(t, manager.AddMute(mute))
+	assert.Equal(t, endTime, mute.Expires)
+	require.Len(t, db.Mutes, 1)
+
+	require.ErrorContains(t, manager.AddMute(&types.Mute{
+		Chain:      null.StringFrom("chain"),
+		ProposalID: null.StringFrom("2"),
+	}), "has already ended")
+	require.ErrorContains(t, manager.AddMute(&types.Mute{
+		Chain:      null.StringFrom("chain"),
+		ProposalID: null.StringFrom("3"),
+	}), "is not found")
+	require.ErrorContains(t, manager.AddMute(&types.Mute{
+		Chain: null.StringFrom("chain"),
+	}), "both chain and proposal are required")
+
+	errorManager := NewMutesManager(log, &databasePkg.StubDatabase{GetProposalError: errors.New("custom error")})
+	require.Error(t, errorManager.AddMute(&types.Mute{
+		Chain:      null.StringFrom("chain"),
+		ProposalID: null.StringFrom("1"),
+	}))
+	require.Len(t, db.Mutes, 1)
+}
@@ -0,0 +1,109 @@
+package outbox
+
+import (
+	databasePkg "main/pkg/database"
+	"main/pkg/events"
+	"main/pkg/report/entry"
+	"main/pkg/types"
+	"time"
+
+	"github.com/guregu/null/v5"
+	"github.com/rs/zerolog"
+)
+
+type Manager struct {
+	Config   types.OutboxConfig
+	Database databasePkg.Database
+	Logger   zerolog.Logger
+}
+
+func NewManager(
+	logger *zerolog.Logger,
+	config types.OutboxConfig,
+	database databasePkg.Database,
+) *Manager {
+	return &Manager{
+		Config:   config,
+		Database: database,
+		Logger:   logger.With().Str("component", "outbox_manager").Logger(),
+	}
+}
+
+func (m *Manager) Enabled() bool {
+	return m.Config.Enabled.Bool
+}
+
+// Add stores the report entry before it's sent by the reporter. It is only due
+// to be retried after the initial backoff, so it's not picked up while it's being sent.
+func (m *Manager) Add(reporter string, reportEntry entry.ReportEntry) (*types.OutboxEntry, error) {
+	payload, err := events.MarshalEntry(reportEntry)
+	if err != nil {
+		m.Logger.Error().Err(err).Str("entry", reportEntry.Name()).Msg("Error serializing report entry")
+		return nil, err
+	}
+
+	now := time.Now()
+	outboxEntry := &types.OutboxEntry{
+		Reporter:      reporter,
+		Event:         reportEntry.Name(),
+		Payload:       payload,
+		Status:        types.OutboxStatusPending,
+		NextAttemptAt: now.Add(m.Config.InitialBackoff.Duration),
+		CreatedAt:     now,
+	}
+
+	chain, wallet, proposal := events.GetEntryLabels(reportEntry)
+	if chain != nil {
+		outboxEntry.Chain = null.StringFrom(chain.GetName())
+	}
+	if wallet != nil {
+		outboxEntry.Wallet = null.StringFrom(wallet.AddressOrAlias())
+	}
+	if proposal != nil {
+		outboxEntry.ProposalID = null.StringFrom(proposal.ID)
+	}
+
+	if err := m.Database.InsertOutboxEntry(outboxEntry); err != nil {
+		return nil, err
+	}
+
+	return outboxEntry, nil
+}
+
+func (m *Manager) MarkSent(outboxEntry *types.OutboxEntry) error {
+	return m.Database.DeleteOutboxEntry(outboxEntry.ID)
+}
+
+func (m *Manager) MarkFailed(outboxEntry *types.OutboxEntry, sendErr error) error {
+	outboxEntry.MarkFailed(sendErr, m.Config, time.Now())
+
+	if outboxEntry.IsDead() {
+		m.Logger.Warn().
+			Int64("id", outboxEntry.ID).
+			Str("reporter", outboxEntry.Reporter).
+			Str("entry", outboxEntry.Event).
+			Int("attempts", outboxEntry.Attempts).
+			Msg("Failed to send report entry too many times, giving up")
+	}
+
+	return m.Database.UpdateOutboxEntry(outboxEntry)
+}
+
+// MarkDead is for entries that cannot be retried at all, like ones
+// for a reporter that is not enabled anymore.
+func (m *Manager) MarkDead(outboxEntry *types.OutboxEntry, reason error) error {
+	outboxEntry.MarkDead(reason)
+	return m.Database.UpdateOutboxEntry(outboxEntry)
+}
+
+func (m *Manager) GetDue() ([]*types.OutboxEntry, error) {
+	return m.Database.GetDueOutboxEntries(time.Now())
+}
+
+func (m *Manager) GetUndelivered() ([]*types.OutboxEntry, error) {
+	return m.Database.GetUndeliveredOutboxEntries()
+}
+
+func (m *Manager) GetReportEntry(outboxEntry *types.OutboxEntry) (entry.ReportEntry, error) {
+	return events.UnmarshalEntry(outboxEntry.Event, outboxEntry.Payload)
+}
@@ -0,0 +1,99 @@
+package outbox
+
+import (
+	"errors"
+	databasePkg "main/pkg/database"
+	"main/pkg/events"
+	loggerPkg "main/pkg/logger"
+	"main/pkg/types"
+	"testing"
+	"time"
+
+	"github.com/guregu/null/v5"
+	"github.com/stretchr/testify/require"
+)
+
+func getTestManager(database databasePkg.Database) *Manager {
+	return NewManager(loggerPkg.GetNopLogger(), types.OutboxConfig{
+		Enabled:        null.BoolFrom(true),
+		MaxAttempts:    2,
+		InitialBackoff: types.Duration{Duration: time.Minute},
+		MaxBackoff:     types.Duration{Duration: time.Hour},
+	}, database)
+}
+
+func TestOutboxManagerAddAndSent(t *testing.T) {
+	t.Parallel()
+
+	database := &databasePkg.StubDatabase{}
+	manager := getTestManager(database)
+	require.True(t, manager.Enabled())
+
+	reportEntry := events.NotVotedEvent{
+		Chain:    &types.Chain{Name: "chain"},
+		Wallet:   &types.Wallet{Address: "wallet", Alias: "alias"},
+		Proposal: types.Proposal{ID: "proposal"},
+	}
+
+	outboxEntry, err := manager.Add("reporter", reportEntry)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), outboxEntry.ID)
+	require.Equal(t, "reporter", outboxEntry.Reporter)
+	require.Equal(t, "not_voted", outboxEntry.Event)
+	require.Equal(t, null.StringFrom("chain"), outboxEntry.Chain)
+	require.Equal(t, null.StringFrom("proposal"), outboxEntry.ProposalID)
+	require.Equal(t, null.StringFrom("alias"), outboxEntry.Wallet)
+	require.Equal(t, types.OutboxStatusPending, outboxEntry.Status)
+	require.True(t, outboxEntry.NextAttemptAt.After(time.Now()))
+
+	// not due until the initial backoff passes
+	due, err := manager.GetDue()
+	require.NoError(t, err)
+	require.Empty(t, due)
+
+	restored, err := manager.GetReportEntry(outboxEntry)
+	require.NoError(t, err)
+	require.Equal(t, reportEntry, restored)
+
+	undelivered, err := manager.GetUndelivered()
+	require.NoError(t, err)
+	require.Len(t, undelivered, 1)
+
+	require.NoError(t, manager.MarkSent(outboxEntry))
+	require.Empty(t, database.Outbox)
+}
+
+func TestOutboxManagerAddError(t *testing.T) {
+	t.Parallel()
+
+	manager := getTestManager(&databasePkg.StubDatabase{InsertOutboxError: errors.New("custom error")})
+
+	outboxEntry, err := manager.Add("reporter", events.GenericErrorEvent{Error: errors.New("error")})
+	require.Error(t, err)
+	require.Nil(t, outboxEntry)
+}
+
+func TestOutboxManagerMarkFailed(t *testing.T) {
+	t.Parallel()
+
+	database := &databasePkg.StubDatabase{}
+	manager := getTestManager(database)
+
+	outboxEntry, err := manager.Add("reporter", events.ProposalsQueryErrorEvent{
+		Chain: &types.Chain{Name: "chain"},
+		Error: &types.QueryError{QueryError: errors.New("error")},
+	})
+	require.NoError(t, err)
+	require.Equal(t, null.StringFrom("chain"), outboxEntry.Chain)
+	require.False(t, outboxEntry.ProposalID.Valid)
+
+	require.NoError(t, manager.MarkFailed(outboxEntry, errors.New("send error")))
+	require.False(t, database.Outbox[0].IsDead())
+	require.Equal(t, 1, database.Outbox[0].Attempts)
+
+	require.NoError(t, manager.MarkFailed(outboxEntry, errors.New("send error")))
+	require.True(t, database.Outbox[0].IsDead())
+
+	require.NoError(t, manager.MarkDead(outboxEntry, errors.New("reason")))
+	require.Equal(t, "reason", database.Outbox[0].LastError.String)
+}
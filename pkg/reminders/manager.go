@@ -0,0 +1,125 @@
+package reminders
+
+import (
+	databasePkg "main/pkg/database"
+	"main/pkg/events"
+	"main/pkg/report/entry"
+	"main/pkg/types"
+	"main/pkg/utils"
+	"strconv"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// ReminderInitial is stored when the first "wallet hasn't voted" alert is sent,
+// so it's sent only once, when the voting starts or the proposal is first seen.
+const ReminderInitial = "initial"
+
+type Manager struct {
+	Database  databasePkg.Database
+	Logger    zerolog.Logger
+	Reminders []time.Duration
+}
+
+func NewManager(
+	logger *zerolog.Logger,
+	database databasePkg.Database,
+	reminders []types.Duration,
+) *Manager {
+	return &Manager{
+		Database: database,
+		Logger:   logger.With().Str("component", "reminders_manager").Logger(),
+		Reminders: utils.Map(reminders, func(d types.Duration) time.Duration {
+			return d.Duration
+		}),
+	}
+}
+
+// Enabled returns whether the reminders schedule is set. If it's not set,
+// "wallet hasn't voted" alerts are sent on every run.
+func (m *Manager) Enabled() bool {
+	return len(m.Reminders) > 0
+}
+
+// GetDueReminders returns the reminders that should have been sent by now,
+// including the initial one. Reminders are stored as the number of seconds before
+// the voting ends, so the stored values do not depend on how durations are formatted.
+func (m *Manager) GetDueReminders(timeLeft time.Duration) []string {
+	due := []string{ReminderInitial}
+
+	for _, reminder := range m.Reminders {
+		if timeLeft <= reminder {
+			due = append(due, strconv.FormatInt(int64(reminder.Seconds()), 10))
+		}
+	}
+
+	return due
+}
+
+// GetNotSentReminders returns the reminders that are due by now but were not sent yet.
+func (m *Manager) GetNotSentReminders(event events.NotVotedEvent) ([]string, error) {
+	sent, err := m.Database.GetSentReminders(event.Chain, event.Proposal, event.Wallet)
+	if err != nil {
+		return []string{}, err
+	}
+
+	timeLeft := event.Proposal.EndTime.Sub(event.RenderTime)
+	return utils.Filter(m.GetDueReminders(timeLeft), func(reminder string) bool {
+		return !utils.Contains(sent, reminder)
+	}), nil
+}
+
+// ShouldSendEntry checks whether a report entry should be sent according to the reminders
+// schedule. It does not store anything, the reminders are stored with MarkEntrySent
+// once the entry is actually sent.
+func (m *Manager) ShouldSendEntry(reportEntry entry.ReportEntry) (bool, error) {
+	if !m.Enabled() {
+		return true, nil
+	}
+
+	event, ok := reportEntry.(events.NotVotedEvent)
+	if !ok {
+		return true, nil
+	}
+
+	notSent, err := m.GetNotSentReminders(event)
+	if err != nil {
+		return false, err
+	}
+
+	if len(notSent) == 0 {
+		m.Logger.Trace().
+			Str("chain", event.Chain.Name).
+			Str("proposal", event.Proposal.ID).
+			Str("wallet", event.Wallet.Address).
+			Msg("All due reminders were already sent, not sending.")
+		return false, nil
+	}
+
+	return true, nil
+}
+
+// MarkEntrySent stores all the reminders that are due by now as sent, so if the
+// proposal appears only a few hours before the voting ends, only one alert is sent.
+func (m *Manager) MarkEntrySent(reportEntry entry.ReportEntry) error {
+	if !m.Enabled() {
+		return nil
+	}
+
+	event, ok := reportEntry.(events.NotVotedEvent)
+	if !ok {
+		return nil
+	}
+
+	notSent, err := m.GetNotSentReminders(event)
+	if err != nil {
+		return err
+	}
+
+	if len(notSent) == 0 {
+		return nil
+	}
+
+	return m.Database.InsertSentReminders(event.Chain, event.Proposal, event.Wallet, notSent)
+}
@@ -0,0 +1,216 @@
+package reminders
+
+import (
+	"errors"
+	databasePkg "main/pkg/database"
+	"main/pkg/events"
+	"main/pkg/logger"
+	"main/pkg/types"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRemindersManagerDisabled(t *testing.T) {
+	t.Parallel()
+
+	manager := NewManager(logger.GetNopLogger(), &databasePkg.StubDatabase{}, []types.Duration{})
+	assert.False(t, manager.Enabled())
+
+	shouldSend, err := manager.ShouldSendEntry(events.NotVotedEvent{})
+	require.NoError(t, err)
+	assert.True(t, shouldSend)
+}
+
+func TestRemindersManagerNotNotVotedEvent(t *testing.T) {
+	t.Parallel()
+
+	manager := NewManager(logger.GetNopLogger(), &databasePkg.StubDatabase{}, []types.Duration{
+		{Duration: time.Hour},
+	})
+	assert.True(t, manager.Enabled())
+
+	shouldSend, err := manager.ShouldSendEntry(events.VotedEvent{})
+	require.NoError(t, err)
+	assert.True(t, shouldSend)
+}
+
+func TestRemindersManagerGetDueReminders(t *testing.T) {
+	t.Parallel()
+
+	manager := NewManager(logger.GetNopLogger(), &databasePkg.StubDatabase{}, []types.Duration{
+		{Duration: 72 * time.Hour},
+		{Duration: 24 * time.Hour},
+		{Duration: time.Hour},
+	})
+
+	assert.Equal(t, []string{"initial"}, manager.GetDueReminders(100*time.Hour))
+	assert.Equal(t, []string{"initial", "259200", "86400"}, manager.GetDueReminders(10*time.Hour))
+	assert.Equal(t, []string{"initial", "259200", "86400", "3600"}, manager.GetDueReminders(time.Minute))
+}
+
+func TestRemindersManagerSchedule(t *testing.T) {
+	t.Parallel()
+
+	db := &databasePkg.StubDatabase{}
+	manager := NewManager(logger.GetNopLogger(), db, []types.Duration{
+		{Duration: 24 * time.Hour},
+		{Duration: time.Hour},
+	})
+
+	now := time.Now()
+	event := events.NotVotedEvent{
+		Chain:      &types.Chain{Name: "chain"},
+		Wallet:     &types.Wallet{Address: "wallet"},
+		Proposal:   types.Proposal{ID: "proposal", EndTime: now.Add(48 * time.Hour)},
+		RenderTime: now,
+	}
+
+	// initial reminder
+	shouldSend, err := manager.ShouldSendEntry(event)
+	require.NoError(t, err)
+	assert.True(t, shouldSend)
+	require.NoError(t, manager.MarkEntrySent(event))
+
+	// nothing is due
+	shouldSend, err = manager.ShouldSendEntry(event)
+	require.NoError(t, err)
+	assert.False(t, shouldSend)
+
+	// 24h reminder
+	event.RenderTime = now.Add(30 * time.Hour)
+	shouldSend, err = manager.ShouldSendEntry(event)
+	require.NoError(t, err)
+	assert.True(t, shouldSend)
+	require.NoError(t, manager.MarkEntrySent(event))
+
+	shouldSend, err = manager.ShouldSendEntry(event)
+	require.NoError(t, err)
+	assert.False(t, shouldSend)
+
+	// 1h reminder
+	event.RenderTime = now.Add(47*time.Hour + 30*time.Minute)
+	shouldSend, err = manager.ShouldSendEntry(event)
+	require.NoError(t, err)
+	assert.True(t, shouldSend)
+	require.NoError(t, manager.MarkEntrySent(event))
+
+	shouldSend, err = manager.ShouldSendEntry(event)
+	require.NoError(t, err)
+	assert.False(t, shouldSend)
+}
+
+func TestRemindersManagerFirstSeenLate(t *testing.T) {
+	t.Parallel()
+
+	db := &databasePkg.StubDatabase{}
+	manager := NewManager(logger.GetNopLogger(), db, []types.Duration{
+		{Duration: 72 * time.Hour},
+		{Duration: 24 * time.Hour},
+		{Duration: time.Hour},
+	})
+
+	now := time.Now()
+	event := events.NotVotedEvent{
+		Chain:      &types.Chain{Name: "chain"},
+		Wallet:     &types.Wallet{Address: "wallet"},
+		Proposal:   types.Proposal{ID: "proposal", EndTime: now.Add(10 * time.Hour)},
+		RenderTime: now,
+	}
+
+	shouldSend, err := manager.ShouldSendEntry(event)
+	require.NoError(t, err)
+	assert.True(t, shouldSend)
+	require.NoError(t, manager.MarkEntrySent(event))
+	assert.ElementsMatch(
+		t,
+		[]string{"initial", "259200", "86400"},
+		db.Reminders["chain"]["proposal"]["wallet"],
+	)
+
+	shouldSend, err = manager.ShouldSendEntry(event)
+	require.NoError(t, err)
+	assert.False(t, shouldSend)
+}
+
+func TestRemindersManagerGetError(t *testing.T) {
+	t.Parallel()
+
+	db := &databasePkg.StubDatabase{GetRemindersError: errors.New("custom error")}
+	manager := NewManager(logger.GetNopLogger(), db, []types.Duration{{Duration: time.Hour}})
+
+	shouldSend, err := manager.ShouldSendEntry(events.NotVotedEvent{
+		Chain:    &types.Chain{Name: "chain"},
+		Wallet:   &types.Wallet{Address: "wallet"},
+		Proposal: types.Proposal{ID: "proposal"},
+	})
+	require.Error(t, err)
+	assert.False(t, shouldSend)
+}
+
+func TestRemindersManagerInsertError(t *testing.T) {
+	t.Parallel()
+
+	db := &databasePkg.StubDatabase{InsertRemindersError: errors.New("custom error")}
+	manager := NewManager(logger.GetNopLogger(), db, []types.Duration{{Duration: time.Hour}})
+
+	err := manager.MarkEntrySent(events.NotVotedEvent{
+		Chain:    &types.Chain{Name: "chain"},
+		Wallet:   &types.Wallet{Address: "wallet"},
+		Proposal: types.Proposal{ID: "proposal"},
+	})
+	require.Error(t, err)
+}
+
+func TestRemindersManagerMarkSentGetError(t *testing.T) {
+	t.Parallel()
+
+	db := &databasePkg.StubDatabase{GetRemindersError: errors.New("custom error")}
+	manager := NewManager(logger.GetNopLogger(), db, []types.Duration{{Duration: time.Hour}})
+
+	err := manager.MarkEntrySent(events.NotVotedEvent{
+		Chain:    &types.Chain{Name: "chain"},
+		Wallet:   &types.Wallet{Address: "wallet"},
+		Proposal: types.Proposal{ID: "proposal"},
+	})
+	require.Error(t, err)
+}
+
+func TestRemindersManagerMarkSentNotNotVotedEvent(t *testing.T) {
+	t.Parallel()
+
+	db := &databasePkg.StubDatabase{}
+	manager := NewManager(logger.GetNopLogger(), db, []types.Duration{{Duration: time.Hour}})
+
+	require.NoError(t, manager.MarkEntrySent(events.VotedEvent{}))
+	assert.Empty(t, db.Reminders)
+
+	disabledManager := NewManager(logger.GetNopLogger(), db, []types.Duration{})
+	require.NoError(t, disabledManager.MarkEntrySent(events.NotVotedEvent{}))
+	assert.Empty(t, db.Reminders)
+}
+
+func TestRemindersManagerCheckDoesNotStore(t *testing.T) {
+	t.Parallel()
+
+	db := &databasePkg.StubDatabase{}
+	manager := NewManager(logger.GetNopLogger(), db, []types.Duration{{Duration: time.Hour}})
+
+	now := time.Now()
+	event := events.NotVotedEvent{
+		Chain:      &types.Chain{Name: "chain"},
+		Wallet:     &types.Wallet{Address: "wallet"},
+		Proposal:   types.Proposal{ID: "proposal", EndTime: now.Add(10 * time.Hour)},
+		RenderTime: now,
+	}
+
+	for i := 0; i < 2; i++ {
+		shouldSend, err := manager.ShouldSendEntry(event)
+		require.NoError(t, err)
+		assert.True(t, shouldSend)
+	}
+
+	assert.Empty(t, db.Reminders)
+}
@@ -2,8 +2,19 @@ package report
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"main/pkg/events"
+	"main/pkg/metrics"
 	mutes "main/pkg/mutes"
+	"main/pkg/outbox"
+	"main/pkg/reminders"
+	"main/pkg/report/entry"
 	reportersPkg "main/pkg/reporters"
+	"main/pkg/routes"
+	statePkg "main/pkg/state"
+	"main/pkg/types"
+	"sync"
 
 	"go.opentelemetry.io/otel/trace"
 
@@ -11,23 +22,38 @@ import (
 )
 
 type Dispatcher struct {
-	Logger       zerolog.Logger
-	MutesManager *mutes.Manager
-	Reporters    []reportersPkg.Reporter
-	Tracer       trace.Tracer
+	Logger           zerolog.Logger
+	MutesManager     *mutes.Manager
+	RemindersManager *reminders.Manager
+	RoutesManager    *routes.Manager
+	MetricsManager   *metrics.Manager
+	OutboxManager    *outbox.Manager
+	Reporters        []reportersPkg.Reporter
+	Tracer           trace.Tracer
+
+	// RetryMutex prevents retries from overlapping if they take longer than the retry interval.
+	RetryMutex sync.Mutex
 }
 
 func NewDispatcher(
 	logger *zerolog.Logger,
 	mutesManager *mutes.Manager,
+	remindersManager *reminders.Manager,
+	routesManager *routes.Manager,
+	metricsManager *metrics.Manager,
+	outboxManager *outbox.Manager,
 	reporters []reportersPkg.Reporter,
 	tracer trace.Tracer,
 ) *Dispatcher {
 	return &Dispatcher{
-		Logger:       logger.With().Str("component", "report_dispatcher").Logger(),
-		MutesManager: mutesManager,
-		Reporters:    reporters,
-		Tracer:       tracer,
+		Logger:           logger.With().Str("component", "report_dispatcher").Logger(),
+		MutesManager:     mutesManager,
+		RemindersManager: remindersManager,
+		RoutesManager:    routesManager,
+		MetricsManager:   metricsManager,
+		OutboxManager:    outboxManager,
+		Reporters:        reporters,
+		Tracer:           tracer,
 	}
 }
 
@@ -58,6 +84,21 @@ func (d *Dispatcher) SendReport(report reportersPkg.Report, ctx context.Context)
 
 	d.Logger.Debug().Int("len", len(report.Entries)).Msg("Got non-empty report")
 
+	entries, notDueEntries := d.FilterScheduledEntries(report.Entries)
+	if len(entries) == 0 && len(notDueEntries) == 0 {
+		d.Logger.Debug().Msg("No entries are due to be sent, not sending.")
+		return
+	}
+
+	// each entry is checked for mutes for every reporter and destination,
+	// so all the mutes are only loaded once for the whole report
+	d.MutesManager.CacheMutes()
+	defer d.MutesManager.StopCachingMutes()
+
+	// the reminders are only marked as sent once all the reporters got the entries,
+	// so whether they're used up does not depend on the order of the reporters
+	sentEntries := make([]entry.ReportEntry, 0, len(entries))
+
 	for _, reporter := range d.Reporters {
 		if !reporter.Enabled() {
 			d.Logger.Debug().
@@ -70,25 +111,366 @@ func (d *Dispatcher) SendReport(report reportersPkg.Report, ctx context.Context)
 			Str("name", reporter.Name()).
 			Msg("Sending report...")
 
-		for _, reportEntry := range report.Entries {
-			if isMuted, muteErr := d.MutesManager.IsEntryMuted(reportEntry); muteErr != nil {
-				d.Logger.Warn().
-					Err(muteErr).
-					Msg("Error checking whether the proposal was muted.")
-			} else if isMuted {
-				d.Logger.Debug().
-					Str("entry", reportEntry.Name()).
-					Msg("Notifications are muted, not sending.")
-				continue
+		reporterEntries := d.GetReporterEntries(reporter, entries)
+
+		if groupingReporter, ok := reporter.(reportersPkg.GroupingReporter); ok && groupingReporter.GroupsAlerts() {
+			reporterEntries = events.GroupEntries(reporterEntries)
+		}
+
+		for _, reportEntry := range reporterEntries {
+			if err := d.SendReportEntry(reporter, reportEntry, childCtx); err == nil {
+				sentEntries = append(sentEntries, reportEntry)
+			}
+		}
+
+		// the alerts sent before are still edited with the newer state, like the time left
+		// to vote, even if the reminder is not due yet
+		if editingReporter, ok := reporter.(reportersPkg.AlertEditingReporter); ok && editingReporter.EditsAlerts() {
+			for _, reportEntry := range d.GetReporterEntries(reporter, notDueEntries) {
+				d.EditSentAlert(editingReporter, reportEntry, childCtx)
 			}
 
-			if err := reporter.SendReportEntry(reportEntry, childCtx); err != nil {
-				d.Logger.Error().
-					Err(err).
-					Str("name", reporter.Name()).
-					Str("entry", reportEntry.Name()).
-					Msg("Failed to send report entry")
+			// done for all the entries, as the alerts are not edited anymore
+			// even if the entry was muted or not routed to this reporter
+			for _, reportEntry := range report.Entries {
+				if _, isFinished := reportEntry.(events.FinishedVotingEvent); isFinished {
+					editingReporter.DeleteAlertMessages(reportEntry)
+				}
 			}
 		}
 	}
+
+	d.MarkRemindersSent(sentEntries...)
+}
+
+// GetReporterEntries returns the entries that are routed to the reporter and are not muted.
+func (d *Dispatcher) GetReporterEntries(
+	reporter reportersPkg.Reporter,
+	entries []entry.ReportEntry,
+) []entry.ReportEntry {
+	reporterEntries := make([]entry.ReportEntry, 0, len(entries))
+
+	for _, reportEntry := range entries {
+		if !d.RoutesManager.ShouldSend(reporter.Name(), reportEntry) && !ResolvesAlerts(reporter, reportEntry) {
+			d.Logger.Debug().
+				Str("name", reporter.Name()).
+				Str("entry", reportEntry.Name()).
+				Msg("Entry is not routed to this reporter, not sending.")
+			continue
+		}
+
+		if d.IsEntryMuted(reportEntry) {
+			continue
+		}
+
+		reporterEntries = append(reporterEntries, reportEntry)
+	}
+
+	return reporterEntries
+}
+
+// EditSentAlert edits the alert sent before for an entry which reminder is not due yet.
+// It's not stored in the outbox, as the alert is edited again on the next run anyway,
+// and it does not use up the reminder.
+func (d *Dispatcher) EditSentAlert(
+	reporter reportersPkg.AlertEditingReporter,
+	reportEntry entry.ReportEntry,
+	ctx context.Context,
+) {
+	if err := reporter.EditSentAlert(reportEntry, ctx); err != nil {
+		d.Logger.Error().
+			Err(err).
+			Str("name", reporter.Name()).
+			Str("entry", reportEntry.Name()).
+			Msg("Failed to edit sent alert")
+	}
+}
+
+// ResolvesAlerts returns whether the entry resolves the alerts the reporter opened before,
+// so it should be sent even if it's not routed to this reporter.
+func ResolvesAlerts(reporter reportersPkg.Reporter, reportEntry entry.ReportEntry) bool {
+	resolvingReporter, ok := reporter.(reportersPkg.ResolvingReporter)
+	return ok && resolvingReporter.ResolvesAlerts(reportEntry)
+}
+
+func (d *Dispatcher) IsEntryMuted(reportEntry entry.ReportEntry) bool {
+	isMuted, err := d.MutesManager.IsEntryMuted(reportEntry)
+	if err != nil {
+		d.Logger.Warn().
+			Err(err).
+			Msg("Error checking whether the proposal was muted.")
+		return false
+	}
+
+	if isMuted {
+		d.Logger.Debug().
+			Str("entry", reportEntry.Name()).
+			Msg("Notifications are muted, not sending.")
+	}
+
+	return isMuted
+}
+
+// SendReportEntry sends the entry, storing it in the outbox first if it's enabled,
+// so it's retried later if sending fails.
+func (d *Dispatcher) SendReportEntry(
+	reporter reportersPkg.Reporter,
+	reportEntry entry.ReportEntry,
+	ctx context.Context,
+) error {
+	var outboxEntry *types.OutboxEntry
+
+	if d.OutboxManager.Enabled() {
+		var outboxErr error
+		if outboxEntry, outboxErr = d.OutboxManager.Add(reporter.Name(), reportEntry); outboxErr != nil {
+			d.Logger.Warn().
+				Err(outboxErr).
+				Str("name", reporter.Name()).
+				Str("entry", reportEntry.Name()).
+				Msg("Error storing report entry in outbox, it won't be retried if sending fails.")
+		}
+	}
+
+	err := d.sendToReporter(reporter, reportEntry, []string{}, ctx)
+
+	if outboxEntry != nil {
+		d.UpdateOutboxEntry(outboxEntry, err)
+	}
+
+	return err
+}
+
+// RetryUndelivered sends the entries from the outbox that failed to be sent before
+// and are due to be retried.
+func (d *Dispatcher) RetryUndelivered(ctx context.Context) {
+	childCtx, span := d.Tracer.Start(ctx, "Retrying undelivered entries")
+	defer span.End()
+
+	if !d.RetryMutex.TryLock() {
+		d.Logger.Debug().Msg("Previous retry is still in progress, not retrying.")
+		return
+	}
+	defer d.RetryMutex.Unlock()
+
+	outboxEntries, err := d.OutboxManager.GetDue()
+	if err != nil {
+		d.Logger.Error().Err(err).Msg("Error getting undelivered entries")
+		return
+	}
+
+	d.MutesManager.CacheMutes()
+	defer d.MutesManager.StopCachingMutes()
+
+	for _, outboxEntry := range outboxEntries {
+		d.RetryOutboxEntry(outboxEntry, childCtx)
+	}
+}
+
+func (d *Dispatcher) RetryOutboxEntry(outboxEntry *types.OutboxEntry, ctx context.Context) {
+	var reporter reportersPkg.Reporter
+	for _, otherReporter := range d.Reporters {
+		if otherReporter.Name() == outboxEntry.Reporter && otherReporter.Enabled() {
+			reporter = otherReporter
+		}
+	}
+
+	if reporter == nil {
+		d.MarkOutboxEntryDead(outboxEntry, fmt.Errorf("reporter %s is not enabled", outboxEntry.Reporter))
+		return
+	}
+
+	reportEntry, err := d.OutboxManager.GetReportEntry(outboxEntry)
+	if err != nil {
+		d.MarkOutboxEntryDead(outboxEntry, fmt.Errorf("error deserializing report entry: %s", err))
+		return
+	}
+
+	// the entries in a group can be muted separately, so only the ones
+	// that are not muted yet are retried
+	var isMuted bool
+	if grouped, ok := reportEntry.(events.GroupedEvent); ok {
+		grouped = grouped.Filter(func(groupedEntry entry.ReportEntry) bool {
+			return !d.IsEntryMuted(groupedEntry)
+		})
+		reportEntry = grouped
+		isMuted = len(grouped.Entries) == 0
+	} else {
+		isMuted = d.IsEntryMuted(reportEntry)
+	}
+
+	if isMuted {
+		d.Logger.Debug().
+			Str("entry", reportEntry.Name()).
+			Msg("Notifications were muted since, not retrying.")
+		d.UpdateOutboxEntry(outboxEntry, nil)
+		return
+	}
+
+	d.Logger.Debug().
+		Int64("id", outboxEntry.ID).
+		Str("name", reporter.Name()).
+		Str("entry", reportEntry.Name()).
+		Int("attempts", outboxEntry.Attempts).
+		Strs("destinations", outboxEntry.GetDestinations()).
+		Msg("Retrying sending report entry")
+
+	sendErr := d.sendToReporter(reporter, reportEntry, outboxEntry.GetDestinations(), ctx)
+	d.UpdateOutboxEntry(outboxEntry, sendErr)
+
+	// the reminder was not used up when the entry failed to be sent before
+	if sendErr == nil {
+		d.MarkRemindersSent(reportEntry)
+	}
+}
+
+// UpdateOutboxEntry removes the entry from the outbox once it's sent,
+// or schedules the next attempt otherwise. If the entry was only sent to some
+// of the reporter's destinations, only the remaining ones are retried.
+func (d *Dispatcher) UpdateOutboxEntry(outboxEntry *types.OutboxEntry, sendErr error) {
+	var err error
+	if sendErr == nil {
+		err = d.OutboxManager.MarkSent(outboxEntry)
+	} else {
+		var destinationsErr *reportersPkg.DestinationsError
+		if errors.As(sendErr, &destinationsErr) {
+			outboxEntry.SetDestinations(destinationsErr.Destinations())
+		}
+
+		err = d.OutboxManager.MarkFailed(outboxEntry, sendErr)
+	}
+
+	if err != nil {
+		d.Logger.Warn().
+			Err(err).
+			Int64("id", outboxEntry.ID).
+			Msg("Error updating outbox entry")
+	}
+}
+
+func (d *Dispatcher) MarkOutboxEntryDead(outboxEntry *types.OutboxEntry, reason error) {
+	d.Logger.Warn().
+		Err(reason).
+		Int64("id", outboxEntry.ID).
+		Str("name", outboxEntry.Reporter).
+		Str("entry", outboxEntry.Event).
+		Msg("Cannot retry sending report entry, giving up")
+
+	if err := d.OutboxManager.MarkDead(outboxEntry, reason); err != nil {
+		d.Logger.Warn().
+			Err(err).
+			Int64("id", outboxEntry.ID).
+			Msg("Error updating outbox entry")
+	}
+}
+
+// sendToReporter sends the entry to all the reporter's destinations, or only to the given ones
+// if it's retried after failing to be sent to some of them.
+func (d *Dispatcher) sendToReporter(
+	reporter reportersPkg.Reporter,
+	reportEntry entry.ReportEntry,
+	destinations []string,
+	ctx context.Context,
+) error {
+	var err error
+	if multiDestinationReporter, ok := reporter.(reportersPkg.MultiDestinationReporter); ok && len(destinations) > 0 {
+		err = multiDestinationReporter.SendReportEntryToDestinations(reportEntry, destinations, ctx)
+	} else {
+		err = reporter.SendReportEntry(reportEntry, ctx)
+	}
+	if err != nil {
+		d.Logger.Error().
+			Err(err).
+			Str("name", reporter.Name()).
+			Str("entry", reportEntry.Name()).
+			Msg("Failed to send report entry")
+	}
+
+	d.MetricsManager.LogReporterEntrySent(reporter.Name(), reportEntry.Name(), err == nil)
+
+	return err
+}
+
+// MarkRemindersSent stores the reminders for the entries as sent. It's only called for
+// the entries that at least one reporter actually sent, so reminders for muted entries
+// or entries that failed to be sent everywhere are not used up. An entry sent by several
+// reporters is only stored once, as the reminders already stored are skipped.
+func (d *Dispatcher) MarkRemindersSent(reportEntries ...entry.ReportEntry) {
+	sentEntries := make([]entry.ReportEntry, 0, len(reportEntries))
+	for _, reportEntry := range reportEntries {
+		if grouped, ok := reportEntry.(events.GroupedEvent); ok {
+			sentEntries = append(sentEntries, grouped.Entries...)
+		} else {
+			sentEntries = append(sentEntries, reportEntry)
+		}
+	}
+
+	for _, sentEntry := range sentEntries {
+		if err := d.RemindersManager.MarkEntrySent(sentEntry); err != nil {
+			d.Logger.Warn().
+				Err(err).
+				Str("entry", sentEntry.Name()).
+				Msg("Error storing sent reminder")
+		}
+	}
+}
+
+// HasDashboards returns whether any of the enabled reporters keeps a dashboard,
+// so the state is only fetched if it's needed.
+func (d *Dispatcher) HasDashboards() bool {
+	for _, reporter := range d.Reporters {
+		if dashboardReporter, ok := reporter.(reportersPkg.DashboardReporter); ok &&
+			reporter.Enabled() &&
+			dashboardReporter.DashboardEnabled() {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (d *Dispatcher) UpdateDashboards(state statePkg.RenderedState, ctx context.Context) {
+	childCtx, span := d.Tracer.Start(ctx, "Updating dashboards")
+	defer span.End()
+
+	for _, reporter := range d.Reporters {
+		dashboardReporter, ok := reporter.(reportersPkg.DashboardReporter)
+		if !ok || !reporter.Enabled() || !dashboardReporter.DashboardEnabled() {
+			continue
+		}
+
+		if err := dashboardReporter.UpdateDashboard(state, childCtx); err != nil {
+			d.Logger.Error().
+				Err(err).
+				Str("name", reporter.Name()).
+				Msg("Failed to update dashboard")
+		}
+	}
+}
+
+// FilterScheduledEntries splits the entries into the ones that are due to be sent,
+// and the ones which reminders are not due yet.
+func (d *Dispatcher) FilterScheduledEntries(
+	entries []entry.ReportEntry,
+) ([]entry.ReportEntry, []entry.ReportEntry) {
+	filtered := make([]entry.ReportEntry, 0, len(entries))
+	notDue := make([]entry.ReportEntry, 0)
+
+	for _, reportEntry := range entries {
+		if shouldSend, err := d.RemindersManager.ShouldSendEntry(reportEntry); err != nil {
+			d.Logger.Warn().
+				Err(err).
+				Str("entry", reportEntry.Name()).
+				Msg("Error checking whether the reminder was sent, sending anyway.")
+		} else if !shouldSend {
+			d.Logger.Debug().
+				Str("entry", reportEntry.Name()).
+				Msg("Reminder is not due yet, not sending.")
+			notDue = append(notDue, reportEntry)
+			continue
+		}
+
+		filtered = append(filtered, reportEntry)
+	}
+
+	return filtered, notDue
 }
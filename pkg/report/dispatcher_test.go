@@ -6,14 +6,21 @@ import (
 	databasePkg "main/pkg/database"
 	"main/pkg/events"
 	"main/pkg/logger"
+	"main/pkg/metrics"
 	mutes "main/pkg/mutes"
+	"main/pkg/outbox"
+	"main/pkg/reminders"
 	"main/pkg/report/entry"
 	reportersPkg "main/pkg/reporters"
+	"main/pkg/routes"
+	statePkg "main/pkg/state"
 	"main/pkg/tracing"
 	"main/pkg/types"
 	"testing"
 	"time"
 
+	"github.com/guregu/null/v5"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -22,9 +29,18 @@ func TestReportDispatcherInitFail(t *testing.T) {
 
 	db := &databasePkg.StubDatabase{}
 	mutesManager := mutes.NewMutesManager(logger.GetNopLogger(), db)
-	dispatcher := NewDispatcher(logger.GetNopLogger(), mutesManager, []reportersPkg.Reporter{
-		&reportersPkg.TestReporter{WithInitFail: true},
-	}, tracing.InitNoopTracer())
+	remindersManager := reminders.NewManager(logger.GetNopLogger(), db, []types.Duration{})
+	metricsManager := metrics.NewManager(logger.GetNopLogger(), types.MetricsConfig{})
+	dispatcher := NewDispatcher(
+		logger.GetNopLogger(),
+		mutesManager,
+		remindersManager,
+		routes.NewManager(logger.GetNopLogger(), types.Routes{}),
+		metricsManager,
+		outbox.NewManager(logger.GetNopLogger(), types.OutboxConfig{}, db),
+		[]reportersPkg.Reporter{&reportersPkg.TestReporter{WithInitFail: true}},
+		tracing.InitNoopTracer(),
+	)
 
 	err := dispatcher.Init()
 	require.Error(t, err)
@@ -35,9 +51,18 @@ func TestReportDispatcherInitOk(t *testing.T) {
 
 	db := &databasePkg.StubDatabase{}
 	mutesManager := mutes.NewMutesManager(logger.GetNopLogger(), db)
-	dispatcher := NewDispatcher(logger.GetNopLogger(), mutesManager, []reportersPkg.Reporter{
-		&reportersPkg.TestReporter{},
-	}, tracing.InitNoopTracer())
+	remindersManager := reminders.NewManager(logger.GetNopLogger(), db, []types.Duration{})
+	metricsManager := metrics.NewManager(logger.GetNopLogger(), types.MetricsConfig{})
+	dispatcher := NewDispatcher(
+		logger.GetNopLogger(),
+		mutesManager,
+		remindersManager,
+		routes.NewManager(logger.GetNopLogger(), types.Routes{}),
+		metricsManager,
+		outbox.NewManager(logger.GetNopLogger(), types.OutboxConfig{}, db),
+		[]reportersPkg.Reporter{&reportersPkg.TestReporter{}},
+		tracing.InitNoopTracer(),
+	)
 
 	err := dispatcher.Init()
 	require.NoError(t, err)
@@ -48,9 +73,18 @@ func TestReportDispatcherSendEmptyReport(t *testing.T) {
 
 	db := &databasePkg.StubDatabase{}
 	mutesManager := mutes.NewMutesManager(logger.GetNopLogger(), db)
-	dispatcher := NewDispatcher(logger.GetNopLogger(), mutesManager, []reportersPkg.Reporter{
-		&reportersPkg.TestReporter{},
-	}, tracing.InitNoopTracer())
+	remindersManager := reminders.NewManager(logger.GetNopLogger(), db, []types.Duration{})
+	metricsManager := metrics.NewManager(logger.GetNopLogger(), types.MetricsConfig{})
+	dispatcher := NewDispatcher(
+		logger.GetNopLogger(),
+		mutesManager,
+		remindersManager,
+		routes.NewManager(logger.GetNopLogger(), types.Routes{}),
+		metricsManager,
+		outbox.NewManager(logger.GetNopLogger(), types.OutboxConfig{}, db),
+		[]reportersPkg.Reporter{&reportersPkg.TestReporter{}},
+		tracing.InitNoopTracer(),
+	)
 
 	err := dispatcher.Init()
 	require.NoError(t, err)
@@ -63,9 +97,18 @@ func TestReportDispatcherSendReportDisabledReporter(t *testing.T) {
 
 	db := &databasePkg.StubDatabase{}
 	mutesManager := mutes.NewMutesManager(logger.GetNopLogger(), db)
-	dispatcher := NewDispatcher(logger.GetNopLogger(), mutesManager, []reportersPkg.Reporter{
-		&reportersPkg.TestReporter{WithDisabled: true},
-	}, tracing.InitNoopTracer())
+	remindersManager := reminders.NewManager(logger.GetNopLogger(), db, []types.Duration{})
+	metricsManager := metrics.NewManager(logger.GetNopLogger(), types.MetricsConfig{})
+	dispatcher := NewDispatcher(
+		logger.GetNopLogger(),
+		mutesManager,
+		remindersManager,
+		routes.NewManager(logger.GetNopLogger(), types.Routes{}),
+		metricsManager,
+		outbox.NewManager(logger.GetNopLogger(), types.OutboxConfig{}, db),
+		[]reportersPkg.Reporter{&reportersPkg.TestReporter{WithDisabled: true}},
+		tracing.InitNoopTracer(),
+	)
 
 	err := dispatcher.Init()
 	require.NoError(t, err)
@@ -80,9 +123,18 @@ func TestReportDispatcherSendReportMuted(t *testing.T) {
 
 	db := &databasePkg.StubDatabase{}
 	mutesManager := mutes.NewMutesManager(logger.GetNopLogger(), db)
-	dispatcher := NewDispatcher(logger.GetNopLogger(), mutesManager, []reportersPkg.Reporter{
-		&reportersPkg.TestReporter{},
-	}, tracing.InitNoopTracer())
+	remindersManager := reminders.NewManager(logger.GetNopLogger(), db, []types.Duration{})
+	metricsManager := metrics.NewManager(logger.GetNopLogger(), types.MetricsConfig{})
+	dispatcher := NewDispatcher(
+		logger.GetNopLogger(),
+		mutesManager,
+		remindersManager,
+		routes.NewManager(logger.GetNopLogger(), types.Routes{}),
+		metricsManager,
+		outbox.NewManager(logger.GetNopLogger(), types.OutboxConfig{}, db),
+		[]reportersPkg.Reporter{&reportersPkg.TestReporter{}},
+		tracing.InitNoopTracer(),
+	)
 
 	err := dispatcher.Init()
 	require.NoError(t, err)
@@ -103,9 +155,18 @@ func TestReportDispatcherSendReportErrorSending(t *testing.T) {
 
 	db := &databasePkg.StubDatabase{}
 	mutesManager := mutes.NewMutesManager(logger.GetNopLogger(), db)
-	dispatcher := NewDispatcher(logger.GetNopLogger(), mutesManager, []reportersPkg.Reporter{
-		&reportersPkg.TestReporter{WithErrorSending: true},
-	}, tracing.InitNoopTracer())
+	remindersManager := reminders.NewManager(logger.GetNopLogger(), db, []types.Duration{})
+	metricsManager := metrics.NewManager(logger.GetNopLogger(), types.MetricsConfig{})
+	dispatcher := NewDispatcher(
+		logger.GetNopLogger(),
+		mutesManager,
+		remindersManager,
+		routes.NewManager(logger.GetNopLogger(), types.Routes{}),
+		metricsManager,
+		outbox.NewManager(logger.GetNopLogger(), types.OutboxConfig{}, db),
+		[]reportersPkg.Reporter{&reportersPkg.TestReporter{WithErrorSending: true}},
+		tracing.InitNoopTracer(),
+	)
 
 	err := dispatcher.Init()
 	require.NoError(t, err)
@@ -120,9 +181,18 @@ func TestReportDispatcherSendReportErrorGettingMutes(t *testing.T) {
 
 	db := &databasePkg.StubDatabase{IsMutedError: errors.New("mutes error")}
 	mutesManager := mutes.NewMutesManager(logger.GetNopLogger(), db)
-	dispatcher := NewDispatcher(logger.GetNopLogger(), mutesManager, []reportersPkg.Reporter{
-		&reportersPkg.TestReporter{},
-	}, tracing.InitNoopTracer())
+	remindersManager := reminders.NewManager(logger.GetNopLogger(), db, []types.Duration{})
+	metricsManager := metrics.NewManager(logger.GetNopLogger(), types.MetricsConfig{})
+	dispatcher := NewDispatcher(
+		logger.GetNopLogger(),
+		mutesManager,
+		remindersManager,
+		routes.NewManager(logger.GetNopLogger(), types.Routes{}),
+		metricsManager,
+		outbox.NewManager(logger.GetNopLogger(), types.OutboxConfig{}, db),
+		[]reportersPkg.Reporter{&reportersPkg.TestReporter{}},
+		tracing.InitNoopTracer(),
+	)
 
 	err := dispatcher.Init()
 	require.NoError(t, err)
@@ -140,9 +210,18 @@ func TestReportDispatcherSendReportOk(t *testing.T) {
 
 	db := &databasePkg.StubDatabase{}
 	mutesManager := mutes.NewMutesManager(logger.GetNopLogger(), db)
-	dispatcher := NewDispatcher(logger.GetNopLogger(), mutesManager, []reportersPkg.Reporter{
-		&reportersPkg.TestReporter{},
-	}, tracing.InitNoopTracer())
+	remindersManager := reminders.NewManager(logger.GetNopLogger(), db, []types.Duration{})
+	metricsManager := metrics.NewManager(logger.GetNopLogger(), types.MetricsConfig{})
+	dispatcher := NewDispatcher(
+		logger.GetNopLogger(),
+		mutesManager,
+		remindersManager,
+		routes.NewManager(logger.GetNopLogger(), types.Routes{}),
+		metricsManager,
+		outbox.NewManager(logger.GetNopLogger(), types.OutboxConfig{}, db),
+		[]reportersPkg.Reporter{&reportersPkg.TestReporter{}},
+		tracing.InitNoopTracer(),
+	)
 
 	err := dispatcher.Init()
 	require.NoError(t, err)
@@ -151,3 +230,800 @@ func TestReportDispatcherSendReportOk(t *testing.T) {
 		events.ProposalsQueryErrorEvent{},
 	}}, context.Background())
 }
+
+func TestReportDispatcherSendReportReminders(t *testing.T) {
+	t.Parallel()
+
+	db := &databasePkg.StubDatabase{}
+	mutesManager := mutes.NewMutesManager(logger.GetNopLogger(), db)
+	remindersManager := reminders.NewManager(logger.GetNopLogger(), db, []types.Duration{
+		{Duration: time.Hour},
+	})
+	metricsManager := metrics.NewManager(logger.GetNopLogger(), types.MetricsConfig{})
+	reporter := &reportersPkg.TestReporter{}
+	dispatcher := NewDispatcher(
+		logger.GetNopLogger(),
+		mutesManager,
+		remindersManager,
+		routes.NewManager(logger.GetNopLogger(), types.Routes{}),
+		metricsManager,
+		outbox.NewManager(logger.GetNopLogger(), types.OutboxConfig{}, db),
+		[]reportersPkg.Reporter{reporter},
+		tracing.InitNoopTracer(),
+	)
+
+	err := dispatcher.Init()
+	require.NoError(t, err)
+
+	now := time.Now()
+	report := reportersPkg.Report{Entries: []entry.ReportEntry{
+		events.NotVotedEvent{
+			Chain:      &types.Chain{Name: "chain"},
+			Wallet:     &types.Wallet{Address: "wallet"},
+			Proposal:   types.Proposal{ID: "proposal", EndTime: now.Add(2 * time.Hour)},
+			RenderTime: now,
+		},
+	}}
+
+	dispatcher.SendReport(report, context.Background())
+	assert.Len(t, reporter.SentEntries, 1)
+
+	dispatcher.SendReport(report, context.Background())
+	assert.Len(t, reporter.SentEntries, 1)
+
+	report.Entries = append(report.Entries, events.VotedEvent{
+		Chain:    &types.Chain{Name: "chain"},
+		Wallet:   &types.Wallet{Address: "wallet2"},
+		Proposal: types.Proposal{ID: "proposal"},
+	})
+	dispatcher.SendReport(report, context.Background())
+	assert.Len(t, reporter.SentEntries, 2)
+}
+
+func getRemindersTestDispatcher(db *databasePkg.StubDatabase, reporters ...reportersPkg.Reporter) *Dispatcher {
+	return NewDispatcher(
+		logger.GetNopLogger(),
+		mutes.NewMutesManager(logger.GetNopLogger(), db),
+		reminders.NewManager(logger.GetNopLogger(), db, []types.Duration{{Duration: time.Hour}}),
+		routes.NewManager(logger.GetNopLogger(), types.Routes{}),
+		metrics.NewManager(logger.GetNopLogger(), types.MetricsConfig{}),
+		outbox.NewManager(logger.GetNopLogger(), types.OutboxConfig{}, db),
+		reporters,
+		tracing.InitNoopTracer(),
+	)
+}
+
+func TestReportDispatcherSendReportRemindersSeveralReporters(t *testing.T) {
+	t.Parallel()
+
+	db := &databasePkg.StubDatabase{}
+	failingReporter := &reportersPkg.TestReporter{WithErrorSending: true}
+	reporter := &reportersPkg.TestReporter{WithErrorSending: true}
+	dispatcher := getRemindersTestDispatcher(db, reporter, failingReporter)
+
+	now := time.Now()
+	report := reportersPkg.Report{Entries: []entry.ReportEntry{
+		events.NotVotedEvent{
+			Chain:      &types.Chain{Name: "chain"},
+			Wallet:     &types.Wallet{Address: "wallet"},
+			Proposal:   types.Proposal{ID: "proposal", EndTime: now.Add(2 * time.Hour)},
+			RenderTime: now,
+		},
+	}}
+
+	// not used up when no reporter sent it
+	dispatcher.SendReport(report, context.Background())
+	require.Empty(t, db.Reminders)
+
+	// used up once, even if it's sent before the other reporter fails
+	reporter.WithErrorSending = false
+	dispatcher.SendReport(report, context.Background())
+	require.Len(t, reporter.SentEntries, 1)
+	require.Empty(t, failingReporter.SentEntries)
+	require.Len(t, db.Reminders["chain"]["proposal"]["wallet"], 1)
+
+	dispatcher.SendReport(report, context.Background())
+	require.Len(t, reporter.SentEntries, 1)
+}
+
+func TestReportDispatcherSendReportRemindersMuted(t *testing.T) {
+	t.Parallel()
+
+	db := &databasePkg.StubDatabase{
+		Mutes: []*types.Mute{{Wallet: null.StringFrom("wallet"), Expires: time.Now().Add(time.Hour)}},
+	}
+	reporter := &reportersPkg.TestReporter{}
+	dispatcher := getRemindersTestDispatcher(db, reporter)
+
+	now := time.Now()
+	report := reportersPkg.Report{Entries: []entry.ReportEntry{
+		events.NotVotedEvent{
+			Chain:      &types.Chain{Name: "chain"},
+			Wallet:     &types.Wallet{Address: "wallet"},
+			Proposal:   types.Proposal{ID: "proposal", EndTime: now.Add(2 * time.Hour)},
+			RenderTime: now,
+		},
+	}}
+
+	dispatcher.SendReport(report, context.Background())
+	require.Empty(t, reporter.SentEntries)
+	require.Empty(t, db.Reminders)
+
+	// the reminder was not used up while muted
+	db.Mutes = []*types.Mute{}
+	dispatcher.SendReport(report, context.Background())
+	require.Len(t, reporter.SentEntries, 1)
+	require.Len(t, db.Reminders["chain"]["proposal"]["wallet"], 1)
+}
+
+func TestReportDispatcherSendReportRemindersEditAlerts(t *testing.T) {
+	t.Parallel()
+
+	db := &databasePkg.StubDatabase{}
+	reporter := &reportersPkg.TestReporter{WithEditing: true}
+	dispatcher := getRemindersTestDispatcher(db, reporter)
+
+	now := time.Now()
+	report := reportersPkg.Report{Entries: []entry.ReportEntry{
+		events.NotVotedEvent{
+			Chain:      &types.Chain{Name: "chain"},
+			Wallet:     &types.Wallet{Address: "wallet"},
+			Proposal:   types.Proposal{ID: "proposal", EndTime: now.Add(2 * time.Hour)},
+			RenderTime: now,
+		},
+	}}
+
+	dispatcher.SendReport(report, context.Background())
+	require.Len(t, reporter.SentEntries, 1)
+	require.Empty(t, reporter.EditedEntries)
+
+	// the reminder is not due yet, so the sent alert is only edited
+	dispatcher.SendReport(report, context.Background())
+	require.Len(t, reporter.SentEntries, 1)
+	require.Len(t, reporter.EditedEntries, 1)
+	require.Len(t, db.Reminders["chain"]["proposal"]["wallet"], 1)
+
+	// muted entries are not edited either
+	db.Mutes = []*types.Mute{{Wallet: null.StringFrom("wallet"), Expires: time.Now().Add(time.Hour)}}
+	dispatcher.SendReport(report, context.Background())
+	require.Len(t, reporter.EditedEntries, 1)
+
+	db.Mutes = []*types.Mute{}
+	reporter.WithErrorSending = true
+	dispatcher.SendReport(report, context.Background())
+	require.Len(t, reporter.EditedEntries, 1)
+
+	// reporters that do not edit alerts get nothing between the reminders
+	reporter.WithErrorSending = false
+	reporter.WithEditing = false
+	dispatcher.SendReport(report, context.Background())
+	require.Len(t, reporter.SentEntries, 1)
+	require.Len(t, reporter.EditedEntries, 1)
+}
+
+func TestReportDispatcherSendReportDeleteAlertMessages(t *testing.T) {
+	t.Parallel()
+
+	db := &databasePkg.StubDatabase{
+		Mutes: []*types.Mute{{Chain: null.StringFrom("chain"), Expires: time.Now().Add(time.Hour)}},
+	}
+	reporter := &reportersPkg.TestReporter{WithEditing: true}
+	dispatcher := getRemindersTestDispatcher(db, reporter)
+
+	report := reportersPkg.Report{Entries: []entry.ReportEntry{
+		events.FinishedVotingEvent{
+			Chain:    &types.Chain{Name: "chain"},
+			Proposal: types.Proposal{ID: "proposal"},
+		},
+	}}
+
+	// the alert messages are deleted even if the entry is muted
+	dispatcher.SendReport(report, context.Background())
+	require.Empty(t, reporter.SentEntries)
+	require.Len(t, reporter.DeletedAlerts, 1)
+
+	// but not for reporters not editing alerts
+	reporter.WithEditing = false
+	dispatcher.SendReport(report, context.Background())
+	require.Len(t, reporter.DeletedAlerts, 1)
+}
+
+func TestReportDispatcherSendReportRemindersErrorSending(t *testing.T) {
+	t.Parallel()
+
+	db := &databasePkg.StubDatabase{}
+	reporter := &reportersPkg.TestReporter{WithErrorSending: true}
+	dispatcher := getRemindersTestDispatcher(db, reporter)
+
+	now := time.Now()
+	report := reportersPkg.Report{Entries: []entry.ReportEntry{
+		events.NotVotedEvent{
+			Chain:      &types.Chain{Name: "chain"},
+			Wallet:     &types.Wallet{Address: "wallet"},
+			Proposal:   types.Proposal{ID: "proposal", EndTime: now.Add(2 * time.Hour)},
+			RenderTime: now,
+		},
+	}}
+
+	dispatcher.SendReport(report, context.Background())
+	require.Empty(t, db.Reminders)
+
+	reporter.WithErrorSending = false
+	dispatcher.SendReport(report, context.Background())
+	require.Len(t, reporter.SentEntries, 1)
+	require.Len(t, db.Reminders["chain"]["proposal"]["wallet"], 1)
+}
+
+func TestReportDispatcherSendReportRemindersGrouped(t *testing.T) {
+	t.Parallel()
+
+	db := &databasePkg.StubDatabase{InsertRemindersError: errors.New("custom error")}
+	reporter := &reportersPkg.TestReporter{WithGrouping: true}
+	dispatcher := getRemindersTestDispatcher(db, reporter)
+
+	now := time.Now()
+	chain := &types.Chain{Name: "chain"}
+	proposal := types.Proposal{ID: "proposal", EndTime: now.Add(2 * time.Hour)}
+	report := reportersPkg.Report{Entries: []entry.ReportEntry{
+		events.NotVotedEvent{Chain: chain, Wallet: &types.Wallet{Address: "wallet1"}, Proposal: proposal, RenderTime: now},
+		events.NotVotedEvent{Chain: chain, Wallet: &types.Wallet{Address: "wallet2"}, Proposal: proposal, RenderTime: now},
+	}}
+
+	// error storing reminders is not fatal
+	dispatcher.SendReport(report, context.Background())
+	require.Len(t, reporter.SentEntries, 1)
+	require.Empty(t, db.Reminders)
+
+	db.InsertRemindersError = nil
+	dispatcher.SendReport(report, context.Background())
+	require.Len(t, reporter.SentEntries, 2)
+	require.Len(t, db.Reminders["chain"]["proposal"]["wallet1"], 1)
+	require.Len(t, db.Reminders["chain"]["proposal"]["wallet2"], 1)
+
+	dispatcher.SendReport(report, context.Background())
+	require.Len(t, reporter.SentEntries, 2)
+}
+
+func TestReportDispatcherSendReportErrorGettingReminders(t *testing.T) {
+	t.Parallel()
+
+	db := &databasePkg.StubDatabase{GetRemindersError: errors.New("reminders error")}
+	mutesManager := mutes.NewMutesManager(logger.GetNopLogger(), db)
+	remindersManager := reminders.NewManager(logger.GetNopLogger(), db, []types.Duration{
+		{Duration: time.Hour},
+	})
+	metricsManager := metrics.NewManager(logger.GetNopLogger(), types.MetricsConfig{})
+	reporter := &reportersPkg.TestReporter{}
+	dispatcher := NewDispatcher(
+		logger.GetNopLogger(),
+		mutesManager,
+		remindersManager,
+		routes.NewManager(logger.GetNopLogger(), types.Routes{}),
+		metricsManager,
+		outbox.NewManager(logger.GetNopLogger(), types.OutboxConfig{}, db),
+		[]reportersPkg.Reporter{reporter},
+		tracing.InitNoopTracer(),
+	)
+
+	err := dispatcher.Init()
+	require.NoError(t, err)
+
+	dispatcher.SendReport(reportersPkg.Report{Entries: []entry.ReportEntry{
+		events.NotVotedEvent{
+			Chain:    &types.Chain{Name: "chain"},
+			Wallet:   &types.Wallet{Address: "wallet"},
+			Proposal: types.Proposal{ID: "proposal"},
+		},
+	}}, context.Background())
+	assert.Len(t, reporter.SentEntries, 1)
+}
+
+func TestReportDispatcherSendReportNotRouted(t *testing.T) {
+	t.Parallel()
+
+	db := &databasePkg.StubDatabase{}
+	mutesManager := mutes.NewMutesManager(logger.GetNopLogger(), db)
+	remindersManager := reminders.NewManager(logger.GetNopLogger(), db, []types.Duration{})
+	routesManager := routes.NewManager(logger.GetNopLogger(), types.Routes{
+		{Reporter: "test-reporter", Events: []string{"not_voted"}},
+	})
+	metricsManager := metrics.NewManager(logger.GetNopLogger(), types.MetricsConfig{})
+	reporter := &reportersPkg.TestReporter{}
+	dispatcher := NewDispatcher(
+		logger.GetNopLogger(),
+		mutesManager,
+		remindersManager,
+		routesManager,
+		metricsManager,
+		outbox.NewManager(logger.GetNopLogger(), types.OutboxConfig{}, db),
+		[]reportersPkg.Reporter{reporter},
+		tracing.InitNoopTracer(),
+	)
+
+	err := dispatcher.Init()
+	require.NoError(t, err)
+
+	dispatcher.SendReport(reportersPkg.Report{Entries: []entry.ReportEntry{
+		events.NotVotedEvent{
+			Chain:    &types.Chain{Name: "chain"},
+			Wallet:   &types.Wallet{Address: "wallet"},
+			Proposal: types.Proposal{ID: "proposal"},
+		},
+		events.VotedEvent{
+			Chain:    &types.Chain{Name: "chain"},
+			Wallet:   &types.Wallet{Address: "wallet"},
+			Proposal: types.Proposal{ID: "proposal"},
+		},
+	}}, context.Background())
+	require.Len(t, reporter.SentEntries, 1)
+	assert.Equal(t, "not_voted", reporter.SentEntries[0].Name())
+}
+
+func TestReportDispatcherSendReportNotRoutedResolvesAlerts(t *testing.T) {
+	t.Parallel()
+
+	db := &databasePkg.StubDatabase{}
+	routesManager := routes.NewManager(logger.GetNopLogger(), types.Routes{
+		{Reporter: "test-reporter", Events: []string{"not_voted"}},
+	})
+	reporter := &reportersPkg.TestReporter{ResolvedEvents: []string{"voted", "finished_voting"}}
+	dispatcher := NewDispatcher(
+		logger.GetNopLogger(),
+		mutes.NewMutesManager(logger.GetNopLogger(), db),
+		reminders.NewManager(logger.GetNopLogger(), db, []types.Duration{}),
+		routesManager,
+		metrics.NewManager(logger.GetNopLogger(), types.MetricsConfig{}),
+		outbox.NewManager(logger.GetNopLogger(), types.OutboxConfig{}, db),
+		[]reportersPkg.Reporter{reporter},
+		tracing.InitNoopTracer(),
+	)
+
+	chain := &types.Chain{Name: "chain"}
+	wallet := &types.Wallet{Address: "wallet"}
+	proposal := types.Proposal{ID: "proposal"}
+
+	dispatcher.SendReport(reportersPkg.Report{Entries: []entry.ReportEntry{
+		events.NotVotedEvent{Chain: chain, Wallet: wallet, Proposal: proposal},
+		events.VotedEvent{Chain: chain, Wallet: wallet, Proposal: proposal},
+		events.RevotedEvent{Chain: chain, Wallet: wallet, Proposal: proposal},
+		events.FinishedVotingEvent{Chain: chain, Proposal: proposal},
+	}}, context.Background())
+
+	// the entries resolving alerts are sent even if they are not routed
+	require.Len(t, reporter.SentEntries, 3)
+	assert.Equal(t, "not_voted", reporter.SentEntries[0].Name())
+	assert.Equal(t, "voted", reporter.SentEntries[1].Name())
+	assert.Equal(t, "finished_voting", reporter.SentEntries[2].Name())
+}
+
+func getOutboxTestDispatcher(db *databasePkg.StubDatabase, reporter reportersPkg.Reporter) *Dispatcher {
+	config := types.OutboxConfig{
+		Enabled:        null.BoolFrom(true),
+		MaxAttempts:    2,
+		InitialBackoff: types.Duration{Duration: time.Minute},
+		MaxBackoff:     types.Duration{Duration: time.Hour},
+	}
+
+	return NewDispatcher(
+		logger.GetNopLogger(),
+		mutes.NewMutesManager(logger.GetNopLogger(), db),
+		reminders.NewManager(logger.GetNopLogger(), db, []types.Duration{}),
+		routes.NewManager(logger.GetNopLogger(), types.Routes{}),
+		metrics.NewManager(logger.GetNopLogger(), types.MetricsConfig{}),
+		outbox.NewManager(logger.GetNopLogger(), config, db),
+		[]reportersPkg.Reporter{reporter},
+		tracing.InitNoopTracer(),
+	)
+}
+
+func TestReportDispatcherSendReportOutboxSent(t *testing.T) {
+	t.Parallel()
+
+	db := &databasePkg.StubDatabase{}
+	reporter := &reportersPkg.TestReporter{}
+	dispatcher := getOutboxTestDispatcher(db, reporter)
+
+	dispatcher.SendReport(reportersPkg.Report{Entries: []entry.ReportEntry{
+		events.FinishedVotingEvent{Chain: &types.Chain{Name: "chain"}},
+	}}, context.Background())
+
+	require.Len(t, reporter.SentEntries, 1)
+	require.Empty(t, db.Outbox)
+}
+
+func TestReportDispatcherSendReportOutboxFailed(t *testing.T) {
+	t.Parallel()
+
+	db := &databasePkg.StubDatabase{}
+	reporter := &reportersPkg.TestReporter{WithErrorSending: true}
+	dispatcher := getOutboxTestDispatcher(db, reporter)
+
+	dispatcher.SendReport(reportersPkg.Report{Entries: []entry.ReportEntry{
+		events.FinishedVotingEvent{Chain: &types.Chain{Name: "chain"}},
+	}}, context.Background())
+
+	require.Len(t, db.Outbox, 1)
+	require.Equal(t, "test-reporter", db.Outbox[0].Reporter)
+	require.Equal(t, "finished_voting", db.Outbox[0].Event)
+	require.Equal(t, types.OutboxStatusPending, db.Outbox[0].Status)
+	require.Equal(t, 1, db.Outbox[0].Attempts)
+	require.Equal(t, "fail", db.Outbox[0].LastError.String)
+	require.True(t, db.Outbox[0].NextAttemptAt.After(time.Now()))
+}
+
+func TestReportDispatcherSendReportOutboxErrorStoring(t *testing.T) {
+	t.Parallel()
+
+	db := &databasePkg.StubDatabase{InsertOutboxError: errors.New("custom error")}
+	reporter := &reportersPkg.TestReporter{}
+	dispatcher := getOutboxTestDispatcher(db, reporter)
+
+	dispatcher.SendReport(reportersPkg.Report{Entries: []entry.ReportEntry{
+		events.FinishedVotingEvent{Chain: &types.Chain{Name: "chain"}},
+	}}, context.Background())
+
+	require.Len(t, reporter.SentEntries, 1)
+	require.Empty(t, db.Outbox)
+}
+
+func TestReportDispatcherRetryUndeliveredOk(t *testing.T) {
+	t.Parallel()
+
+	db := &databasePkg.StubDatabase{}
+	reporter := &reportersPkg.TestReporter{WithErrorSending: true}
+	dispatcher := getOutboxTestDispatcher(db, reporter)
+
+	dispatcher.SendReport(reportersPkg.Report{Entries: []entry.ReportEntry{
+		events.FinishedVotingEvent{
+			Chain:    &types.Chain{Name: "chain"},
+			Proposal: types.Proposal{ID: "proposal"},
+		},
+	}}, context.Background())
+	require.Len(t, db.Outbox, 1)
+
+	// not due yet
+	dispatcher.RetryUndelivered(context.Background())
+	require.Equal(t, 1, db.Outbox[0].Attempts)
+
+	db.Outbox[0].NextAttemptAt = time.Now()
+	reporter.WithErrorSending = false
+	dispatcher.RetryUndelivered(context.Background())
+
+	require.Empty(t, db.Outbox)
+	require.Len(t, reporter.SentEntries, 1)
+	require.Equal(t, events.FinishedVotingEvent{
+		Chain:    &types.Chain{Name: "chain"},
+		Proposal: types.Proposal{ID: "proposal"},
+	}, reporter.SentEntries[0])
+}
+
+func TestReportDispatcherRetryUndeliveredReminders(t *testing.T) {
+	t.Parallel()
+
+	db := &databasePkg.StubDatabase{}
+	reporter := &reportersPkg.TestReporter{WithErrorSending: true}
+	dispatcher := getOutboxTestDispatcher(db, reporter)
+	dispatcher.RemindersManager = reminders.NewManager(
+		logger.GetNopLogger(),
+		db,
+		[]types.Duration{{Duration: time.Hour}},
+	)
+
+	now := time.Now()
+	dispatcher.SendReport(reportersPkg.Report{Entries: []entry.ReportEntry{
+		events.NotVotedEvent{
+			Chain:      &types.Chain{Name: "chain"},
+			Wallet:     &types.Wallet{Address: "wallet"},
+			Proposal:   types.Proposal{ID: "proposal", EndTime: now.Add(2 * time.Hour)},
+			RenderTime: now,
+		},
+	}}, context.Background())
+	require.Len(t, db.Outbox, 1)
+	require.Empty(t, db.Reminders)
+
+	// the reminder is used up once the entry is delivered
+	db.Outbox[0].NextAttemptAt = time.Now()
+	reporter.WithErrorSending = false
+	dispatcher.RetryUndelivered(context.Background())
+	require.Empty(t, db.Outbox)
+	require.Len(t, db.Reminders["chain"]["proposal"]["wallet"], 1)
+}
+
+func TestReportDispatcherRetryUndeliveredFailedDestinations(t *testing.T) {
+	t.Parallel()
+
+	db := &databasePkg.StubDatabase{}
+	reporter := &reportersPkg.TestReporter{FailingDestinations: []string{"chat2", "chat3"}}
+	dispatcher := getOutboxTestDispatcher(db, reporter)
+	dispatcher.OutboxManager.Config.MaxAttempts = 3
+
+	dispatcher.SendReport(reportersPkg.Report{Entries: []entry.ReportEntry{
+		events.FinishedVotingEvent{Chain: &types.Chain{Name: "chain"}},
+	}}, context.Background())
+	require.Len(t, db.Outbox, 1)
+	require.Equal(t, []string{"chat2", "chat3"}, db.Outbox[0].GetDestinations())
+
+	// only the destinations that failed are retried
+	db.Outbox[0].NextAttemptAt = time.Now()
+	reporter.FailingDestinations = []string{"chat3"}
+	dispatcher.RetryUndelivered(context.Background())
+	require.Len(t, db.Outbox, 1)
+	require.Equal(t, []string{"chat3"}, db.Outbox[0].GetDestinations())
+	require.Equal(t, 2, db.Outbox[0].Attempts)
+	require.Equal(t, [][]string{{"chat2", "chat3"}}, reporter.SentDestinations)
+
+	db.Outbox[0].NextAttemptAt = time.Now()
+	reporter.FailingDestinations = []string{}
+	dispatcher.RetryUndelivered(context.Background())
+	require.Empty(t, db.Outbox)
+	require.Equal(t, [][]string{{"chat2", "chat3"}, {"chat3"}}, reporter.SentDestinations)
+	require.Len(t, reporter.SentEntries, 1)
+}
+
+func TestReportDispatcherRetryUndeliveredDead(t *testing.T) {
+	t.Parallel()
+
+	db := &databasePkg.StubDatabase{}
+	reporter := &reportersPkg.TestReporter{WithErrorSending: true}
+	dispatcher := getOutboxTestDispatcher(db, reporter)
+
+	dispatcher.SendReport(reportersPkg.Report{Entries: []entry.ReportEntry{
+		events.FinishedVotingEvent{Chain: &types.Chain{Name: "chain"}},
+	}}, context.Background())
+	require.Len(t, db.Outbox, 1)
+
+	db.Outbox[0].NextAttemptAt = time.Now()
+	dispatcher.RetryUndelivered(context.Background())
+
+	require.Len(t, db.Outbox, 1)
+	require.Equal(t, 2, db.Outbox[0].Attempts)
+	require.True(t, db.Outbox[0].IsDead())
+
+	// dead entries are not retried
+	dispatcher.RetryUndelivered(context.Background())
+	require.Equal(t, 2, db.Outbox[0].Attempts)
+}
+
+func TestReportDispatcherRetryUndeliveredReporterDisabled(t *testing.T) {
+	t.Parallel()
+
+	db := &databasePkg.StubDatabase{
+		Outbox: []*types.OutboxEntry{{
+			ID:       1,
+			Reporter: "another-reporter",
+			Event:    "finished_voting",
+			Payload:  "{}",
+			Status:   types.OutboxStatusPending,
+		}},
+	}
+	reporter := &reportersPkg.TestReporter{}
+	dispatcher := getOutboxTestDispatcher(db, reporter)
+
+	dispatcher.RetryUndelivered(context.Background())
+	require.Empty(t, reporter.SentEntries)
+	require.True(t, db.Outbox[0].IsDead())
+	require.Equal(t, "reporter another-reporter is not enabled", db.Outbox[0].LastError.String)
+}
+
+func TestReportDispatcherRetryUndeliveredInvalidPayload(t *testing.T) {
+	t.Parallel()
+
+	db := &databasePkg.StubDatabase{
+		Outbox: []*types.OutboxEntry{{
+			ID:       1,
+			Reporter: "test-reporter",
+			Event:    "finished_voting",
+			Payload:  "invalid",
+			Status:   types.OutboxStatusPending,
+		}},
+	}
+	reporter := &reportersPkg.TestReporter{}
+	dispatcher := getOutboxTestDispatcher(db, reporter)
+
+	dispatcher.RetryUndelivered(context.Background())
+	require.Empty(t, reporter.SentEntries)
+	require.True(t, db.Outbox[0].IsDead())
+}
+
+func TestReportDispatcherRetryUndeliveredMuted(t *testing.T) {
+	t.Parallel()
+
+	db := &databasePkg.StubDatabase{
+		Mutes: []*types.Mute{{Expires: time.Now().Add(time.Hour)}},
+		Outbox: []*types.OutboxEntry{{
+			ID:       1,
+			Reporter: "test-reporter",
+			Event:    "finished_voting",
+			Payload:  `{"Chain":{"Name":"chain"},"Proposal":{"ID":"proposal"}}`,
+			Status:   types.OutboxStatusPending,
+		}},
+	}
+	reporter := &reportersPkg.TestReporter{}
+	dispatcher := getOutboxTestDispatcher(db, reporter)
+
+	dispatcher.RetryUndelivered(context.Background())
+	require.Empty(t, reporter.SentEntries)
+	require.Empty(t, db.Outbox)
+}
+
+func TestReportDispatcherRetryUndeliveredErrorGetting(t *testing.T) {
+	t.Parallel()
+
+	db := &databasePkg.StubDatabase{GetOutboxError: errors.New("custom error")}
+	reporter := &reportersPkg.TestReporter{}
+	dispatcher := getOutboxTestDispatcher(db, reporter)
+
+	dispatcher.RetryUndelivered(context.Background())
+	require.Empty(t, reporter.SentEntries)
+}
+
+func TestReportDispatcherRetryUndeliveredInProgress(t *testing.T) {
+	t.Parallel()
+
+	db := &databasePkg.StubDatabase{
+		Outbox: []*types.OutboxEntry{{
+			ID:       1,
+			Reporter: "test-reporter",
+			Event:    "finished_voting",
+			Payload:  "{}",
+			Status:   types.OutboxStatusPending,
+		}},
+	}
+	reporter := &reportersPkg.TestReporter{}
+	dispatcher := getOutboxTestDispatcher(db, reporter)
+
+	dispatcher.RetryMutex.Lock()
+	dispatcher.RetryUndelivered(context.Background())
+	dispatcher.RetryMutex.Unlock()
+
+	require.Empty(t, reporter.SentEntries)
+	require.Len(t, db.Outbox, 1)
+}
+
+func TestReportDispatcherSendReportGrouped(t *testing.T) {
+	t.Parallel()
+
+	chain := &types.Chain{Name: "chain"}
+	anotherChain := &types.Chain{Name: "another-chain"}
+	proposal := types.Proposal{ID: "proposal"}
+
+	db := &databasePkg.StubDatabase{
+		Mutes: []*types.Mute{{Wallet: null.StringFrom("muted"), Expires: time.Now().Add(time.Hour)}},
+	}
+	groupingReporter := &reportersPkg.TestReporter{WithGrouping: true}
+	reporter := &reportersPkg.TestReporter{}
+
+	dispatcher := NewDispatcher(
+		logger.GetNopLogger(),
+		mutes.NewMutesManager(logger.GetNopLogger(), db),
+		reminders.NewManager(logger.GetNopLogger(), db, []types.Duration{}),
+		routes.NewManager(logger.GetNopLogger(), types.Routes{}),
+		metrics.NewManager(logger.GetNopLogger(), types.MetricsConfig{}),
+		outbox.NewManager(logger.GetNopLogger(), types.OutboxConfig{}, db),
+		[]reportersPkg.Reporter{groupingReporter, reporter},
+		tracing.InitNoopTracer(),
+	)
+
+	dispatcher.SendReport(reportersPkg.Report{Entries: []entry.ReportEntry{
+		events.NotVotedEvent{Chain: chain, Wallet: &types.Wallet{Address: "wallet1"}, Proposal: proposal},
+		events.NotVotedEvent{Chain: chain, Wallet: &types.Wallet{Address: "muted"}, Proposal: proposal},
+		events.VotedEvent{Chain: chain, Wallet: &types.Wallet{Address: "wallet2"}, Proposal: proposal},
+		events.NotVotedEvent{Chain: anotherChain, Wallet: &types.Wallet{Address: "wallet1"}, Proposal: proposal},
+		events.FinishedVotingEvent{Chain: chain, Proposal: proposal},
+	}}, context.Background())
+
+	require.Equal(t, []entry.ReportEntry{
+		events.GroupedEvent{Chain: chain, Entries: []entry.ReportEntry{
+			events.NotVotedEvent{Chain: chain, Wallet: &types.Wallet{Address: "wallet1"}, Proposal: proposal},
+			events.VotedEvent{Chain: chain, Wallet: &types.Wallet{Address: "wallet2"}, Proposal: proposal},
+		}},
+		events.NotVotedEvent{Chain: anotherChain, Wallet: &types.Wallet{Address: "wallet1"}, Proposal: proposal},
+		events.FinishedVotingEvent{Chain: chain, Proposal: proposal},
+	}, groupingReporter.SentEntries)
+	require.Len(t, reporter.SentEntries, 4)
+}
+
+func TestReportDispatcherRetryUndeliveredGroupedPartiallyMuted(t *testing.T) {
+	t.Parallel()
+
+	chain := &types.Chain{Name: "chain"}
+	proposal := types.Proposal{ID: "proposal"}
+
+	db := &databasePkg.StubDatabase{}
+	reporter := &reportersPkg.TestReporter{WithErrorSending: true, WithGrouping: true}
+	dispatcher := getOutboxTestDispatcher(db, reporter)
+
+	dispatcher.SendReport(reportersPkg.Report{Entries: []entry.ReportEntry{
+		events.NotVotedEvent{Chain: chain, Wallet: &types.Wallet{Address: "wallet"}, Proposal: proposal},
+		events.NotVotedEvent{Chain: chain, Wallet: &types.Wallet{Address: "muted"}, Proposal: proposal},
+	}}, context.Background())
+	require.Len(t, db.Outbox, 1)
+	require.Equal(t, "grouped", db.Outbox[0].Event)
+
+	db.Mutes = []*types.Mute{{Wallet: null.StringFrom("muted"), Expires: time.Now().Add(time.Hour)}}
+	db.Outbox[0].NextAttemptAt = time.Now()
+	reporter.WithErrorSending = false
+	dispatcher.RetryUndelivered(context.Background())
+
+	require.Empty(t, db.Outbox)
+	require.Equal(t, []entry.ReportEntry{
+		events.GroupedEvent{Chain: chain, Entries: []entry.ReportEntry{
+			events.NotVotedEvent{Chain: chain, Wallet: &types.Wallet{Address: "wallet"}, Proposal: proposal},
+		}},
+	}, reporter.SentEntries)
+}
+
+func TestReportDispatcherRetryUndeliveredGroupedMuted(t *testing.T) {
+	t.Parallel()
+
+	chain := &types.Chain{Name: "chain"}
+	proposal := types.Proposal{ID: "proposal"}
+
+	db := &databasePkg.StubDatabase{}
+	reporter := &reportersPkg.TestReporter{WithErrorSending: true, WithGrouping: true}
+	dispatcher := getOutboxTestDispatcher(db, reporter)
+
+	dispatcher.SendReport(reportersPkg.Report{Entries: []entry.ReportEntry{
+		events.NotVotedEvent{Chain: chain, Wallet: &types.Wallet{Address: "wallet1"}, Proposal: proposal},
+		events.NotVotedEvent{Chain: chain, Wallet: &types.Wallet{Address: "wallet2"}, Proposal: proposal},
+	}}, context.Background())
+	require.Len(t, db.Outbox, 1)
+
+	db.Mutes = []*types.Mute{{Chain: null.StringFrom("chain"), Expires: time.Now().Add(time.Hour)}}
+	db.Outbox[0].NextAttemptAt = time.Now()
+	reporter.WithErrorSending = false
+	dispatcher.RetryUndelivered(context.Background())
+
+	require.Empty(t, db.Outbox)
+	require.Empty(t, reporter.SentEntries)
+}
+
+func TestReportDispatcherUpdateDashboards(t *testing.T) {
+	t.Parallel()
+
+	db := &databasePkg.StubDatabase{}
+	dashboardReporter := &reportersPkg.TestReporter{WithDashboard: true}
+	disabledReporter := &reportersPkg.TestReporter{WithDashboard: true, WithDisabled: true}
+	failingReporter := &reportersPkg.TestReporter{WithDashboard: true, WithErrorSending: true}
+	reporter := &reportersPkg.TestReporter{}
+
+	dispatcher := NewDispatcher(
+		logger.GetNopLogger(),
+		mutes.NewMutesManager(logger.GetNopLogger(), db),
+		reminders.NewManager(logger.GetNopLogger(), db, []types.Duration{}),
+		routes.NewManager(logger.GetNopLogger(), types.Routes{}),
+		metrics.NewManager(logger.GetNopLogger(), types.MetricsConfig{}),
+		outbox.NewManager(logger.GetNopLogger(), types.OutboxConfig{}, db),
+		[]reportersPkg.Reporter{dashboardReporter, disabledReporter, failingReporter, reporter},
+		tracing.InitNoopTracer(),
+	)
+	require.True(t, dispatcher.HasDashboards())
+
+	renderedState := statePkg.RenderedState{RenderTime: time.Now()}
+	dispatcher.UpdateDashboards(renderedState, context.Background())
+
+	require.Equal(t, []statePkg.RenderedState{renderedState}, dashboardReporter.Dashboards)
+	require.Empty(t, disabledReporter.Dashboards)
+	require.Empty(t, failingReporter.Dashboards)
+	require.Empty(t, reporter.Dashboards)
+}
+
+func TestReportDispatcherHasNoDashboards(t *testing.T) {
+	t.Parallel()
+
+	db := &databasePkg.StubDatabase{}
+	dispatcher := NewDispatcher(
+		logger.GetNopLogger(),
+		mutes.NewMutesManager(logger.GetNopLogger(), db),
+		reminders.NewManager(logger.GetNopLogger(), db, []types.Duration{}),
+		routes.NewManager(logger.GetNopLogger(), types.Routes{}),
+		metrics.NewManager(logger.GetNopLogger(), types.MetricsConfig{}),
+		outbox.NewManager(logger.GetNopLogger(), types.OutboxConfig{}, db),
+		[]reportersPkg.Reporter{
+			&reportersPkg.TestReporter{},
+			&reportersPkg.TestReporter{WithDashboard: true, WithDisabled: true},
+		},
+		tracing.InitNoopTracer(),
+	)
+	require.False(t, dispatcher.HasDashboards())
+}
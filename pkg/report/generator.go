@@ -6,9 +6,12 @@ import (
 	databasePkg "main/pkg/database"
 	"main/pkg/events"
 	fetchersPkg "main/pkg/fetchers"
+	"main/pkg/metrics"
 	"main/pkg/report/entry"
 	"main/pkg/reporters"
+	"main/pkg/spam"
 	"main/pkg/types"
+	"main/pkg/utils"
 	"sync"
 	"time"
 
@@ -18,31 +21,37 @@ import (
 )
 
 type Generator struct {
-	Chains   types.Chains
-	Logger   zerolog.Logger
-	Database databasePkg.Database
-	Fetchers map[string]fetchersPkg.Fetcher
-	Tracer   trace.Tracer
+	Chains         types.Chains
+	Logger         zerolog.Logger
+	Database       databasePkg.Database
+	Fetchers       map[string]fetchersPkg.Fetcher
+	MetricsManager *metrics.Manager
+	SpamManager    *spam.Manager
+	Tracer         trace.Tracer
 }
 
 func NewReportNewGenerator(
 	logger *zerolog.Logger,
 	chains types.Chains,
 	database databasePkg.Database,
+	metricsManager *metrics.Manager,
+	spamManager *spam.Manager,
 	tracer trace.Tracer,
 ) *Generator {
 	fetchers := make(map[string]fetchersPkg.Fetcher, len(chains))
 
 	for _, chain := range chains {
-		fetchers[chain.Name] = fetchersPkg.GetFetcher(chain, logger, tracer)
+		fetchers[chain.Name] = fetchersPkg.GetFetcher(chain, logger, metricsManager, tracer)
 	}
 
 	return &Generator{
-		Chains:   chains,
-		Logger:   logger.With().Str("component", "report_generator").Logger(),
-		Tracer:   tracer,
-		Fetchers: fetchers,
-		Database: database,
+		Chains:         chains,
+		Logger:         logger.With().Str("component", "report_generator").Logger(),
+		Tracer:         tracer,
+		Fetchers:       fetchers,
+		Database:       database,
+		MetricsManager: metricsManager,
+		SpamManager:    spamManager,
 	}
 }
 
@@ -50,6 +59,7 @@ func (g *Generator) GenerateReport(ctx context.Context) reporters.Report {
 	_, span := g.Tracer.Start(ctx, "Generating report")
 	defer span.End()
 
+	start := time.Now()
 	entries := []entry.ReportEntry{}
 
 	var wg sync.WaitGroup
@@ -71,6 +81,8 @@ func (g *Generator) GenerateReport(ctx context.Context) reporters.Report {
 
 	wg.Wait()
 
+	g.MetricsManager.LogReportGeneration(time.Since(start))
+
 	return reporters.Report{Entries: entries}
 }
 
@@ -106,6 +118,14 @@ func (g *Generator) ProcessChain(chain *types.Chain, ctx context.Context) []entr
 		span.RecordError(prevHeightErr)
 	}
 
+	proposalsInVoting := utils.Filter(proposals, func(p types.Proposal) bool {
+		return p.IsInVoting()
+	})
+	g.MetricsManager.SetProposalsInVoting(chain.Name, len(proposalsInVoting))
+	g.MetricsManager.ResetNotVoted(chain.Name)
+
+	minDeposit := g.GetMinDeposit(chain, len(proposalsInVoting), childCtx)
+
 	var wg sync.WaitGroup
 	var mutex sync.Mutex
 
@@ -117,7 +137,7 @@ func (g *Generator) ProcessChain(chain *types.Chain, ctx context.Context) []entr
 		go func(proposal types.Proposal) {
 			defer wg.Done()
 
-			proposalEntries := g.ProcessProposal(chain, proposal, childCtx)
+			proposalEntries := g.ProcessProposal(chain, proposal, minDeposit, childCtx)
 
 			mutex.Lock()
 			entries = append(entries, proposalEntries...)
@@ -130,9 +150,33 @@ func (g *Generator) ProcessChain(chain *types.Chain, ctx context.Context) []entr
 	return entries
 }
 
+// GetMinDeposit returns the chain's min deposit if it's needed to check proposals for spam,
+// or nothing if it's not needed or cannot be fetched, so the deposit is not checked.
+func (g *Generator) GetMinDeposit(
+	chain *types.Chain,
+	proposalsInVoting int,
+	ctx context.Context,
+) []types.Amount {
+	if proposalsInVoting == 0 || !g.SpamManager.NeedsMinDeposit() {
+		return []types.Amount{}
+	}
+
+	params, errs := g.Fetchers[chain.Name].GetChainParams(ctx)
+	if len(errs) > 0 || params == nil {
+		g.Logger.Warn().
+			Str("chain", chain.Name).
+			Errs("errors", errs).
+			Msg("Error fetching chain params, not checking proposals deposit")
+		return []types.Amount{}
+	}
+
+	return params.GetMinDeposit()
+}
+
 func (g *Generator) ProcessProposal(
 	chain *types.Chain,
 	proposal types.Proposal,
+	minDeposit []types.Amount,
 	ctx context.Context,
 ) []entry.ReportEntry {
 	childCtx, span := g.Tracer.Start(ctx, "Processing proposal")
@@ -163,6 +207,39 @@ func (g *Generator) ProcessProposal(
 		})
 	}
 
+	if previousProposal != nil &&
+		(previousProposal.Status != proposal.Status || (previousProposal.Expedited && !proposal.Expedited)) {
+		g.Logger.Trace().
+			Str("chain", chain.Name).
+			Str("proposal", proposal.ID).
+			Str("old_status", string(previousProposal.Status)).
+			Str("status", string(proposal.Status)).
+			Msg("Proposal status has changed")
+
+		entries = append(entries, events.ProposalStatusChangedEvent{
+			Chain:          chain,
+			Proposal:       proposal,
+			OldStatus:      previousProposal.Status,
+			OldIsExpedited: previousProposal.Expedited,
+		})
+	}
+
+	// proposals seen for the first time are announced if they are in voting already,
+	// like the ones submitted with the full deposit; to not announce all of them when
+	// starting with an empty database, run the backfill command first
+	if proposal.IsInVoting() && (previousProposal == nil || !previousProposal.IsInVoting()) {
+		g.Logger.Trace().
+			Str("chain", chain.Name).
+			Str("proposal", proposal.ID).
+			Msg("Proposal has entered voting")
+
+		entries = append(entries, events.ProposalEnteredVotingEvent{
+			Chain:    chain,
+			Proposal: proposal,
+			Wallets:  chain.Wallets,
+		})
+	}
+
 	if previousProposal == nil || !previousProposal.Equals(proposal) {
 		if updateErr := g.Database.UpsertProposal(chain, proposal); updateErr != nil {
 			g.Logger.Error().Err(updateErr).Msg("Failed to update proposal in DB")
@@ -183,6 +260,22 @@ func (g *Generator) ProcessProposal(
 		Str("proposal", proposal.ID).
 		Msg("Processing proposal...")
 
+	spamFlag, isNewSpam, spamErr := g.SpamManager.CheckProposal(chain, proposal, minDeposit)
+	if spamErr != nil {
+		g.Logger.Error().Err(spamErr).Msg("Failed to check proposal for spam")
+		span.RecordError(spamErr)
+	}
+
+	if isNewSpam {
+		entries = append(entries, events.SuspectedSpamEvent{
+			Chain:    chain,
+			Proposal: proposal,
+			Reasons:  spamFlag.Reasons,
+		})
+	}
+
+	isSpam := spamFlag != nil && spamFlag.Flagged
+
 	var wg sync.WaitGroup
 	var mutex sync.Mutex
 
@@ -194,6 +287,13 @@ func (g *Generator) ProcessProposal(
 
 			walletEntries := g.ProcessWallet(chain, proposal, wallet, childCtx)
 
+			if isSpam {
+				walletEntries = utils.Filter(walletEntries, func(e entry.ReportEntry) bool {
+					_, isNotVoted := e.(events.NotVotedEvent)
+					return !isNotVoted
+				})
+			}
+
 			mutex.Lock()
 			entries = append(entries, walletEntries...)
 			mutex.Unlock()
@@ -259,6 +359,7 @@ func (g *Generator) ProcessWallet(
 			Str("proposal", proposal.ID).
 			Str("address", wallet.Address).
 			Msg("Wallet has not voted - sending an alert.")
+		g.MetricsManager.SetNotVoted(chain.Name, proposal, wallet, time.Until(proposal.EndTime))
 		return []entry.ReportEntry{
 			events.NotVotedEvent{
 				Chain:      chain,
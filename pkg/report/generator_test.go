@@ -7,10 +7,14 @@ import (
 	"main/pkg/events"
 	fetchersPkg "main/pkg/fetchers"
 	loggerPkg "main/pkg/logger"
+	"main/pkg/metrics"
+	"main/pkg/report/entry"
+	"main/pkg/spam"
 	"main/pkg/tracing"
 	"main/pkg/types"
 	"testing"
 
+	"github.com/guregu/null/v5"
 	"github.com/stretchr/testify/require"
 )
 
@@ -18,10 +22,18 @@ func TestGeneratorNew(t *testing.T) {
 	t.Parallel()
 
 	logger := loggerPkg.GetNopLogger()
+	metricsManager := metrics.NewManager(logger, types.MetricsConfig{})
 	tracer := tracing.InitNoopTracer()
 	db := &databasePkg.StubDatabase{}
 	chains := types.Chains{{Name: "chain"}}
-	generator := NewReportNewGenerator(logger, chains, db, tracer)
+	generator := NewReportNewGenerator(
+		logger,
+		chains,
+		db,
+		metricsManager,
+		spam.NewManager(logger, types.SpamConfig{}, db),
+		tracer,
+	)
 	require.NotNil(t, generator)
 }
 
@@ -29,6 +41,7 @@ func TestGeneratorProposalsError(t *testing.T) {
 	t.Parallel()
 
 	logger := loggerPkg.GetNopLogger()
+	metricsManager := metrics.NewManager(logger, types.MetricsConfig{})
 	tracer := tracing.InitNoopTracer()
 	db := &databasePkg.StubDatabase{}
 	chains := types.Chains{{
@@ -36,10 +49,12 @@ func TestGeneratorProposalsError(t *testing.T) {
 		Wallets: []*types.Wallet{{Address: "address"}},
 	}}
 	generator := &Generator{
-		Logger:   *logger,
-		Chains:   chains,
-		Database: db,
-		Tracer:   tracer,
+		Logger:         *logger,
+		Chains:         chains,
+		Database:       db,
+		Tracer:         tracer,
+		MetricsManager: metricsManager,
+		SpamManager:    spam.NewManager(logger, types.SpamConfig{}, db),
 		Fetchers: map[string]fetchersPkg.Fetcher{
 			"chain": &fetchersPkg.TestFetcher{WithProposalsError: true},
 		},
@@ -57,6 +72,7 @@ func TestGeneratorProposalsGetLastBlockHeightError(t *testing.T) {
 	t.Parallel()
 
 	logger := loggerPkg.GetNopLogger()
+	metricsManager := metrics.NewManager(logger, types.MetricsConfig{})
 	tracer := tracing.InitNoopTracer()
 	db := &databasePkg.StubDatabase{
 		LastHeightQueryErrors: map[string]map[string]error{
@@ -70,10 +86,12 @@ func TestGeneratorProposalsGetLastBlockHeightError(t *testing.T) {
 		Wallets: []*types.Wallet{{Address: "address"}},
 	}}
 	generator := &Generator{
-		Logger:   *logger,
-		Chains:   chains,
-		Database: db,
-		Tracer:   tracer,
+		Logger:         *logger,
+		Chains:         chains,
+		Database:       db,
+		Tracer:         tracer,
+		MetricsManager: metricsManager,
+		SpamManager:    spam.NewManager(logger, types.SpamConfig{}, db),
 		Fetchers: map[string]fetchersPkg.Fetcher{
 			"chain": &fetchersPkg.TestFetcher{},
 		},
@@ -92,6 +110,7 @@ func TestGeneratorProposalGetLastBlockHeightError(t *testing.T) {
 	t.Parallel()
 
 	logger := loggerPkg.GetNopLogger()
+	metricsManager := metrics.NewManager(logger, types.MetricsConfig{})
 	tracer := tracing.InitNoopTracer()
 	db := &databasePkg.StubDatabase{
 		GetProposalError:     errors.New("custom error"),
@@ -102,10 +121,12 @@ func TestGeneratorProposalGetLastBlockHeightError(t *testing.T) {
 		Wallets: []*types.Wallet{{Address: "address"}},
 	}}
 	generator := &Generator{
-		Logger:   *logger,
-		Chains:   chains,
-		Database: db,
-		Tracer:   tracer,
+		Logger:         *logger,
+		Chains:         chains,
+		Database:       db,
+		Tracer:         tracer,
+		MetricsManager: metricsManager,
+		SpamManager:    spam.NewManager(logger, types.SpamConfig{}, db),
 		Fetchers: map[string]fetchersPkg.Fetcher{
 			"chain": &fetchersPkg.TestFetcher{},
 		},
@@ -124,6 +145,7 @@ func TestGeneratorProposalNotInVoting(t *testing.T) {
 	t.Parallel()
 
 	logger := loggerPkg.GetNopLogger()
+	metricsManager := metrics.NewManager(logger, types.MetricsConfig{})
 	tracer := tracing.InitNoopTracer()
 	db := &databasePkg.StubDatabase{}
 	chains := types.Chains{{
@@ -131,10 +153,12 @@ func TestGeneratorProposalNotInVoting(t *testing.T) {
 		Wallets: []*types.Wallet{{Address: "address"}},
 	}}
 	generator := &Generator{
-		Logger:   *logger,
-		Chains:   chains,
-		Database: db,
-		Tracer:   tracer,
+		Logger:         *logger,
+		Chains:         chains,
+		Database:       db,
+		Tracer:         tracer,
+		MetricsManager: metricsManager,
+		SpamManager:    spam.NewManager(logger, types.SpamConfig{}, db),
 		Fetchers: map[string]fetchersPkg.Fetcher{
 			"chain": &fetchersPkg.TestFetcher{WithPassedProposals: true},
 		},
@@ -148,6 +172,7 @@ func TestGeneratorProposalFinishedVoting(t *testing.T) {
 	t.Parallel()
 
 	logger := loggerPkg.GetNopLogger()
+	metricsManager := metrics.NewManager(logger, types.MetricsConfig{})
 	tracer := tracing.InitNoopTracer()
 	db := &databasePkg.StubDatabase{
 		UpsertProposalError: errors.New("write error"),
@@ -162,26 +187,34 @@ func TestGeneratorProposalFinishedVoting(t *testing.T) {
 		Wallets: []*types.Wallet{{Address: "address"}},
 	}}
 	generator := &Generator{
-		Logger:   *logger,
-		Chains:   chains,
-		Database: db,
-		Tracer:   tracer,
+		Logger:         *logger,
+		Chains:         chains,
+		Database:       db,
+		Tracer:         tracer,
+		MetricsManager: metricsManager,
+		SpamManager:    spam.NewManager(logger, types.SpamConfig{}, db),
 		Fetchers: map[string]fetchersPkg.Fetcher{
 			"chain": &fetchersPkg.TestFetcher{WithPassedProposals: true},
 		},
 	}
 
 	report := generator.GenerateReport(context.Background())
-	require.Len(t, report.Entries, 1)
+	require.Len(t, report.Entries, 2)
 	firstEntry, ok := report.Entries[0].(events.FinishedVotingEvent)
 	require.True(t, ok)
 	require.NotNil(t, firstEntry)
+
+	secondEntry, ok := report.Entries[1].(events.ProposalStatusChangedEvent)
+	require.True(t, ok)
+	require.Equal(t, types.ProposalStatusVoting, secondEntry.OldStatus)
+	require.Equal(t, types.ProposalStatusPassed, secondEntry.Proposal.Status)
 }
 
 func TestGeneratorProposalVoteLastHeightQueryError(t *testing.T) {
 	t.Parallel()
 
 	logger := loggerPkg.GetNopLogger()
+	metricsManager := metrics.NewManager(logger, types.MetricsConfig{})
 	tracer := tracing.InitNoopTracer()
 	db := &databasePkg.StubDatabase{
 		LastHeightQueryErrors: map[string]map[string]error{
@@ -195,19 +228,24 @@ func TestGeneratorProposalVoteLastHeightQueryError(t *testing.T) {
 		Wallets: []*types.Wallet{{Address: "address"}},
 	}}
 	generator := &Generator{
-		Logger:   *logger,
-		Chains:   chains,
-		Database: db,
-		Tracer:   tracer,
+		Logger:         *logger,
+		Chains:         chains,
+		Database:       db,
+		Tracer:         tracer,
+		MetricsManager: metricsManager,
+		SpamManager:    spam.NewManager(logger, types.SpamConfig{}, db),
 		Fetchers: map[string]fetchersPkg.Fetcher{
 			"chain": &fetchersPkg.TestFetcher{},
 		},
 	}
 
 	report := generator.GenerateReport(context.Background())
-	require.Len(t, report.Entries, 1)
+	require.Len(t, report.Entries, 2)
 
-	firstEntry, ok := report.Entries[0].(events.GenericErrorEvent)
+	_, ok := report.Entries[0].(events.ProposalEnteredVotingEvent)
+	require.True(t, ok)
+
+	firstEntry, ok := report.Entries[1].(events.GenericErrorEvent)
 	require.True(t, ok)
 	require.NotNil(t, firstEntry)
 	require.ErrorContains(t, firstEntry.Error, "custom error")
@@ -217,6 +255,7 @@ func TestGeneratorProposalVoteFetchVoteError(t *testing.T) {
 	t.Parallel()
 
 	logger := loggerPkg.GetNopLogger()
+	metricsManager := metrics.NewManager(logger, types.MetricsConfig{})
 	tracer := tracing.InitNoopTracer()
 	db := &databasePkg.StubDatabase{}
 	chains := types.Chains{{
@@ -224,19 +263,24 @@ func TestGeneratorProposalVoteFetchVoteError(t *testing.T) {
 		Wallets: []*types.Wallet{{Address: "address"}},
 	}}
 	generator := &Generator{
-		Logger:   *logger,
-		Chains:   chains,
-		Database: db,
-		Tracer:   tracer,
+		Logger:         *logger,
+		Chains:         chains,
+		Database:       db,
+		Tracer:         tracer,
+		MetricsManager: metricsManager,
+		SpamManager:    spam.NewManager(logger, types.SpamConfig{}, db),
 		Fetchers: map[string]fetchersPkg.Fetcher{
 			"chain": &fetchersPkg.TestFetcher{WithVoteError: true},
 		},
 	}
 
 	report := generator.GenerateReport(context.Background())
-	require.Len(t, report.Entries, 1)
+	require.Len(t, report.Entries, 2)
 
-	firstEntry, ok := report.Entries[0].(events.VoteQueryError)
+	_, ok := report.Entries[0].(events.ProposalEnteredVotingEvent)
+	require.True(t, ok)
+
+	firstEntry, ok := report.Entries[1].(events.VoteQueryError)
 	require.True(t, ok)
 	require.NotNil(t, firstEntry)
 	require.ErrorContains(t, firstEntry.Error, "vote query error")
@@ -246,6 +290,7 @@ func TestGeneratorProposalVoteNotVoted(t *testing.T) {
 	t.Parallel()
 
 	logger := loggerPkg.GetNopLogger()
+	metricsManager := metrics.NewManager(logger, types.MetricsConfig{})
 	tracer := tracing.InitNoopTracer()
 	db := &databasePkg.StubDatabase{}
 	chains := types.Chains{{
@@ -253,19 +298,24 @@ func TestGeneratorProposalVoteNotVoted(t *testing.T) {
 		Wallets: []*types.Wallet{{Address: "address"}},
 	}}
 	generator := &Generator{
-		Logger:   *logger,
-		Chains:   chains,
-		Database: db,
-		Tracer:   tracer,
+		Logger:         *logger,
+		Chains:         chains,
+		Database:       db,
+		Tracer:         tracer,
+		MetricsManager: metricsManager,
+		SpamManager:    spam.NewManager(logger, types.SpamConfig{}, db),
 		Fetchers: map[string]fetchersPkg.Fetcher{
 			"chain": &fetchersPkg.TestFetcher{},
 		},
 	}
 
 	report := generator.GenerateReport(context.Background())
-	require.Len(t, report.Entries, 1)
+	require.Len(t, report.Entries, 2)
 
-	firstEntry, ok := report.Entries[0].(events.NotVotedEvent)
+	_, ok := report.Entries[0].(events.ProposalEnteredVotingEvent)
+	require.True(t, ok)
+
+	firstEntry, ok := report.Entries[1].(events.NotVotedEvent)
 	require.True(t, ok)
 	require.NotNil(t, firstEntry)
 }
@@ -274,6 +324,7 @@ func TestGeneratorProposalVoteGetVoteError(t *testing.T) {
 	t.Parallel()
 
 	logger := loggerPkg.GetNopLogger()
+	metricsManager := metrics.NewManager(logger, types.MetricsConfig{})
 	tracer := tracing.InitNoopTracer()
 	db := &databasePkg.StubDatabase{
 		GetVoteError: errors.New("get vote error"),
@@ -283,19 +334,24 @@ func TestGeneratorProposalVoteGetVoteError(t *testing.T) {
 		Wallets: []*types.Wallet{{Address: "address"}},
 	}}
 	generator := &Generator{
-		Logger:   *logger,
-		Chains:   chains,
-		Database: db,
-		Tracer:   tracer,
+		Logger:         *logger,
+		Chains:         chains,
+		Database:       db,
+		Tracer:         tracer,
+		MetricsManager: metricsManager,
+		SpamManager:    spam.NewManager(logger, types.SpamConfig{}, db),
 		Fetchers: map[string]fetchersPkg.Fetcher{
 			"chain": &fetchersPkg.TestFetcher{WithVote: true},
 		},
 	}
 
 	report := generator.GenerateReport(context.Background())
-	require.Len(t, report.Entries, 1)
+	require.Len(t, report.Entries, 2)
 
-	firstEntry, ok := report.Entries[0].(events.GenericErrorEvent)
+	_, ok := report.Entries[0].(events.ProposalEnteredVotingEvent)
+	require.True(t, ok)
+
+	firstEntry, ok := report.Entries[1].(events.GenericErrorEvent)
 	require.True(t, ok)
 	require.NotNil(t, firstEntry)
 	require.ErrorContains(t, firstEntry.Error, "get vote error")
@@ -305,6 +361,7 @@ func TestGeneratorProposalVoteVoted(t *testing.T) {
 	t.Parallel()
 
 	logger := loggerPkg.GetNopLogger()
+	metricsManager := metrics.NewManager(logger, types.MetricsConfig{})
 	tracer := tracing.InitNoopTracer()
 	db := &databasePkg.StubDatabase{
 		UpsertVoteError: errors.New("write error"),
@@ -314,19 +371,24 @@ func TestGeneratorProposalVoteVoted(t *testing.T) {
 		Wallets: []*types.Wallet{{Address: "address"}},
 	}}
 	generator := &Generator{
-		Logger:   *logger,
-		Chains:   chains,
-		Database: db,
-		Tracer:   tracer,
+		Logger:         *logger,
+		Chains:         chains,
+		Database:       db,
+		Tracer:         tracer,
+		MetricsManager: metricsManager,
+		SpamManager:    spam.NewManager(logger, types.SpamConfig{}, db),
 		Fetchers: map[string]fetchersPkg.Fetcher{
 			"chain": &fetchersPkg.TestFetcher{WithVote: true},
 		},
 	}
 
 	report := generator.GenerateReport(context.Background())
-	require.Len(t, report.Entries, 1)
+	require.Len(t, report.Entries, 2)
 
-	firstEntry, ok := report.Entries[0].(events.VotedEvent)
+	_, ok := report.Entries[0].(events.ProposalEnteredVotingEvent)
+	require.True(t, ok)
+
+	firstEntry, ok := report.Entries[1].(events.VotedEvent)
 	require.True(t, ok)
 	require.NotNil(t, firstEntry)
 }
@@ -335,6 +397,7 @@ func TestGeneratorProposalVoteRevoted(t *testing.T) {
 	t.Parallel()
 
 	logger := loggerPkg.GetNopLogger()
+	metricsManager := metrics.NewManager(logger, types.MetricsConfig{})
 	tracer := tracing.InitNoopTracer()
 	db := &databasePkg.StubDatabase{
 		Votes: map[string]map[string]map[string]*types.Vote{
@@ -354,19 +417,24 @@ func TestGeneratorProposalVoteRevoted(t *testing.T) {
 		Wallets: []*types.Wallet{{Address: "address"}},
 	}}
 	generator := &Generator{
-		Logger:   *logger,
-		Chains:   chains,
-		Database: db,
-		Tracer:   tracer,
+		Logger:         *logger,
+		Chains:         chains,
+		Database:       db,
+		Tracer:         tracer,
+		MetricsManager: metricsManager,
+		SpamManager:    spam.NewManager(logger, types.SpamConfig{}, db),
 		Fetchers: map[string]fetchersPkg.Fetcher{
 			"chain": &fetchersPkg.TestFetcher{WithVote: true},
 		},
 	}
 
 	report := generator.GenerateReport(context.Background())
-	require.Len(t, report.Entries, 1)
+	require.Len(t, report.Entries, 2)
 
-	firstEntry, ok := report.Entries[0].(events.RevotedEvent)
+	_, ok := report.Entries[0].(events.ProposalEnteredVotingEvent)
+	require.True(t, ok)
+
+	firstEntry, ok := report.Entries[1].(events.RevotedEvent)
 	require.True(t, ok)
 	require.NotNil(t, firstEntry)
 }
@@ -375,6 +443,7 @@ func TestGeneratorProposalVoteAlreadyVoted(t *testing.T) {
 	t.Parallel()
 
 	logger := loggerPkg.GetNopLogger()
+	metricsManager := metrics.NewManager(logger, types.MetricsConfig{})
 	tracer := tracing.InitNoopTracer()
 	db := &databasePkg.StubDatabase{
 		LastHeightWriteError: errors.New("custom error"),
@@ -393,15 +462,324 @@ func TestGeneratorProposalVoteAlreadyVoted(t *testing.T) {
 		Wallets: []*types.Wallet{{Address: "address"}},
 	}}
 	generator := &Generator{
-		Logger:   *logger,
-		Chains:   chains,
-		Database: db,
-		Tracer:   tracer,
+		Logger:         *logger,
+		Chains:         chains,
+		Database:       db,
+		Tracer:         tracer,
+		MetricsManager: metricsManager,
+		SpamManager:    spam.NewManager(logger, types.SpamConfig{}, db),
 		Fetchers: map[string]fetchersPkg.Fetcher{
 			"chain": &fetchersPkg.TestFetcher{WithVote: true},
 		},
 	}
 
 	report := generator.GenerateReport(context.Background())
+	require.Len(t, report.Entries, 1)
+
+	_, ok := report.Entries[0].(events.ProposalEnteredVotingEvent)
+	require.True(t, ok)
+}
+
+func TestGeneratorSuspectedSpam(t *testing.T) {
+	t.Parallel()
+
+	logger := loggerPkg.GetNopLogger()
+	metricsManager := metrics.NewManager(logger, types.MetricsConfig{})
+	tracer := tracing.InitNoopTracer()
+	db := &databasePkg.StubDatabase{}
+	chains := types.Chains{{
+		Name:    "chain",
+		Wallets: []*types.Wallet{{Address: "address"}},
+	}}
+	spamConfig := types.SpamConfig{
+		Enabled:        null.BoolFrom(true),
+		BlockedDomains: []string{"example.com"},
+	}
+	generator := &Generator{
+		Logger:         *logger,
+		Chains:         chains,
+		Database:       db,
+		Tracer:         tracer,
+		MetricsManager: metricsManager,
+		SpamManager:    spam.NewManager(logger, spamConfig, db),
+		Fetchers: map[string]fetchersPkg.Fetcher{
+			"chain": &fetchersPkg.TestFetcher{WithSpamProposals: true},
+		},
+	}
+
+	// reported once, and the not voted alert is suppressed
+	report := generator.GenerateReport(context.Background())
+	require.Len(t, report.Entries, 2)
+
+	spamEntry, ok := report.Entries[1].(events.SuspectedSpamEvent)
+	require.True(t, ok)
+	require.Equal(t, []string{"links to blocked domain 'example.com'"}, spamEntry.Reasons)
+
+	report = generator.GenerateReport(context.Background())
 	require.Empty(t, report.Entries)
+
+	// not voted alerts are sent again once un-flagged
+	found, err := generator.SpamManager.Unflag("chain", "1", "user")
+	require.NoError(t, err)
+	require.True(t, found)
+
+	report = generator.GenerateReport(context.Background())
+	require.Len(t, report.Entries, 1)
+
+	_, ok = report.Entries[0].(events.NotVotedEvent)
+	require.True(t, ok)
+}
+
+func TestGeneratorSuspectedSpamCheckError(t *testing.T) {
+	t.Parallel()
+
+	logger := loggerPkg.GetNopLogger()
+	metricsManager := metrics.NewManager(logger, types.MetricsConfig{})
+	tracer := tracing.InitNoopTracer()
+	db := &databasePkg.StubDatabase{GetSpamFlagError: errors.New("custom error")}
+	chains := types.Chains{{
+		Name:    "chain",
+		Wallets: []*types.Wallet{{Address: "address"}},
+	}}
+	spamConfig := types.SpamConfig{
+		Enabled:  null.BoolFrom(true),
+		Keywords: []string{"airdrop"},
+	}
+	generator := &Generator{
+		Logger:         *logger,
+		Chains:         chains,
+		Database:       db,
+		Tracer:         tracer,
+		MetricsManager: metricsManager,
+		SpamManager:    spam.NewManager(logger, spamConfig, db),
+		Fetchers: map[string]fetchersPkg.Fetcher{
+			"chain": &fetchersPkg.TestFetcher{WithSpamProposals: true},
+		},
+	}
+
+	// failing to check for spam should not suppress the alerts
+	report := generator.GenerateReport(context.Background())
+	require.Len(t, report.Entries, 2)
+
+	_, ok := report.Entries[1].(events.NotVotedEvent)
+	require.True(t, ok)
+}
+
+func TestGeneratorProposalEnteredVotingFromDeposit(t *testing.T) {
+	t.Parallel()
+
+	logger := loggerPkg.GetNopLogger()
+	metricsManager := metrics.NewManager(logger, types.MetricsConfig{})
+	tracer := tracing.InitNoopTracer()
+	db := &databasePkg.StubDatabase{
+		Proposals: map[string]map[string]*types.Proposal{
+			"chain": {
+				"1": &types.Proposal{ID: "1", Status: types.ProposalStatusDeposit},
+			},
+		},
+		Votes: map[string]map[string]map[string]*types.Vote{
+			"chain": {
+				"1": {
+					"address": &types.Vote{
+						Options: types.VoteOptions{},
+					},
+				},
+			},
+		},
+	}
+	wallets := []*types.Wallet{{Address: "address"}}
+	chains := types.Chains{{
+		Name:    "chain",
+		Wallets: wallets,
+	}}
+	generator := &Generator{
+		Logger:         *logger,
+		Chains:         chains,
+		Database:       db,
+		Tracer:         tracer,
+		MetricsManager: metricsManager,
+		SpamManager:    spam.NewManager(logger, types.SpamConfig{}, db),
+		Fetchers: map[string]fetchersPkg.Fetcher{
+			"chain": &fetchersPkg.TestFetcher{WithVote: true},
+		},
+	}
+
+	report := generator.GenerateReport(context.Background())
+	require.Len(t, report.Entries, 2)
+
+	statusChangedEntry, ok := report.Entries[0].(events.ProposalStatusChangedEvent)
+	require.True(t, ok)
+	require.Equal(t, types.ProposalStatusDeposit, statusChangedEntry.OldStatus)
+	require.True(t, statusChangedEntry.IsStatusChanged())
+	require.False(t, statusChangedEntry.IsNoLongerExpedited())
+
+	enteredVotingEntry, ok := report.Entries[1].(events.ProposalEnteredVotingEvent)
+	require.True(t, ok)
+	require.Equal(t, wallets, enteredVotingEntry.Wallets)
+
+	// announced only once
+	report = generator.GenerateReport(context.Background())
+	require.Empty(t, report.Entries)
+}
+
+func TestGeneratorProposalNoLongerExpedited(t *testing.T) {
+	t.Parallel()
+
+	logger := loggerPkg.GetNopLogger()
+	metricsManager := metrics.NewManager(logger, types.MetricsConfig{})
+	tracer := tracing.InitNoopTracer()
+	db := &databasePkg.StubDatabase{
+		Proposals: map[string]map[string]*types.Proposal{
+			"chain": {
+				"1": &types.Proposal{ID: "1", Status: types.ProposalStatusVoting, Expedited: true},
+			},
+		},
+		Votes: map[string]map[string]map[string]*types.Vote{
+			"chain": {
+				"1": {
+					"address": &types.Vote{
+						Options: types.VoteOptions{},
+					},
+				},
+			},
+		},
+	}
+	chains := types.Chains{{
+		Name:    "chain",
+		Wallets: []*types.Wallet{{Address: "address"}},
+	}}
+	generator := &Generator{
+		Logger:         *logger,
+		Chains:         chains,
+		Database:       db,
+		Tracer:         tracer,
+		MetricsManager: metricsManager,
+		SpamManager:    spam.NewManager(logger, types.SpamConfig{}, db),
+		Fetchers: map[string]fetchersPkg.Fetcher{
+			"chain": &fetchersPkg.TestFetcher{WithVote: true},
+		},
+	}
+
+	report := generator.GenerateReport(context.Background())
+	require.Len(t, report.Entries, 1)
+
+	statusChangedEntry, ok := report.Entries[0].(events.ProposalStatusChangedEvent)
+	require.True(t, ok)
+	require.False(t, statusChangedEntry.IsStatusChanged())
+	require.True(t, statusChangedEntry.IsNoLongerExpedited())
+}
+
+func TestGeneratorProposalTransitions(t *testing.T) {
+	t.Parallel()
+
+	chain := &types.Chain{Name: "chain"}
+	voting := types.Proposal{ID: "1", Status: types.ProposalStatusVoting}
+	passed := types.Proposal{ID: "1", Status: types.ProposalStatusPassed}
+	failed := types.Proposal{ID: "1", Status: types.ProposalStatusFailed}
+
+	tests := []struct {
+		name     string
+		previous *types.Proposal
+		current  types.Proposal
+		expected []entry.ReportEntry
+	}{
+		{
+			name:     "new proposal in voting",
+			previous: nil,
+			current:  voting,
+			expected: []entry.ReportEntry{
+				events.ProposalEnteredVotingEvent{Chain: chain, Proposal: voting},
+			},
+		},
+		{
+			name:     "new proposal in deposit",
+			previous: nil,
+			current:  types.Proposal{ID: "1", Status: types.ProposalStatusDeposit},
+			expected: []entry.ReportEntry{},
+		},
+		{
+			name:     "deposit to voting",
+			previous: &types.Proposal{ID: "1", Status: types.ProposalStatusDeposit},
+			current:  voting,
+			expected: []entry.ReportEntry{
+				events.ProposalStatusChangedEvent{
+					Chain:     chain,
+					Proposal:  voting,
+					OldStatus: types.ProposalStatusDeposit,
+				},
+				events.ProposalEnteredVotingEvent{Chain: chain, Proposal: voting},
+			},
+		},
+		{
+			name:     "voting to passed",
+			previous: &types.Proposal{ID: "1", Status: types.ProposalStatusVoting},
+			current:  passed,
+			expected: []entry.ReportEntry{
+				events.FinishedVotingEvent{Chain: chain, Proposal: passed},
+				events.ProposalStatusChangedEvent{
+					Chain:     chain,
+					Proposal:  passed,
+					OldStatus: types.ProposalStatusVoting,
+				},
+			},
+		},
+		{
+			name:     "deposit to failed",
+			previous: &types.Proposal{ID: "1", Status: types.ProposalStatusDeposit},
+			current:  failed,
+			expected: []entry.ReportEntry{
+				events.ProposalStatusChangedEvent{
+					Chain:     chain,
+					Proposal:  failed,
+					OldStatus: types.ProposalStatusDeposit,
+				},
+			},
+		},
+		{
+			name:     "expedited to regular",
+			previous: &types.Proposal{ID: "1", Status: types.ProposalStatusVoting, Expedited: true},
+			current:  voting,
+			expected: []entry.ReportEntry{
+				events.ProposalStatusChangedEvent{
+					Chain:          chain,
+					Proposal:       voting,
+					OldStatus:      types.ProposalStatusVoting,
+					OldIsExpedited: true,
+				},
+			},
+		},
+		{
+			name:     "not changed",
+			previous: &types.Proposal{ID: "1", Status: types.ProposalStatusVoting},
+			current:  voting,
+			expected: []entry.ReportEntry{},
+		},
+	}
+
+	for _, testCase := range tests {
+		tc := testCase
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			logger := loggerPkg.GetNopLogger()
+			db := &databasePkg.StubDatabase{}
+			if tc.previous != nil {
+				db.Proposals = map[string]map[string]*types.Proposal{"chain": {"1": tc.previous}}
+			}
+
+			generator := &Generator{
+				Logger:         *logger,
+				Chains:         types.Chains{chain},
+				Database:       db,
+				Tracer:         tracing.InitNoopTracer(),
+				MetricsManager: metrics.NewManager(logger, types.MetricsConfig{}),
+				SpamManager:    spam.NewManager(logger, types.SpamConfig{}, db),
+			}
+
+			entries := generator.ProcessProposal(chain, tc.current, []types.Amount{}, context.Background())
+			require.Equal(t, tc.expected, entries)
+		})
+	}
+
 }
@@ -0,0 +1,165 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"main/pkg/events"
+	"main/pkg/report/entry"
+	reportersPkg "main/pkg/reporters"
+	"main/pkg/templates"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+const (
+	PrintFormatText = "text"
+	PrintFormatJSON = "json"
+)
+
+type PrintedEntry struct {
+	Type          string     `json:"type"`
+	IsAlert       bool       `json:"is_alert"`
+	Chain         string     `json:"chain,omitempty"`
+	ProposalID    string     `json:"proposal_id,omitempty"`
+	ProposalTitle string     `json:"proposal_title,omitempty"`
+	VotingEndTime *time.Time `json:"voting_end_time,omitempty"`
+	Wallet        string     `json:"wallet,omitempty"`
+	Vote          string     `json:"vote,omitempty"`
+	OldVote       string     `json:"old_vote,omitempty"`
+	Error         string     `json:"error,omitempty"`
+	Text          string     `json:"text"`
+}
+
+type PrintedReport struct {
+	Entries []PrintedEntry `json:"entries"`
+}
+
+type Printer struct {
+	TemplatesManager templates.Manager
+	Writer           io.Writer
+}
+
+func NewPrinter(
+	logger *zerolog.Logger,
+	timezone *time.Location,
+	writer io.Writer,
+) *Printer {
+	return &Printer{
+		TemplatesManager: templates.NewTextTemplatesManager(logger, timezone),
+		Writer:           writer,
+	}
+}
+
+func ValidatePrintFormat(format string) error {
+	if format != PrintFormatText && format != PrintFormatJSON {
+		return fmt.Errorf(
+			"expected format to be one of '%s', '%s', but got '%s'",
+			PrintFormatText,
+			PrintFormatJSON,
+			format,
+		)
+	}
+
+	return nil
+}
+
+func (p *Printer) Print(report reportersPkg.Report, format string) error {
+	if err := ValidatePrintFormat(format); err != nil {
+		return err
+	}
+
+	printedEntries := make([]PrintedEntry, len(report.Entries))
+
+	for index, reportEntry := range report.Entries {
+		printedEntry, err := p.SerializeEntry(reportEntry)
+		if err != nil {
+			return err
+		}
+
+		printedEntries[index] = printedEntry
+	}
+
+	if format == PrintFormatJSON {
+		encoder := json.NewEncoder(p.Writer)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(PrintedReport{Entries: printedEntries})
+	}
+
+	for _, printedEntry := range printedEntries {
+		if _, err := fmt.Fprintln(p.Writer, printedEntry.Text); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (p *Printer) SerializeEntry(reportEntry entry.ReportEntry) (PrintedEntry, error) {
+	text, err := p.TemplatesManager.Render(reportEntry.Name(), reportEntry)
+	if err != nil {
+		return PrintedEntry{}, err
+	}
+
+	printedEntry := PrintedEntry{
+		Type:    reportEntry.Name(),
+		IsAlert: reportEntry.IsAlert(),
+		Text:    text,
+	}
+
+	if entryNotError, ok := reportEntry.(entry.ReportEntryNotError); ok {
+		proposal := entryNotError.GetProposal()
+		printedEntry.Chain = entryNotError.GetChain().Name
+		printedEntry.ProposalID = proposal.ID
+		printedEntry.ProposalTitle = proposal.Title
+		printedEntry.VotingEndTime = &proposal.EndTime
+
+		if wallet := entryNotError.GetWallet(); wallet != nil {
+			printedEntry.Wallet = wallet.Address
+		}
+	}
+
+	switch typedEntry := reportEntry.(type) {
+	case events.FinishedVotingEvent:
+		printedEntry.Chain = typedEntry.Chain.Name
+		printedEntry.ProposalID = typedEntry.Proposal.ID
+		printedEntry.ProposalTitle = typedEntry.Proposal.Title
+		printedEntry.VotingEndTime = &typedEntry.Proposal.EndTime
+	case events.ProposalEnteredVotingEvent:
+		printedEntry.Chain = typedEntry.Chain.Name
+		printedEntry.ProposalID = typedEntry.Proposal.ID
+		printedEntry.ProposalTitle = typedEntry.Proposal.Title
+		printedEntry.VotingEndTime = &typedEntry.Proposal.EndTime
+	case events.ProposalStatusChangedEvent:
+		printedEntry.Chain = typedEntry.Chain.Name
+		printedEntry.ProposalID = typedEntry.Proposal.ID
+		printedEntry.ProposalTitle = typedEntry.Proposal.Title
+		printedEntry.VotingEndTime = &typedEntry.Proposal.EndTime
+	case events.SuspectedSpamEvent:
+		printedEntry.Chain = typedEntry.Chain.Name
+		printedEntry.ProposalID = typedEntry.Proposal.ID
+		printedEntry.ProposalTitle = typedEntry.Proposal.Title
+		printedEntry.VotingEndTime = &typedEntry.Proposal.EndTime
+	case events.VotedEvent:
+		printedEntry.Vote = typedEntry.Vote.ResolveVote()
+	case events.RevotedEvent:
+		printedEntry.Vote = typedEntry.Vote.ResolveVote()
+		printedEntry.OldVote = typedEntry.OldVote.ResolveVote()
+	case events.GenericErrorEvent:
+		if typedEntry.Chain != nil {
+			printedEntry.Chain = typedEntry.Chain.Name
+		}
+		printedEntry.Error = typedEntry.Error.Error()
+	case events.ProposalsQueryErrorEvent:
+		printedEntry.Chain = typedEntry.Chain.Name
+		printedEntry.Error = typedEntry.Error.Error()
+	case events.VoteQueryError:
+		printedEntry.Chain = typedEntry.Chain.Name
+		printedEntry.ProposalID = typedEntry.Proposal.ID
+		printedEntry.ProposalTitle = typedEntry.Proposal.Title
+		printedEntry.Error = typedEntry.Error.Error()
+	}
+
+	return printedEntry, nil
+}
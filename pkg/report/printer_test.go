@@ -0,0 +1,139 @@
+package report
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"main/pkg/events"
+	"main/pkg/logger"
+	"main/pkg/report/entry"
+	reportersPkg "main/pkg/reporters"
+	"main/pkg/types"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidatePrintFormat(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, ValidatePrintFormat("text"))
+	require.NoError(t, ValidatePrintFormat("json"))
+	require.Error(t, ValidatePrintFormat("xml"))
+}
+
+func TestPrinterPrintInvalidFormat(t *testing.T) {
+	t.Parallel()
+
+	var buffer bytes.Buffer
+
+	printer := NewPrinter(logger.GetNopLogger(), time.UTC, &buffer)
+	err := printer.Print(reportersPkg.Report{}, "xml")
+	require.Error(t, err)
+	assert.Empty(t, buffer.String())
+}
+
+func TestPrinterPrintTemplateError(t *testing.T) {
+	t.Parallel()
+
+	var buffer bytes.Buffer
+
+	printer := NewPrinter(logger.GetNopLogger(), time.UTC, &buffer)
+	err := printer.Print(reportersPkg.Report{Entries: []entry.ReportEntry{
+		events.NotExistingEvent{},
+	}}, "text")
+	require.Error(t, err)
+	assert.Empty(t, buffer.String())
+}
+
+func TestPrinterPrintText(t *testing.T) {
+	t.Parallel()
+
+	var buffer bytes.Buffer
+
+	printer := NewPrinter(logger.GetNopLogger(), time.UTC, &buffer)
+	err := printer.Print(reportersPkg.Report{Entries: []entry.ReportEntry{
+		events.ProposalsQueryErrorEvent{
+			Chain: &types.Chain{Name: "chain"},
+			Error: &types.QueryError{QueryError: errors.New("custom error")},
+		},
+		events.FinishedVotingEvent{
+			Chain:    &types.Chain{Name: "chain"},
+			Proposal: types.Proposal{ID: "1", Title: "Title", Status: types.ProposalStatusPassed},
+		},
+	}}, "text")
+	require.NoError(t, err)
+	assert.Equal(
+		t,
+		"There was an error querying proposals on chain: custom error\n\n"+
+			"Voting on proposal 1 on chain is finished\nTitle\nVoting status: 🙌 Passed\n\n",
+		buffer.String(),
+	)
+}
+
+func TestPrinterPrintJSON(t *testing.T) {
+	t.Parallel()
+
+	var buffer bytes.Buffer
+
+	endTime := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	chain := &types.Chain{Name: "chain"}
+	proposal := types.Proposal{ID: "1", Title: "Title", EndTime: endTime}
+	wallet := &types.Wallet{Address: "wallet"}
+
+	printer := NewPrinter(logger.GetNopLogger(), time.UTC, &buffer)
+	err := printer.Print(reportersPkg.Report{Entries: []entry.ReportEntry{
+		events.NotVotedEvent{Chain: chain, Proposal: proposal, Wallet: wallet, RenderTime: endTime},
+		events.VotedEvent{
+			Chain:      chain,
+			Proposal:   proposal,
+			Wallet:     wallet,
+			RenderTime: endTime,
+			Vote:       &types.Vote{Options: types.VoteOptions{{Option: "Yes", Weight: 1}}},
+		},
+		events.RevotedEvent{
+			Chain:      chain,
+			Proposal:   proposal,
+			Wallet:     wallet,
+			RenderTime: endTime,
+			Vote:       &types.Vote{Options: types.VoteOptions{{Option: "Yes", Weight: 1}}},
+			OldVote:    &types.Vote{Options: types.VoteOptions{{Option: "No", Weight: 1}}},
+		},
+		events.GenericErrorEvent{Error: errors.New("generic error")},
+		events.VoteQueryError{
+			Chain:    chain,
+			Proposal: proposal,
+			Error:    &types.QueryError{QueryError: errors.New("vote error")},
+		},
+	}}, "json")
+	require.NoError(t, err)
+
+	var printedReport PrintedReport
+	require.NoError(t, json.Unmarshal(buffer.Bytes(), &printedReport))
+	require.Len(t, printedReport.Entries, 5)
+
+	notVoted := printedReport.Entries[0]
+	assert.Equal(t, "not_voted", notVoted.Type)
+	assert.True(t, notVoted.IsAlert)
+	assert.Equal(t, "chain", notVoted.Chain)
+	assert.Equal(t, "1", notVoted.ProposalID)
+	assert.Equal(t, "Title", notVoted.ProposalTitle)
+	assert.Equal(t, "wallet", notVoted.Wallet)
+	require.NotNil(t, notVoted.VotingEndTime)
+	assert.True(t, endTime.Equal(*notVoted.VotingEndTime))
+	assert.NotEmpty(t, notVoted.Text)
+
+	assert.Equal(t, "Yes", printedReport.Entries[1].Vote)
+	assert.Equal(t, "Yes", printedReport.Entries[2].Vote)
+	assert.Equal(t, "No", printedReport.Entries[2].OldVote)
+
+	assert.Equal(t, "generic_error", printedReport.Entries[3].Type)
+	assert.Empty(t, printedReport.Entries[3].Chain)
+	assert.Equal(t, "generic error", printedReport.Entries[3].Error)
+
+	assert.Equal(t, "chain", printedReport.Entries[4].Chain)
+	assert.Equal(t, "1", printedReport.Entries[4].ProposalID)
+	assert.Equal(t, "vote error", printedReport.Entries[4].Error)
+}
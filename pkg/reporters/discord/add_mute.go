@@ -3,7 +3,6 @@ package discord
 import (
 	"fmt"
 	"main/pkg/types"
-	"time"
 
 	"github.com/guregu/null/v5"
 
@@ -12,6 +11,7 @@ import (
 
 func (reporter *Reporter) GetAddMuteCommand() *Command {
 	return &Command{
+		AccessLevel: types.AccessLevelWrite,
 		Info: &discordgo.ApplicationCommand{
 			Name:        "proposals_mute",
 			Description: "Mute proposals' notifications",
@@ -19,7 +19,7 @@ func (reporter *Reporter) GetAddMuteCommand() *Command {
 				{
 					Type:        discordgo.ApplicationCommandOptionString,
 					Name:        "duration",
-					Description: "For how long to mute notifications",
+					Description: "For how long to mute notifications, or \"until-end\" to mute until the voting ends",
 					Required:    true,
 				},
 				{
@@ -34,6 +34,25 @@ func (reporter *Reporter) GetAddMuteCommand() *Command {
 					Description: "Proposal to mute notifications on",
 					Required:    false,
 				},
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "wallet",
+					Description: "Wallet address or alias to mute notifications on",
+					Required:    false,
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "event",
+					Description: "Event type to mute notifications on",
+					Required:    false,
+					Choices:     GetMuteEventChoices(),
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "title",
+					Description: "Regular expression on the proposal title to mute notifications on",
+					Required:    false,
+				},
 			},
 		},
 		Handler: func(s *discordgo.Session, i *discordgo.InteractionCreate) {
@@ -42,6 +61,9 @@ func (reporter *Reporter) GetAddMuteCommand() *Command {
 			durationString, _ := options[0].Value.(string)
 			chain := null.NewString("", false)
 			proposal := null.NewString("", false)
+			wallet := null.NewString("", false)
+			event := null.NewString("", false)
+			titlePattern := null.NewString("", false)
 
 			_, opts := options[0], options[1:]
 
@@ -54,27 +76,56 @@ func (reporter *Reporter) GetAddMuteCommand() *Command {
 					proposalRaw, _ := opt.Value.(string)
 					proposal = null.StringFrom(proposalRaw)
 				}
+				if opt.Name == "wallet" {
+					walletRaw, _ := opt.Value.(string)
+					wallet = null.StringFrom(walletRaw)
+				}
+				if opt.Name == "event" {
+					eventRaw, _ := opt.Value.(string)
+					event = null.StringFrom(eventRaw)
+				}
+				if opt.Name == "title" {
+					titleRaw, _ := opt.Value.(string)
+					titlePattern = null.StringFrom(titleRaw)
+				}
+			}
+
+			if titlePattern.Valid {
+				if err := types.ValidateMuteTitlePattern(titlePattern.String); err != nil {
+					reporter.BotRespond(s, i, "Invalid title pattern provided: "+err.Error())
+					return
+				}
 			}
 
-			duration, err := time.ParseDuration(durationString)
+			expires, durationText, err := types.ParseMuteExpiry(durationString)
 			if err != nil {
-				reporter.BotRespond(s, i, "Invalid mute duration provided: %s")
+				reporter.BotRespond(s, i, "Invalid mute duration provided: "+durationString)
 				return
 			}
 
 			mute := &types.Mute{
-				Chain:      chain,
-				ProposalID: proposal,
-				Expires:    time.Now().Add(duration),
+				Chain:        chain,
+				ProposalID:   proposal,
+				Wallet:       wallet,
+				Event:        event,
+				TitlePattern: titlePattern,
+				Expires:      expires,
 				Comment: fmt.Sprintf(
-					"Muted using cosmos-proposals-checker for %s",
-					duration,
+					"Muted using cosmos-proposals-checker %s by %s",
+					durationText,
+					GetUser(i).Username,
 				),
+				Author: null.StringFrom(GetUser(i).Username),
+				Source: null.StringFrom(types.MuteSourceDiscord),
+			}
+
+			if destination := reporter.GetMuteDestination(i); destination != "" {
+				mute.Destination = null.StringFrom(destination)
 			}
 
 			if insertErr := reporter.MutesManager.AddMute(mute); insertErr != nil {
-				reporter.Logger.Error().Err(err).Msg("Error adding mute")
-				reporter.BotRespond(s, i, "Error adding mute!")
+				reporter.Logger.Error().Err(insertErr).Msg("Error adding mute")
+				reporter.BotRespond(s, i, "Error adding mute: "+insertErr.Error())
 				return
 			}
 
@@ -88,3 +139,17 @@ func (reporter *Reporter) GetAddMuteCommand() *Command {
 		},
 	}
 }
+
+// GetMuteEventChoices returns the event types that can be muted, so Discord
+// only allows to choose one of them.
+func GetMuteEventChoices() []*discordgo.ApplicationCommandOptionChoice {
+	choices := make([]*discordgo.ApplicationCommandOptionChoice, len(types.RouteEvents))
+	for index, event := range types.RouteEvents {
+		choices[index] = &discordgo.ApplicationCommandOptionChoice{
+			Name:  event,
+			Value: event,
+		}
+	}
+
+	return choices
+}
@@ -0,0 +1,111 @@
+package discord
+
+import (
+	"main/pkg/events"
+	"main/pkg/report/entry"
+	"main/pkg/types"
+	"main/pkg/utils"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/guregu/null/v5"
+)
+
+// EditAlertMessage edits the message sent before about the same wallet and proposal
+// to show the entry instead, returning false if there's no such message or it cannot be edited,
+// so a new one should be sent.
+func (reporter *Reporter) EditAlertMessage(
+	channel types.DiscordChannel,
+	reportEntry entry.ReportEntry,
+	text string,
+) bool {
+	alertEntry, ok := reportEntry.(entry.ReportEntryNotError)
+	if !ok {
+		return false
+	}
+
+	message, err := reporter.AlertsManager.Get(channel.Destination(), alertEntry)
+	if err != nil {
+		reporter.Logger.Warn().
+			Err(err).
+			Str("destination", channel.Destination()).
+			Msg("Error getting alert message, sending a new one")
+		return false
+	}
+
+	if message == nil {
+		return false
+	}
+
+	if _, editErr := reporter.DiscordSession.ChannelMessageEdit(
+		channel.ID,
+		message.MessageID,
+		text,
+	); editErr != nil {
+		// the message might have been deleted, so a new one is sent and stored instead
+		reporter.Logger.Warn().
+			Err(editErr).
+			Str("destination", channel.Destination()).
+			Str("message_id", message.MessageID).
+			Msg("Could not edit alert message, sending a new one")
+		return false
+	}
+
+	if saveErr := reporter.AlertsManager.Save(
+		channel.Destination(),
+		alertEntry,
+		message.MessageID,
+		message.Action,
+	); saveErr != nil {
+		reporter.Logger.Warn().
+			Err(saveErr).
+			Str("destination", channel.Destination()).
+			Msg("Error updating alert message")
+	}
+
+	return true
+}
+
+// SaveAlertMessage stores the message sent for the entry, so it's edited by the next alerts
+// about the same wallet and proposal.
+func (reporter *Reporter) SaveAlertMessage(
+	channel types.DiscordChannel,
+	reportEntry entry.ReportEntry,
+	message *discordgo.Message,
+) {
+	alertEntry, ok := reportEntry.(entry.ReportEntryNotError)
+	if !ok || message == nil {
+		return
+	}
+
+	if err := reporter.AlertsManager.Save(
+		channel.Destination(),
+		alertEntry,
+		message.ID,
+		null.String{},
+	); err != nil {
+		reporter.Logger.Warn().
+			Err(err).
+			Str("destination", channel.Destination()).
+			Msg("Error saving alert message, it won't be edited later")
+	}
+}
+
+// DeleteAlertMessages forgets the messages sent for a proposal that has finished voting.
+func (reporter *Reporter) DeleteAlertMessages(reportEntry entry.ReportEntry) {
+	chain, _, proposal := events.GetEntryLabels(reportEntry)
+	if chain == nil || proposal == nil {
+		return
+	}
+
+	destinations := utils.Map(reporter.Channels, func(channel types.DiscordChannel) string {
+		return channel.Destination()
+	})
+
+	if err := reporter.AlertsManager.DeleteForProposal(destinations, chain.Name, proposal.ID); err != nil {
+		reporter.Logger.Warn().
+			Err(err).
+			Str("chain", chain.Name).
+			Str("proposal", proposal.ID).
+			Msg("Error deleting alert messages")
+	}
+}
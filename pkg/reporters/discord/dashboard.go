@@ -0,0 +1,84 @@
+package discord
+
+import (
+	"context"
+	"errors"
+	statePkg "main/pkg/state"
+	"main/pkg/types"
+	"main/pkg/utils"
+	"strings"
+)
+
+func (reporter *Reporter) DashboardEnabled() bool {
+	return reporter.Config.DiscordConfig.Dashboard
+}
+
+func (reporter *Reporter) UpdateDashboard(state statePkg.RenderedState, ctx context.Context) error {
+	_, span := reporter.Tracer.Start(ctx, "Updating Discord dashboard")
+	defer span.End()
+
+	errs := make([]error, 0)
+
+	for _, channel := range reporter.Channels {
+		if err := reporter.UpdateChannelDashboard(channel, state.Filter(&channel.DestinationFilter)); err != nil {
+			reporter.Logger.Err(err).
+				Str("destination", channel.Destination()).
+				Msg("Could not update dashboard")
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// UpdateChannelDashboard edits the dashboard message in the channel, or sends and pins a new one
+// if there's none yet or it cannot be edited, for example, if it was deleted.
+func (reporter *Reporter) UpdateChannelDashboard(channel types.DiscordChannel, state statePkg.RenderedState) error {
+	rendered, err := reporter.TemplatesManager.Render("dashboard", state)
+	if err != nil {
+		return err
+	}
+
+	// the dashboard should be a single message to be edited later, so the rest is cut
+	chunks := utils.SplitStringIntoChunks(strings.TrimSpace(rendered), MaxMessageSize)
+	if len(chunks) > 1 {
+		reporter.Logger.Warn().
+			Str("destination", channel.Destination()).
+			Int("chunks", len(chunks)).
+			Msg("Dashboard does not fit into a single message, it's cut")
+	}
+
+	text := strings.TrimSpace(chunks[0])
+
+	message, err := reporter.DashboardManager.Get(channel.Destination())
+	if err != nil {
+		return err
+	}
+
+	if message != nil {
+		_, editErr := reporter.DiscordSession.ChannelMessageEdit(channel.ID, message.MessageID, text)
+		if editErr == nil {
+			return reporter.DashboardManager.Save(channel.Destination(), message.MessageID)
+		}
+
+		reporter.Logger.Warn().
+			Err(editErr).
+			Str("destination", channel.Destination()).
+			Str("message_id", message.MessageID).
+			Msg("Could not edit dashboard message, sending a new one")
+	}
+
+	sent, err := reporter.DiscordSession.ChannelMessageSend(channel.ID, text)
+	if err != nil {
+		return err
+	}
+
+	if pinErr := reporter.DiscordSession.ChannelMessagePin(channel.ID, sent.ID); pinErr != nil {
+		reporter.Logger.Warn().
+			Err(pinErr).
+			Str("destination", channel.Destination()).
+			Msg("Could not pin dashboard message, the bot should be allowed to manage messages")
+	}
+
+	return reporter.DashboardManager.Save(channel.Destination(), sent.ID)
+}
@@ -11,6 +11,7 @@ import (
 
 func (reporter *Reporter) GetDeleteMuteCommand() *Command {
 	return &Command{
+		AccessLevel: types.AccessLevelWrite,
 		Info: &discordgo.ApplicationCommand{
 			Name:        "proposals_unmute",
 			Description: "Unmute proposals' notifications",
@@ -27,6 +28,25 @@ func (reporter *Reporter) GetDeleteMuteCommand() *Command {
 					Description: "Proposal to mute notifications on",
 					Required:    false,
 				},
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "wallet",
+					Description: "Wallet address or alias to unmute notifications on",
+					Required:    false,
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "event",
+					Description: "Event type to unmute notifications on",
+					Required:    false,
+					Choices:     GetMuteEventChoices(),
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "title",
+					Description: "Regular expression on the proposal title to unmute notifications on",
+					Required:    false,
+				},
 			},
 		},
 		Handler: func(s *discordgo.Session, i *discordgo.InteractionCreate) {
@@ -34,6 +54,9 @@ func (reporter *Reporter) GetDeleteMuteCommand() *Command {
 
 			chain := null.NewString("", false)
 			proposal := null.NewString("", false)
+			wallet := null.NewString("", false)
+			event := null.NewString("", false)
+			titlePattern := null.NewString("", false)
 
 			for _, opt := range options {
 				if opt.Name == "chain" {
@@ -44,16 +67,38 @@ func (reporter *Reporter) GetDeleteMuteCommand() *Command {
 					proposalRaw, _ := opt.Value.(string)
 					proposal = null.StringFrom(proposalRaw)
 				}
+				if opt.Name == "wallet" {
+					walletRaw, _ := opt.Value.(string)
+					wallet = null.StringFrom(walletRaw)
+				}
+				if opt.Name == "event" {
+					eventRaw, _ := opt.Value.(string)
+					event = null.StringFrom(eventRaw)
+				}
+				if opt.Name == "title" {
+					titleRaw, _ := opt.Value.(string)
+					titlePattern = null.StringFrom(titleRaw)
+				}
 			}
 
 			mute := &types.Mute{
-				Chain:      chain,
-				ProposalID: proposal,
-				Expires:    time.Now(),
-				Comment:    "",
+				Chain:        chain,
+				ProposalID:   proposal,
+				Wallet:       wallet,
+				Event:        event,
+				TitlePattern: titlePattern,
+				Expires:      time.Now(),
+				Comment:      "",
+			}
+
+			if destination := reporter.GetMuteDestination(i); destination != "" {
+				mute.Destination = null.StringFrom(destination)
 			}
 
-			if found, insertErr := reporter.MutesManager.DeleteMute(mute); !found {
+			if found, insertErr := reporter.MutesManager.DeleteMute(
+				mute,
+				GetUser(i).Username,
+			); !found {
 				reporter.BotRespond(s, i, "Could not find the mute to delete!")
 				return
 			} else if insertErr != nil {
@@ -2,9 +2,16 @@ package discord
 
 import (
 	"context"
+	"main/pkg/alerts"
+	"main/pkg/dashboard"
 	"main/pkg/data"
+	"main/pkg/events"
 	mutes "main/pkg/mutes"
+	"main/pkg/outbox"
 	"main/pkg/report/entry"
+	reportersPkg "main/pkg/reporters"
+	"main/pkg/routes"
+	"main/pkg/spam"
 	statePkg "main/pkg/state"
 	templatesPkg "main/pkg/templates"
 	types "main/pkg/types"
@@ -18,10 +25,12 @@ import (
 	"github.com/rs/zerolog"
 )
 
+const MaxMessageSize = 2000
+
 type Reporter struct {
-	Token   string
-	Guild   string
-	Channel string
+	Token    string
+	Guild    string
+	Channels []types.DiscordChannel
 
 	Version string
 
@@ -30,6 +39,11 @@ type Reporter struct {
 	Logger           zerolog.Logger
 	Config           *types.Config
 	MutesManager     *mutes.Manager
+	RoutesManager    *routes.Manager
+	SpamManager      *spam.Manager
+	OutboxManager    *outbox.Manager
+	AlertsManager    *alerts.Manager
+	DashboardManager *dashboard.Manager
 	DataManager      *data.Manager
 	TemplatesManager templatesPkg.Manager
 	Commands         map[string]*Command
@@ -42,6 +56,11 @@ func NewReporter(
 	version string,
 	logger *zerolog.Logger,
 	mutesManager *mutes.Manager,
+	routesManager *routes.Manager,
+	spamManager *spam.Manager,
+	outboxManager *outbox.Manager,
+	alertsManager *alerts.Manager,
+	dashboardManager *dashboard.Manager,
 	dataManager *data.Manager,
 	stateGenerator *statePkg.Generator,
 	timezone *time.Location,
@@ -50,10 +69,15 @@ func NewReporter(
 	return &Reporter{
 		Token:            config.DiscordConfig.Token,
 		Guild:            config.DiscordConfig.Guild,
-		Channel:          config.DiscordConfig.Channel,
+		Channels:         config.DiscordConfig.GetChannels(),
 		Config:           config,
 		Logger:           logger.With().Str("component", "discord_reporter").Logger(),
 		MutesManager:     mutesManager,
+		RoutesManager:    routesManager,
+		SpamManager:      spamManager,
+		OutboxManager:    outboxManager,
+		AlertsManager:    alertsManager,
+		DashboardManager: dashboardManager,
 		DataManager:      dataManager,
 		StateGenerator:   stateGenerator,
 		TemplatesManager: templatesPkg.NewDiscordTemplatesManager(logger, timezone),
@@ -87,13 +111,17 @@ func (reporter *Reporter) Init() error {
 	reporter.Logger.Info().Err(err).Msg("Discord bot listening")
 
 	reporter.Commands = map[string]*Command{
-		"help":             reporter.GetHelpCommand(),
-		"proposals":        reporter.GetProposalsCommand(),
-		"proposals_mute":   reporter.GetAddMuteCommand(),
-		"proposals_unmute": reporter.GetDeleteMuteCommand(),
-		"proposals_mutes":  reporter.GetMutesCommand(),
-		"params":           reporter.GetParamsCommand(),
-		"tally":            reporter.GetTallyCommand(),
+		"help":                    reporter.GetHelpCommand(),
+		"proposals":               reporter.GetProposalsCommand(),
+		"proposals_mute":          reporter.GetAddMuteCommand(),
+		"proposals_unmute":        reporter.GetDeleteMuteCommand(),
+		"proposals_mutes":         reporter.GetMutesCommand(),
+		"proposals_mutes_history": reporter.GetMutesHistoryCommand(),
+		"routes":                  reporter.GetRoutesCommand(),
+		"params":                  reporter.GetParamsCommand(),
+		"tally":                   reporter.GetTallyCommand(),
+		"proposals_not_spam":      reporter.GetNotSpamCommand(),
+		"proposals_undelivered":   reporter.GetUndeliveredCommand(),
 	}
 
 	go reporter.InitCommands()
@@ -108,9 +136,31 @@ func (reporter *Reporter) InitCommands() {
 	session.AddHandler(func(s *discordgo.Session, i *discordgo.InteractionCreate) {
 		commandName := i.ApplicationCommandData().Name
 
-		if command, ok := reporter.Commands[commandName]; ok {
-			command.Handler(s, i)
+		command, ok := reporter.Commands[commandName]
+		if !ok {
+			return
+		}
+
+		if !reporter.HasAccess(i, command.AccessLevel) {
+			reporter.Logger.Warn().
+				Str("user_id", GetUser(i).ID).
+				Str("command", commandName).
+				Str("level", command.AccessLevel.String()).
+				Msg("Got command from a user without access, ignoring")
+			reporter.BotRespond(s, i, "You are not allowed to run this command.")
+			return
 		}
+
+		if command.AccessLevel == types.AccessLevelWrite {
+			reporter.Logger.Info().
+				Str("user_id", GetUser(i).ID).
+				Str("username", GetUser(i).Username).
+				Str("channel_id", i.ChannelID).
+				Str("command", commandName).
+				Msg("Running mutating command")
+		}
+
+		command.Handler(s, i)
 	})
 
 	registeredCommands, err := session.ApplicationCommands(session.State.User.ID, reporter.Guild)
@@ -201,7 +251,7 @@ func (reporter *Reporter) InitCommands() {
 }
 
 func (reporter *Reporter) Enabled() bool {
-	return reporter.Token != "" && reporter.Guild != "" && reporter.Channel != ""
+	return reporter.Token != "" && reporter.Guild != "" && len(reporter.Channels) > 0
 }
 
 func (reporter *Reporter) Name() string {
@@ -213,25 +263,221 @@ func (reporter *Reporter) SerializeReportEntry(e entry.ReportEntry) (string, err
 }
 
 func (reporter *Reporter) SendReportEntry(reportEntry entry.ReportEntry, ctx context.Context) error {
-	_, span := reporter.Tracer.Start(ctx, "Sending Telegram report entry")
+	_, span := reporter.Tracer.Start(ctx, "Sending Discord report entry")
 	defer span.End()
 
-	serializedEntry, err := reporter.SerializeReportEntry(reportEntry)
+	return reporter.sendReportEntryToChannels(reportEntry, reporter.Channels)
+}
+
+// SendReportEntryToDestinations sends the entry only to the channels it failed to be sent to before.
+func (reporter *Reporter) SendReportEntryToDestinations(
+	reportEntry entry.ReportEntry,
+	destinations []string,
+	ctx context.Context,
+) error {
+	_, span := reporter.Tracer.Start(ctx, "Sending Discord report entry to destinations")
+	defer span.End()
+
+	channels := utils.Filter(reporter.Channels, func(channel types.DiscordChannel) bool {
+		return utils.Contains(destinations, channel.Destination())
+	})
+
+	return reporter.sendReportEntryToChannels(reportEntry, channels)
+}
+
+func (reporter *Reporter) sendReportEntryToChannels(
+	reportEntry entry.ReportEntry,
+	channels []types.DiscordChannel,
+) error {
+	errs := make(map[string]error)
+
+	for _, channel := range channels {
+		channelEntry, shouldSend := reporter.GetEntryForChannel(reportEntry, channel)
+		if !shouldSend {
+			continue
+		}
+
+		serializedEntry, err := reporter.SerializeReportEntry(channelEntry)
+		if err != nil {
+			reporter.Logger.Err(err).Msg("Could not serialize report entry")
+			errs[channel.Destination()] = err
+			continue
+		}
+
+		if reporter.Config.DiscordConfig.EditAlerts &&
+			reporter.AlertsManager.ShouldEdit(channelEntry) &&
+			reporter.EditAlertMessage(channel, channelEntry, serializedEntry) {
+			continue
+		}
+
+		// long entries, like digests, do not fit into a single message
+		chunks := utils.SplitStringIntoChunks(serializedEntry, MaxMessageSize)
+
+		for _, chunk := range chunks {
+			message, sendErr := reporter.DiscordSession.ChannelMessageSend(
+				channel.ID,
+				chunk,
+			)
+			if sendErr != nil {
+				reporter.Logger.Err(sendErr).
+					Str("channel", channel.ID).
+					Msg("Could not send Discord message")
+				errs[channel.Destination()] = sendErr
+				break
+			}
+
+			if reporter.Config.DiscordConfig.EditAlerts && len(chunks) == 1 {
+				reporter.SaveAlertMessage(channel, channelEntry, message)
+			}
+		}
+	}
+
+	return reportersPkg.NewDestinationsError(errs)
+}
+
+func (reporter *Reporter) EditsAlerts() bool {
+	return reporter.Config.DiscordConfig.EditAlerts
+}
+
+// EditSentAlert edits the messages sent before for the entry with its newer state,
+// without sending new messages to the channels that have none.
+func (reporter *Reporter) EditSentAlert(reportEntry entry.ReportEntry, ctx context.Context) error {
+	_, span := reporter.Tracer.Start(ctx, "Editing Discord alert")
+	defer span.End()
+
+	errs := make(map[string]error)
+
+	for _, channel := range reporter.Channels {
+		channelEntry, shouldSend := reporter.GetEntryForChannel(reportEntry, channel)
+		if !shouldSend {
+			continue
+		}
+
+		serializedEntry, err := reporter.SerializeReportEntry(channelEntry)
+		if err != nil {
+			reporter.Logger.Err(err).Msg("Could not serialize report entry")
+			errs[channel.Destination()] = err
+			continue
+		}
+
+		reporter.EditAlertMessage(channel, channelEntry, serializedEntry)
+	}
+
+	return reportersPkg.NewDestinationsError(errs)
+}
+
+func (reporter *Reporter) GroupsAlerts() bool {
+	return reporter.Config.DiscordConfig.GroupAlerts
+}
+
+// GetEntryForChannel returns the entry to send to the channel, which for grouped alerts
+// only has the alerts the channel should receive, and whether anything should be sent.
+func (reporter *Reporter) GetEntryForChannel(
+	reportEntry entry.ReportEntry,
+	channel types.DiscordChannel,
+) (entry.ReportEntry, bool) {
+	if groupedEvent, ok := reportEntry.(events.GroupedEvent); ok {
+		filtered := groupedEvent.Filter(func(groupedEntry entry.ReportEntry) bool {
+			return reporter.ShouldSendToChannel(groupedEntry, channel)
+		})
+		return filtered, len(filtered.Entries) > 0
+	}
+
+	// the digest is not related to a single chain, so it's always sent,
+	// but only with the chains and wallets this channel receives notifications about
+	if digestEvent, ok := reportEntry.(events.DigestEvent); ok {
+		return digestEvent.Filter(&channel.DestinationFilter), true
+	}
+
+	return reportEntry, reporter.ShouldSendToChannel(reportEntry, channel)
+}
+
+func (reporter *Reporter) ShouldSendToChannel(reportEntry entry.ReportEntry, channel types.DiscordChannel) bool {
+	chain, wallet, _ := events.GetEntryLabels(reportEntry)
+	if !channel.Matches(chain, wallet) {
+		return false
+	}
+
+	if !channel.HasOwnMutes() {
+		return true
+	}
+
+	isMuted, err := reporter.MutesManager.IsEntryMutedForDestination(reportEntry, channel.Destination())
 	if err != nil {
-		reporter.Logger.Err(err).Msg("Could not serialize report entry")
-		return err
+		reporter.Logger.Warn().
+			Err(err).
+			Str("destination", channel.Destination()).
+			Msg("Error checking whether the entry was muted")
+		return true
 	}
 
-	_, err = reporter.DiscordSession.ChannelMessageSend(
-		reporter.Channel,
-		serializedEntry,
-	)
+	if isMuted {
+		reporter.Logger.Debug().
+			Str("destination", channel.Destination()).
+			Str("entry", reportEntry.Name()).
+			Msg("Notifications are muted for this channel, not sending.")
+	}
+
+	return !isMuted
+}
+
+// HasAccess checks whether the member who sent the command has one of the roles
+// allowed to run commands of this level.
+func (reporter *Reporter) HasAccess(i *discordgo.InteractionCreate, level types.AccessLevel) bool {
+	roles := make([]string, 0)
+	if i.Member != nil {
+		roles = i.Member.Roles
+	}
+
+	return reporter.Config.DiscordConfig.HasAccess(roles, level)
+}
+
+// GetUser returns the user who sent the command, which is set in different
+// fields depending on whether it was sent in a guild or in a direct message.
+func GetUser(i *discordgo.InteractionCreate) *discordgo.User {
+	if i.Member != nil && i.Member.User != nil {
+		return i.Member.User
+	}
+
+	if i.User != nil {
+		return i.User
+	}
+
+	return &discordgo.User{}
+}
+
+func (reporter *Reporter) GetChannel(i *discordgo.InteractionCreate) *types.DiscordChannel {
+	for index := range reporter.Channels {
+		if reporter.Channels[index].ID == i.ChannelID {
+			return &reporter.Channels[index]
+		}
+	}
+
+	return nil
+}
+
+// GetChannelChains returns the chains the commands sent from this channel should display,
+// or nil, meaning all the chains, if the channel is not configured or is not filtered by chains.
+func (reporter *Reporter) GetChannelChains(i *discordgo.InteractionCreate) []string {
+	if channel := reporter.GetChannel(i); channel != nil {
+		return channel.Chains
+	}
+
+	return nil
+}
+
+// GetMuteDestination returns the destination the mutes added from this channel apply to,
+// or an empty string if they should apply to everything.
+func (reporter *Reporter) GetMuteDestination(i *discordgo.InteractionCreate) string {
+	if channel := reporter.GetChannel(i); channel != nil && channel.HasOwnMutes() {
+		return channel.Destination()
+	}
 
-	return err
+	return ""
 }
 
 func (reporter *Reporter) BotRespond(s *discordgo.Session, i *discordgo.InteractionCreate, text string) {
-	chunks := utils.SplitStringIntoChunks(text, 2000)
+	chunks := utils.SplitStringIntoChunks(text, MaxMessageSize)
 	firstChunk, rest := chunks[0], chunks[1:]
 
 	reporter.BotSendInteraction(s, i, firstChunk)
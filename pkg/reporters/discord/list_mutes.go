@@ -20,8 +20,9 @@ func (reporter *Reporter) GetMutesCommand() *Command {
 				return
 			}
 
+			destination := reporter.GetMuteDestination(i)
 			filteredMutes := utils.Filter(mutes, func(m *types.Mute) bool {
-				return !m.IsExpired()
+				return !m.IsExpired() && m.MatchesDestination(destination)
 			})
 
 			template, err := reporter.TemplatesManager.Render("mutes", filteredMutes)
@@ -0,0 +1,37 @@
+package discord
+
+import (
+	"main/pkg/types"
+	"main/pkg/utils"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+func (reporter *Reporter) GetMutesHistoryCommand() *Command {
+	return &Command{
+		Info: &discordgo.ApplicationCommand{
+			Name:        "proposals_mutes_history",
+			Description: "List recently deleted and expired mutes.",
+		},
+		Handler: func(s *discordgo.Session, i *discordgo.InteractionCreate) {
+			history, err := reporter.MutesManager.GetMutesHistory()
+			if err != nil {
+				reporter.Logger.Error().Err(err).Msg("Error getting mutes history")
+				return
+			}
+
+			destination := reporter.GetMuteDestination(i)
+			filteredHistory := utils.Filter(history, func(e *types.MuteHistoryEntry) bool {
+				return e.MatchesDestination(destination)
+			})
+
+			template, err := reporter.TemplatesManager.Render("mutes_history", filteredHistory)
+			if err != nil {
+				reporter.Logger.Error().Err(err).Str("template", "mutes_history").Msg("Error rendering template")
+				return
+			}
+
+			reporter.BotRespond(s, i, template)
+		},
+	}
+}
@@ -14,7 +14,11 @@ func (reporter *Reporter) GetProposalsCommand() *Command {
 			Description: "Get list of active proposals and your wallet's votes on them.",
 		},
 		Handler: func(s *discordgo.Session, i *discordgo.InteractionCreate) {
-			state := reporter.StateGenerator.GetState(statePkg.NewState(), context.Background())
+			state := reporter.StateGenerator.GetState(
+				statePkg.NewState(),
+				context.Background(),
+				reporter.GetChannelChains(i)...,
+			)
 			renderedState := state.ToRenderedState()
 
 			template, err := reporter.TemplatesManager.Render("proposals", renderedState)
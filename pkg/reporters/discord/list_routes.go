@@ -0,0 +1,23 @@
+package discord
+
+import (
+	"github.com/bwmarrin/discordgo"
+)
+
+func (reporter *Reporter) GetRoutesCommand() *Command {
+	return &Command{
+		Info: &discordgo.ApplicationCommand{
+			Name:        "routes",
+			Description: "List the routing rules deciding which reporter gets which notifications.",
+		},
+		Handler: func(s *discordgo.Session, i *discordgo.InteractionCreate) {
+			template, err := reporter.TemplatesManager.Render("routes", reporter.RoutesManager.GetRoutes())
+			if err != nil {
+				reporter.Logger.Error().Err(err).Str("template", "routes").Msg("Error rendering template")
+				return
+			}
+
+			reporter.BotRespond(s, i, template)
+		},
+	}
+}
@@ -0,0 +1,30 @@
+package discord
+
+import (
+	"github.com/bwmarrin/discordgo"
+)
+
+func (reporter *Reporter) GetUndeliveredCommand() *Command {
+	return &Command{
+		Info: &discordgo.ApplicationCommand{
+			Name:        "proposals_undelivered",
+			Description: "List notifications that failed to be delivered.",
+		},
+		Handler: func(s *discordgo.Session, i *discordgo.InteractionCreate) {
+			outboxEntries, err := reporter.OutboxManager.GetUndelivered()
+			if err != nil {
+				reporter.Logger.Error().Err(err).Msg("Error getting undelivered notifications")
+				reporter.BotRespond(s, i, "Error getting undelivered notifications!")
+				return
+			}
+
+			template, err := reporter.TemplatesManager.Render("undelivered", outboxEntries)
+			if err != nil {
+				reporter.Logger.Error().Err(err).Str("template", "undelivered").Msg("Error rendering template")
+				return
+			}
+
+			reporter.BotRespond(s, i, template)
+		},
+	}
+}
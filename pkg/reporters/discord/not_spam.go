@@ -0,0 +1,57 @@
+package discord
+
+import (
+	"fmt"
+	"main/pkg/types"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+func (reporter *Reporter) GetNotSpamCommand() *Command {
+	return &Command{
+		AccessLevel: types.AccessLevelWrite,
+		Info: &discordgo.ApplicationCommand{
+			Name:        "proposals_not_spam",
+			Description: "Mark a proposal suspected to be spam as not spam",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "chain",
+					Description: "Chain the proposal is on",
+					Required:    true,
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "proposal",
+					Description: "Proposal ID",
+					Required:    true,
+				},
+			},
+		},
+		Handler: func(s *discordgo.Session, i *discordgo.InteractionCreate) {
+			options := i.ApplicationCommandData().Options
+
+			var chain, proposal string
+
+			for _, opt := range options {
+				if opt.Name == "chain" {
+					chain, _ = opt.Value.(string)
+				}
+				if opt.Name == "proposal" {
+					proposal, _ = opt.Value.(string)
+				}
+			}
+
+			found, err := reporter.SpamManager.Unflag(chain, proposal, GetUser(i).Username)
+			if err != nil {
+				reporter.BotRespond(s, i, "Error un-flagging proposal!")
+				return
+			} else if !found {
+				reporter.BotRespond(s, i, "Could not find the proposal flagged as spam!")
+				return
+			}
+
+			reporter.BotRespond(s, i, fmt.Sprintf("Proposal %s on %s is not considered spam anymore.", proposal, chain))
+		},
+	}
+}
@@ -14,7 +14,7 @@ func (reporter *Reporter) GetParamsCommand() *Command {
 			Description: "List all chains params.",
 		},
 		Handler: func(s *discordgo.Session, i *discordgo.InteractionCreate) {
-			params, err := reporter.DataManager.GetParams(context.Background())
+			params, err := reporter.DataManager.GetParams(context.Background(), reporter.GetChannelChains(i)...)
 			if err != nil {
 				reporter.BotRespond(s, i, fmt.Sprintf("Error getting chain params: %s", err))
 				return
@@ -17,7 +17,7 @@ func (reporter *Reporter) GetTallyCommand() *Command {
 		Handler: func(s *discordgo.Session, i *discordgo.InteractionCreate) {
 			reporter.BotSendInteraction(s, i, "Calculating tally for proposals. This might take a while...")
 
-			tallies, err := reporter.DataManager.GetTallies(context.Background())
+			tallies, err := reporter.DataManager.GetTallies(context.Background(), reporter.GetChannelChains(i)...)
 			if err != nil {
 				reporter.BotRespond(s, i, fmt.Sprintf("Error getting tallies info: %s", err))
 				return
@@ -1,12 +1,15 @@
 package discord
 
 import (
+	"main/pkg/types"
+
 	"github.com/bwmarrin/discordgo"
 )
 
 type Command struct {
-	Info    *discordgo.ApplicationCommand
-	Handler func(s *discordgo.Session, i *discordgo.InteractionCreate)
+	Info        *discordgo.ApplicationCommand
+	AccessLevel types.AccessLevel
+	Handler     func(s *discordgo.Session, i *discordgo.InteractionCreate)
 }
 
 type helpRender struct {
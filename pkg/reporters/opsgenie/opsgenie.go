@@ -0,0 +1,219 @@
+package opsgenie
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"main/pkg/events"
+	"main/pkg/report/entry"
+	"main/pkg/types"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/rs/zerolog"
+)
+
+type Reporter struct {
+	OpsgenieURL string
+	APIKey      string
+	Priority    string
+	Logger      zerolog.Logger
+	Tracer      trace.Tracer
+}
+
+type Alert struct {
+	Message     string            `json:"message"`
+	Alias       string            `json:"alias"`
+	Description string            `json:"description"`
+	Priority    string            `json:"priority"`
+	Source      string            `json:"source"`
+	Tags        []string          `json:"tags"`
+	Details     map[string]string `json:"details"`
+}
+
+type CloseAlert struct {
+	Source string `json:"source"`
+	Note   string `json:"note"`
+}
+
+type Response struct {
+	Result    string `json:"result"`
+	Message   string `json:"message"`
+	RequestID string `json:"requestId"`
+}
+
+func NewOpsgenieReporter(
+	config types.OpsgenieConfig,
+	logger *zerolog.Logger,
+	tracer trace.Tracer,
+) Reporter {
+	return Reporter{
+		OpsgenieURL: config.OpsgenieURL,
+		APIKey:      config.APIKey,
+		Priority:    config.Priority,
+		Logger:      logger.With().Str("component", "opsgenie_reporter").Logger(),
+		Tracer:      tracer,
+	}
+}
+
+func (r Reporter) Init() error {
+	return nil
+}
+
+func (r Reporter) Enabled() bool {
+	return r.APIKey != ""
+}
+
+func (r Reporter) Name() string {
+	return "opsgenie-reporter"
+}
+
+func GetAlias(event entry.ReportEntryNotError) string {
+	return fmt.Sprintf(
+		"cosmos-proposals-checker chain=%s proposal=%s wallet=%s",
+		event.GetChain().Name,
+		event.GetProposal().ID,
+		event.GetWallet().Address,
+	)
+}
+
+func (r Reporter) NewAlertFromReportEntry(eventRaw entry.ReportEntry) (Alert, error) {
+	event, ok := eventRaw.(entry.ReportEntryNotError)
+	if !ok {
+		return Alert{}, errors.New("error converting alert entry")
+	}
+
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "unknown-host"
+	}
+
+	description := event.GetProposal().Description
+	for _, link := range event.GetChain().GetExplorerProposalsLinks(event.GetProposal().ID) {
+		description += fmt.Sprintf("\n%s: %s", link.Name, link.Href)
+	}
+
+	return Alert{
+		Message: fmt.Sprintf(
+			"Wallet %s hasn't voted on proposal %s on %s: %s",
+			event.GetWallet().AddressOrAlias(),
+			event.GetProposal().ID,
+			event.GetChain().GetName(),
+			event.GetProposal().Title,
+		),
+		Alias:       GetAlias(event),
+		Description: description,
+		Priority:    r.Priority,
+		Source:      hostname,
+		Tags:        []string{"cosmos-proposals-checker", event.GetChain().Name},
+		Details: map[string]string{
+			"Wallet":         event.GetWallet().AddressOrAlias(),
+			"Chain":          event.GetChain().GetName(),
+			"Proposal ID":    event.GetProposal().ID,
+			"Proposal title": event.GetProposal().Title,
+			"Voting ends at": event.GetProposal().EndTime.Format(time.RFC3339),
+		},
+	}, nil
+}
+
+// ResolvesAlerts returns whether the entry closes the alert created before,
+// which is the case when a wallet has voted.
+func (r Reporter) ResolvesAlerts(reportEntry entry.ReportEntry) bool {
+	switch reportEntry.(type) {
+	case events.VotedEvent, events.RevotedEvent:
+		return true
+	default:
+		return false
+	}
+}
+
+func (r Reporter) SendReportEntry(reportEntry entry.ReportEntry, ctx context.Context) error {
+	_, span := r.Tracer.Start(ctx, "Sending Opsgenie report entry")
+	defer span.End()
+
+	switch event := reportEntry.(type) {
+	case events.NotVotedEvent:
+		alert, alertCreateErr := r.NewAlertFromReportEntry(event)
+		if alertCreateErr != nil {
+			return alertCreateErr
+		}
+
+		return r.CreateAlert(alert)
+	case events.VotedEvent, events.RevotedEvent:
+		// a wallet has voted, so the alert created for not voting is not relevant anymore
+		notErrorEvent, _ := event.(entry.ReportEntryNotError)
+		return r.CloseAlert(GetAlias(notErrorEvent), fmt.Sprintf(
+			"Wallet %s has voted on proposal %s on %s",
+			notErrorEvent.GetWallet().AddressOrAlias(),
+			notErrorEvent.GetProposal().ID,
+			notErrorEvent.GetChain().GetName(),
+		))
+	default:
+		return nil
+	}
+}
+
+func (r Reporter) CreateAlert(alert Alert) error {
+	return r.DoRequest(r.OpsgenieURL+"/v2/alerts", alert)
+}
+
+func (r Reporter) CloseAlert(alias string, note string) error {
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "unknown-host"
+	}
+
+	return r.DoRequest(
+		fmt.Sprintf("%s/v2/alerts/%s/close?identifierType=alias", r.OpsgenieURL, url.PathEscape(alias)),
+		CloseAlert{Source: hostname, Note: note},
+	)
+}
+
+func (r Reporter) DoRequest(url string, body interface{}) error {
+	client := &http.Client{Timeout: 10 * time.Second}
+	start := time.Now()
+
+	jsonBody, err := json.Marshal(body)
+	if err != nil {
+		r.Logger.Err(err).Msg("Error marshalling request body")
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewBuffer(jsonBody))
+	if err != nil {
+		r.Logger.Err(err).Msg("Error instantiating request")
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "GenieKey "+r.APIKey)
+
+	r.Logger.Debug().Str("url", url).Msg("Doing a query...")
+
+	res, err := client.Do(req)
+	if err != nil {
+		r.Logger.Warn().Str("url", url).Err(err).Msg("Query failed")
+		return err
+	}
+	defer res.Body.Close()
+
+	r.Logger.Debug().Str("url", url).Dur("duration", time.Since(start)).Msg("Query is finished")
+
+	var response Response
+	if err := json.NewDecoder(res.Body).Decode(&response); err != nil {
+		return err
+	}
+
+	// Opsgenie processes requests asynchronously and returns 202 Accepted if the request is valid.
+	if res.StatusCode != http.StatusAccepted {
+		return fmt.Errorf("expected 202 status code, got %d. Error: %s", res.StatusCode, response.Message)
+	}
+
+	return nil
+}
@@ -0,0 +1,190 @@
+package opsgenie
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"main/pkg/events"
+	loggerPkg "main/pkg/logger"
+	"main/pkg/tracing"
+	"main/pkg/types"
+	"net/http"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/require"
+)
+
+const testAlias = "cosmos-proposals-checker chain=chain proposal=proposal wallet=wallet"
+
+func getTestReporter() Reporter {
+	return NewOpsgenieReporter(
+		types.OpsgenieConfig{OpsgenieURL: "https://api.opsgenie.com", APIKey: "key", Priority: "P2"},
+		loggerPkg.GetNopLogger(),
+		tracing.InitNoopTracer(),
+	)
+}
+
+func TestOpsgenieReporterBase(t *testing.T) {
+	t.Parallel()
+
+	reporter := getTestReporter()
+	require.NoError(t, reporter.Init())
+	require.True(t, reporter.Enabled())
+	require.Equal(t, "opsgenie-reporter", reporter.Name())
+
+	disabledReporter := NewOpsgenieReporter(
+		types.OpsgenieConfig{},
+		loggerPkg.GetNopLogger(),
+		tracing.InitNoopTracer(),
+	)
+	require.False(t, disabledReporter.Enabled())
+}
+
+func TestOpsgenieReporterNewAlertInvalidEntry(t *testing.T) {
+	t.Parallel()
+
+	reporter := getTestReporter()
+	_, err := reporter.NewAlertFromReportEntry(events.GenericErrorEvent{})
+	require.Error(t, err)
+}
+
+func TestOpsgenieReporterNewAlertOk(t *testing.T) {
+	t.Parallel()
+
+	reporter := getTestReporter()
+	alert, err := reporter.NewAlertFromReportEntry(events.NotVotedEvent{
+		Chain:    &types.Chain{Name: "chain", PrettyName: "Chain"},
+		Wallet:   &types.Wallet{Address: "wallet", Alias: "alias"},
+		Proposal: types.Proposal{ID: "proposal", Title: "title"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, testAlias, alert.Alias)
+	require.Equal(t, "P2", alert.Priority)
+	require.Equal(t, "Wallet alias hasn't voted on proposal proposal on Chain: title", alert.Message)
+}
+
+//nolint:paralleltest // disabled
+func TestOpsgenieReporterSendNotVoted(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder(
+		"POST",
+		"https://api.opsgenie.com/v2/alerts",
+		func(req *http.Request) (*http.Response, error) {
+			require.Equal(t, "GenieKey key", req.Header.Get("Authorization"))
+
+			var alert Alert
+			require.NoError(t, json.NewDecoder(req.Body).Decode(&alert))
+			require.Equal(t, testAlias, alert.Alias)
+
+			return httpmock.NewStringResponse(202, `{"result":"Request will be processed"}`), nil
+		},
+	)
+
+	err := getTestReporter().SendReportEntry(events.NotVotedEvent{
+		Chain:    &types.Chain{Name: "chain"},
+		Wallet:   &types.Wallet{Address: "wallet"},
+		Proposal: types.Proposal{ID: "proposal"},
+	}, context.Background())
+	require.NoError(t, err)
+}
+
+//nolint:paralleltest // disabled
+func TestOpsgenieReporterSendVoted(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder(
+		"POST",
+		"https://api.opsgenie.com/v2/alerts/cosmos-proposals-checker%20chain=chain%20proposal=proposal%20wallet=wallet/close",
+		httpmock.NewStringResponder(202, `{"result":"Request will be processed"}`),
+	)
+
+	err := getTestReporter().SendReportEntry(events.VotedEvent{
+		Chain:    &types.Chain{Name: "chain"},
+		Wallet:   &types.Wallet{Address: "wallet"},
+		Proposal: types.Proposal{ID: "proposal"},
+		Vote:     &types.Vote{},
+	}, context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 1, httpmock.GetTotalCallCount())
+}
+
+//nolint:paralleltest // disabled
+func TestOpsgenieReporterSendNotAlert(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	err := getTestReporter().SendReportEntry(events.FinishedVotingEvent{}, context.Background())
+	require.NoError(t, err)
+	require.Zero(t, httpmock.GetTotalCallCount())
+}
+
+//nolint:paralleltest // disabled
+func TestOpsgenieReporterSendError(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder(
+		"POST",
+		"https://api.opsgenie.com/v2/alerts",
+		httpmock.NewErrorResponder(errors.New("custom error")),
+	)
+
+	err := getTestReporter().SendReportEntry(events.NotVotedEvent{
+		Chain:    &types.Chain{Name: "chain"},
+		Wallet:   &types.Wallet{Address: "wallet"},
+		Proposal: types.Proposal{ID: "proposal"},
+	}, context.Background())
+	require.ErrorContains(t, err, "custom error")
+}
+
+//nolint:paralleltest // disabled
+func TestOpsgenieReporterSendUnexpectedStatus(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder(
+		"POST",
+		"https://api.opsgenie.com/v2/alerts",
+		httpmock.NewStringResponder(422, `{"message":"Request body is not processable"}`),
+	)
+
+	err := getTestReporter().SendReportEntry(events.NotVotedEvent{
+		Chain:    &types.Chain{Name: "chain"},
+		Wallet:   &types.Wallet{Address: "wallet"},
+		Proposal: types.Proposal{ID: "proposal"},
+	}, context.Background())
+	require.ErrorContains(t, err, "expected 202 status code, got 422. Error: Request body is not processable")
+}
+
+//nolint:paralleltest // disabled
+func TestOpsgenieReporterSendInvalidJSON(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder(
+		"POST",
+		"https://api.opsgenie.com/v2/alerts",
+		httpmock.NewStringResponder(202, "not json"),
+	)
+
+	err := getTestReporter().SendReportEntry(events.NotVotedEvent{
+		Chain:    &types.Chain{Name: "chain"},
+		Wallet:   &types.Wallet{Address: "wallet"},
+		Proposal: types.Proposal{ID: "proposal"},
+	}, context.Background())
+	require.Error(t, err)
+}
+
+func TestOpsgenieReporterResolvesAlerts(t *testing.T) {
+	t.Parallel()
+
+	reporter := getTestReporter()
+	require.False(t, reporter.ResolvesAlerts(events.NotVotedEvent{}))
+	require.True(t, reporter.ResolvesAlerts(events.VotedEvent{}))
+	require.True(t, reporter.ResolvesAlerts(events.RevotedEvent{}))
+	require.False(t, reporter.ResolvesAlerts(events.FinishedVotingEvent{}))
+}
@@ -6,7 +6,9 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	databasePkg "main/pkg/database"
 	"main/pkg/events"
+	mutes "main/pkg/mutes"
 	"main/pkg/report/entry"
 	"main/pkg/types"
 	"net/http"
@@ -19,10 +21,15 @@ import (
 )
 
 type Reporter struct {
-	PagerDutyURL string
-	APIKey       string
-	Logger       zerolog.Logger
-	Tracer       trace.Tracer
+	PagerDutyURL      string
+	APIKey            string
+	ErrorThreshold    time.Duration
+	CriticalThreshold time.Duration
+	Chains            types.Chains
+	Database          databasePkg.Database
+	MutesManager      *mutes.Manager
+	Logger            zerolog.Logger
+	Tracer            trace.Tracer
 }
 
 type AlertPayload struct {
@@ -39,13 +46,13 @@ type Link struct {
 }
 
 type Alert struct {
-	Payload     AlertPayload `json:"payload"`
-	RoutingKey  string       `json:"routing_key"`
-	EventAction string       `json:"event_action"`
-	DedupKey    string       `json:"dedup_key"`
-	Client      string       `json:"client"`
-	Links       []Link       `json:"links"`
-	ClientURL   string       `json:"client_url"`
+	Payload     *AlertPayload `json:"payload,omitempty"`
+	RoutingKey  string        `json:"routing_key"`
+	EventAction string        `json:"event_action"`
+	DedupKey    string        `json:"dedup_key"`
+	Client      string        `json:"client,omitempty"`
+	Links       []Link        `json:"links,omitempty"`
+	ClientURL   string        `json:"client_url,omitempty"`
 }
 
 type Response struct {
@@ -60,16 +67,12 @@ func (r Reporter) NewAlertFromReportEntry(eventRaw entry.ReportEntry) (Alert, er
 	}
 
 	eventAction := "trigger"
-	if _, ok := event.(events.VotedEvent); ok {
+	switch event.(type) {
+	case events.VotedEvent, events.RevotedEvent:
 		eventAction = "resolve"
 	}
 
-	dedupKey := fmt.Sprintf(
-		"cosmos-proposals-checker alert chain=%s proposal=%s wallet=%s",
-		event.GetChain().Name,
-		event.GetProposal().ID,
-		event.GetWallet().AddressOrAlias(),
-	)
+	dedupKey := GetDedupKey(event)
 
 	hostname, err := os.Hostname()
 	if err != nil {
@@ -86,7 +89,7 @@ func (r Reporter) NewAlertFromReportEntry(eventRaw entry.ReportEntry) (Alert, er
 	}
 
 	return Alert{
-		Payload: AlertPayload{
+		Payload: &AlertPayload{
 			Summary: fmt.Sprintf(
 				"Wallet %s hasn't voted on proposal %s on %s: %s",
 				event.GetWallet().AddressOrAlias(),
@@ -95,7 +98,7 @@ func (r Reporter) NewAlertFromReportEntry(eventRaw entry.ReportEntry) (Alert, er
 				event.GetProposal().Title,
 			),
 			Timestamp: time.Now().Format(time.RFC3339),
-			Severity:  "error",
+			Severity:  r.GetSeverity(event.GetProposal().EndTime),
 			Source:    hostname,
 			CustomDetails: map[string]string{
 				"Wallet":               event.GetWallet().AddressOrAlias(),
@@ -114,16 +117,48 @@ func (r Reporter) NewAlertFromReportEntry(eventRaw entry.ReportEntry) (Alert, er
 	}, nil
 }
 
+func GetDedupKey(event entry.ReportEntryNotError) string {
+	return fmt.Sprintf(
+		"cosmos-proposals-checker alert chain=%s proposal=%s wallet=%s",
+		event.GetChain().Name,
+		event.GetProposal().ID,
+		event.GetWallet().AddressOrAlias(),
+	)
+}
+
+// GetSeverity escalates the alert severity as the voting end time approaches.
+func (r Reporter) GetSeverity(endTime time.Time) string {
+	timeLeft := time.Until(endTime)
+
+	if timeLeft > r.ErrorThreshold {
+		return "warning"
+	}
+
+	if timeLeft > r.CriticalThreshold {
+		return "error"
+	}
+
+	return "critical"
+}
+
 func NewPagerDutyReporter(
 	config types.PagerDutyConfig,
+	chains types.Chains,
+	database databasePkg.Database,
+	mutesManager *mutes.Manager,
 	logger *zerolog.Logger,
 	tracer trace.Tracer,
 ) Reporter {
 	return Reporter{
-		PagerDutyURL: config.PagerDutyURL,
-		APIKey:       config.APIKey,
-		Logger:       logger.With().Str("component", "pagerduty_reporter").Logger(),
-		Tracer:       tracer,
+		PagerDutyURL:      config.PagerDutyURL,
+		APIKey:            config.APIKey,
+		ErrorThreshold:    config.ErrorThreshold.Duration,
+		CriticalThreshold: config.CriticalThreshold.Duration,
+		Chains:            chains,
+		Database:          database,
+		MutesManager:      mutesManager,
+		Logger:            logger.With().Str("component", "pagerduty_reporter").Logger(),
+		Tracer:            tracer,
 	}
 }
 
@@ -139,10 +174,28 @@ func (r Reporter) Name() string {
 	return "pagerduty-reporter"
 }
 
+// ResolvesAlerts returns whether the entry resolves the incidents opened before,
+// which is the case when a wallet has voted or when the voting has finished.
+func (r Reporter) ResolvesAlerts(reportEntry entry.ReportEntry) bool {
+	switch reportEntry.(type) {
+	case events.VotedEvent, events.RevotedEvent, events.FinishedVotingEvent:
+		return true
+	default:
+		return false
+	}
+}
+
 func (r Reporter) SendReportEntry(reportEntry entry.ReportEntry, ctx context.Context) error {
 	_, span := r.Tracer.Start(ctx, "Sending Pagerduty report entry")
 	defer span.End()
 
+	if finishedVotingEvent, ok := reportEntry.(events.FinishedVotingEvent); ok {
+		return r.ResolveIncidents(func(incident *types.Incident) bool {
+			return incident.Chain == finishedVotingEvent.Chain.Name &&
+				incident.ProposalID == finishedVotingEvent.Proposal.ID
+		})
+	}
+
 	if !reportEntry.IsAlert() {
 		return nil
 	}
@@ -152,7 +205,115 @@ func (r Reporter) SendReportEntry(reportEntry entry.ReportEntry, ctx context.Con
 		return alertCreateErr
 	}
 
-	return r.SendAlert(alert)
+	if err := r.SendAlert(alert); err != nil {
+		return err
+	}
+
+	if alert.EventAction == "resolve" {
+		return r.Database.DeleteIncident(r.Name(), alert.DedupKey)
+	}
+
+	event, _ := reportEntry.(entry.ReportEntryNotError)
+
+	return r.Database.InsertIncident(&types.Incident{
+		Reporter:   r.Name(),
+		Chain:      event.GetChain().Name,
+		ProposalID: event.GetProposal().ID,
+		Wallet:     event.GetWallet().Address,
+		DedupKey:   alert.DedupKey,
+		CreatedAt:  time.Now(),
+	})
+}
+
+// ResolveIncidents resolves all the open incidents matching the predicate,
+// for cases when there won't be a VotedEvent for a wallet, like when the voting
+// has finished or notifications for a proposal were muted.
+func (r Reporter) ResolveIncidents(predicate func(incident *types.Incident) bool) error {
+	incidents, err := r.Database.GetIncidents(r.Name())
+	if err != nil {
+		return err
+	}
+
+	errs := make([]error, 0)
+
+	for _, incident := range incidents {
+		if !predicate(incident) {
+			continue
+		}
+
+		r.Logger.Debug().
+			Str("dedup_key", incident.DedupKey).
+			Msg("Resolving incident")
+
+		if err := r.SendAlert(Alert{
+			RoutingKey:  r.APIKey,
+			EventAction: "resolve",
+			DedupKey:    incident.DedupKey,
+		}); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		if err := r.Database.DeleteIncident(r.Name(), incident.DedupKey); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (r Reporter) HandleMuteAdded(mute *types.Mute) {
+	// mutes scoped to a chat or channel do not affect PagerDuty
+	if !r.Enabled() || !mute.IsGlobal() {
+		return
+	}
+
+	if err := r.ResolveIncidents(func(incident *types.Incident) bool {
+		// matched the same way as the entries the dispatcher does not send,
+		// so mutes by wallet alias or by proposal title resolve incidents as well
+		isMuted, err := r.MutesManager.IsEntryMuted(r.GetIncidentEntry(incident))
+		if err != nil {
+			r.Logger.Warn().
+				Err(err).
+				Str("dedup_key", incident.DedupKey).
+				Msg("Error checking whether the incident was muted")
+			return false
+		}
+
+		return isMuted
+	}); err != nil {
+		r.Logger.Error().Err(err).Msg("Error resolving incidents for a muted proposal")
+	}
+}
+
+// GetIncidentEntry returns the alert the incident was opened for. Incidents are only
+// opened for wallets that have not voted, and only store the wallet address and the proposal ID,
+// so the wallet alias is taken from the config, and the proposal title from the database.
+func (r Reporter) GetIncidentEntry(incident *types.Incident) events.NotVotedEvent {
+	chain := r.Chains.FindByName(incident.Chain)
+	if chain == nil {
+		chain = &types.Chain{Name: incident.Chain}
+	}
+
+	wallet := &types.Wallet{Address: incident.Wallet}
+	for _, chainWallet := range chain.Wallets {
+		if chainWallet.Address == incident.Wallet {
+			wallet = chainWallet
+		}
+	}
+
+	proposal := types.Proposal{ID: incident.ProposalID}
+	storedProposal, err := r.Database.GetProposal(chain, incident.ProposalID)
+	if err != nil {
+		r.Logger.Warn().
+			Err(err).
+			Str("dedup_key", incident.DedupKey).
+			Msg("Error getting the incident proposal, matching mutes without its title")
+	} else if storedProposal != nil {
+		proposal = *storedProposal
+	}
+
+	return events.NotVotedEvent{Chain: chain, Wallet: wallet, Proposal: proposal}
 }
 
 func (r Reporter) SendAlert(alert Alert) error {
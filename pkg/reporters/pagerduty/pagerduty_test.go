@@ -0,0 +1,412 @@
+package pagerduty
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	databasePkg "main/pkg/database"
+	"main/pkg/events"
+	loggerPkg "main/pkg/logger"
+	mutes "main/pkg/mutes"
+	"main/pkg/tracing"
+	"main/pkg/types"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/guregu/null/v5"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/require"
+)
+
+func getTestReporter(database databasePkg.Database) Reporter {
+	return NewPagerDutyReporter(
+		types.PagerDutyConfig{
+			PagerDutyURL:      "https://events.pagerduty.com",
+			APIKey:            "key",
+			ErrorThreshold:    types.Duration{Duration: 24 * time.Hour},
+			CriticalThreshold: types.Duration{Duration: time.Hour},
+		},
+		types.Chains{{Name: "chain", Wallets: []*types.Wallet{{Address: "wallet", Alias: "alias"}}}},
+		database,
+		mutes.NewMutesManager(loggerPkg.GetNopLogger(), database),
+		loggerPkg.GetNopLogger(),
+		tracing.InitNoopTracer(),
+	)
+}
+
+func getTestNotVotedEvent(endTime time.Time) events.NotVotedEvent {
+	return events.NotVotedEvent{
+		Chain:    &types.Chain{Name: "chain"},
+		Wallet:   &types.Wallet{Address: "wallet"},
+		Proposal: types.Proposal{ID: "proposal", EndTime: endTime},
+	}
+}
+
+func getActionResponder(t *testing.T, expectedAction string) httpmock.Responder {
+	t.Helper()
+
+	return func(req *http.Request) (*http.Response, error) {
+		var alert Alert
+		require.NoError(t, json.NewDecoder(req.Body).Decode(&alert))
+		require.Equal(t, expectedAction, alert.EventAction)
+
+		return httpmock.NewStringResponse(202, `{"status":"success"}`), nil
+	}
+}
+
+func TestPagerDutyReporterBase(t *testing.T) {
+	t.Parallel()
+
+	reporter := getTestReporter(&databasePkg.StubDatabase{})
+	require.NoError(t, reporter.Init())
+	require.True(t, reporter.Enabled())
+	require.Equal(t, "pagerduty-reporter", reporter.Name())
+}
+
+func TestPagerDutyReporterGetSeverity(t *testing.T) {
+	t.Parallel()
+
+	reporter := getTestReporter(&databasePkg.StubDatabase{})
+	require.Equal(t, "warning", reporter.GetSeverity(time.Now().Add(48*time.Hour)))
+	require.Equal(t, "error", reporter.GetSeverity(time.Now().Add(12*time.Hour)))
+	require.Equal(t, "critical", reporter.GetSeverity(time.Now().Add(30*time.Minute)))
+}
+
+func TestPagerDutyReporterNewAlertInvalidEntry(t *testing.T) {
+	t.Parallel()
+
+	reporter := getTestReporter(&databasePkg.StubDatabase{})
+	_, err := reporter.NewAlertFromReportEntry(events.GenericErrorEvent{})
+	require.Error(t, err)
+}
+
+//nolint:paralleltest // disabled
+func TestPagerDutyReporterNotVotedStoresIncident(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder(
+		"POST",
+		"https://events.pagerduty.com/v2/enqueue",
+		func(req *http.Request) (*http.Response, error) {
+			var alert Alert
+			require.NoError(t, json.NewDecoder(req.Body).Decode(&alert))
+			require.Equal(t, "trigger", alert.EventAction)
+			require.Equal(t, "critical", alert.Payload.Severity)
+
+			return httpmock.NewStringResponse(202, `{"status":"success"}`), nil
+		},
+	)
+
+	database := &databasePkg.StubDatabase{}
+	reporter := getTestReporter(database)
+
+	err := reporter.SendReportEntry(getTestNotVotedEvent(time.Now().Add(time.Minute)), context.Background())
+	require.NoError(t, err)
+	require.Len(t, database.Incidents, 1)
+	require.Equal(t, "proposal", database.Incidents[0].ProposalID)
+}
+
+//nolint:paralleltest // disabled
+func TestPagerDutyReporterNotVotedErrorSending(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder(
+		"POST",
+		"https://events.pagerduty.com/v2/enqueue",
+		httpmock.NewStringResponder(400, `{"status":"invalid event","message":"Event object is invalid"}`),
+	)
+
+	database := &databasePkg.StubDatabase{}
+	reporter := getTestReporter(database)
+
+	err := reporter.SendReportEntry(getTestNotVotedEvent(time.Now().Add(time.Minute)), context.Background())
+	require.ErrorContains(t, err, "Event object is invalid")
+	require.Empty(t, database.Incidents)
+}
+
+//nolint:paralleltest // disabled
+func TestPagerDutyReporterVotedResolvesIncident(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder(
+		"POST",
+		"https://events.pagerduty.com/v2/enqueue",
+		getActionResponder(t, "resolve"),
+	)
+
+	notVotedEvent := getTestNotVotedEvent(time.Now().Add(time.Hour))
+	database := &databasePkg.StubDatabase{
+		Incidents: []*types.Incident{
+			{Reporter: "pagerduty-reporter", Chain: "chain", ProposalID: "proposal", DedupKey: GetDedupKey(notVotedEvent)},
+		},
+	}
+	reporter := getTestReporter(database)
+
+	err := reporter.SendReportEntry(events.VotedEvent{
+		Chain:    notVotedEvent.Chain,
+		Wallet:   notVotedEvent.Wallet,
+		Proposal: notVotedEvent.Proposal,
+		Vote:     &types.Vote{},
+	}, context.Background())
+	require.NoError(t, err)
+	require.Empty(t, database.Incidents)
+}
+
+//nolint:paralleltest // disabled
+func TestPagerDutyReporterFinishedVotingResolvesIncidents(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder(
+		"POST",
+		"https://events.pagerduty.com/v2/enqueue",
+		getActionResponder(t, "resolve"),
+	)
+
+	database := &databasePkg.StubDatabase{
+		Incidents: []*types.Incident{
+			{Reporter: "pagerduty-reporter", Chain: "chain", ProposalID: "proposal", DedupKey: "key1"},
+			{Reporter: "pagerduty-reporter", Chain: "chain", ProposalID: "proposal", DedupKey: "key2"},
+			{Reporter: "pagerduty-reporter", Chain: "chain", ProposalID: "another", DedupKey: "key3"},
+		},
+	}
+	reporter := getTestReporter(database)
+
+	err := reporter.SendReportEntry(events.FinishedVotingEvent{
+		Chain:    &types.Chain{Name: "chain"},
+		Proposal: types.Proposal{ID: "proposal"},
+	}, context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 2, httpmock.GetTotalCallCount())
+	require.Len(t, database.Incidents, 1)
+	require.Equal(t, "key3", database.Incidents[0].DedupKey)
+}
+
+//nolint:paralleltest // disabled
+func TestPagerDutyReporterFinishedVotingErrorGettingIncidents(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	reporter := getTestReporter(&databasePkg.StubDatabase{GetIncidentsError: errors.New("custom error")})
+
+	err := reporter.SendReportEntry(events.FinishedVotingEvent{
+		Chain:    &types.Chain{Name: "chain"},
+		Proposal: types.Proposal{ID: "proposal"},
+	}, context.Background())
+	require.ErrorContains(t, err, "custom error")
+	require.Zero(t, httpmock.GetTotalCallCount())
+}
+
+//nolint:paralleltest // disabled
+func TestPagerDutyReporterFinishedVotingErrorResolving(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder(
+		"POST",
+		"https://events.pagerduty.com/v2/enqueue",
+		httpmock.NewErrorResponder(errors.New("custom error")),
+	)
+
+	database := &databasePkg.StubDatabase{
+		Incidents: []*types.Incident{
+			{Reporter: "pagerduty-reporter", Chain: "chain", ProposalID: "proposal", DedupKey: "key1"},
+		},
+	}
+	reporter := getTestReporter(database)
+
+	err := reporter.SendReportEntry(events.FinishedVotingEvent{
+		Chain:    &types.Chain{Name: "chain"},
+		Proposal: types.Proposal{ID: "proposal"},
+	}, context.Background())
+	require.ErrorContains(t, err, "custom error")
+	require.Len(t, database.Incidents, 1)
+}
+
+//nolint:paralleltest // disabled
+func TestPagerDutyReporterMuteResolvesIncidents(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder(
+		"POST",
+		"https://events.pagerduty.com/v2/enqueue",
+		getActionResponder(t, "resolve"),
+	)
+
+	database := &databasePkg.StubDatabase{
+		Incidents: []*types.Incident{
+			{Reporter: "pagerduty-reporter", Chain: "chain", ProposalID: "proposal", DedupKey: "key1"},
+			{Reporter: "pagerduty-reporter", Chain: "another", ProposalID: "proposal", DedupKey: "key2"},
+		},
+	}
+	reporter := getTestReporter(database)
+
+	mute := &types.Mute{Chain: null.StringFrom("chain"), Expires: time.Now().Add(time.Hour)}
+	database.Mutes = []*types.Mute{mute}
+
+	reporter.HandleMuteAdded(mute)
+	require.Equal(t, 1, httpmock.GetTotalCallCount())
+	require.Len(t, database.Incidents, 1)
+	require.Equal(t, "key2", database.Incidents[0].DedupKey)
+}
+
+//nolint:paralleltest // disabled
+func TestPagerDutyReporterMuteByAliasResolvesIncidents(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder(
+		"POST",
+		"https://events.pagerduty.com/v2/enqueue",
+		getActionResponder(t, "resolve"),
+	)
+
+	database := &databasePkg.StubDatabase{
+		Incidents: []*types.Incident{
+			{Reporter: "pagerduty-reporter", Chain: "chain", ProposalID: "proposal", Wallet: "wallet", DedupKey: "key1"},
+			{Reporter: "pagerduty-reporter", Chain: "chain", ProposalID: "proposal", Wallet: "another", DedupKey: "key2"},
+		},
+	}
+	reporter := getTestReporter(database)
+
+	mute := &types.Mute{Wallet: null.StringFrom("alias"), Expires: time.Now().Add(time.Hour)}
+	database.Mutes = []*types.Mute{mute}
+
+	reporter.HandleMuteAdded(mute)
+	require.Equal(t, 1, httpmock.GetTotalCallCount())
+	require.Len(t, database.Incidents, 1)
+	require.Equal(t, "key2", database.Incidents[0].DedupKey)
+}
+
+//nolint:paralleltest // disabled
+func TestPagerDutyReporterMuteByTitleResolvesIncidents(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder(
+		"POST",
+		"https://events.pagerduty.com/v2/enqueue",
+		getActionResponder(t, "resolve"),
+	)
+
+	database := &databasePkg.StubDatabase{
+		Incidents: []*types.Incident{
+			{Reporter: "pagerduty-reporter", Chain: "chain", ProposalID: "1", Wallet: "wallet", DedupKey: "key1"},
+			{Reporter: "pagerduty-reporter", Chain: "chain", ProposalID: "2", Wallet: "wallet", DedupKey: "key2"},
+			{Reporter: "pagerduty-reporter", Chain: "chain", ProposalID: "3", Wallet: "wallet", DedupKey: "key3"},
+		},
+		Proposals: map[string]map[string]*types.Proposal{
+			"chain": {
+				"1": {ID: "1", Title: "Upgrade to v2"},
+				"2": {ID: "2", Title: "Community pool spend"},
+			},
+		},
+	}
+	reporter := getTestReporter(database)
+
+	mute := &types.Mute{TitlePattern: null.StringFrom("(?i)upgrade"), Expires: time.Now().Add(time.Hour)}
+	database.Mutes = []*types.Mute{mute}
+
+	reporter.HandleMuteAdded(mute)
+	require.Equal(t, 1, httpmock.GetTotalCallCount())
+	require.Len(t, database.Incidents, 2)
+	require.Equal(t, "key2", database.Incidents[0].DedupKey)
+	require.Equal(t, "key3", database.Incidents[1].DedupKey)
+}
+
+//nolint:paralleltest // disabled
+func TestPagerDutyReporterMuteErrorCheckingMute(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	database := &databasePkg.StubDatabase{
+		Incidents: []*types.Incident{
+			{Reporter: "pagerduty-reporter", Chain: "chain", ProposalID: "proposal", DedupKey: "key1"},
+		},
+		GetProposalError: errors.New("custom error"),
+		IsMutedError:     errors.New("custom error"),
+	}
+	reporter := getTestReporter(database)
+
+	reporter.HandleMuteAdded(&types.Mute{Chain: null.StringFrom("chain")})
+	require.Zero(t, httpmock.GetTotalCallCount())
+	require.Len(t, database.Incidents, 1)
+}
+
+//nolint:paralleltest // disabled
+func TestPagerDutyReporterMuteDisabled(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	database := &databasePkg.StubDatabase{
+		Incidents: []*types.Incident{
+			{Reporter: "pagerduty-reporter", Chain: "chain", ProposalID: "proposal", DedupKey: "key1"},
+		},
+	}
+	reporter := NewPagerDutyReporter(
+		types.PagerDutyConfig{},
+		types.Chains{},
+		database,
+		mutes.NewMutesManager(loggerPkg.GetNopLogger(), database),
+		loggerPkg.GetNopLogger(),
+		tracing.InitNoopTracer(),
+	)
+
+	reporter.HandleMuteAdded(&types.Mute{})
+	require.Zero(t, httpmock.GetTotalCallCount())
+	require.Len(t, database.Incidents, 1)
+}
+
+//nolint:paralleltest // disabled
+func TestPagerDutyReporterMuteNotGlobal(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	database := &databasePkg.StubDatabase{
+		Incidents: []*types.Incident{
+			{Reporter: "pagerduty-reporter", Chain: "chain", ProposalID: "proposal", DedupKey: "key1"},
+		},
+	}
+	reporter := getTestReporter(database)
+
+	reporter.HandleMuteAdded(&types.Mute{
+		Chain:       null.StringFrom("chain"),
+		Destination: null.StringFrom("telegram:123"),
+	})
+	require.Zero(t, httpmock.GetTotalCallCount())
+	require.Len(t, database.Incidents, 1)
+}
+
+//nolint:paralleltest // disabled
+func TestPagerDutyReporterMuteErrorResolving(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	reporter := getTestReporter(&databasePkg.StubDatabase{GetIncidentsError: errors.New("custom error")})
+	reporter.HandleMuteAdded(&types.Mute{})
+	require.Zero(t, httpmock.GetTotalCallCount())
+}
+
+func TestPagerDutyReporterNotAlert(t *testing.T) {
+	t.Parallel()
+
+	reporter := getTestReporter(&databasePkg.StubDatabase{})
+	err := reporter.SendReportEntry(events.GenericErrorEvent{}, context.Background())
+	require.NoError(t, err)
+}
+
+func TestPagerDutyReporterResolvesAlerts(t *testing.T) {
+	t.Parallel()
+
+	reporter := getTestReporter(&databasePkg.StubDatabase{})
+	require.False(t, reporter.ResolvesAlerts(events.NotVotedEvent{}))
+	require.True(t, reporter.ResolvesAlerts(events.VotedEvent{}))
+	require.True(t, reporter.ResolvesAlerts(events.RevotedEvent{}))
+	require.True(t, reporter.ResolvesAlerts(events.FinishedVotingEvent{}))
+	require.False(t, reporter.ResolvesAlerts(events.GenericErrorEvent{}))
+}
@@ -2,7 +2,12 @@ package reporters
 
 import (
 	"context"
+	"fmt"
+	"main/pkg/events"
 	"main/pkg/report/entry"
+	"main/pkg/state"
+	"sort"
+	"strings"
 )
 
 type Reporter interface {
@@ -12,6 +17,82 @@ type Reporter interface {
 	Name() string
 }
 
+// GroupingReporter is a reporter that can send all the wallet-related alerts
+// for a chain as a single message, see events.GroupedEvent.
+type GroupingReporter interface {
+	Reporter
+	GroupsAlerts() bool
+}
+
+// DashboardReporter is a reporter that can keep a pinned message with the proposals
+// in voting up to date in each of its chats or channels.
+type DashboardReporter interface {
+	Reporter
+	DashboardEnabled() bool
+	UpdateDashboard(state state.RenderedState, ctx context.Context) error
+}
+
+// ResolvingReporter is a reporter that opens alerts and resolves them later, like PagerDuty incidents.
+// The entries resolving alerts are sent to it even if none of its routes match them,
+// as otherwise the alerts opened before would never be resolved.
+type ResolvingReporter interface {
+	Reporter
+	ResolvesAlerts(entry entry.ReportEntry) bool
+}
+
+// AlertEditingReporter is a reporter that edits the alerts it sent before with the newer state.
+// The sent alerts are edited on every run, even when the reminder for the entry is not due yet
+// and it's not sent as a new message. Once voting has finished, the stored alerts are deleted
+// even if the entry is muted or not routed to this reporter, as they won't be edited anymore.
+type AlertEditingReporter interface {
+	Reporter
+	EditsAlerts() bool
+	EditSentAlert(entry entry.ReportEntry, ctx context.Context) error
+	DeleteAlertMessages(entry entry.ReportEntry)
+}
+
+// MultiDestinationReporter is a reporter that sends entries to several destinations,
+// like chats or channels. When sending to some of them fails, it returns a DestinationsError,
+// so the entry is only retried for the destinations it failed to be sent to.
+type MultiDestinationReporter interface {
+	Reporter
+	SendReportEntryToDestinations(entry entry.ReportEntry, destinations []string, ctx context.Context) error
+}
+
+// DestinationsError is returned when a report entry failed to be sent to some of the
+// destinations, with the errors for each of them.
+type DestinationsError struct {
+	Errors map[string]error
+}
+
+// NewDestinationsError returns nil if there are no errors, so it can be returned as is.
+func NewDestinationsError(errs map[string]error) error {
+	if len(errs) == 0 {
+		return nil
+	}
+
+	return &DestinationsError{Errors: errs}
+}
+
+func (e *DestinationsError) Destinations() []string {
+	destinations := make([]string, 0, len(e.Errors))
+	for destination := range e.Errors {
+		destinations = append(destinations, destination)
+	}
+
+	sort.Strings(destinations)
+	return destinations
+}
+
+func (e *DestinationsError) Error() string {
+	messages := make([]string, 0, len(e.Errors))
+	for _, destination := range e.Destinations() {
+		messages = append(messages, fmt.Sprintf("%s: %s", destination, e.Errors[destination]))
+	}
+
+	return strings.Join(messages, "\n")
+}
+
 type Report struct {
 	Entries []entry.ReportEntry
 }
@@ -19,3 +100,13 @@ type Report struct {
 func (r *Report) Empty() bool {
 	return len(r.Entries) == 0
 }
+
+func (r *Report) HasNotVotedEntries() bool {
+	for _, reportEntry := range r.Entries {
+		if _, ok := reportEntry.(events.NotVotedEvent); ok {
+			return true
+		}
+	}
+
+	return false
+}
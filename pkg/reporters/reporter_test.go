@@ -0,0 +1,23 @@
+package reporters
+
+import (
+	"main/pkg/events"
+	"main/pkg/report/entry"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReportHasNotVotedEntries(t *testing.T) {
+	t.Parallel()
+
+	assert.False(t, (&Report{}).HasNotVotedEntries())
+	assert.False(t, (&Report{Entries: []entry.ReportEntry{
+		events.VotedEvent{},
+		events.GenericErrorEvent{},
+	}}).HasNotVotedEntries())
+	assert.True(t, (&Report{Entries: []entry.ReportEntry{
+		events.VotedEvent{},
+		events.NotVotedEvent{},
+	}}).HasNotVotedEntries())
+}
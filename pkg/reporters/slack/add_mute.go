@@ -0,0 +1,26 @@
+package slack
+
+import (
+	"fmt"
+
+	"github.com/slack-go/slack"
+)
+
+func (reporter *Reporter) HandleAddMute(cmd slack.SlashCommand) error {
+	reporter.Logger.Info().
+		Str("sender", cmd.UserName).
+		Str("text", cmd.Text).
+		Msg("Got add mute query")
+
+	mute, err := ParseMuteOptions(cmd)
+	if err != "" {
+		return reporter.BotReply(cmd, "Error muting notification: "+err)
+	}
+
+	if insertErr := reporter.MutesManager.AddMute(mute); insertErr != nil {
+		reporter.Logger.Error().Err(insertErr).Msg("Error adding mute")
+		return reporter.BotReply(cmd, fmt.Sprintf("Error adding mute: %s", insertErr))
+	}
+
+	return reporter.ReplyRender(cmd, "mute_added", mute)
+}
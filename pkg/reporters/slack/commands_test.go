@@ -0,0 +1,461 @@
+package slack
+
+import (
+	"errors"
+	"main/assets"
+	databasePkg "main/pkg/database"
+	"main/pkg/types"
+	"testing"
+	"time"
+
+	"github.com/guregu/null/v5"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/require"
+)
+
+//nolint:paralleltest // disabled
+func TestSlackReporterHelp(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder(
+		"POST",
+		"https://slack.com/api/chat.postMessage",
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("slack-post-message-ok.json")))
+
+	reporter := getInitializedTestReporter(t, types.Chains{}, &databasePkg.StubDatabase{})
+
+	err := reporter.HandleCommand(getTestCommand("/proposals_help", ""))
+	require.NoError(t, err)
+}
+
+//nolint:paralleltest // disabled
+func TestSlackReporterAddMuteInvalidArgs(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterMatcherResponder(
+		"POST",
+		"https://slack.com/api/chat.postMessage",
+		types.SlackRequestHasText("Error muting notification: Invalid duration provided: invalid"),
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("slack-post-message-ok.json")))
+
+	reporter := getInitializedTestReporter(t, types.Chains{}, &databasePkg.StubDatabase{})
+
+	err := reporter.HandleCommand(getTestCommand("/proposals_mute", "invalid"))
+	require.NoError(t, err)
+}
+
+//nolint:paralleltest // disabled
+func TestSlackReporterAddMuteErrorSaving(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterMatcherResponder(
+		"POST",
+		"https://slack.com/api/chat.postMessage",
+		types.SlackRequestHasText("Error adding mute: storage error"),
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("slack-post-message-ok.json")))
+
+	reporter := getInitializedTestReporter(t, types.Chains{}, &databasePkg.StubDatabase{
+		UpsertMuteError: errors.New("storage error"),
+	})
+
+	err := reporter.HandleCommand(getTestCommand("/proposals_mute", "1h chain=chain"))
+	require.NoError(t, err)
+}
+
+//nolint:paralleltest // disabled
+func TestSlackReporterAddMuteOk(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder(
+		"POST",
+		"https://slack.com/api/chat.postMessage",
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("slack-post-message-ok.json")))
+
+	database := &databasePkg.StubDatabase{}
+	reporter := getInitializedTestReporter(t, types.Chains{}, database)
+
+	err := reporter.HandleCommand(getTestCommand("/proposals_mute", "1h chain=chain"))
+	require.NoError(t, err)
+
+	mutes, err := database.GetAllMutes()
+	require.NoError(t, err)
+	require.Len(t, mutes, 1)
+}
+
+//nolint:paralleltest // disabled
+func TestSlackReporterDeleteMuteNotFound(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterMatcherResponder(
+		"POST",
+		"https://slack.com/api/chat.postMessage",
+		types.SlackRequestHasText("Could not find the mute to delete!"),
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("slack-post-message-ok.json")))
+
+	reporter := getInitializedTestReporter(t, types.Chains{}, &databasePkg.StubDatabase{})
+
+	err := reporter.HandleCommand(getTestCommand("/proposals_unmute", "chain=chain"))
+	require.NoError(t, err)
+}
+
+//nolint:paralleltest // disabled
+func TestSlackReporterDeleteMuteOk(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterMatcherResponder(
+		"POST",
+		"https://slack.com/api/chat.postMessage",
+		types.SlackRequestHasText("Deleted mute with the following params:\n*Chain:* chain\n*Proposal ID:* all proposals"),
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("slack-post-message-ok.json")))
+
+	database := &databasePkg.StubDatabase{}
+	require.NoError(t, database.UpsertMute(&types.Mute{
+		Chain:   null.StringFrom("chain"),
+		Expires: time.Now().Add(time.Hour),
+	}))
+
+	reporter := getInitializedTestReporter(t, types.Chains{}, database)
+
+	err := reporter.HandleCommand(getTestCommand("/proposals_unmute", "chain=chain"))
+	require.NoError(t, err)
+}
+
+//nolint:paralleltest // disabled
+func TestSlackReporterListMutesError(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterMatcherResponder(
+		"POST",
+		"https://slack.com/api/chat.postMessage",
+		types.SlackRequestHasText("Error fetching mutes: storage error"),
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("slack-post-message-ok.json")))
+
+	reporter := getInitializedTestReporter(t, types.Chains{}, &databasePkg.StubDatabase{
+		GetAllMutesError: errors.New("storage error"),
+	})
+
+	err := reporter.HandleCommand(getTestCommand("/proposals_mutes", ""))
+	require.NoError(t, err)
+}
+
+//nolint:paralleltest // disabled
+func TestSlackReporterListMutesEmpty(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterMatcherResponder(
+		"POST",
+		"https://slack.com/api/chat.postMessage",
+		types.SlackRequestHasText("No active mutes."),
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("slack-post-message-ok.json")))
+
+	reporter := getInitializedTestReporter(t, types.Chains{}, &databasePkg.StubDatabase{})
+
+	err := reporter.HandleCommand(getTestCommand("/proposals_mutes", ""))
+	require.NoError(t, err)
+}
+
+//nolint:paralleltest // disabled
+func TestSlackReporterListMutesHistoryError(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterMatcherResponder(
+		"POST",
+		"https://slack.com/api/chat.postMessage",
+		types.SlackRequestHasText("Error fetching mutes history: storage error"),
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("slack-post-message-ok.json")))
+
+	reporter := getInitializedTestReporter(t, types.Chains{}, &databasePkg.StubDatabase{
+		GetMutesHistoryError: errors.New("storage error"),
+	})
+
+	err := reporter.HandleCommand(getTestCommand("/proposals_mutes_history", ""))
+	require.NoError(t, err)
+}
+
+//nolint:paralleltest // disabled
+func TestSlackReporterListMutesHistoryEmpty(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterMatcherResponder(
+		"POST",
+		"https://slack.com/api/chat.postMessage",
+		types.SlackRequestHasText("No deleted or expired mutes."),
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("slack-post-message-ok.json")))
+
+	reporter := getInitializedTestReporter(t, types.Chains{}, &databasePkg.StubDatabase{})
+
+	err := reporter.HandleCommand(getTestCommand("/proposals_mutes_history", ""))
+	require.NoError(t, err)
+}
+
+//nolint:paralleltest // disabled
+func TestSlackReporterListRoutesEmpty(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterMatcherResponder(
+		"POST",
+		"https://slack.com/api/chat.postMessage",
+		types.SlackRequestHasText("No routing rules, all reporters receive all notifications."),
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("slack-post-message-ok.json")))
+
+	reporter := getInitializedTestReporter(t, types.Chains{}, &databasePkg.StubDatabase{})
+
+	err := reporter.HandleCommand(getTestCommand("/routes", ""))
+	require.NoError(t, err)
+}
+
+//nolint:paralleltest // disabled
+func TestSlackReporterListProposals(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterMatcherResponder(
+		"POST",
+		"https://slack.com/api/chat.postMessage",
+		types.SlackRequestHasText("*No active proposals*"),
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("slack-post-message-ok.json")))
+
+	reporter := getInitializedTestReporter(t, types.Chains{}, &databasePkg.StubDatabase{})
+
+	err := reporter.HandleCommand(getTestCommand("/proposals", ""))
+	require.NoError(t, err)
+}
+
+//nolint:paralleltest // disabled
+func TestSlackReporterParamsError(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder(
+		"GET",
+		"https://example.com/cosmos/gov/v1beta1/params/deposit",
+		httpmock.NewErrorResponder(errors.New("custom error")))
+	httpmock.RegisterResponder(
+		"GET",
+		"https://example.com/cosmos/gov/v1beta1/params/voting",
+		httpmock.NewErrorResponder(errors.New("custom error")))
+	httpmock.RegisterResponder(
+		"GET",
+		"https://example.com/cosmos/gov/v1beta1/params/tallying",
+		httpmock.NewErrorResponder(errors.New("custom error")))
+
+	httpmock.RegisterResponder(
+		"POST",
+		"https://slack.com/api/chat.postMessage",
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("slack-post-message-ok.json")))
+
+	chains := types.Chains{{Name: "chain", LCDEndpoints: []string{"https://example.com"}}}
+	reporter := getInitializedTestReporter(t, chains, &databasePkg.StubDatabase{})
+
+	err := reporter.HandleCommand(getTestCommand("/params", ""))
+	require.NoError(t, err)
+}
+
+//nolint:paralleltest // disabled
+func TestSlackReporterTallyErrorSendingFirstMessage(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterMatcherResponder(
+		"POST",
+		"https://slack.com/api/chat.postMessage",
+		types.SlackRequestHasText("Calculating tally for proposals. This might take a while..."),
+		httpmock.NewErrorResponder(errors.New("custom error")))
+
+	reporter := getInitializedTestReporter(t, types.Chains{}, &databasePkg.StubDatabase{})
+
+	err := reporter.HandleCommand(getTestCommand("/tally", ""))
+	require.Error(t, err)
+	require.ErrorContains(t, err, "custom error")
+}
+
+//nolint:paralleltest // disabled
+func TestSlackReporterTallyOk(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder(
+		"POST",
+		"https://slack.com/api/chat.postMessage",
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("slack-post-message-ok.json")))
+
+	reporter := getInitializedTestReporter(t, types.Chains{}, &databasePkg.StubDatabase{})
+
+	err := reporter.HandleCommand(getTestCommand("/tally", ""))
+	require.NoError(t, err)
+}
+
+//nolint:paralleltest // disabled
+func TestSlackReporterNotSpamInvalidArgs(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterMatcherResponder(
+		"POST",
+		"https://slack.com/api/chat.postMessage",
+		types.SlackRequestHasText("Usage: /proposals_not_spam <chain> <proposal ID>"),
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("slack-post-message-ok.json")))
+
+	reporter := getInitializedTestReporter(t, types.Chains{}, &databasePkg.StubDatabase{})
+
+	err := reporter.HandleCommand(getTestCommand("/proposals_not_spam", "chain"))
+	require.NoError(t, err)
+}
+
+//nolint:paralleltest // disabled
+func TestSlackReporterNotSpamNotFound(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterMatcherResponder(
+		"POST",
+		"https://slack.com/api/chat.postMessage",
+		types.SlackRequestHasText("Could not find the proposal flagged as spam!"),
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("slack-post-message-ok.json")))
+
+	reporter := getInitializedTestReporter(t, types.Chains{}, &databasePkg.StubDatabase{})
+
+	err := reporter.HandleCommand(getTestCommand("/proposals_not_spam", "chain 1"))
+	require.NoError(t, err)
+}
+
+//nolint:paralleltest // disabled
+func TestSlackReporterNotSpamOk(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterMatcherResponder(
+		"POST",
+		"https://slack.com/api/chat.postMessage",
+		types.SlackRequestHasText("Proposal 1 on chain is not considered spam anymore."),
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("slack-post-message-ok.json")))
+
+	database := &databasePkg.StubDatabase{
+		SpamFlags: []*types.SpamFlag{{Chain: "chain", ProposalID: "1", Flagged: true}},
+	}
+	reporter := getInitializedTestReporter(t, types.Chains{}, database)
+
+	err := reporter.HandleCommand(getTestCommand("/proposals_not_spam", "chain 1"))
+	require.NoError(t, err)
+	require.False(t, database.SpamFlags[0].Flagged)
+}
+
+//nolint:paralleltest // disabled
+func TestSlackReporterListUndeliveredError(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterMatcherResponder(
+		"POST",
+		"https://slack.com/api/chat.postMessage",
+		types.SlackRequestHasText("Error fetching undelivered notifications: storage error"),
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("slack-post-message-ok.json")))
+
+	reporter := getInitializedTestReporter(t, types.Chains{}, &databasePkg.StubDatabase{
+		GetOutboxError: errors.New("storage error"),
+	})
+
+	err := reporter.HandleCommand(getTestCommand("/proposals_undelivered", ""))
+	require.NoError(t, err)
+}
+
+//nolint:paralleltest // disabled
+func TestSlackReporterListUndeliveredEmpty(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterMatcherResponder(
+		"POST",
+		"https://slack.com/api/chat.postMessage",
+		types.SlackRequestHasText("No undelivered notifications."),
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("slack-post-message-ok.json")))
+
+	reporter := getInitializedTestReporter(t, types.Chains{}, &databasePkg.StubDatabase{})
+
+	err := reporter.HandleCommand(getTestCommand("/proposals_undelivered", ""))
+	require.NoError(t, err)
+}
+
+func getAccessTestReporter(t *testing.T, database databasePkg.Database) *Reporter {
+	t.Helper()
+
+	httpmock.RegisterResponder(
+		"POST",
+		"https://slack.com/api/auth.test",
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("slack-auth-ok.json")))
+
+	reporter := getTestReporter(t, types.SlackConfig{
+		Token:   "xoxb-token",
+		Channel: "channel",
+		Users:   []string{"user"},
+		Admins:  []string{"admin"},
+	}, types.Chains{}, database)
+	require.NoError(t, reporter.InitBot())
+
+	return reporter
+}
+
+//nolint:paralleltest // disabled
+func TestSlackReporterAccessNotAllowed(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterMatcherResponder(
+		"POST",
+		"https://slack.com/api/chat.postMessage",
+		types.SlackRequestHasText("You are not allowed to run this command."),
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("slack-post-message-ok.json")))
+
+	database := &databasePkg.StubDatabase{
+		SpamFlags: []*types.SpamFlag{{Chain: "chain", ProposalID: "1"}},
+	}
+	reporter := getAccessTestReporter(t, database)
+
+	command := getTestCommand("/routes", "")
+	command.UserID = "stranger"
+	require.NoError(t, reporter.HandleCommand(command))
+
+	// users cannot run mutating commands if admins are set
+	for _, text := range []string{"/proposals_mute", "/proposals_unmute", "/proposals_not_spam"} {
+		command = getTestCommand(text, "chain 1")
+		command.UserID = "user"
+		require.NoError(t, reporter.HandleCommand(command))
+	}
+
+	require.Empty(t, database.Mutes)
+	require.Len(t, database.SpamFlags, 1)
+}
+
+//nolint:paralleltest // disabled
+func TestSlackReporterAccessAllowed(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder(
+		"POST",
+		"https://slack.com/api/chat.postMessage",
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("slack-post-message-ok.json")))
+
+	database := &databasePkg.StubDatabase{}
+	reporter := getAccessTestReporter(t, database)
+
+	command := getTestCommand("/routes", "")
+	command.UserID = "user"
+	require.NoError(t, reporter.HandleCommand(command))
+
+	command = getTestCommand("/proposals_mute", "1h chain=chain")
+	command.UserID = "admin"
+	require.NoError(t, reporter.HandleCommand(command))
+	require.Len(t, database.Mutes, 1)
+}
@@ -0,0 +1,27 @@
+package slack
+
+import (
+	"fmt"
+
+	"github.com/slack-go/slack"
+)
+
+func (reporter *Reporter) HandleDeleteMute(cmd slack.SlashCommand) error {
+	reporter.Logger.Info().
+		Str("sender", cmd.UserName).
+		Str("text", cmd.Text).
+		Msg("Got delete mute query")
+
+	mute, err := ParseMuteDeleteOptions(cmd)
+	if err != "" {
+		return reporter.BotReply(cmd, "Error deleting mute: "+err)
+	}
+
+	if found, deleteErr := reporter.MutesManager.DeleteMute(mute, cmd.UserName); deleteErr != nil {
+		return reporter.BotReply(cmd, fmt.Sprintf("Error deleting mute: %s!", deleteErr))
+	} else if !found {
+		return reporter.BotReply(cmd, "Could not find the mute to delete!")
+	}
+
+	return reporter.ReplyRender(cmd, "mute_deleted", mute)
+}
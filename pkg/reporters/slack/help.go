@@ -0,0 +1,14 @@
+package slack
+
+import (
+	"github.com/slack-go/slack"
+)
+
+func (reporter *Reporter) HandleHelp(cmd slack.SlashCommand) error {
+	reporter.Logger.Info().
+		Str("sender", cmd.UserName).
+		Str("text", cmd.Text).
+		Msg("Got help query")
+
+	return reporter.ReplyRender(cmd, "help", reporter.Version)
+}
@@ -0,0 +1,28 @@
+package slack
+
+import (
+	"fmt"
+	"main/pkg/types"
+	"main/pkg/utils"
+
+	"github.com/slack-go/slack"
+)
+
+func (reporter *Reporter) HandleListMutes(cmd slack.SlashCommand) error {
+	reporter.Logger.Info().
+		Str("sender", cmd.UserName).
+		Str("text", cmd.Text).
+		Msg("Got list mutes query")
+
+	mutes, err := reporter.MutesManager.GetAllMutes()
+	if err != nil {
+		reporter.Logger.Error().Err(err).Msg("Error fetching mutes")
+		return reporter.BotReply(cmd, fmt.Sprintf("Error fetching mutes: %s", err))
+	}
+
+	filteredMutes := utils.Filter(mutes, func(m *types.Mute) bool {
+		return !m.IsExpired()
+	})
+
+	return reporter.ReplyRender(cmd, "mutes", filteredMutes)
+}
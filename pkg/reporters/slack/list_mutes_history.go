@@ -0,0 +1,22 @@
+package slack
+
+import (
+	"fmt"
+
+	"github.com/slack-go/slack"
+)
+
+func (reporter *Reporter) HandleListMutesHistory(cmd slack.SlashCommand) error {
+	reporter.Logger.Info().
+		Str("sender", cmd.UserName).
+		Str("text", cmd.Text).
+		Msg("Got list mutes history query")
+
+	history, err := reporter.MutesManager.GetMutesHistory()
+	if err != nil {
+		reporter.Logger.Error().Err(err).Msg("Error fetching mutes history")
+		return reporter.BotReply(cmd, fmt.Sprintf("Error fetching mutes history: %s", err))
+	}
+
+	return reporter.ReplyRender(cmd, "mutes_history", history)
+}
@@ -0,0 +1,20 @@
+package slack
+
+import (
+	"context"
+	statePkg "main/pkg/state"
+
+	"github.com/slack-go/slack"
+)
+
+func (reporter *Reporter) HandleProposals(cmd slack.SlashCommand) error {
+	reporter.Logger.Info().
+		Str("sender", cmd.UserName).
+		Str("text", cmd.Text).
+		Msg("Got proposals list query")
+
+	state := reporter.StateGenerator.GetState(statePkg.NewState(), context.Background())
+	renderedState := state.ToRenderedState()
+
+	return reporter.ReplyRender(cmd, "proposals", renderedState)
+}
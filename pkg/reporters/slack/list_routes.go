@@ -0,0 +1,14 @@
+package slack
+
+import (
+	"github.com/slack-go/slack"
+)
+
+func (reporter *Reporter) HandleRoutes(cmd slack.SlashCommand) error {
+	reporter.Logger.Info().
+		Str("sender", cmd.UserName).
+		Str("text", cmd.Text).
+		Msg("Got routes query")
+
+	return reporter.ReplyRender(cmd, "routes", reporter.RoutesManager.GetRoutes())
+}
@@ -0,0 +1,22 @@
+package slack
+
+import (
+	"fmt"
+
+	"github.com/slack-go/slack"
+)
+
+func (reporter *Reporter) HandleListUndelivered(cmd slack.SlashCommand) error {
+	reporter.Logger.Info().
+		Str("sender", cmd.UserName).
+		Str("text", cmd.Text).
+		Msg("Got list undelivered notifications query")
+
+	outboxEntries, err := reporter.OutboxManager.GetUndelivered()
+	if err != nil {
+		reporter.Logger.Error().Err(err).Msg("Error fetching undelivered notifications")
+		return reporter.BotReply(cmd, fmt.Sprintf("Error fetching undelivered notifications: %s", err))
+	}
+
+	return reporter.ReplyRender(cmd, "undelivered", outboxEntries)
+}
@@ -0,0 +1,32 @@
+package slack
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/slack-go/slack"
+)
+
+func (reporter *Reporter) HandleNotSpam(cmd slack.SlashCommand) error {
+	reporter.Logger.Info().
+		Str("sender", cmd.UserName).
+		Str("text", cmd.Text).
+		Msg("Got not spam query")
+
+	args := strings.Fields(cmd.Text)
+	if len(args) != 2 {
+		return reporter.BotReply(cmd, "Usage: /proposals_not_spam <chain> <proposal ID>")
+	}
+
+	found, err := reporter.SpamManager.Unflag(args[0], args[1], cmd.UserName)
+	if err != nil {
+		return reporter.BotReply(cmd, fmt.Sprintf("Error un-flagging proposal: %s!", err))
+	} else if !found {
+		return reporter.BotReply(cmd, "Could not find the proposal flagged as spam!")
+	}
+
+	return reporter.BotReply(
+		cmd,
+		fmt.Sprintf("Proposal %s on %s is not considered spam anymore.", args[1], args[0]),
+	)
+}
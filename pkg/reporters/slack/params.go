@@ -0,0 +1,22 @@
+package slack
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/slack-go/slack"
+)
+
+func (reporter *Reporter) HandleParams(cmd slack.SlashCommand) error {
+	reporter.Logger.Info().
+		Str("sender", cmd.UserName).
+		Str("text", cmd.Text).
+		Msg("Got params query")
+
+	params, err := reporter.DataManager.GetParams(context.Background())
+	if err != nil {
+		return reporter.BotReply(cmd, fmt.Sprintf("Error getting chain params: %s", err))
+	}
+
+	return reporter.ReplyRender(cmd, "params", params)
+}
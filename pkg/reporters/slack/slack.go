@@ -0,0 +1,370 @@
+package slack
+
+import (
+	"context"
+	"fmt"
+	"main/pkg/data"
+	mutes "main/pkg/mutes"
+	"main/pkg/outbox"
+	"main/pkg/report/entry"
+	"main/pkg/routes"
+	"main/pkg/spam"
+	"main/pkg/state"
+	"main/pkg/templates"
+	"main/pkg/types"
+	"main/pkg/utils"
+	"strings"
+	"time"
+
+	"github.com/guregu/null/v5"
+	"github.com/rs/zerolog"
+	"github.com/slack-go/slack"
+	"github.com/slack-go/slack/socketmode"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type Command struct {
+	Handler     func(cmd slack.SlashCommand) error
+	AccessLevel types.AccessLevel
+}
+
+type Reporter struct {
+	Token    string
+	AppToken string
+	Channel  string
+	Config   types.SlackConfig
+
+	MutesManager     *mutes.Manager
+	RoutesManager    *routes.Manager
+	SpamManager      *spam.Manager
+	OutboxManager    *outbox.Manager
+	StateGenerator   *state.Generator
+	DataManager      *data.Manager
+	TemplatesManager templates.Manager
+	Tracer           trace.Tracer
+
+	Client       *slack.Client
+	SocketClient *socketmode.Client
+	Commands     map[string]*Command
+	Logger       zerolog.Logger
+
+	Version string
+}
+
+const (
+	MaxMessageSize = 4000
+)
+
+func NewSlackReporter(
+	config types.SlackConfig,
+	mutesManager *mutes.Manager,
+	routesManager *routes.Manager,
+	spamManager *spam.Manager,
+	outboxManager *outbox.Manager,
+	stateGenerator *state.Generator,
+	dataManager *data.Manager,
+	logger *zerolog.Logger,
+	version string,
+	timezone *time.Location,
+	tracer trace.Tracer,
+) *Reporter {
+	return &Reporter{
+		Token:            config.Token,
+		AppToken:         config.AppToken,
+		Channel:          config.Channel,
+		Config:           config,
+		MutesManager:     mutesManager,
+		RoutesManager:    routesManager,
+		SpamManager:      spamManager,
+		OutboxManager:    outboxManager,
+		StateGenerator:   stateGenerator,
+		DataManager:      dataManager,
+		Logger:           logger.With().Str("component", "slack_reporter").Logger(),
+		TemplatesManager: templates.NewSlackTemplatesManager(logger, timezone),
+		Commands:         make(map[string]*Command),
+		Version:          version,
+		Tracer:           tracer,
+	}
+}
+
+func (reporter *Reporter) Init() error {
+	if !reporter.Enabled() {
+		reporter.Logger.Debug().Msg("Slack credentials not set, not creating Slack reporter.")
+		return nil
+	}
+
+	if err := reporter.InitBot(); err != nil {
+		return err
+	}
+
+	if reporter.SocketClient != nil {
+		go reporter.Listen()
+
+		go func() {
+			if err := reporter.SocketClient.Run(); err != nil {
+				reporter.Logger.Error().Err(err).Msg("Slack socket mode connection closed")
+			}
+		}()
+	}
+
+	return nil
+}
+
+func (reporter *Reporter) InitBot() error {
+	options := []slack.Option{}
+	if reporter.AppToken != "" {
+		options = append(options, slack.OptionAppLevelToken(reporter.AppToken))
+	}
+
+	client := slack.New(reporter.Token, options...)
+
+	if _, err := client.AuthTest(); err != nil {
+		reporter.Logger.Warn().Err(err).Msg("Could not create Slack bot")
+		return err
+	}
+
+	reporter.Client = client
+	reporter.Commands = map[string]*Command{
+		"/proposals_help":          {Handler: reporter.HandleHelp},
+		"/proposals_mute":          {Handler: reporter.HandleAddMute, AccessLevel: types.AccessLevelWrite},
+		"/proposals_unmute":        {Handler: reporter.HandleDeleteMute, AccessLevel: types.AccessLevelWrite},
+		"/proposals_mutes":         {Handler: reporter.HandleListMutes},
+		"/proposals_mutes_history": {Handler: reporter.HandleListMutesHistory},
+		"/routes":                  {Handler: reporter.HandleRoutes},
+		"/proposals":               {Handler: reporter.HandleProposals},
+		"/tally":                   {Handler: reporter.HandleTally},
+		"/params":                  {Handler: reporter.HandleParams},
+		"/proposals_not_spam":      {Handler: reporter.HandleNotSpam, AccessLevel: types.AccessLevelWrite},
+		"/proposals_undelivered":   {Handler: reporter.HandleListUndelivered},
+	}
+
+	// Slash commands are delivered via Socket Mode, which requires an app-level token.
+	// Without it, the reporter would only send alerts.
+	if reporter.AppToken != "" {
+		reporter.SocketClient = socketmode.New(client)
+	} else {
+		reporter.Logger.Info().Msg("Slack app token is not set, slash commands are disabled.")
+	}
+
+	return nil
+}
+
+func (reporter *Reporter) Listen() {
+	for event := range reporter.SocketClient.Events {
+		switch event.Type {
+		case socketmode.EventTypeConnecting:
+			reporter.Logger.Debug().Msg("Connecting to Slack with Socket Mode...")
+		case socketmode.EventTypeConnectionError:
+			reporter.Logger.Warn().Msg("Slack Socket Mode connection failed, retrying...")
+		case socketmode.EventTypeConnected:
+			reporter.Logger.Info().Msg("Slack bot listening")
+		case socketmode.EventTypeSlashCommand:
+			cmd, ok := event.Data.(slack.SlashCommand)
+			if !ok {
+				reporter.Logger.Warn().Msg("Got unexpected slash command payload")
+				continue
+			}
+
+			if event.Request != nil {
+				reporter.SocketClient.Ack(*event.Request)
+			}
+
+			if err := reporter.HandleCommand(cmd); err != nil {
+				reporter.Logger.Error().
+					Err(err).
+					Str("command", cmd.Command).
+					Msg("Error handling Slack command")
+			}
+		default:
+		}
+	}
+}
+
+func (reporter *Reporter) HandleCommand(cmd slack.SlashCommand) error {
+	command, ok := reporter.Commands[cmd.Command]
+	if !ok {
+		return reporter.BotReply(cmd, fmt.Sprintf("Unknown command: %s", cmd.Command))
+	}
+
+	if !reporter.Config.HasAccess(cmd.UserID, command.AccessLevel) {
+		reporter.Logger.Warn().
+			Str("user_id", cmd.UserID).
+			Str("command", cmd.Command).
+			Str("level", command.AccessLevel.String()).
+			Msg("Got command from a user without access, ignoring")
+		return reporter.BotReply(cmd, "You are not allowed to run this command.")
+	}
+
+	if command.AccessLevel == types.AccessLevelWrite {
+		reporter.Logger.Info().
+			Str("user_id", cmd.UserID).
+			Str("username", cmd.UserName).
+			Str("channel_id", cmd.ChannelID).
+			Str("command", cmd.Command).
+			Str("text", cmd.Text).
+			Msg("Running mutating command")
+	}
+
+	return command.Handler(cmd)
+}
+
+func (reporter *Reporter) Enabled() bool {
+	return reporter.Token != "" && reporter.Channel != ""
+}
+
+func (reporter *Reporter) SerializeReportEntry(e entry.ReportEntry) (string, error) {
+	return reporter.TemplatesManager.Render(e.Name(), e)
+}
+
+func (reporter *Reporter) SendReportEntry(reportEntry entry.ReportEntry, ctx context.Context) error {
+	_, span := reporter.Tracer.Start(ctx, "Sending Slack report entry")
+	defer span.End()
+
+	serializedEntry, err := reporter.SerializeReportEntry(reportEntry)
+	if err != nil {
+		reporter.Logger.Err(err).Msg("Could not serialize report entry")
+		return err
+	}
+
+	if err := reporter.SendMessage(reporter.Channel, serializedEntry); err != nil {
+		reporter.Logger.Err(err).Msg("Could not send Slack message")
+		return err
+	}
+
+	return nil
+}
+
+func (reporter *Reporter) Name() string {
+	return "slack-reporter"
+}
+
+func (reporter *Reporter) SendMessage(channel string, msg string) error {
+	for _, chunk := range utils.SplitStringIntoChunks(msg, MaxMessageSize) {
+		if strings.TrimSpace(chunk) == "" {
+			continue
+		}
+
+		if _, _, err := reporter.Client.PostMessage(
+			channel,
+			slack.MsgOptionText(strings.TrimSpace(chunk), false),
+			slack.MsgOptionDisableLinkUnfurl(),
+		); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (reporter *Reporter) BotReply(cmd slack.SlashCommand, msg string) error {
+	if err := reporter.SendMessage(cmd.ChannelID, msg); err != nil {
+		reporter.Logger.Error().Err(err).Msg("Could not send Slack message")
+		return err
+	}
+
+	return nil
+}
+
+func (reporter *Reporter) ReplyRender(
+	cmd slack.SlashCommand,
+	templateName string,
+	renderStruct any,
+) error {
+	template, err := reporter.TemplatesManager.Render(templateName, renderStruct)
+	if err != nil {
+		reporter.Logger.Error().Str("template", templateName).Err(err).Msg("Error rendering template")
+		return reporter.BotReply(cmd, fmt.Sprintf("Error rendering template: %s", err))
+	}
+
+	return reporter.BotReply(cmd, template)
+}
+
+func ParseMuteOptions(cmd slack.SlashCommand) (*types.Mute, string) {
+	args := strings.Fields(cmd.Text)
+	if len(args) < 1 {
+		return nil, "Usage: /proposals_mute <duration|until-end> [params]"
+	}
+
+	durationString, args := args[0], args[1:]
+
+	expires, durationText, err := types.ParseMuteExpiry(durationString)
+	if err != nil {
+		return nil, fmt.Sprintf("Invalid duration provided: %s", durationString)
+	}
+
+	mute, parseErr := parseMuteParams(args)
+	if parseErr != "" {
+		return nil, parseErr
+	}
+
+	mute.Expires = expires
+	mute.Comment = fmt.Sprintf(
+		"Muted using cosmos-proposals-checker %s by %s",
+		durationText,
+		cmd.UserName,
+	)
+	mute.Author = null.StringFrom(cmd.UserName)
+	mute.Source = null.StringFrom(types.MuteSourceSlack)
+
+	return mute, ""
+}
+
+func ParseMuteDeleteOptions(cmd slack.SlashCommand) (*types.Mute, string) {
+	// we only construct mute with its labels to compare, no need to take care
+	// about the expiration/comment
+	mute, err := parseMuteParams(strings.Fields(cmd.Text))
+	if err != "" {
+		return nil, err
+	}
+
+	mute.Expires = time.Now()
+	return mute, ""
+}
+
+func parseMuteParams(args []string) (*types.Mute, string) {
+	mute := &types.Mute{
+		Chain:      null.NewString("", false),
+		ProposalID: null.NewString("", false),
+	}
+
+	for index, arg := range args {
+		argSplit := strings.SplitN(arg, "=", 2)
+		if len(argSplit) < 2 {
+			return nil, fmt.Sprintf(
+				"Invalid param at position %d: expected an expression like \"[chain=cosmos]\", but got %s",
+				index+1,
+				arg,
+			)
+		}
+
+		switch argSplit[0] {
+		case "chain":
+			mute.Chain = null.StringFrom(argSplit[1])
+		case "proposal":
+			mute.ProposalID = null.StringFrom(argSplit[1])
+		case "wallet":
+			mute.Wallet = null.StringFrom(argSplit[1])
+		case "event":
+			if err := types.ValidateMuteEvent(argSplit[1]); err != nil {
+				return nil, fmt.Sprintf("Invalid event provided: %s", err)
+			}
+
+			mute.Event = null.StringFrom(argSplit[1])
+		case "title":
+			if err := types.ValidateMuteTitlePattern(argSplit[1]); err != nil {
+				return nil, fmt.Sprintf("Invalid title pattern provided: %s", err)
+			}
+
+			mute.TitlePattern = null.StringFrom(argSplit[1])
+		default:
+			// a typo in a param would otherwise create a broader mute than intended
+			return nil, fmt.Sprintf(
+				"Unknown param at position %d: %s, expected one of chain, proposal, wallet, event or title",
+				index+1,
+				argSplit[0],
+			)
+		}
+	}
+
+	return mute, ""
+}
@@ -0,0 +1,274 @@
+package slack
+
+import (
+	"context"
+	"errors"
+	"main/assets"
+	"main/pkg/data"
+	databasePkg "main/pkg/database"
+	"main/pkg/events"
+	loggerPkg "main/pkg/logger"
+	"main/pkg/metrics"
+	mutesmanager "main/pkg/mutes"
+	"main/pkg/outbox"
+	"main/pkg/routes"
+	"main/pkg/spam"
+	"main/pkg/state"
+	"main/pkg/tracing"
+	"main/pkg/types"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/require"
+)
+
+func getTestReporter(
+	t *testing.T,
+	config types.SlackConfig,
+	chains types.Chains,
+	database databasePkg.Database,
+) *Reporter {
+	t.Helper()
+
+	logger := loggerPkg.GetNopLogger()
+	metricsManager := metrics.NewManager(logger, types.MetricsConfig{})
+	tracer := tracing.InitNoopTracer()
+	mutesManager := mutesmanager.NewMutesManager(logger, database)
+	spamManager := spam.NewManager(logger, types.SpamConfig{}, database)
+	outboxManager := outbox.NewManager(logger, types.OutboxConfig{}, database)
+	routesManager := routes.NewManager(logger, types.Routes{})
+	stateGenerator := state.NewStateGenerator(logger, metricsManager, tracer, chains)
+	dataManager := data.NewManager(logger, chains, metricsManager, tracer)
+
+	timezone, err := time.LoadLocation("Etc/GMT")
+	require.NoError(t, err)
+
+	return NewSlackReporter(
+		config,
+		mutesManager,
+		routesManager,
+		spamManager,
+		outboxManager,
+		stateGenerator,
+		dataManager,
+		logger,
+		"1.2.3",
+		timezone,
+		tracer,
+	)
+}
+
+func getInitializedTestReporter(t *testing.T, chains types.Chains, database databasePkg.Database) *Reporter {
+	t.Helper()
+
+	httpmock.RegisterResponder(
+		"POST",
+		"https://slack.com/api/auth.test",
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("slack-auth-ok.json")))
+
+	reporter := getTestReporter(t, types.SlackConfig{Token: "xoxb-token", Channel: "channel"}, chains, database)
+	require.NoError(t, reporter.InitBot())
+
+	return reporter
+}
+
+func getTestCommand(command, text string) slack.SlashCommand {
+	return slack.SlashCommand{
+		Command:   command,
+		Text:      text,
+		UserName:  "testuser",
+		ChannelID: "channel",
+	}
+}
+
+//nolint:paralleltest // disabled
+func TestSlackReporterInitNotEnabled(t *testing.T) {
+	reporter := getTestReporter(t, types.SlackConfig{}, types.Chains{}, &databasePkg.StubDatabase{})
+
+	err := reporter.Init()
+	require.NoError(t, err)
+	require.False(t, reporter.Enabled())
+	require.Nil(t, reporter.Client)
+}
+
+//nolint:paralleltest // disabled
+func TestSlackReporterInitAuthFailed(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder(
+		"POST",
+		"https://slack.com/api/auth.test",
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("slack-auth-error.json")))
+
+	reporter := getTestReporter(
+		t,
+		types.SlackConfig{Token: "xoxb-token", Channel: "channel"},
+		types.Chains{},
+		&databasePkg.StubDatabase{},
+	)
+
+	err := reporter.Init()
+	require.Error(t, err)
+	require.ErrorContains(t, err, "invalid_auth")
+}
+
+//nolint:paralleltest // disabled
+func TestSlackReporterInitWithoutAppToken(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder(
+		"POST",
+		"https://slack.com/api/auth.test",
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("slack-auth-ok.json")))
+
+	reporter := getTestReporter(
+		t,
+		types.SlackConfig{Token: "xoxb-token", Channel: "channel"},
+		types.Chains{},
+		&databasePkg.StubDatabase{},
+	)
+
+	err := reporter.Init()
+	require.NoError(t, err)
+	require.NotNil(t, reporter.Client)
+	require.Nil(t, reporter.SocketClient)
+	require.Len(t, reporter.Commands, 11)
+}
+
+//nolint:paralleltest // disabled
+func TestSlackReporterInitBotWithAppToken(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder(
+		"POST",
+		"https://slack.com/api/auth.test",
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("slack-auth-ok.json")))
+
+	reporter := getTestReporter(
+		t,
+		types.SlackConfig{Token: "xoxb-token", AppToken: "xapp-token", Channel: "channel"},
+		types.Chains{},
+		&databasePkg.StubDatabase{},
+	)
+
+	err := reporter.InitBot()
+	require.NoError(t, err)
+	require.NotNil(t, reporter.SocketClient)
+}
+
+//nolint:paralleltest // disabled
+func TestSlackReporterSendReportEntryFailed(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder(
+		"POST",
+		"https://slack.com/api/chat.postMessage",
+		httpmock.NewErrorResponder(errors.New("custom error")))
+
+	reporter := getInitializedTestReporter(t, types.Chains{}, &databasePkg.StubDatabase{})
+
+	err := reporter.SendReportEntry(events.ProposalsQueryErrorEvent{
+		Chain: &types.Chain{Name: "chain"},
+		Error: &types.QueryError{QueryError: errors.New("query error")},
+	}, context.Background())
+	require.Error(t, err)
+	require.ErrorContains(t, err, "custom error")
+}
+
+//nolint:paralleltest // disabled
+func TestSlackReporterSendReportEntryOk(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterMatcherResponder(
+		"POST",
+		"https://slack.com/api/chat.postMessage",
+		types.SlackRequestHasText(
+			"❌ There was an error querying proposals on chain.\n"+
+				"*Error text:* query error\n"+
+				"Sent by <https://github.com/QuokkaStake/cosmos-proposals-checker|cosmos-proposals-checker>.",
+		),
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("slack-post-message-ok.json")))
+
+	reporter := getInitializedTestReporter(t, types.Chains{}, &databasePkg.StubDatabase{})
+	require.Equal(t, "slack-reporter", reporter.Name())
+
+	err := reporter.SendReportEntry(events.ProposalsQueryErrorEvent{
+		Chain: &types.Chain{Name: "chain"},
+		Error: &types.QueryError{QueryError: errors.New("query error")},
+	}, context.Background())
+	require.NoError(t, err)
+}
+
+//nolint:paralleltest // disabled
+func TestSlackReporterHandleUnknownCommand(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterMatcherResponder(
+		"POST",
+		"https://slack.com/api/chat.postMessage",
+		types.SlackRequestHasText("Unknown command: /unknown"),
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("slack-post-message-ok.json")))
+
+	reporter := getInitializedTestReporter(t, types.Chains{}, &databasePkg.StubDatabase{})
+
+	err := reporter.HandleCommand(getTestCommand("/unknown", ""))
+	require.NoError(t, err)
+}
+
+func TestSlackParseMuteOptions(t *testing.T) {
+	t.Parallel()
+
+	mute, err := ParseMuteOptions(getTestCommand("/proposals_mute", ""))
+	require.Nil(t, mute)
+	require.Equal(t, "Usage: /proposals_mute <duration|until-end> [params]", err)
+
+	mute, err = ParseMuteOptions(getTestCommand("/proposals_mute", "invalid"))
+	require.Nil(t, mute)
+	require.Equal(t, "Invalid duration provided: invalid", err)
+
+	mute, err = ParseMuteOptions(getTestCommand("/proposals_mute", "1h chain"))
+	require.Nil(t, mute)
+	require.Contains(t, err, "Invalid param at position 1")
+
+	mute, err = ParseMuteOptions(getTestCommand("/proposals_mute", "1h chain=chain walet=wallet"))
+	require.Nil(t, mute)
+	require.Equal(t, "Unknown param at position 2: walet, expected one of chain, proposal, wallet, event or title", err)
+
+	mute, err = ParseMuteOptions(getTestCommand("/proposals_mute", "1h chain=chain proposal=123"))
+	require.Empty(t, err)
+	require.NotNil(t, mute)
+	require.Equal(t, "chain", mute.Chain.String)
+	require.Equal(t, "123", mute.ProposalID.String)
+	require.Equal(t, "Muted using cosmos-proposals-checker for 1h0m0s by testuser", mute.Comment)
+	require.False(t, mute.IsExpired())
+}
+
+func TestSlackParseMuteDeleteOptions(t *testing.T) {
+	t.Parallel()
+
+	mute, err := ParseMuteDeleteOptions(getTestCommand("/proposals_unmute", "chain"))
+	require.Nil(t, mute)
+	require.Contains(t, err, "Invalid param at position 1")
+
+	mute, err = ParseMuteDeleteOptions(getTestCommand("/proposals_unmute", "walet=wallet"))
+	require.Nil(t, mute)
+	require.Contains(t, err, "Unknown param at position 1: walet")
+
+	mute, err = ParseMuteDeleteOptions(getTestCommand("/proposals_unmute", ""))
+	require.Empty(t, err)
+	require.False(t, mute.Chain.Valid)
+	require.False(t, mute.ProposalID.Valid)
+
+	mute, err = ParseMuteDeleteOptions(getTestCommand("/proposals_unmute", "proposal=123"))
+	require.Empty(t, err)
+	require.False(t, mute.Chain.Valid)
+	require.Equal(t, "123", mute.ProposalID.String)
+}
@@ -0,0 +1,26 @@
+package slack
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/slack-go/slack"
+)
+
+func (reporter *Reporter) HandleTally(cmd slack.SlashCommand) error {
+	reporter.Logger.Info().
+		Str("sender", cmd.UserName).
+		Str("text", cmd.Text).
+		Msg("Got tally list query")
+
+	if err := reporter.BotReply(cmd, "Calculating tally for proposals. This might take a while..."); err != nil {
+		return err
+	}
+
+	tallies, err := reporter.DataManager.GetTallies(context.Background())
+	if err != nil {
+		return reporter.BotReply(cmd, fmt.Sprintf("Error getting tallies info: %s", err))
+	}
+
+	return reporter.ReplyRender(cmd, "tally", tallies)
+}
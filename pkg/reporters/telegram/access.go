@@ -0,0 +1,54 @@
+package telegram
+
+import (
+	"main/pkg/types"
+
+	tele "gopkg.in/telebot.v3"
+)
+
+// RequireAccess only lets users allowed by the config to run the command,
+// and records who ran each mutating one.
+func (reporter *Reporter) RequireAccess(level types.AccessLevel) tele.MiddlewareFunc {
+	return func(next tele.HandlerFunc) tele.HandlerFunc {
+		return func(c tele.Context) error {
+			sender := c.Sender()
+			if sender == nil || !reporter.Config.HasAccess(sender.ID, level) {
+				reporter.Logger.Warn().
+					Interface("sender", sender).
+					Str("level", level.String()).
+					Str("text", GetActionText(c)).
+					Msg("Got command from a user without access, ignoring")
+
+				if c.Callback() != nil {
+					return c.Respond(&tele.CallbackResponse{Text: "You are not allowed to do this!"})
+				}
+
+				return c.Reply("You are not allowed to run this command.")
+			}
+
+			if level == types.AccessLevelWrite {
+				logEvent := reporter.Logger.Info().
+					Int64("user_id", sender.ID).
+					Str("username", sender.Username).
+					Str("name", sender.FirstName).
+					Str("text", GetActionText(c))
+
+				if chat := c.Chat(); chat != nil {
+					logEvent = logEvent.Int64("chat_id", chat.ID)
+				}
+
+				logEvent.Msg("Running mutating command")
+			}
+
+			return next(c)
+		}
+	}
+}
+
+func GetActionText(c tele.Context) string {
+	if callback := c.Callback(); callback != nil {
+		return callback.Unique + "|" + callback.Data
+	}
+
+	return c.Text()
+}
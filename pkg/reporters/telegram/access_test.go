@@ -0,0 +1,123 @@
+package telegram
+
+import (
+	"main/assets"
+	databasePkg "main/pkg/database"
+	"main/pkg/types"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	tele "gopkg.in/telebot.v3"
+)
+
+//nolint:paralleltest // disabled
+func TestTelegramReporterRequireAccessDenied(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder(
+		"POST",
+		"https://api.telegram.org/botxxx:yyy/getMe",
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("telegram-bot-ok.json")))
+
+	httpmock.RegisterMatcherResponder(
+		"POST",
+		"https://api.telegram.org/botxxx:yyy/sendMessage",
+		types.TelegramResponseHasText("You are not allowed to run this command."),
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("telegram-send-message-ok.json")),
+	)
+
+	database := &databasePkg.StubDatabase{}
+	reporter := getTestReporterWithChats(t, database, []types.TelegramChat{{ID: 100}})
+	reporter.Config.Users = []int64{1}
+	reporter.Config.Admins = []int64{2}
+
+	ctx := reporter.TelegramBot.NewContext(tele.Update{
+		ID: 1,
+		Message: &tele.Message{
+			Sender: &tele.User{ID: 1, Username: "testuser"},
+			Text:   "/proposals_mute 1h chain=chain",
+			Chat:   &tele.Chat{ID: 100},
+		},
+	})
+
+	called := false
+	handler := reporter.RequireAccess(types.AccessLevelWrite)(func(c tele.Context) error {
+		called = true
+		return nil
+	})
+
+	err := handler(ctx)
+	require.NoError(t, err)
+	assert.False(t, called)
+}
+
+//nolint:paralleltest // disabled
+func TestTelegramReporterRequireAccessCallbackDenied(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	edited := make([]map[string]string, 0)
+	registerButtonResponders(t, &edited)
+
+	database := &databasePkg.StubDatabase{}
+	reporter := getTestReporterWithChats(t, database, []types.TelegramChat{{ID: 100}})
+	reporter.Config.Admins = []int64{2}
+
+	handler := reporter.RequireAccess(types.AccessLevelWrite)(reporter.HandleMuteProposalButton)
+
+	err := handler(getButtonContext(reporter, "chain|1|1h"))
+	require.NoError(t, err)
+	assert.Empty(t, database.Mutes)
+	assert.Empty(t, edited)
+}
+
+//nolint:paralleltest // disabled
+func TestTelegramReporterRequireAccessAllowed(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder(
+		"POST",
+		"https://api.telegram.org/botxxx:yyy/getMe",
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("telegram-bot-ok.json")))
+
+	reporter := getTestReporterWithChats(t, &databasePkg.StubDatabase{}, []types.TelegramChat{{ID: 100}})
+	reporter.Config.Users = []int64{1}
+	reporter.Config.Admins = []int64{2}
+
+	ctx := reporter.TelegramBot.NewContext(tele.Update{
+		ID: 1,
+		Message: &tele.Message{
+			Sender: &tele.User{ID: 2, Username: "admin"},
+			Text:   "/proposals_mute 1h chain=chain",
+			Chat:   &tele.Chat{ID: 100},
+		},
+	})
+
+	called := false
+	handler := reporter.RequireAccess(types.AccessLevelWrite)(func(c tele.Context) error {
+		called = true
+		return nil
+	})
+
+	err := handler(ctx)
+	require.NoError(t, err)
+	assert.True(t, called)
+}
+
+func TestGetActionText(t *testing.T) {
+	t.Parallel()
+
+	bot, err := tele.NewBot(tele.Settings{Offline: true})
+	require.NoError(t, err)
+
+	assert.Equal(t, "/proposals", GetActionText(bot.NewContext(tele.Update{
+		Message: &tele.Message{Text: "/proposals"},
+	})))
+	assert.Equal(t, "ack|data", GetActionText(bot.NewContext(tele.Update{
+		Callback: &tele.Callback{Unique: "ack", Data: "data"},
+	})))
+}
@@ -3,6 +3,8 @@ package telegram
 import (
 	"fmt"
 
+	"github.com/guregu/null/v5"
+
 	tele "gopkg.in/telebot.v3"
 )
 
@@ -17,6 +19,10 @@ func (reporter *Reporter) HandleAddMute(c tele.Context) error {
 		return c.Reply("Error muting notification: " + err)
 	}
 
+	if destination := reporter.GetMuteDestination(c); destination != "" {
+		mute.Destination = null.StringFrom(destination)
+	}
+
 	if insertErr := reporter.MutesManager.AddMute(mute); insertErr != nil {
 		reporter.Logger.Error().Err(insertErr).Msg("Error adding mute")
 		return reporter.BotReply(c, fmt.Sprintf("Error adding mute: %s", insertErr))
@@ -3,10 +3,16 @@ package telegram
 import (
 	"errors"
 	"main/assets"
+	"main/pkg/alerts"
+	"main/pkg/dashboard"
 	"main/pkg/data"
 	databasePkg "main/pkg/database"
 	loggerPkg "main/pkg/logger"
+	"main/pkg/metrics"
 	mutesmanager "main/pkg/mutes"
+	"main/pkg/outbox"
+	"main/pkg/routes"
+	"main/pkg/spam"
 	"main/pkg/state"
 	"main/pkg/tracing"
 	"main/pkg/types"
@@ -33,18 +39,24 @@ func TestTelegramReporterReporterAddMuteNotEnoughArgs(t *testing.T) {
 	httpmock.RegisterMatcherResponder(
 		"POST",
 		"https://api.telegram.org/botxxx:yyy/sendMessage",
-		types.TelegramResponseHasText("Error muting notification: Usage: /proposals_mute <duration> [params]"),
+		types.TelegramResponseHasText("Error muting notification: Usage: /proposals_mute <duration|until-end> [params]"),
 		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("telegram-send-message-ok.json")),
 	)
 
 	config := types.TelegramConfig{TelegramToken: "xxx:yyy", TelegramChat: 123}
 	chains := types.Chains{{Name: "chain", LCDEndpoints: []string{"https://example.com"}}}
 	logger := loggerPkg.GetNopLogger()
+	metricsManager := metrics.NewManager(logger, types.MetricsConfig{})
 	tracer := tracing.InitNoopTracer()
 	database := &databasePkg.StubDatabase{}
 	mutesManager := mutesmanager.NewMutesManager(logger, database)
-	stateGenerator := state.NewStateGenerator(logger, tracer, chains)
-	dataManager := data.NewManager(logger, chains, tracer)
+	spamManager := spam.NewManager(logger, types.SpamConfig{}, database)
+	outboxManager := outbox.NewManager(logger, types.OutboxConfig{}, database)
+	alertsManager := alerts.NewManager(logger, database, []types.Duration{})
+	dashboardManager := dashboard.NewManager(logger, database)
+	routesManager := routes.NewManager(logger, types.Routes{})
+	stateGenerator := state.NewStateGenerator(logger, metricsManager, tracer, chains)
+	dataManager := data.NewManager(logger, chains, metricsManager, tracer)
 
 	timezone, err := time.LoadLocation("Etc/GMT")
 	require.NoError(t, err)
@@ -52,6 +64,11 @@ func TestTelegramReporterReporterAddMuteNotEnoughArgs(t *testing.T) {
 	reporter := NewTelegramReporter(
 		config,
 		mutesManager,
+		routesManager,
+		spamManager,
+		outboxManager,
+		alertsManager,
+		dashboardManager,
 		stateGenerator,
 		dataManager,
 		logger,
@@ -96,11 +113,17 @@ func TestTelegramReporterReporterAddMuteInvalidDuration(t *testing.T) {
 	config := types.TelegramConfig{TelegramToken: "xxx:yyy", TelegramChat: 123}
 	chains := types.Chains{{Name: "chain", LCDEndpoints: []string{"https://example.com"}}}
 	logger := loggerPkg.GetNopLogger()
+	metricsManager := metrics.NewManager(logger, types.MetricsConfig{})
 	tracer := tracing.InitNoopTracer()
 	database := &databasePkg.StubDatabase{}
 	mutesManager := mutesmanager.NewMutesManager(logger, database)
-	stateGenerator := state.NewStateGenerator(logger, tracer, chains)
-	dataManager := data.NewManager(logger, chains, tracer)
+	spamManager := spam.NewManager(logger, types.SpamConfig{}, database)
+	outboxManager := outbox.NewManager(logger, types.OutboxConfig{}, database)
+	alertsManager := alerts.NewManager(logger, database, []types.Duration{})
+	dashboardManager := dashboard.NewManager(logger, database)
+	routesManager := routes.NewManager(logger, types.Routes{})
+	stateGenerator := state.NewStateGenerator(logger, metricsManager, tracer, chains)
+	dataManager := data.NewManager(logger, chains, metricsManager, tracer)
 
 	timezone, err := time.LoadLocation("Etc/GMT")
 	require.NoError(t, err)
@@ -108,6 +131,11 @@ func TestTelegramReporterReporterAddMuteInvalidDuration(t *testing.T) {
 	reporter := NewTelegramReporter(
 		config,
 		mutesManager,
+		routesManager,
+		spamManager,
+		outboxManager,
+		alertsManager,
+		dashboardManager,
 		stateGenerator,
 		dataManager,
 		logger,
@@ -152,11 +180,17 @@ func TestTelegramReporterReporterAddMuteInvalidArgument(t *testing.T) {
 	config := types.TelegramConfig{TelegramToken: "xxx:yyy", TelegramChat: 123}
 	chains := types.Chains{{Name: "chain", LCDEndpoints: []string{"https://example.com"}}}
 	logger := loggerPkg.GetNopLogger()
+	metricsManager := metrics.NewManager(logger, types.MetricsConfig{})
 	tracer := tracing.InitNoopTracer()
 	database := &databasePkg.StubDatabase{}
 	mutesManager := mutesmanager.NewMutesManager(logger, database)
-	stateGenerator := state.NewStateGenerator(logger, tracer, chains)
-	dataManager := data.NewManager(logger, chains, tracer)
+	spamManager := spam.NewManager(logger, types.SpamConfig{}, database)
+	outboxManager := outbox.NewManager(logger, types.OutboxConfig{}, database)
+	alertsManager := alerts.NewManager(logger, database, []types.Duration{})
+	dashboardManager := dashboard.NewManager(logger, database)
+	routesManager := routes.NewManager(logger, types.Routes{})
+	stateGenerator := state.NewStateGenerator(logger, metricsManager, tracer, chains)
+	dataManager := data.NewManager(logger, chains, metricsManager, tracer)
 
 	timezone, err := time.LoadLocation("Etc/GMT")
 	require.NoError(t, err)
@@ -164,6 +198,11 @@ func TestTelegramReporterReporterAddMuteInvalidArgument(t *testing.T) {
 	reporter := NewTelegramReporter(
 		config,
 		mutesManager,
+		routesManager,
+		spamManager,
+		outboxManager,
+		alertsManager,
+		dashboardManager,
 		stateGenerator,
 		dataManager,
 		logger,
@@ -208,11 +247,17 @@ func TestTelegramReporterReporterAddMuteError(t *testing.T) {
 	config := types.TelegramConfig{TelegramToken: "xxx:yyy", TelegramChat: 123}
 	chains := types.Chains{{Name: "chain", LCDEndpoints: []string{"https://example.com"}}}
 	logger := loggerPkg.GetNopLogger()
+	metricsManager := metrics.NewManager(logger, types.MetricsConfig{})
 	tracer := tracing.InitNoopTracer()
 	database := &databasePkg.StubDatabase{UpsertMuteError: errors.New("custom error")}
 	mutesManager := mutesmanager.NewMutesManager(logger, database)
-	stateGenerator := state.NewStateGenerator(logger, tracer, chains)
-	dataManager := data.NewManager(logger, chains, tracer)
+	spamManager := spam.NewManager(logger, types.SpamConfig{}, database)
+	outboxManager := outbox.NewManager(logger, types.OutboxConfig{}, database)
+	alertsManager := alerts.NewManager(logger, database, []types.Duration{})
+	dashboardManager := dashboard.NewManager(logger, database)
+	routesManager := routes.NewManager(logger, types.Routes{})
+	stateGenerator := state.NewStateGenerator(logger, metricsManager, tracer, chains)
+	dataManager := data.NewManager(logger, chains, metricsManager, tracer)
 
 	timezone, err := time.LoadLocation("Etc/GMT")
 	require.NoError(t, err)
@@ -220,6 +265,11 @@ func TestTelegramReporterReporterAddMuteError(t *testing.T) {
 	reporter := NewTelegramReporter(
 		config,
 		mutesManager,
+		routesManager,
+		spamManager,
+		outboxManager,
+		alertsManager,
+		dashboardManager,
 		stateGenerator,
 		dataManager,
 		logger,
@@ -263,11 +313,17 @@ func TestTelegramReporterReporterAddMuteOk(t *testing.T) {
 	config := types.TelegramConfig{TelegramToken: "xxx:yyy", TelegramChat: 123}
 	chains := types.Chains{{Name: "chain", LCDEndpoints: []string{"https://example.com"}}}
 	logger := loggerPkg.GetNopLogger()
+	metricsManager := metrics.NewManager(logger, types.MetricsConfig{})
 	tracer := tracing.InitNoopTracer()
 	database := &databasePkg.StubDatabase{}
 	mutesManager := mutesmanager.NewMutesManager(logger, database)
-	stateGenerator := state.NewStateGenerator(logger, tracer, chains)
-	dataManager := data.NewManager(logger, chains, tracer)
+	spamManager := spam.NewManager(logger, types.SpamConfig{}, database)
+	outboxManager := outbox.NewManager(logger, types.OutboxConfig{}, database)
+	alertsManager := alerts.NewManager(logger, database, []types.Duration{})
+	dashboardManager := dashboard.NewManager(logger, database)
+	routesManager := routes.NewManager(logger, types.Routes{})
+	stateGenerator := state.NewStateGenerator(logger, metricsManager, tracer, chains)
+	dataManager := data.NewManager(logger, chains, metricsManager, tracer)
 
 	timezone, err := time.LoadLocation("Etc/GMT")
 	require.NoError(t, err)
@@ -275,6 +331,11 @@ func TestTelegramReporterReporterAddMuteOk(t *testing.T) {
 	reporter := NewTelegramReporter(
 		config,
 		mutesManager,
+		routesManager,
+		spamManager,
+		outboxManager,
+		alertsManager,
+		dashboardManager,
 		stateGenerator,
 		dataManager,
 		logger,
@@ -318,11 +379,17 @@ func TestTelegramReporterReporterAddMuteOkWithParams(t *testing.T) {
 	config := types.TelegramConfig{TelegramToken: "xxx:yyy", TelegramChat: 123}
 	chains := types.Chains{{Name: "chain", LCDEndpoints: []string{"https://example.com"}}}
 	logger := loggerPkg.GetNopLogger()
+	metricsManager := metrics.NewManager(logger, types.MetricsConfig{})
 	tracer := tracing.InitNoopTracer()
 	database := &databasePkg.StubDatabase{}
 	mutesManager := mutesmanager.NewMutesManager(logger, database)
-	stateGenerator := state.NewStateGenerator(logger, tracer, chains)
-	dataManager := data.NewManager(logger, chains, tracer)
+	spamManager := spam.NewManager(logger, types.SpamConfig{}, database)
+	outboxManager := outbox.NewManager(logger, types.OutboxConfig{}, database)
+	alertsManager := alerts.NewManager(logger, database, []types.Duration{})
+	dashboardManager := dashboard.NewManager(logger, database)
+	routesManager := routes.NewManager(logger, types.Routes{})
+	stateGenerator := state.NewStateGenerator(logger, metricsManager, tracer, chains)
+	dataManager := data.NewManager(logger, chains, metricsManager, tracer)
 
 	timezone, err := time.LoadLocation("Etc/GMT")
 	require.NoError(t, err)
@@ -330,6 +397,11 @@ func TestTelegramReporterReporterAddMuteOkWithParams(t *testing.T) {
 	reporter := NewTelegramReporter(
 		config,
 		mutesManager,
+		routesManager,
+		spamManager,
+		outboxManager,
+		alertsManager,
+		dashboardManager,
 		stateGenerator,
 		dataManager,
 		logger,
@@ -374,11 +446,17 @@ func TestTelegramReporterReporterRenderMuteNoParams(t *testing.T) {
 	config := types.TelegramConfig{TelegramToken: "xxx:yyy", TelegramChat: 123}
 	chains := types.Chains{{Name: "chain", LCDEndpoints: []string{"https://example.com"}}}
 	logger := loggerPkg.GetNopLogger()
+	metricsManager := metrics.NewManager(logger, types.MetricsConfig{})
 	tracer := tracing.InitNoopTracer()
 	database := &databasePkg.StubDatabase{}
 	mutesManager := mutesmanager.NewMutesManager(logger, database)
-	stateGenerator := state.NewStateGenerator(logger, tracer, chains)
-	dataManager := data.NewManager(logger, chains, tracer)
+	spamManager := spam.NewManager(logger, types.SpamConfig{}, database)
+	outboxManager := outbox.NewManager(logger, types.OutboxConfig{}, database)
+	alertsManager := alerts.NewManager(logger, database, []types.Duration{})
+	dashboardManager := dashboard.NewManager(logger, database)
+	routesManager := routes.NewManager(logger, types.Routes{})
+	stateGenerator := state.NewStateGenerator(logger, metricsManager, tracer, chains)
+	dataManager := data.NewManager(logger, chains, metricsManager, tracer)
 
 	timezone, err := time.LoadLocation("Etc/GMT")
 	require.NoError(t, err)
@@ -386,6 +464,11 @@ func TestTelegramReporterReporterRenderMuteNoParams(t *testing.T) {
 	reporter := NewTelegramReporter(
 		config,
 		mutesManager,
+		routesManager,
+		spamManager,
+		outboxManager,
+		alertsManager,
+		dashboardManager,
 		stateGenerator,
 		dataManager,
 		logger,
@@ -437,11 +520,17 @@ func TestTelegramReporterReporterRenderMuteWithParams(t *testing.T) {
 	config := types.TelegramConfig{TelegramToken: "xxx:yyy", TelegramChat: 123}
 	chains := types.Chains{{Name: "chain", LCDEndpoints: []string{"https://example.com"}}}
 	logger := loggerPkg.GetNopLogger()
+	metricsManager := metrics.NewManager(logger, types.MetricsConfig{})
 	tracer := tracing.InitNoopTracer()
 	database := &databasePkg.StubDatabase{}
 	mutesManager := mutesmanager.NewMutesManager(logger, database)
-	stateGenerator := state.NewStateGenerator(logger, tracer, chains)
-	dataManager := data.NewManager(logger, chains, tracer)
+	spamManager := spam.NewManager(logger, types.SpamConfig{}, database)
+	outboxManager := outbox.NewManager(logger, types.OutboxConfig{}, database)
+	alertsManager := alerts.NewManager(logger, database, []types.Duration{})
+	dashboardManager := dashboard.NewManager(logger, database)
+	routesManager := routes.NewManager(logger, types.Routes{})
+	stateGenerator := state.NewStateGenerator(logger, metricsManager, tracer, chains)
+	dataManager := data.NewManager(logger, chains, metricsManager, tracer)
 
 	timezone, err := time.LoadLocation("Etc/GMT")
 	require.NoError(t, err)
@@ -449,6 +538,11 @@ func TestTelegramReporterReporterRenderMuteWithParams(t *testing.T) {
 	reporter := NewTelegramReporter(
 		config,
 		mutesManager,
+		routesManager,
+		spamManager,
+		outboxManager,
+		alertsManager,
+		dashboardManager,
 		stateGenerator,
 		dataManager,
 		logger,
@@ -482,3 +576,153 @@ func TestTelegramReporterReporterRenderMuteWithParams(t *testing.T) {
 	err = reporter.ReplyRender(ctx, "mute_added", mute)
 	require.NoError(t, err)
 }
+
+//nolint:paralleltest // disabled
+func TestTelegramReporterReporterAddMuteWalletAndEvent(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder(
+		"POST",
+		"https://api.telegram.org/botxxx:yyy/getMe",
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("telegram-bot-ok.json")))
+
+	httpmock.RegisterResponder(
+		"POST",
+		"https://api.telegram.org/botxxx:yyy/sendMessage",
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("telegram-send-message-ok.json")),
+	)
+
+	database := &databasePkg.StubDatabase{}
+	reporter := getTestReporterWithChats(t, database, []types.TelegramChat{{ID: 100}})
+
+	invalidCtx := reporter.TelegramBot.NewContext(tele.Update{
+		ID: 1,
+		Message: &tele.Message{
+			Sender: &tele.User{Username: "testuser"},
+			Text:   "/proposals_mute 1h event=invalid",
+			Chat:   &tele.Chat{ID: 100},
+		},
+	})
+
+	err := reporter.HandleAddMute(invalidCtx)
+	require.NoError(t, err)
+	require.Empty(t, database.Mutes)
+
+	ctx := reporter.TelegramBot.NewContext(tele.Update{
+		ID: 1,
+		Message: &tele.Message{
+			Sender: &tele.User{Username: "testuser"},
+			Text:   "/proposals_mute 1h chain=chain wallet=retired event=vote_query_error",
+			Chat:   &tele.Chat{ID: 100},
+		},
+	})
+
+	err = reporter.HandleAddMute(ctx)
+	require.NoError(t, err)
+	require.Len(t, database.Mutes, 1)
+	require.Equal(t, "retired", database.Mutes[0].Wallet.String)
+	require.Equal(t, "vote_query_error", database.Mutes[0].Event.String)
+
+	deleteCtx := reporter.TelegramBot.NewContext(tele.Update{
+		ID: 1,
+		Message: &tele.Message{
+			Sender:  &tele.User{Username: "testuser"},
+			Text:    "/proposals_unmute chain=chain wallet=retired event=vote_query_error",
+			Payload: "chain=chain wallet=retired event=vote_query_error",
+			Chat:    &tele.Chat{ID: 100},
+		},
+	})
+
+	err = reporter.HandleDeleteMute(deleteCtx)
+	require.NoError(t, err)
+	require.Empty(t, database.Mutes)
+}
+
+//nolint:paralleltest // disabled
+func TestTelegramReporterReporterAddMuteUntilVotingEnds(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder(
+		"POST",
+		"https://api.telegram.org/botxxx:yyy/getMe",
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("telegram-bot-ok.json")))
+
+	httpmock.RegisterResponder(
+		"POST",
+		"https://api.telegram.org/botxxx:yyy/sendMessage",
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("telegram-send-message-ok.json")),
+	)
+
+	endTime := time.Now().Add(time.Hour)
+	database := &databasePkg.StubDatabase{
+		Proposals: map[string]map[string]*types.Proposal{
+			"chain": {"1": {ID: "1", EndTime: endTime}},
+		},
+	}
+	reporter := getTestReporterWithChats(t, database, []types.TelegramChat{{ID: 100}})
+
+	ctx := reporter.TelegramBot.NewContext(tele.Update{
+		ID: 1,
+		Message: &tele.Message{
+			Sender: &tele.User{Username: "testuser", FirstName: "Test"},
+			Text:   "/proposals_mute until-end chain=chain proposal=1 title=(?i)airdrop",
+			Chat:   &tele.Chat{ID: 100},
+		},
+	})
+
+	err := reporter.HandleAddMute(ctx)
+	require.NoError(t, err)
+	require.Len(t, database.Mutes, 1)
+	require.Equal(t, endTime, database.Mutes[0].Expires)
+	require.Equal(t, "(?i)airdrop", database.Mutes[0].TitlePattern.String)
+	require.Equal(t, "Muted using cosmos-proposals-checker until voting ends by Test", database.Mutes[0].Comment)
+
+	invalidCtx := reporter.TelegramBot.NewContext(tele.Update{
+		ID: 1,
+		Message: &tele.Message{
+			Sender: &tele.User{Username: "testuser"},
+			Text:   "/proposals_mute until-end chain=chain proposal=2 title=(",
+			Chat:   &tele.Chat{ID: 100},
+		},
+	})
+
+	err = reporter.HandleAddMute(invalidCtx)
+	require.NoError(t, err)
+	require.Len(t, database.Mutes, 1)
+}
+
+//nolint:paralleltest // disabled
+func TestTelegramReporterReporterAddMuteUnknownArgument(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder(
+		"POST",
+		"https://api.telegram.org/botxxx:yyy/getMe",
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("telegram-bot-ok.json")))
+
+	httpmock.RegisterMatcherResponder(
+		"POST",
+		"https://api.telegram.org/botxxx:yyy/sendMessage",
+		types.TelegramResponseHasText("Error muting notification: Unknown param at position 2: walet, expected one of chain, proposal, wallet, event or title"),
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("telegram-send-message-ok.json")),
+	)
+
+	database := &databasePkg.StubDatabase{}
+	reporter := getTestReporterWithChats(t, database, []types.TelegramChat{{ID: 2}})
+
+	ctx := reporter.TelegramBot.NewContext(tele.Update{
+		ID: 1,
+		Message: &tele.Message{
+			Sender: &tele.User{Username: "testuser"},
+			Text:   "/proposals_mute 48h chain=chain walet=wallet",
+			Chat:   &tele.Chat{ID: 2},
+		},
+	})
+
+	err := reporter.HandleAddMute(ctx)
+	require.NoError(t, err)
+	require.Empty(t, database.Mutes)
+}
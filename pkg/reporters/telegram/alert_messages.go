@@ -0,0 +1,127 @@
+package telegram
+
+import (
+	"errors"
+	"html"
+	"main/pkg/events"
+	"main/pkg/report/entry"
+	"main/pkg/types"
+	"main/pkg/utils"
+	"strconv"
+	"strings"
+
+	"github.com/guregu/null/v5"
+	tele "gopkg.in/telebot.v3"
+)
+
+// EditAlertMessage edits the message sent before about the same wallet and proposal
+// to show the entry instead, returning false if there's no such message or it cannot be edited,
+// so a new one should be sent.
+func (reporter *Reporter) EditAlertMessage(
+	chat types.TelegramChat,
+	reportEntry entry.ReportEntry,
+	text string,
+	markup *tele.ReplyMarkup,
+) bool {
+	alertEntry, ok := reportEntry.(entry.ReportEntryNotError)
+	if !ok {
+		return false
+	}
+
+	message, err := reporter.AlertsManager.Get(chat.Destination(), alertEntry)
+	if err != nil {
+		reporter.Logger.Warn().
+			Err(err).
+			Str("destination", chat.Destination()).
+			Msg("Error getting alert message, sending a new one")
+		return false
+	}
+
+	if message == nil {
+		return false
+	}
+
+	// the action taken from the buttons is kept, and as the alert was handled already,
+	// the buttons are not shown again
+	if message.Action.Valid {
+		text = strings.TrimSpace(text) + "\n\n" + html.EscapeString(message.Action.String)
+		markup = nil
+	}
+
+	if editErr := reporter.EditMessage(
+		&tele.StoredMessage{MessageID: message.MessageID, ChatID: chat.ID},
+		text,
+		&tele.SendOptions{
+			ParseMode:             tele.ModeHTML,
+			DisableWebPagePreview: true,
+			ReplyMarkup:           markup,
+		},
+	); editErr != nil && !errors.Is(editErr, tele.ErrSameMessageContent) {
+		// the message might have been deleted, so a new one is sent and stored instead
+		reporter.Logger.Warn().
+			Err(editErr).
+			Str("destination", chat.Destination()).
+			Str("message_id", message.MessageID).
+			Msg("Could not edit alert message, sending a new one")
+		return false
+	}
+
+	if saveErr := reporter.AlertsManager.Save(
+		chat.Destination(),
+		alertEntry,
+		message.MessageID,
+		message.Action,
+	); saveErr != nil {
+		reporter.Logger.Warn().
+			Err(saveErr).
+			Str("destination", chat.Destination()).
+			Msg("Error updating alert message")
+	}
+
+	return true
+}
+
+// SaveAlertMessage stores the message sent for the entry, so it's edited by the next alerts
+// about the same wallet and proposal.
+func (reporter *Reporter) SaveAlertMessage(
+	chat types.TelegramChat,
+	reportEntry entry.ReportEntry,
+	message *tele.Message,
+) {
+	alertEntry, ok := reportEntry.(entry.ReportEntryNotError)
+	if !ok || message == nil {
+		return
+	}
+
+	if err := reporter.AlertsManager.Save(
+		chat.Destination(),
+		alertEntry,
+		strconv.Itoa(message.ID),
+		null.String{},
+	); err != nil {
+		reporter.Logger.Warn().
+			Err(err).
+			Str("destination", chat.Destination()).
+			Msg("Error saving alert message, it won't be edited later")
+	}
+}
+
+// DeleteAlertMessages forgets the messages sent for a proposal that has finished voting.
+func (reporter *Reporter) DeleteAlertMessages(reportEntry entry.ReportEntry) {
+	chain, _, proposal := events.GetEntryLabels(reportEntry)
+	if chain == nil || proposal == nil {
+		return
+	}
+
+	destinations := utils.Map(reporter.Chats, func(chat types.TelegramChat) string {
+		return chat.Destination()
+	})
+
+	if err := reporter.AlertsManager.DeleteForProposal(destinations, chain.Name, proposal.ID); err != nil {
+		reporter.Logger.Warn().
+			Err(err).
+			Str("chain", chain.Name).
+			Str("proposal", proposal.ID).
+			Msg("Error deleting alert messages")
+	}
+}
@@ -0,0 +1,349 @@
+package telegram
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"main/assets"
+	databasePkg "main/pkg/database"
+	"main/pkg/events"
+	"main/pkg/types"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func registerAlertMessagesResponders(t *testing.T, sent, edited *[]map[string]any, editErr error) {
+	t.Helper()
+
+	recorder := func(requests *[]map[string]any) httpmock.Responder {
+		return func(req *http.Request) (*http.Response, error) {
+			body := map[string]any{}
+			if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+				return nil, err
+			}
+
+			*requests = append(*requests, body)
+			return httpmock.NewBytesResponse(200, assets.GetBytesOrPanic("telegram-send-message-ok.json")), nil
+		}
+	}
+
+	httpmock.RegisterResponder(
+		"POST",
+		"https://api.telegram.org/botxxx:yyy/getMe",
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("telegram-bot-ok.json")))
+
+	httpmock.RegisterResponder(
+		"POST",
+		"https://api.telegram.org/botxxx:yyy/sendMessage",
+		recorder(sent),
+	)
+
+	editResponder := recorder(edited)
+	if editErr != nil {
+		editResponder = httpmock.NewErrorResponder(editErr)
+	}
+
+	httpmock.RegisterResponder(
+		"POST",
+		"https://api.telegram.org/botxxx:yyy/editMessageText",
+		editResponder,
+	)
+}
+
+//nolint:paralleltest // disabled
+func TestTelegramReporterEditAlertMessages(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	sent := make([]map[string]any, 0)
+	edited := make([]map[string]any, 0)
+	registerAlertMessagesResponders(t, &sent, &edited, nil)
+
+	database := &databasePkg.StubDatabase{}
+	reporter := getTestReporterWithChats(t, database, []types.TelegramChat{{ID: 100}})
+	reporter.Config.EditAlerts = true
+
+	chain := &types.Chain{Name: "chain"}
+	wallet := &types.Wallet{Address: "wallet"}
+	proposal := types.Proposal{ID: "proposal", EndTime: time.Now().Add(time.Hour)}
+
+	err := reporter.SendReportEntry(events.NotVotedEvent{
+		Chain:    chain,
+		Wallet:   wallet,
+		Proposal: proposal,
+	}, context.Background())
+	require.NoError(t, err)
+	require.Len(t, sent, 1)
+	require.Empty(t, edited)
+	require.Len(t, database.AlertMessages, 1)
+	assert.Equal(t, "telegram:100", database.AlertMessages[0].Destination)
+	assert.Equal(t, "0", database.AlertMessages[0].MessageID)
+
+	err = reporter.SendReportEntry(events.VotedEvent{
+		Chain:    chain,
+		Wallet:   wallet,
+		Proposal: proposal,
+		Vote:     &types.Vote{Options: types.VoteOptions{{Option: "Yes", Weight: 1}}},
+	}, context.Background())
+	require.NoError(t, err)
+	require.Len(t, sent, 1)
+	require.Len(t, edited, 1)
+	assert.Equal(t, "100", edited[0]["chat_id"])
+	assert.Equal(t, "0", edited[0]["message_id"])
+	assert.Contains(t, edited[0]["text"], "has voted on proposal")
+	require.Len(t, database.AlertMessages, 1)
+
+	// alerts on other wallets are sent as new messages
+	err = reporter.SendReportEntry(events.NotVotedEvent{
+		Chain:    chain,
+		Wallet:   &types.Wallet{Address: "another"},
+		Proposal: proposal,
+	}, context.Background())
+	require.NoError(t, err)
+	require.Len(t, sent, 2)
+	require.Len(t, database.AlertMessages, 2)
+
+	reporter.DeleteAlertMessages(events.FinishedVotingEvent{
+		Chain:    chain,
+		Proposal: proposal,
+	})
+	require.Len(t, sent, 2)
+	require.Empty(t, database.AlertMessages)
+}
+
+//nolint:paralleltest // disabled
+func TestTelegramReporterEditAlertMessagesFailed(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	sent := make([]map[string]any, 0)
+	edited := make([]map[string]any, 0)
+	registerAlertMessagesResponders(t, &sent, &edited, errors.New("custom error"))
+
+	database := &databasePkg.StubDatabase{
+		AlertMessages: []*types.AlertMessage{{
+			Destination: "telegram:100",
+			Chain:       "chain",
+			ProposalID:  "proposal",
+			Wallet:      "wallet",
+			MessageID:   "123",
+		}},
+	}
+	reporter := getTestReporterWithChats(t, database, []types.TelegramChat{{ID: 100}})
+	reporter.Config.EditAlerts = true
+
+	err := reporter.SendReportEntry(events.NotVotedEvent{
+		Chain:    &types.Chain{Name: "chain"},
+		Wallet:   &types.Wallet{Address: "wallet"},
+		Proposal: types.Proposal{ID: "proposal", EndTime: time.Now().Add(time.Hour)},
+	}, context.Background())
+	require.NoError(t, err)
+	require.Len(t, sent, 1)
+	require.Len(t, database.AlertMessages, 1)
+	assert.Equal(t, "0", database.AlertMessages[0].MessageID)
+}
+
+//nolint:paralleltest // disabled
+func TestTelegramReporterEditAlertMessagesErrorGetting(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	sent := make([]map[string]any, 0)
+	edited := make([]map[string]any, 0)
+	registerAlertMessagesResponders(t, &sent, &edited, nil)
+
+	database := &databasePkg.StubDatabase{
+		GetAlertMessageError:    errors.New("custom error"),
+		UpsertAlertMessageError: errors.New("custom error"),
+		DeleteAlertMessageError: errors.New("custom error"),
+	}
+	reporter := getTestReporterWithChats(t, database, []types.TelegramChat{{ID: 100}})
+	reporter.Config.EditAlerts = true
+
+	err := reporter.SendReportEntry(events.NotVotedEvent{
+		Chain:    &types.Chain{Name: "chain"},
+		Wallet:   &types.Wallet{Address: "wallet"},
+		Proposal: types.Proposal{ID: "proposal", EndTime: time.Now().Add(time.Hour)},
+	}, context.Background())
+	require.NoError(t, err)
+
+	reporter.DeleteAlertMessages(events.FinishedVotingEvent{
+		Chain:    &types.Chain{Name: "chain"},
+		Proposal: types.Proposal{ID: "proposal"},
+	})
+
+	require.Len(t, sent, 1)
+	require.Empty(t, edited)
+}
+
+//nolint:paralleltest // disabled
+func TestTelegramReporterEditAlertMessagesDisabled(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	sent := make([]map[string]any, 0)
+	edited := make([]map[string]any, 0)
+	registerAlertMessagesResponders(t, &sent, &edited, nil)
+
+	database := &databasePkg.StubDatabase{
+		AlertMessages: []*types.AlertMessage{{
+			Destination: "telegram:100",
+			Chain:       "chain",
+			ProposalID:  "proposal",
+			Wallet:      "wallet",
+			MessageID:   "123",
+		}},
+	}
+	reporter := getTestReporterWithChats(t, database, []types.TelegramChat{{ID: 100}})
+
+	err := reporter.SendReportEntry(events.NotVotedEvent{
+		Chain:    &types.Chain{Name: "chain"},
+		Wallet:   &types.Wallet{Address: "wallet"},
+		Proposal: types.Proposal{ID: "proposal", EndTime: time.Now().Add(time.Hour)},
+	}, context.Background())
+	require.NoError(t, err)
+	require.Len(t, sent, 1)
+	require.Empty(t, edited)
+	assert.Equal(t, "123", database.AlertMessages[0].MessageID)
+}
+
+//nolint:paralleltest // disabled
+func TestTelegramReporterEditAlertMessagesKeepsAction(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	sent := make([]map[string]any, 0)
+	edited := make([]map[string]any, 0)
+	registerAlertMessagesResponders(t, &sent, &edited, nil)
+	httpmock.RegisterResponder(
+		"POST",
+		"https://api.telegram.org/botxxx:yyy/answerCallbackQuery",
+		httpmock.NewStringResponder(200, `{"ok":true,"result":true}`),
+	)
+
+	database := &databasePkg.StubDatabase{
+		AlertMessages: []*types.AlertMessage{{
+			Destination: "telegram:100",
+			Chain:       "chain",
+			ProposalID:  "proposal",
+			Wallet:      "wallet",
+			MessageID:   "10",
+		}},
+	}
+	reporter := getTestReporterWithChats(t, database, []types.TelegramChat{{ID: 100}})
+	reporter.Config.EditAlerts = true
+
+	err := reporter.HandleAcknowledgeButton(getButtonContext(reporter, ""))
+	require.NoError(t, err)
+	require.Len(t, edited, 1)
+	assert.Equal(t, "✅ Acknowledged by @testuser", database.AlertMessages[0].Action.String)
+
+	err = reporter.SendReportEntry(events.NotVotedEvent{
+		Chain:    &types.Chain{Name: "chain"},
+		Wallet:   &types.Wallet{Address: "wallet"},
+		Proposal: types.Proposal{ID: "proposal", EndTime: time.Now().Add(time.Hour)},
+	}, context.Background())
+	require.NoError(t, err)
+	require.Empty(t, sent)
+	require.Len(t, edited, 2)
+	assert.Equal(t, "10", edited[1]["message_id"])
+	assert.Contains(t, edited[1]["text"], "hasn't voted")
+	assert.Contains(t, edited[1]["text"], "\n\n✅ Acknowledged by @testuser")
+	assert.NotContains(t, edited[1], "reply_markup")
+	assert.Equal(t, "✅ Acknowledged by @testuser", database.AlertMessages[0].Action.String)
+}
+
+//nolint:paralleltest // disabled
+func TestTelegramReporterEditSentAlert(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	sent := make([]map[string]any, 0)
+	edited := make([]map[string]any, 0)
+	registerAlertMessagesResponders(t, &sent, &edited, nil)
+
+	database := &databasePkg.StubDatabase{
+		AlertMessages: []*types.AlertMessage{{
+			Destination: "telegram:100",
+			Chain:       "chain",
+			ProposalID:  "proposal",
+			Wallet:      "wallet",
+			MessageID:   "123",
+		}},
+	}
+	reporter := getTestReporterWithChats(t, database, []types.TelegramChat{{ID: 100}, {ID: 200}})
+	reporter.Config.EditAlerts = true
+	require.True(t, reporter.EditsAlerts())
+
+	// only the message sent before is edited, no new message is sent to the other chat
+	err := reporter.EditSentAlert(events.NotVotedEvent{
+		Chain:    &types.Chain{Name: "chain"},
+		Wallet:   &types.Wallet{Address: "wallet"},
+		Proposal: types.Proposal{ID: "proposal", EndTime: time.Now().Add(time.Hour)},
+	}, context.Background())
+	require.NoError(t, err)
+	require.Empty(t, sent)
+	require.Len(t, edited, 1)
+	assert.Equal(t, "100", edited[0]["chat_id"])
+	assert.Equal(t, "123", edited[0]["message_id"])
+	assert.Contains(t, edited[0]["text"], "hasn't voted")
+}
+
+//nolint:paralleltest // disabled
+func TestTelegramReporterEditAlertMessagesReminders(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	sent := make([]map[string]any, 0)
+	edited := make([]map[string]any, 0)
+	registerAlertMessagesResponders(t, &sent, &edited, nil)
+
+	database := &databasePkg.StubDatabase{
+		AlertMessages: []*types.AlertMessage{{
+			Destination: "telegram:100",
+			Chain:       "chain",
+			ProposalID:  "proposal",
+			Wallet:      "wallet",
+			MessageID:   "123",
+		}},
+	}
+	reporter := getTestReporterWithChats(t, database, []types.TelegramChat{{ID: 100}})
+	reporter.Config.EditAlerts = true
+	reporter.AlertsManager.RemindersEnabled = true
+
+	notVoted := events.NotVotedEvent{
+		Chain:    &types.Chain{Name: "chain"},
+		Wallet:   &types.Wallet{Address: "wallet"},
+		Proposal: types.Proposal{ID: "proposal", EndTime: time.Now().Add(time.Hour)},
+	}
+
+	// a due reminder is sent as a new message, so it notifies about it, and it's edited later
+	err := reporter.SendReportEntry(notVoted, context.Background())
+	require.NoError(t, err)
+	require.Len(t, sent, 1)
+	require.Empty(t, edited)
+	require.Len(t, database.AlertMessages, 1)
+	assert.Equal(t, "0", database.AlertMessages[0].MessageID)
+
+	err = reporter.EditSentAlert(notVoted, context.Background())
+	require.NoError(t, err)
+	require.Len(t, sent, 1)
+	require.Len(t, edited, 1)
+	assert.Equal(t, "0", edited[0]["message_id"])
+
+	// the vote is still shown by editing the message
+	err = reporter.SendReportEntry(events.VotedEvent{
+		Chain:    notVoted.Chain,
+		Wallet:   notVoted.Wallet,
+		Proposal: notVoted.Proposal,
+		Vote:     &types.Vote{Options: types.VoteOptions{{Option: "Yes", Weight: 1}}},
+	}, context.Background())
+	require.NoError(t, err)
+	require.Len(t, sent, 1)
+	require.Len(t, edited, 2)
+}
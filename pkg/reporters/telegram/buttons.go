@@ -0,0 +1,291 @@
+package telegram
+
+import (
+	"fmt"
+	"main/pkg/events"
+	"main/pkg/report/entry"
+	"main/pkg/types"
+	"strconv"
+	"time"
+
+	"github.com/guregu/null/v5"
+
+	tele "gopkg.in/telebot.v3"
+)
+
+const (
+	ButtonMuteProposal  = "mute_proposal"
+	ButtonMuteUntilEnd  = "mute_until_end"
+	ButtonMuteChain     = "mute_chain"
+	ButtonAcknowledge   = "ack"
+	ButtonNotSpam       = "not_spam"
+	MuteChainDuration   = 24 * time.Hour
+	MaxCallbackDataSize = 64
+)
+
+// GetAlertMarkup returns the inline keyboard attached to the alert, allowing to mute
+// its proposal or chain, or to acknowledge it, or nil if the entry is not an alert.
+// Suspected spam proposals get a button to un-flag them instead.
+func (reporter *Reporter) GetAlertMarkup(reportEntry entry.ReportEntry) *tele.ReplyMarkup {
+	if spamEvent, ok := reportEntry.(events.SuspectedSpamEvent); ok {
+		return GetNotSpamMarkup(spamEvent)
+	}
+
+	// grouped alerts can be about different proposals, so only the chain can be muted
+	if groupedEvent, ok := reportEntry.(events.GroupedEvent); ok {
+		markup := &tele.ReplyMarkup{}
+		markup.Inline(GetChainMarkupRow(markup, groupedEvent.Chain))
+		return markup
+	}
+
+	if !reportEntry.IsAlert() {
+		return nil
+	}
+
+	entryConverted, ok := reportEntry.(entry.ReportEntryNotError)
+	if !ok {
+		return nil
+	}
+
+	chain := entryConverted.GetChain()
+	proposal := entryConverted.GetProposal()
+
+	markup := &tele.ReplyMarkup{}
+	rows := make([]tele.Row, 0)
+
+	proposalButtons := []tele.Btn{
+		markup.Data("🔇 1h", ButtonMuteProposal, chain.Name, proposal.ID, "1h"),
+		markup.Data("🔇 24h", ButtonMuteProposal, chain.Name, proposal.ID, "24h"),
+	}
+
+	if proposal.EndTime.After(time.Now()) {
+		proposalButtons = append(proposalButtons, markup.Data(
+			"🔇 Until end",
+			ButtonMuteUntilEnd,
+			chain.Name,
+			proposal.ID,
+			strconv.FormatInt(proposal.EndTime.Unix(), 10),
+		))
+	}
+
+	// Telegram rejects the whole message if any callback data is too long,
+	// so buttons that do not fit are skipped.
+	if fitting := FilterFittingButtons(proposalButtons); len(fitting) > 0 {
+		rows = append(rows, markup.Row(fitting...))
+	}
+
+	rows = append(rows, GetChainMarkupRow(markup, chain))
+
+	markup.Inline(rows...)
+	return markup
+}
+
+func GetChainMarkupRow(markup *tele.ReplyMarkup, chain *types.Chain) tele.Row {
+	return markup.Row(FilterFittingButtons([]tele.Btn{
+		markup.Data("🔇 Chain 24h", ButtonMuteChain, chain.Name, MuteChainDuration.String()),
+		markup.Data("✅ Acknowledge", ButtonAcknowledge),
+	})...)
+}
+
+func GetNotSpamMarkup(event events.SuspectedSpamEvent) *tele.ReplyMarkup {
+	markup := &tele.ReplyMarkup{}
+
+	buttons := FilterFittingButtons([]tele.Btn{
+		markup.Data("✅ Not spam", ButtonNotSpam, event.Chain.Name, event.Proposal.ID),
+	})
+	if len(buttons) == 0 {
+		return nil
+	}
+
+	markup.Inline(markup.Row(buttons...))
+	return markup
+}
+
+func FilterFittingButtons(buttons []tele.Btn) []tele.Btn {
+	fitting := make([]tele.Btn, 0, len(buttons))
+
+	for _, button := range buttons {
+		// the callback data is sent as "\f<unique>|<data>"
+		data := button.CallbackUnique()
+		if button.Data != "" {
+			data += "|" + button.Data
+		}
+
+		if len(data) <= MaxCallbackDataSize {
+			fitting = append(fitting, button)
+		}
+	}
+
+	return fitting
+}
+
+func (reporter *Reporter) HandleMuteProposalButton(c tele.Context) error {
+	reporter.Logger.Info().
+		Str("sender", c.Sender().Username).
+		Str("data", c.Callback().Data).
+		Msg("Got mute proposal button click")
+
+	args := c.Args()
+	if len(args) != 3 {
+		return c.Respond(&tele.CallbackResponse{Text: "Invalid button data!"})
+	}
+
+	duration, err := time.ParseDuration(args[2])
+	if err != nil {
+		return c.Respond(&tele.CallbackResponse{Text: "Invalid mute duration!"})
+	}
+
+	return reporter.AddMuteFromButton(
+		c,
+		null.StringFrom(args[0]),
+		null.StringFrom(args[1]),
+		time.Now().Add(duration),
+		fmt.Sprintf("🔇 Proposal %s muted for %s by %s", args[1], duration, GetSenderName(c.Sender())),
+	)
+}
+
+func (reporter *Reporter) HandleMuteUntilEndButton(c tele.Context) error {
+	reporter.Logger.Info().
+		Str("sender", c.Sender().Username).
+		Str("data", c.Callback().Data).
+		Msg("Got mute until voting end button click")
+
+	args := c.Args()
+	if len(args) != 3 {
+		return c.Respond(&tele.CallbackResponse{Text: "Invalid button data!"})
+	}
+
+	endTimestamp, err := strconv.ParseInt(args[2], 10, 64)
+	if err != nil {
+		return c.Respond(&tele.CallbackResponse{Text: "Invalid voting end time!"})
+	}
+
+	endTime := time.Unix(endTimestamp, 0)
+	if endTime.Before(time.Now()) {
+		return c.Respond(&tele.CallbackResponse{Text: "Voting has already ended!"})
+	}
+
+	return reporter.AddMuteFromButton(
+		c,
+		null.StringFrom(args[0]),
+		null.StringFrom(args[1]),
+		endTime,
+		fmt.Sprintf("🔇 Proposal %s muted until voting ends by %s", args[1], GetSenderName(c.Sender())),
+	)
+}
+
+func (reporter *Reporter) HandleMuteChainButton(c tele.Context) error {
+	reporter.Logger.Info().
+		Str("sender", c.Sender().Username).
+		Str("data", c.Callback().Data).
+		Msg("Got mute chain button click")
+
+	args := c.Args()
+	if len(args) != 2 {
+		return c.Respond(&tele.CallbackResponse{Text: "Invalid button data!"})
+	}
+
+	duration, err := time.ParseDuration(args[1])
+	if err != nil {
+		return c.Respond(&tele.CallbackResponse{Text: "Invalid mute duration!"})
+	}
+
+	return reporter.AddMuteFromButton(
+		c,
+		null.StringFrom(args[0]),
+		null.NewString("", false),
+		time.Now().Add(duration),
+		fmt.Sprintf("🔇 Chain %s muted for %s by %s", args[0], duration, GetSenderName(c.Sender())),
+	)
+}
+
+func (reporter *Reporter) HandleAcknowledgeButton(c tele.Context) error {
+	reporter.Logger.Info().
+		Str("sender", c.Sender().Username).
+		Msg("Got acknowledge button click")
+
+	if err := reporter.EditAlertWithAction(
+		c,
+		"✅ Acknowledged by "+GetSenderName(c.Sender()),
+	); err != nil {
+		return err
+	}
+
+	return c.Respond(&tele.CallbackResponse{Text: "Acknowledged!"})
+}
+
+func (reporter *Reporter) AddMuteFromButton(
+	c tele.Context,
+	chain null.String,
+	proposalID null.String,
+	expires time.Time,
+	action string,
+) error {
+	mute := &types.Mute{
+		Chain:      chain,
+		ProposalID: proposalID,
+		Expires:    expires,
+		Comment: fmt.Sprintf(
+			"Muted using cosmos-proposals-checker button by %s",
+			c.Sender().FirstName,
+		),
+		Author: null.StringFrom(GetSenderName(c.Sender())),
+		Source: null.StringFrom(types.MuteSourceTelegram),
+	}
+
+	if destination := reporter.GetMuteDestination(c); destination != "" {
+		mute.Destination = null.StringFrom(destination)
+	}
+
+	if err := reporter.MutesManager.AddMute(mute); err != nil {
+		reporter.Logger.Error().Err(err).Msg("Error adding mute")
+		return c.Respond(&tele.CallbackResponse{Text: "Error adding mute!"})
+	}
+
+	if err := reporter.EditAlertWithAction(c, action); err != nil {
+		return err
+	}
+
+	return c.Respond(&tele.CallbackResponse{Text: "Muted!"})
+}
+
+// EditAlertWithAction appends the action taken to the original alert and removes
+// its buttons. The original text is sent back with its entities, as the message
+// HTML is not available anymore at this point.
+func (reporter *Reporter) EditAlertWithAction(c tele.Context, action string) error {
+	message := c.Message()
+	if message == nil {
+		return c.Respond(&tele.CallbackResponse{Text: "Original message is not available!"})
+	}
+
+	if _, err := reporter.TelegramBot.Edit(
+		message,
+		message.Text+"\n\n"+action,
+		tele.Entities(message.Entities),
+		tele.NoPreview,
+	); err != nil {
+		reporter.Logger.Error().Err(err).Msg("Error editing message")
+		return err
+	}
+
+	// the action is stored so it's kept when the alert is edited with the newer state
+	if chat := reporter.GetChat(c); chat != nil && reporter.Config.EditAlerts {
+		if err := reporter.AlertsManager.SetAction(
+			chat.Destination(),
+			strconv.Itoa(message.ID),
+			action,
+		); err != nil {
+			reporter.Logger.Warn().Err(err).Msg("Error saving alert action")
+		}
+	}
+
+	return nil
+}
+
+func GetSenderName(sender *tele.User) string {
+	if sender.Username != "" {
+		return "@" + sender.Username
+	}
+
+	return sender.FirstName
+}
@@ -0,0 +1,296 @@
+package telegram
+
+import (
+	"encoding/json"
+	"errors"
+	"main/assets"
+	databasePkg "main/pkg/database"
+	"main/pkg/events"
+	"main/pkg/report/entry"
+	"main/pkg/types"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	tele "gopkg.in/telebot.v3"
+)
+
+func getButtonContext(reporter *Reporter, data string) tele.Context {
+	return reporter.TelegramBot.NewContext(tele.Update{
+		ID: 1,
+		Callback: &tele.Callback{
+			ID:     "callback",
+			Sender: &tele.User{Username: "testuser", FirstName: "Test"},
+			Data:   data,
+			Message: &tele.Message{
+				ID:   10,
+				Chat: &tele.Chat{ID: 100},
+				Text: "Alert",
+				Entities: tele.Entities{
+					{Type: tele.EntityBold, Offset: 0, Length: 5},
+				},
+			},
+		},
+	})
+}
+
+func registerButtonResponders(t *testing.T, edited *[]map[string]string) {
+	t.Helper()
+
+	httpmock.RegisterResponder(
+		"POST",
+		"https://api.telegram.org/botxxx:yyy/getMe",
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("telegram-bot-ok.json")))
+
+	httpmock.RegisterResponder(
+		"POST",
+		"https://api.telegram.org/botxxx:yyy/editMessageText",
+		func(req *http.Request) (*http.Response, error) {
+			body := map[string]string{}
+			if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+				return nil, err
+			}
+
+			*edited = append(*edited, body)
+			return httpmock.NewBytesResponse(200, assets.GetBytesOrPanic("telegram-send-message-ok.json")), nil
+		},
+	)
+
+	httpmock.RegisterResponder(
+		"POST",
+		"https://api.telegram.org/botxxx:yyy/answerCallbackQuery",
+		httpmock.NewStringResponder(200, `{"ok":true,"result":true}`),
+	)
+}
+
+//nolint:paralleltest // disabled
+func TestTelegramReporterGetAlertMarkup(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	registerButtonResponders(t, &[]map[string]string{})
+
+	reporter := getTestReporterWithChats(t, &databasePkg.StubDatabase{}, []types.TelegramChat{{ID: 100}})
+	endTime := time.Now().Add(time.Hour)
+
+	markup := reporter.GetAlertMarkup(events.NotVotedEvent{
+		Chain:    &types.Chain{Name: "chain"},
+		Wallet:   &types.Wallet{Address: "wallet"},
+		Proposal: types.Proposal{ID: "1", EndTime: endTime},
+	})
+	require.NotNil(t, markup)
+	require.Len(t, markup.InlineKeyboard, 2)
+	require.Len(t, markup.InlineKeyboard[0], 3)
+	assert.Equal(t, ButtonMuteProposal, markup.InlineKeyboard[0][0].Unique)
+	assert.Equal(t, "chain|1|1h", markup.InlineKeyboard[0][0].Data)
+	assert.Equal(t, "chain|1|24h", markup.InlineKeyboard[0][1].Data)
+	assert.Equal(t, ButtonMuteUntilEnd, markup.InlineKeyboard[0][2].Unique)
+	assert.Equal(t, "chain|1|"+strconv.FormatInt(endTime.Unix(), 10), markup.InlineKeyboard[0][2].Data)
+	require.Len(t, markup.InlineKeyboard[1], 2)
+	assert.Equal(t, ButtonMuteChain, markup.InlineKeyboard[1][0].Unique)
+	assert.Equal(t, "chain|24h0m0s", markup.InlineKeyboard[1][0].Data)
+	assert.Equal(t, ButtonAcknowledge, markup.InlineKeyboard[1][1].Unique)
+
+	longMarkup := reporter.GetAlertMarkup(events.NotVotedEvent{
+		Chain:    &types.Chain{Name: "chain"},
+		Wallet:   &types.Wallet{Address: "wallet"},
+		Proposal: types.Proposal{ID: strings.Repeat("1", 50)},
+	})
+	require.NotNil(t, longMarkup)
+	require.Len(t, longMarkup.InlineKeyboard, 1)
+
+	groupedMarkup := reporter.GetAlertMarkup(events.GroupedEvent{
+		Chain:   &types.Chain{Name: "chain"},
+		Entries: []entry.ReportEntry{events.NotVotedEvent{}},
+	})
+	require.NotNil(t, groupedMarkup)
+	require.Len(t, groupedMarkup.InlineKeyboard, 1)
+	require.Len(t, groupedMarkup.InlineKeyboard[0], 2)
+	assert.Equal(t, ButtonMuteChain, groupedMarkup.InlineKeyboard[0][0].Unique)
+	assert.Equal(t, ButtonAcknowledge, groupedMarkup.InlineKeyboard[0][1].Unique)
+
+	assert.Nil(t, reporter.GetAlertMarkup(events.FinishedVotingEvent{
+		Chain:    &types.Chain{Name: "chain"},
+		Proposal: types.Proposal{ID: "1"},
+	}))
+	assert.Nil(t, reporter.GetAlertMarkup(events.GenericErrorEvent{
+		Chain: &types.Chain{Name: "chain"},
+		Error: errors.New("error"),
+	}))
+
+	spamMarkup := reporter.GetAlertMarkup(events.SuspectedSpamEvent{
+		Chain:    &types.Chain{Name: "chain"},
+		Proposal: types.Proposal{ID: "1"},
+	})
+	require.NotNil(t, spamMarkup)
+	require.Len(t, spamMarkup.InlineKeyboard, 1)
+	require.Len(t, spamMarkup.InlineKeyboard[0], 1)
+	assert.Equal(t, ButtonNotSpam, spamMarkup.InlineKeyboard[0][0].Unique)
+	assert.Equal(t, "chain|1", spamMarkup.InlineKeyboard[0][0].Data)
+}
+
+//nolint:paralleltest // disabled
+func TestTelegramReporterMuteProposalButton(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	edited := make([]map[string]string, 0)
+	registerButtonResponders(t, &edited)
+
+	database := &databasePkg.StubDatabase{}
+	reporter := getTestReporterWithChats(t, database, []types.TelegramChat{{ID: 100}})
+
+	err := reporter.HandleMuteProposalButton(getButtonContext(reporter, "chain|1|1h"))
+	require.NoError(t, err)
+
+	require.Len(t, database.Mutes, 1)
+	assert.Equal(t, "chain", database.Mutes[0].Chain.String)
+	assert.Equal(t, "1", database.Mutes[0].ProposalID.String)
+	assert.False(t, database.Mutes[0].Destination.Valid)
+	assert.WithinDuration(t, time.Now().Add(time.Hour), database.Mutes[0].Expires, time.Minute)
+
+	require.Len(t, edited, 1)
+	assert.Equal(t, "Alert\n\n🔇 Proposal 1 muted for 1h0m0s by @testuser", edited[0]["text"])
+	assert.Contains(t, edited[0]["entities"], `"type":"bold","offset":0,"length":5`)
+}
+
+//nolint:paralleltest // disabled
+func TestTelegramReporterMuteUntilEndButton(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	edited := make([]map[string]string, 0)
+	registerButtonResponders(t, &edited)
+
+	database := &databasePkg.StubDatabase{}
+	reporter := getTestReporterWithChats(t, database, []types.TelegramChat{
+		{ID: 100, DestinationFilter: types.DestinationFilter{MuteScope: types.MuteScopeDestination}},
+	})
+
+	endTime := time.Now().Add(time.Hour).Truncate(time.Second)
+
+	err := reporter.HandleMuteUntilEndButton(getButtonContext(
+		reporter,
+		"chain|1|"+strconv.FormatInt(endTime.Unix(), 10),
+	))
+	require.NoError(t, err)
+
+	require.Len(t, database.Mutes, 1)
+	assert.Equal(t, "telegram:100", database.Mutes[0].Destination.String)
+	assert.True(t, endTime.Equal(database.Mutes[0].Expires))
+	require.Len(t, edited, 1)
+
+	err = reporter.HandleMuteUntilEndButton(getButtonContext(reporter, "chain|1|1"))
+	require.NoError(t, err)
+	require.Len(t, database.Mutes, 1)
+	require.Len(t, edited, 1)
+
+	err = reporter.HandleMuteUntilEndButton(getButtonContext(reporter, "chain|1|invalid"))
+	require.NoError(t, err)
+	require.Len(t, edited, 1)
+}
+
+//nolint:paralleltest // disabled
+func TestTelegramReporterMuteChainButton(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	edited := make([]map[string]string, 0)
+	registerButtonResponders(t, &edited)
+
+	database := &databasePkg.StubDatabase{}
+	reporter := getTestReporterWithChats(t, database, []types.TelegramChat{{ID: 100}})
+
+	err := reporter.HandleMuteChainButton(getButtonContext(reporter, "chain|24h"))
+	require.NoError(t, err)
+
+	require.Len(t, database.Mutes, 1)
+	assert.Equal(t, "chain", database.Mutes[0].Chain.String)
+	assert.False(t, database.Mutes[0].ProposalID.Valid)
+	require.Len(t, edited, 1)
+	assert.Equal(t, "Alert\n\n🔇 Chain chain muted for 24h0m0s by @testuser", edited[0]["text"])
+
+	err = reporter.HandleMuteChainButton(getButtonContext(reporter, "chain"))
+	require.NoError(t, err)
+	err = reporter.HandleMuteChainButton(getButtonContext(reporter, "chain|invalid"))
+	require.NoError(t, err)
+	require.Len(t, database.Mutes, 1)
+}
+
+//nolint:paralleltest // disabled
+func TestTelegramReporterMuteButtonDatabaseError(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	edited := make([]map[string]string, 0)
+	registerButtonResponders(t, &edited)
+
+	database := &databasePkg.StubDatabase{UpsertMuteError: errors.New("custom error")}
+	reporter := getTestReporterWithChats(t, database, []types.TelegramChat{{ID: 100}})
+
+	err := reporter.HandleMuteProposalButton(getButtonContext(reporter, "chain|1|1h"))
+	require.NoError(t, err)
+	require.Empty(t, edited)
+
+	err = reporter.HandleMuteProposalButton(getButtonContext(reporter, "chain|1"))
+	require.NoError(t, err)
+	err = reporter.HandleMuteProposalButton(getButtonContext(reporter, "chain|1|invalid"))
+	require.NoError(t, err)
+	require.Empty(t, edited)
+}
+
+//nolint:paralleltest // disabled
+func TestTelegramReporterAcknowledgeButton(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	edited := make([]map[string]string, 0)
+	registerButtonResponders(t, &edited)
+
+	database := &databasePkg.StubDatabase{}
+	reporter := getTestReporterWithChats(t, database, []types.TelegramChat{{ID: 100}})
+
+	err := reporter.HandleAcknowledgeButton(getButtonContext(reporter, ""))
+	require.NoError(t, err)
+	require.Empty(t, database.Mutes)
+	require.Len(t, edited, 1)
+	assert.Equal(t, "Alert\n\n✅ Acknowledged by @testuser", edited[0]["text"])
+}
+
+//nolint:paralleltest // disabled
+func TestTelegramReporterNotSpamButton(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	edited := make([]map[string]string, 0)
+	registerButtonResponders(t, &edited)
+
+	database := &databasePkg.StubDatabase{
+		SpamFlags: []*types.SpamFlag{{Chain: "chain", ProposalID: "1", Flagged: true}},
+	}
+	reporter := getTestReporterWithChats(t, database, []types.TelegramChat{{ID: 100}})
+
+	err := reporter.HandleNotSpamButton(getButtonContext(reporter, "chain|1"))
+	require.NoError(t, err)
+	require.False(t, database.SpamFlags[0].Flagged)
+	assert.Equal(t, "@testuser", database.SpamFlags[0].UpdatedBy.String)
+	require.Len(t, edited, 1)
+	assert.Equal(t, "Alert\n\n✅ Marked as not spam by @testuser", edited[0]["text"])
+
+	// already un-flagged, the message is not edited again
+	err = reporter.HandleNotSpamButton(getButtonContext(reporter, "chain|1"))
+	require.NoError(t, err)
+	require.Len(t, edited, 1)
+}
+
+func TestGetSenderName(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "@user", GetSenderName(&tele.User{Username: "user", FirstName: "User"}))
+	assert.Equal(t, "User", GetSenderName(&tele.User{FirstName: "User"}))
+}
@@ -0,0 +1,96 @@
+package telegram
+
+import (
+	"context"
+	"errors"
+	statePkg "main/pkg/state"
+	"main/pkg/types"
+	"main/pkg/utils"
+	"strconv"
+	"strings"
+
+	tele "gopkg.in/telebot.v3"
+)
+
+func (reporter *Reporter) DashboardEnabled() bool {
+	return reporter.Config.Dashboard
+}
+
+func (reporter *Reporter) UpdateDashboard(state statePkg.RenderedState, ctx context.Context) error {
+	_, span := reporter.Tracer.Start(ctx, "Updating Telegram dashboard")
+	defer span.End()
+
+	errs := make([]error, 0)
+
+	for _, chat := range reporter.Chats {
+		if err := reporter.UpdateChatDashboard(chat, state.Filter(&chat.DestinationFilter)); err != nil {
+			reporter.Logger.Err(err).
+				Str("destination", chat.Destination()).
+				Msg("Could not update dashboard")
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// UpdateChatDashboard edits the dashboard message in the chat, or sends and pins a new one
+// if there's none yet or it cannot be edited, for example, if it was deleted.
+func (reporter *Reporter) UpdateChatDashboard(chat types.TelegramChat, state statePkg.RenderedState) error {
+	rendered, err := reporter.TemplatesManager.Render("dashboard", state)
+	if err != nil {
+		return err
+	}
+
+	// the dashboard should be a single message to be edited later, so the rest is cut
+	chunks := utils.SplitStringIntoChunks(strings.TrimSpace(rendered), MaxMessageSize)
+	if len(chunks) > 1 {
+		reporter.Logger.Warn().
+			Str("destination", chat.Destination()).
+			Int("chunks", len(chunks)).
+			Msg("Dashboard does not fit into a single message, it's cut")
+	}
+
+	text := strings.TrimSpace(chunks[0])
+
+	message, err := reporter.DashboardManager.Get(chat.Destination())
+	if err != nil {
+		return err
+	}
+
+	if message != nil {
+		editErr := reporter.EditMessage(
+			&tele.StoredMessage{MessageID: message.MessageID, ChatID: chat.ID},
+			text,
+			tele.ModeHTML,
+			tele.NoPreview,
+		)
+		if editErr == nil || errors.Is(editErr, tele.ErrSameMessageContent) {
+			return reporter.DashboardManager.Save(chat.Destination(), message.MessageID)
+		}
+
+		reporter.Logger.Warn().
+			Err(editErr).
+			Str("destination", chat.Destination()).
+			Str("message_id", message.MessageID).
+			Msg("Could not edit dashboard message, sending a new one")
+	}
+
+	sent, err := reporter.TelegramBot.Send(&tele.Chat{ID: chat.ID}, text, &tele.SendOptions{
+		ParseMode:             tele.ModeHTML,
+		DisableWebPagePreview: true,
+		ThreadID:              chat.ThreadID,
+	})
+	if err != nil {
+		return err
+	}
+
+	if pinErr := reporter.TelegramBot.Pin(sent, tele.Silent); pinErr != nil {
+		reporter.Logger.Warn().
+			Err(pinErr).
+			Str("destination", chat.Destination()).
+			Msg("Could not pin dashboard message, the bot should be allowed to pin messages")
+	}
+
+	return reporter.DashboardManager.Save(chat.Destination(), strconv.Itoa(sent.ID))
+}
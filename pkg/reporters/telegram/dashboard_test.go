@@ -0,0 +1,217 @@
+package telegram
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"main/assets"
+	databasePkg "main/pkg/database"
+	"main/pkg/state"
+	"main/pkg/types"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/require"
+)
+
+func getTestDashboardState() state.RenderedState {
+	chain := &types.Chain{Name: "chain", PrettyName: "Chain"}
+	renderTime := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	return state.RenderedState{
+		RenderTime: renderTime,
+		ChainInfos: []state.RenderedChainInfo{
+			{
+				Chain: chain,
+				ProposalVotes: []state.RenderedProposalVotes{
+					{
+						Proposal: types.Proposal{
+							ID:      "1",
+							Title:   "Proposal",
+							EndTime: renderTime.Add(2 * time.Hour),
+						},
+						Votes: []state.RenderedWalletVote{
+							{Wallet: &types.Wallet{Address: "voted"}, Vote: &types.Vote{
+								Options: types.VoteOptions{{Option: "YES", Weight: 1}},
+							}},
+							{Wallet: &types.Wallet{Address: "not-voted"}},
+							{Wallet: &types.Wallet{Address: "error"}, Error: &types.QueryError{QueryError: errors.New("error")}},
+						},
+					},
+				},
+			},
+			{
+				Chain:          &types.Chain{Name: "another-chain"},
+				ProposalVotes:  []state.RenderedProposalVotes{},
+				ProposalsError: &types.QueryError{QueryError: errors.New("error")},
+			},
+		},
+	}
+}
+
+func registerDashboardResponders(t *testing.T, sent, edited *[]map[string]any, pinned *int, editErr error) {
+	t.Helper()
+
+	recorder := func(requests *[]map[string]any) httpmock.Responder {
+		return func(req *http.Request) (*http.Response, error) {
+			body := map[string]any{}
+			if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+				return nil, err
+			}
+
+			*requests = append(*requests, body)
+			return httpmock.NewBytesResponse(200, assets.GetBytesOrPanic("telegram-send-message-ok.json")), nil
+		}
+	}
+
+	httpmock.RegisterResponder(
+		"POST",
+		"https://api.telegram.org/botxxx:yyy/getMe",
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("telegram-bot-ok.json")))
+
+	httpmock.RegisterResponder(
+		"POST",
+		"https://api.telegram.org/botxxx:yyy/sendMessage",
+		recorder(sent),
+	)
+
+	httpmock.RegisterResponder(
+		"POST",
+		"https://api.telegram.org/botxxx:yyy/pinChatMessage",
+		func(req *http.Request) (*http.Response, error) {
+			*pinned++
+			return httpmock.NewStringResponse(200, `{"ok":true,"result":true}`), nil
+		},
+	)
+
+	editResponder := recorder(edited)
+	if editErr != nil {
+		editResponder = httpmock.NewErrorResponder(editErr)
+	}
+
+	httpmock.RegisterResponder(
+		"POST",
+		"https://api.telegram.org/botxxx:yyy/editMessageText",
+		editResponder,
+	)
+}
+
+//nolint:paralleltest // disabled
+func TestTelegramReporterUpdateDashboardNew(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	sent := make([]map[string]any, 0)
+	edited := make([]map[string]any, 0)
+	pinned := 0
+	registerDashboardResponders(t, &sent, &edited, &pinned, nil)
+
+	database := &databasePkg.StubDatabase{}
+	reporter := getTestReporterWithChats(t, database, []types.TelegramChat{{ID: 100}})
+	reporter.Config.Dashboard = true
+	require.True(t, reporter.DashboardEnabled())
+
+	err := reporter.UpdateDashboard(getTestDashboardState(), context.Background())
+	require.NoError(t, err)
+
+	require.Len(t, sent, 1)
+	require.Equal(t, string(assets.GetBytesOrPanic("responses/telegram-dashboard.html")), sent[0]["text"])
+	require.Empty(t, edited)
+	require.Equal(t, 1, pinned)
+	require.Len(t, database.Dashboards, 1)
+	require.Equal(t, "telegram:100", database.Dashboards[0].Destination)
+	require.Equal(t, "0", database.Dashboards[0].MessageID)
+}
+
+//nolint:paralleltest // disabled
+func TestTelegramReporterUpdateDashboardEdit(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	sent := make([]map[string]any, 0)
+	edited := make([]map[string]any, 0)
+	pinned := 0
+	registerDashboardResponders(t, &sent, &edited, &pinned, nil)
+
+	database := &databasePkg.StubDatabase{
+		Dashboards: []*types.DashboardMessage{{Destination: "telegram:100", MessageID: "123"}},
+	}
+	reporter := getTestReporterWithChats(t, database, []types.TelegramChat{{ID: 100}})
+
+	err := reporter.UpdateDashboard(getTestDashboardState(), context.Background())
+	require.NoError(t, err)
+
+	require.Empty(t, sent)
+	require.Equal(t, 0, pinned)
+	require.Len(t, edited, 1)
+	require.Equal(t, "123", edited[0]["message_id"])
+	require.Equal(t, "100", edited[0]["chat_id"])
+	require.Equal(t, string(assets.GetBytesOrPanic("responses/telegram-dashboard.html")), edited[0]["text"])
+}
+
+//nolint:paralleltest // disabled
+func TestTelegramReporterUpdateDashboardEditFailed(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	sent := make([]map[string]any, 0)
+	edited := make([]map[string]any, 0)
+	pinned := 0
+	registerDashboardResponders(t, &sent, &edited, &pinned, errors.New("custom error"))
+
+	database := &databasePkg.StubDatabase{
+		Dashboards: []*types.DashboardMessage{{Destination: "telegram:100", MessageID: "123"}},
+	}
+	reporter := getTestReporterWithChats(t, database, []types.TelegramChat{{ID: 100}})
+
+	err := reporter.UpdateDashboard(getTestDashboardState(), context.Background())
+	require.NoError(t, err)
+
+	require.Len(t, sent, 1)
+	require.Equal(t, 1, pinned)
+	require.Len(t, database.Dashboards, 1)
+	require.Equal(t, "0", database.Dashboards[0].MessageID)
+}
+
+//nolint:paralleltest // disabled
+func TestTelegramReporterUpdateDashboardFiltered(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	sent := make([]map[string]any, 0)
+	edited := make([]map[string]any, 0)
+	pinned := 0
+	registerDashboardResponders(t, &sent, &edited, &pinned, nil)
+
+	database := &databasePkg.StubDatabase{}
+	reporter := getTestReporterWithChats(t, database, []types.TelegramChat{
+		{ID: 100, DestinationFilter: types.DestinationFilter{Chains: []string{"another-chain"}}},
+	})
+
+	err := reporter.UpdateDashboard(getTestDashboardState(), context.Background())
+	require.NoError(t, err)
+
+	require.Len(t, sent, 1)
+	require.NotContains(t, sent[0]["text"], "Proposal #1")
+	require.Contains(t, sent[0]["text"], "another-chain")
+}
+
+//nolint:paralleltest // disabled
+func TestTelegramReporterUpdateDashboardErrorGetting(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	sent := make([]map[string]any, 0)
+	edited := make([]map[string]any, 0)
+	pinned := 0
+	registerDashboardResponders(t, &sent, &edited, &pinned, nil)
+
+	database := &databasePkg.StubDatabase{GetDashboardError: errors.New("custom error")}
+	reporter := getTestReporterWithChats(t, database, []types.TelegramChat{{ID: 100}})
+
+	err := reporter.UpdateDashboard(getTestDashboardState(), context.Background())
+	require.Error(t, err)
+	require.Empty(t, sent)
+}
@@ -3,6 +3,8 @@ package telegram
 import (
 	"fmt"
 
+	"github.com/guregu/null/v5"
+
 	tele "gopkg.in/telebot.v3"
 )
 
@@ -17,7 +19,14 @@ func (reporter *Reporter) HandleDeleteMute(c tele.Context) error {
 		return c.Reply("Error deleting mute: " + err)
 	}
 
-	if found, deleteErr := reporter.MutesManager.DeleteMute(mute); deleteErr != nil {
+	if destination := reporter.GetMuteDestination(c); destination != "" {
+		mute.Destination = null.StringFrom(destination)
+	}
+
+	if found, deleteErr := reporter.MutesManager.DeleteMute(
+		mute,
+		GetSenderName(c.Sender()),
+	); deleteErr != nil {
 		return c.Reply(fmt.Sprintf("Error deleting mute: %s!", deleteErr))
 	} else if !found {
 		return c.Reply("Could not find the mute to delete!")
@@ -3,10 +3,16 @@ package telegram
 import (
 	"errors"
 	"main/assets"
+	"main/pkg/alerts"
+	"main/pkg/dashboard"
 	"main/pkg/data"
 	databasePkg "main/pkg/database"
 	loggerPkg "main/pkg/logger"
+	"main/pkg/metrics"
 	mutesmanager "main/pkg/mutes"
+	"main/pkg/outbox"
+	"main/pkg/routes"
+	"main/pkg/spam"
 	"main/pkg/state"
 	"main/pkg/tracing"
 	"main/pkg/types"
@@ -39,11 +45,17 @@ func TestTelegramReporterDeleteMuteInvalidArgument(t *testing.T) {
 	config := types.TelegramConfig{TelegramToken: "xxx:yyy", TelegramChat: 123}
 	chains := types.Chains{{Name: "chain", LCDEndpoints: []string{"https://example.com"}}}
 	logger := loggerPkg.GetNopLogger()
+	metricsManager := metrics.NewManager(logger, types.MetricsConfig{})
 	tracer := tracing.InitNoopTracer()
 	database := &databasePkg.StubDatabase{}
 	mutesManager := mutesmanager.NewMutesManager(logger, database)
-	stateGenerator := state.NewStateGenerator(logger, tracer, chains)
-	dataManager := data.NewManager(logger, chains, tracer)
+	spamManager := spam.NewManager(logger, types.SpamConfig{}, database)
+	outboxManager := outbox.NewManager(logger, types.OutboxConfig{}, database)
+	alertsManager := alerts.NewManager(logger, database, []types.Duration{})
+	dashboardManager := dashboard.NewManager(logger, database)
+	routesManager := routes.NewManager(logger, types.Routes{})
+	stateGenerator := state.NewStateGenerator(logger, metricsManager, tracer, chains)
+	dataManager := data.NewManager(logger, chains, metricsManager, tracer)
 
 	timezone, err := time.LoadLocation("Etc/GMT")
 	require.NoError(t, err)
@@ -51,6 +63,11 @@ func TestTelegramReporterDeleteMuteInvalidArgument(t *testing.T) {
 	reporter := NewTelegramReporter(
 		config,
 		mutesManager,
+		routesManager,
+		spamManager,
+		outboxManager,
+		alertsManager,
+		dashboardManager,
 		stateGenerator,
 		dataManager,
 		logger,
@@ -96,11 +113,17 @@ func TestTelegramReporterDeleteMuteNotFound(t *testing.T) {
 	config := types.TelegramConfig{TelegramToken: "xxx:yyy", TelegramChat: 123}
 	chains := types.Chains{{Name: "chain", LCDEndpoints: []string{"https://example.com"}}}
 	logger := loggerPkg.GetNopLogger()
+	metricsManager := metrics.NewManager(logger, types.MetricsConfig{})
 	tracer := tracing.InitNoopTracer()
 	database := &databasePkg.StubDatabase{}
 	mutesManager := mutesmanager.NewMutesManager(logger, database)
-	stateGenerator := state.NewStateGenerator(logger, tracer, chains)
-	dataManager := data.NewManager(logger, chains, tracer)
+	spamManager := spam.NewManager(logger, types.SpamConfig{}, database)
+	outboxManager := outbox.NewManager(logger, types.OutboxConfig{}, database)
+	alertsManager := alerts.NewManager(logger, database, []types.Duration{})
+	dashboardManager := dashboard.NewManager(logger, database)
+	routesManager := routes.NewManager(logger, types.Routes{})
+	stateGenerator := state.NewStateGenerator(logger, metricsManager, tracer, chains)
+	dataManager := data.NewManager(logger, chains, metricsManager, tracer)
 
 	timezone, err := time.LoadLocation("Etc/GMT")
 	require.NoError(t, err)
@@ -108,6 +131,11 @@ func TestTelegramReporterDeleteMuteNotFound(t *testing.T) {
 	reporter := NewTelegramReporter(
 		config,
 		mutesManager,
+		routesManager,
+		spamManager,
+		outboxManager,
+		alertsManager,
+		dashboardManager,
 		stateGenerator,
 		dataManager,
 		logger,
@@ -153,11 +181,17 @@ func TestTelegramReporterDeleteMuteError(t *testing.T) {
 	config := types.TelegramConfig{TelegramToken: "xxx:yyy", TelegramChat: 123}
 	chains := types.Chains{{Name: "chain", LCDEndpoints: []string{"https://example.com"}}}
 	logger := loggerPkg.GetNopLogger()
+	metricsManager := metrics.NewManager(logger, types.MetricsConfig{})
 	tracer := tracing.InitNoopTracer()
 	database := &databasePkg.StubDatabase{DeleteMuteError: errors.New("custom error")}
 	mutesManager := mutesmanager.NewMutesManager(logger, database)
-	stateGenerator := state.NewStateGenerator(logger, tracer, chains)
-	dataManager := data.NewManager(logger, chains, tracer)
+	spamManager := spam.NewManager(logger, types.SpamConfig{}, database)
+	outboxManager := outbox.NewManager(logger, types.OutboxConfig{}, database)
+	alertsManager := alerts.NewManager(logger, database, []types.Duration{})
+	dashboardManager := dashboard.NewManager(logger, database)
+	routesManager := routes.NewManager(logger, types.Routes{})
+	stateGenerator := state.NewStateGenerator(logger, metricsManager, tracer, chains)
+	dataManager := data.NewManager(logger, chains, metricsManager, tracer)
 
 	timezone, err := time.LoadLocation("Etc/GMT")
 	require.NoError(t, err)
@@ -165,6 +199,11 @@ func TestTelegramReporterDeleteMuteError(t *testing.T) {
 	reporter := NewTelegramReporter(
 		config,
 		mutesManager,
+		routesManager,
+		spamManager,
+		outboxManager,
+		alertsManager,
+		dashboardManager,
 		stateGenerator,
 		dataManager,
 		logger,
@@ -212,11 +251,17 @@ func TestTelegramReporterDeleteMuteOk(t *testing.T) {
 	config := types.TelegramConfig{TelegramToken: "xxx:yyy", TelegramChat: 123}
 	chains := types.Chains{{Name: "chain", LCDEndpoints: []string{"https://example.com"}}}
 	logger := loggerPkg.GetNopLogger()
+	metricsManager := metrics.NewManager(logger, types.MetricsConfig{})
 	tracer := tracing.InitNoopTracer()
 	database := &databasePkg.StubDatabase{}
 	mutesManager := mutesmanager.NewMutesManager(logger, database)
-	stateGenerator := state.NewStateGenerator(logger, tracer, chains)
-	dataManager := data.NewManager(logger, chains, tracer)
+	spamManager := spam.NewManager(logger, types.SpamConfig{}, database)
+	outboxManager := outbox.NewManager(logger, types.OutboxConfig{}, database)
+	alertsManager := alerts.NewManager(logger, database, []types.Duration{})
+	dashboardManager := dashboard.NewManager(logger, database)
+	routesManager := routes.NewManager(logger, types.Routes{})
+	stateGenerator := state.NewStateGenerator(logger, metricsManager, tracer, chains)
+	dataManager := data.NewManager(logger, chains, metricsManager, tracer)
 
 	timezone, err := time.LoadLocation("Etc/GMT")
 	require.NoError(t, err)
@@ -224,6 +269,11 @@ func TestTelegramReporterDeleteMuteOk(t *testing.T) {
 	reporter := NewTelegramReporter(
 		config,
 		mutesManager,
+		routesManager,
+		spamManager,
+		outboxManager,
+		alertsManager,
+		dashboardManager,
 		stateGenerator,
 		dataManager,
 		logger,
@@ -271,11 +321,17 @@ func TestTelegramReporterDeleteMuteOkWithParams(t *testing.T) {
 	config := types.TelegramConfig{TelegramToken: "xxx:yyy", TelegramChat: 123}
 	chains := types.Chains{{Name: "chain", LCDEndpoints: []string{"https://example.com"}}}
 	logger := loggerPkg.GetNopLogger()
+	metricsManager := metrics.NewManager(logger, types.MetricsConfig{})
 	tracer := tracing.InitNoopTracer()
 	database := &databasePkg.StubDatabase{}
 	mutesManager := mutesmanager.NewMutesManager(logger, database)
-	stateGenerator := state.NewStateGenerator(logger, tracer, chains)
-	dataManager := data.NewManager(logger, chains, tracer)
+	spamManager := spam.NewManager(logger, types.SpamConfig{}, database)
+	outboxManager := outbox.NewManager(logger, types.OutboxConfig{}, database)
+	alertsManager := alerts.NewManager(logger, database, []types.Duration{})
+	dashboardManager := dashboard.NewManager(logger, database)
+	routesManager := routes.NewManager(logger, types.Routes{})
+	stateGenerator := state.NewStateGenerator(logger, metricsManager, tracer, chains)
+	dataManager := data.NewManager(logger, chains, metricsManager, tracer)
 
 	timezone, err := time.LoadLocation("Etc/GMT")
 	require.NoError(t, err)
@@ -283,6 +339,11 @@ func TestTelegramReporterDeleteMuteOkWithParams(t *testing.T) {
 	reporter := NewTelegramReporter(
 		config,
 		mutesManager,
+		routesManager,
+		spamManager,
+		outboxManager,
+		alertsManager,
+		dashboardManager,
 		stateGenerator,
 		dataManager,
 		logger,
@@ -334,11 +395,17 @@ func TestTelegramReporterRenderDeleteMuteNoParams(t *testing.T) {
 	config := types.TelegramConfig{TelegramToken: "xxx:yyy", TelegramChat: 123}
 	chains := types.Chains{{Name: "chain", LCDEndpoints: []string{"https://example.com"}}}
 	logger := loggerPkg.GetNopLogger()
+	metricsManager := metrics.NewManager(logger, types.MetricsConfig{})
 	tracer := tracing.InitNoopTracer()
 	database := &databasePkg.StubDatabase{}
 	mutesManager := mutesmanager.NewMutesManager(logger, database)
-	stateGenerator := state.NewStateGenerator(logger, tracer, chains)
-	dataManager := data.NewManager(logger, chains, tracer)
+	spamManager := spam.NewManager(logger, types.SpamConfig{}, database)
+	outboxManager := outbox.NewManager(logger, types.OutboxConfig{}, database)
+	alertsManager := alerts.NewManager(logger, database, []types.Duration{})
+	dashboardManager := dashboard.NewManager(logger, database)
+	routesManager := routes.NewManager(logger, types.Routes{})
+	stateGenerator := state.NewStateGenerator(logger, metricsManager, tracer, chains)
+	dataManager := data.NewManager(logger, chains, metricsManager, tracer)
 
 	timezone, err := time.LoadLocation("Etc/GMT")
 	require.NoError(t, err)
@@ -346,6 +413,11 @@ func TestTelegramReporterRenderDeleteMuteNoParams(t *testing.T) {
 	reporter := NewTelegramReporter(
 		config,
 		mutesManager,
+		routesManager,
+		spamManager,
+		outboxManager,
+		alertsManager,
+		dashboardManager,
 		stateGenerator,
 		dataManager,
 		logger,
@@ -392,11 +464,17 @@ func TestTelegramReporterRenderDeleteMuteWithParams(t *testing.T) {
 	config := types.TelegramConfig{TelegramToken: "xxx:yyy", TelegramChat: 123}
 	chains := types.Chains{{Name: "chain", LCDEndpoints: []string{"https://example.com"}}}
 	logger := loggerPkg.GetNopLogger()
+	metricsManager := metrics.NewManager(logger, types.MetricsConfig{})
 	tracer := tracing.InitNoopTracer()
 	database := &databasePkg.StubDatabase{}
 	mutesManager := mutesmanager.NewMutesManager(logger, database)
-	stateGenerator := state.NewStateGenerator(logger, tracer, chains)
-	dataManager := data.NewManager(logger, chains, tracer)
+	spamManager := spam.NewManager(logger, types.SpamConfig{}, database)
+	outboxManager := outbox.NewManager(logger, types.OutboxConfig{}, database)
+	alertsManager := alerts.NewManager(logger, database, []types.Duration{})
+	dashboardManager := dashboard.NewManager(logger, database)
+	routesManager := routes.NewManager(logger, types.Routes{})
+	stateGenerator := state.NewStateGenerator(logger, metricsManager, tracer, chains)
+	dataManager := data.NewManager(logger, chains, metricsManager, tracer)
 
 	timezone, err := time.LoadLocation("Etc/GMT")
 	require.NoError(t, err)
@@ -404,6 +482,11 @@ func TestTelegramReporterRenderDeleteMuteWithParams(t *testing.T) {
 	reporter := NewTelegramReporter(
 		config,
 		mutesManager,
+		routesManager,
+		spamManager,
+		outboxManager,
+		alertsManager,
+		dashboardManager,
 		stateGenerator,
 		dataManager,
 		logger,
@@ -437,3 +520,37 @@ func TestTelegramReporterRenderDeleteMuteWithParams(t *testing.T) {
 	err = reporter.ReplyRender(ctx, "mute_deleted", mute)
 	require.NoError(t, err)
 }
+
+//nolint:paralleltest // disabled
+func TestTelegramReporterDeleteMuteUnknownArgument(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder(
+		"POST",
+		"https://api.telegram.org/botxxx:yyy/getMe",
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("telegram-bot-ok.json")))
+
+	httpmock.RegisterMatcherResponder(
+		"POST",
+		"https://api.telegram.org/botxxx:yyy/sendMessage",
+		types.TelegramResponseHasText("Error deleting mute: Unknown param at position 1: walet, expected one of chain, proposal, wallet, event or title"),
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("telegram-send-message-ok.json")),
+	)
+
+	database := &databasePkg.StubDatabase{}
+	reporter := getTestReporterWithChats(t, database, []types.TelegramChat{{ID: 2}})
+
+	ctx := reporter.TelegramBot.NewContext(tele.Update{
+		ID: 1,
+		Message: &tele.Message{
+			Sender:  &tele.User{Username: "testuser"},
+			Text:    "/proposals_unmute walet=wallet",
+			Payload: "walet=wallet",
+			Chat:    &tele.Chat{ID: 2},
+		},
+	})
+
+	err := reporter.HandleDeleteMute(ctx)
+	require.NoError(t, err)
+}
@@ -0,0 +1,296 @@
+package telegram
+
+import (
+	"context"
+	"encoding/json"
+	"main/assets"
+	"main/pkg/alerts"
+	"main/pkg/dashboard"
+	"main/pkg/data"
+	databasePkg "main/pkg/database"
+	"main/pkg/events"
+	loggerPkg "main/pkg/logger"
+	"main/pkg/metrics"
+	mutesmanager "main/pkg/mutes"
+	"main/pkg/outbox"
+	"main/pkg/report/entry"
+	"main/pkg/routes"
+	"main/pkg/spam"
+	"main/pkg/state"
+	"main/pkg/tracing"
+	"main/pkg/types"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/guregu/null/v5"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	tele "gopkg.in/telebot.v3"
+)
+
+func getTestReporterWithChats(
+	t *testing.T,
+	database *databasePkg.StubDatabase,
+	chats []types.TelegramChat,
+) *Reporter {
+	t.Helper()
+
+	config := types.TelegramConfig{TelegramToken: "xxx:yyy", Chats: chats}
+	chains := types.Chains{{Name: "chain", LCDEndpoints: []string{"https://example.com"}}}
+	logger := loggerPkg.GetNopLogger()
+	metricsManager := metrics.NewManager(logger, types.MetricsConfig{})
+	tracer := tracing.InitNoopTracer()
+	mutesManager := mutesmanager.NewMutesManager(logger, database)
+	spamManager := spam.NewManager(logger, types.SpamConfig{}, database)
+	outboxManager := outbox.NewManager(logger, types.OutboxConfig{}, database)
+	alertsManager := alerts.NewManager(logger, database, []types.Duration{})
+	dashboardManager := dashboard.NewManager(logger, database)
+	routesManager := routes.NewManager(logger, types.Routes{})
+	stateGenerator := state.NewStateGenerator(logger, metricsManager, tracer, chains)
+	dataManager := data.NewManager(logger, chains, metricsManager, tracer)
+
+	timezone, err := time.LoadLocation("Etc/GMT")
+	require.NoError(t, err)
+
+	reporter := NewTelegramReporter(
+		config,
+		mutesManager,
+		routesManager,
+		spamManager,
+		outboxManager,
+		alertsManager,
+		dashboardManager,
+		stateGenerator,
+		dataManager,
+		logger,
+		"1.2.3",
+		timezone,
+		tracer,
+	)
+
+	err = reporter.InitBot()
+	require.NoError(t, err)
+
+	return reporter
+}
+
+//nolint:paralleltest // disabled
+func TestTelegramReporterSendToMultipleChats(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder(
+		"POST",
+		"https://api.telegram.org/botxxx:yyy/getMe",
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("telegram-bot-ok.json")))
+
+	sentTo := make([]map[string]any, 0)
+
+	httpmock.RegisterResponder(
+		"POST",
+		"https://api.telegram.org/botxxx:yyy/sendMessage",
+		func(req *http.Request) (*http.Response, error) {
+			body := map[string]any{}
+			if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+				return nil, err
+			}
+
+			sentTo = append(sentTo, body)
+			return httpmock.NewBytesResponse(200, assets.GetBytesOrPanic("telegram-send-message-ok.json")), nil
+		},
+	)
+
+	database := &databasePkg.StubDatabase{
+		Mutes: []*types.Mute{
+			{
+				Chain:       null.StringFrom("chain"),
+				Destination: null.StringFrom("telegram:300"),
+				Expires:     time.Now().Add(time.Hour),
+			},
+		},
+	}
+
+	reporter := getTestReporterWithChats(t, database, []types.TelegramChat{
+		{ID: 100, ThreadID: 7, DestinationFilter: types.DestinationFilter{Chains: []string{"chain"}}},
+		{ID: 200, DestinationFilter: types.DestinationFilter{Chains: []string{"another"}}},
+		{ID: 300, DestinationFilter: types.DestinationFilter{MuteScope: types.MuteScopeDestination}},
+		{ID: 400, DestinationFilter: types.DestinationFilter{Wallets: []string{"wallet"}}},
+	})
+
+	err := reporter.SendReportEntry(events.NotVotedEvent{
+		Chain:    &types.Chain{Name: "chain"},
+		Wallet:   &types.Wallet{Address: "wallet"},
+		Proposal: types.Proposal{ID: "proposal", EndTime: time.Now().Add(time.Hour)},
+	}, context.Background())
+	require.NoError(t, err)
+
+	require.Len(t, sentTo, 2)
+	assert.Equal(t, "100", sentTo[0]["chat_id"])
+	assert.Equal(t, "7", sentTo[0]["message_thread_id"])
+	assert.Equal(t, "400", sentTo[1]["chat_id"])
+	assert.NotContains(t, sentTo[1], "message_thread_id")
+}
+
+//nolint:paralleltest // disabled
+func TestTelegramReporterSendGroupedToMultipleChats(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder(
+		"POST",
+		"https://api.telegram.org/botxxx:yyy/getMe",
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("telegram-bot-ok.json")))
+
+	sentTo := make([]map[string]any, 0)
+
+	httpmock.RegisterResponder(
+		"POST",
+		"https://api.telegram.org/botxxx:yyy/sendMessage",
+		func(req *http.Request) (*http.Response, error) {
+			body := map[string]any{}
+			if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+				return nil, err
+			}
+
+			sentTo = append(sentTo, body)
+			return httpmock.NewBytesResponse(200, assets.GetBytesOrPanic("telegram-send-message-ok.json")), nil
+		},
+	)
+
+	database := &databasePkg.StubDatabase{
+		Mutes: []*types.Mute{
+			{
+				Wallet:      null.StringFrom("wallet"),
+				Destination: null.StringFrom("telegram:300"),
+				Expires:     time.Now().Add(time.Hour),
+			},
+			{
+				Chain:       null.StringFrom("chain"),
+				Destination: null.StringFrom("telegram:500"),
+				Expires:     time.Now().Add(time.Hour),
+			},
+		},
+	}
+
+	reporter := getTestReporterWithChats(t, database, []types.TelegramChat{
+		{ID: 100},
+		{ID: 200, DestinationFilter: types.DestinationFilter{Chains: []string{"another"}}},
+		{ID: 300, DestinationFilter: types.DestinationFilter{MuteScope: types.MuteScopeDestination}},
+		{ID: 400, DestinationFilter: types.DestinationFilter{Wallets: []string{"wallet"}}},
+		{ID: 500, DestinationFilter: types.DestinationFilter{MuteScope: types.MuteScopeDestination}},
+	})
+
+	chain := &types.Chain{Name: "chain"}
+	proposal := types.Proposal{ID: "proposal", EndTime: time.Now().Add(time.Hour)}
+
+	err := reporter.SendReportEntry(events.GroupedEvent{
+		Chain: chain,
+		Entries: []entry.ReportEntry{
+			events.NotVotedEvent{Chain: chain, Wallet: &types.Wallet{Address: "wallet"}, Proposal: proposal},
+			events.NotVotedEvent{Chain: chain, Wallet: &types.Wallet{Address: "another"}, Proposal: proposal},
+		},
+	}, context.Background())
+	require.NoError(t, err)
+
+	require.Len(t, sentTo, 3)
+	assert.Equal(t, "100", sentTo[0]["chat_id"])
+	assert.Contains(t, sentTo[0]["text"], "Alerts on chain (2)")
+	assert.Equal(t, "300", sentTo[1]["chat_id"])
+	assert.Contains(t, sentTo[1]["text"], "Alerts on chain (1)")
+	assert.Contains(t, sentTo[1]["text"], "Wallet another")
+	assert.Equal(t, "400", sentTo[2]["chat_id"])
+	assert.Contains(t, sentTo[2]["text"], "Alerts on chain (1)")
+	assert.Contains(t, sentTo[2]["text"], "Wallet wallet")
+}
+
+//nolint:paralleltest // disabled
+func TestTelegramReporterGetChat(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder(
+		"POST",
+		"https://api.telegram.org/botxxx:yyy/getMe",
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("telegram-bot-ok.json")))
+
+	reporter := getTestReporterWithChats(t, &databasePkg.StubDatabase{}, []types.TelegramChat{
+		{ID: 100, DestinationFilter: types.DestinationFilter{Chains: []string{"chain"}}},
+		{ID: 100, ThreadID: 7, DestinationFilter: types.DestinationFilter{
+			Chains:    []string{"another"},
+			MuteScope: types.MuteScopeDestination,
+		}},
+	})
+
+	getContext := func(chatID int64, threadID int) tele.Context {
+		return reporter.TelegramBot.NewContext(tele.Update{
+			ID: 1,
+			Message: &tele.Message{
+				Sender:   &tele.User{Username: "testuser"},
+				Text:     "/proposals",
+				Chat:     &tele.Chat{ID: chatID},
+				ThreadID: threadID,
+			},
+		})
+	}
+
+	assert.Equal(t, []string{"chain"}, reporter.GetChatChains(getContext(100, 0)))
+	assert.Equal(t, []string{"chain"}, reporter.GetChatChains(getContext(100, 5)))
+	assert.Equal(t, []string{"another"}, reporter.GetChatChains(getContext(100, 7)))
+	assert.Nil(t, reporter.GetChatChains(getContext(200, 0)))
+
+	assert.Empty(t, reporter.GetMuteDestination(getContext(100, 0)))
+	assert.Equal(t, "telegram:100:7", reporter.GetMuteDestination(getContext(100, 7)))
+	assert.Empty(t, reporter.GetMuteDestination(getContext(200, 0)))
+}
+
+//nolint:paralleltest // disabled
+func TestTelegramReporterAddMuteWithDestination(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder(
+		"POST",
+		"https://api.telegram.org/botxxx:yyy/getMe",
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("telegram-bot-ok.json")))
+
+	httpmock.RegisterResponder(
+		"POST",
+		"https://api.telegram.org/botxxx:yyy/sendMessage",
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("telegram-send-message-ok.json")),
+	)
+
+	database := &databasePkg.StubDatabase{}
+	reporter := getTestReporterWithChats(t, database, []types.TelegramChat{
+		{ID: 100, DestinationFilter: types.DestinationFilter{MuteScope: types.MuteScopeDestination}},
+	})
+
+	ctx := reporter.TelegramBot.NewContext(tele.Update{
+		ID: 1,
+		Message: &tele.Message{
+			Sender: &tele.User{Username: "testuser"},
+			Text:   "/proposals_mute 1h chain=chain",
+			Chat:   &tele.Chat{ID: 100},
+		},
+	})
+
+	err := reporter.HandleAddMute(ctx)
+	require.NoError(t, err)
+	require.Len(t, database.Mutes, 1)
+	assert.Equal(t, "telegram:100", database.Mutes[0].Destination.String)
+
+	deleteCtx := reporter.TelegramBot.NewContext(tele.Update{
+		ID: 1,
+		Message: &tele.Message{
+			Sender:  &tele.User{Username: "testuser"},
+			Text:    "/proposals_unmute chain=chain",
+			Payload: "chain=chain",
+			Chat:    &tele.Chat{ID: 100},
+		},
+	})
+
+	err = reporter.HandleDeleteMute(deleteCtx)
+	require.NoError(t, err)
+	require.Empty(t, database.Mutes)
+}
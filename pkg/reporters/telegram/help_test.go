@@ -2,10 +2,16 @@ package telegram
 
 import (
 	"main/assets"
+	"main/pkg/alerts"
+	"main/pkg/dashboard"
 	"main/pkg/data"
 	databasePkg "main/pkg/database"
 	loggerPkg "main/pkg/logger"
+	"main/pkg/metrics"
 	mutesmanager "main/pkg/mutes"
+	"main/pkg/outbox"
+	"main/pkg/routes"
+	"main/pkg/spam"
 	"main/pkg/state"
 	"main/pkg/tracing"
 	"main/pkg/types"
@@ -36,11 +42,17 @@ func TestTelegramReporterReporterHelpOk(t *testing.T) {
 	config := types.TelegramConfig{TelegramToken: "xxx:yyy", TelegramChat: 123}
 	chains := types.Chains{{Name: "chain", LCDEndpoints: []string{"https://example.com"}}}
 	logger := loggerPkg.GetNopLogger()
+	metricsManager := metrics.NewManager(logger, types.MetricsConfig{})
 	tracer := tracing.InitNoopTracer()
 	database := &databasePkg.StubDatabase{}
 	mutesManager := mutesmanager.NewMutesManager(logger, database)
-	stateGenerator := state.NewStateGenerator(logger, tracer, chains)
-	dataManager := data.NewManager(logger, chains, tracer)
+	spamManager := spam.NewManager(logger, types.SpamConfig{}, database)
+	outboxManager := outbox.NewManager(logger, types.OutboxConfig{}, database)
+	alertsManager := alerts.NewManager(logger, database, []types.Duration{})
+	dashboardManager := dashboard.NewManager(logger, database)
+	routesManager := routes.NewManager(logger, types.Routes{})
+	stateGenerator := state.NewStateGenerator(logger, metricsManager, tracer, chains)
+	dataManager := data.NewManager(logger, chains, metricsManager, tracer)
 
 	timezone, err := time.LoadLocation("Etc/GMT")
 	require.NoError(t, err)
@@ -48,6 +60,11 @@ func TestTelegramReporterReporterHelpOk(t *testing.T) {
 	reporter := NewTelegramReporter(
 		config,
 		mutesManager,
+		routesManager,
+		spamManager,
+		outboxManager,
+		alertsManager,
+		dashboardManager,
 		stateGenerator,
 		dataManager,
 		logger,
@@ -20,8 +20,9 @@ func (reporter *Reporter) HandleListMutes(c tele.Context) error {
 		return reporter.BotReply(c, fmt.Sprintf("Error fetching mutes: %s", err))
 	}
 
+	destination := reporter.GetMuteDestination(c)
 	filteredMutes := utils.Filter(mutes, func(m *types.Mute) bool {
-		return !m.IsExpired()
+		return !m.IsExpired() && m.MatchesDestination(destination)
 	})
 
 	return reporter.ReplyRender(c, "mutes", filteredMutes)
@@ -0,0 +1,29 @@
+package telegram
+
+import (
+	"fmt"
+	"main/pkg/types"
+	"main/pkg/utils"
+
+	tele "gopkg.in/telebot.v3"
+)
+
+func (reporter *Reporter) HandleListMutesHistory(c tele.Context) error {
+	reporter.Logger.Info().
+		Str("sender", c.Sender().Username).
+		Str("text", c.Text()).
+		Msg("Got list mutes history query")
+
+	history, err := reporter.MutesManager.GetMutesHistory()
+	if err != nil {
+		reporter.Logger.Error().Err(err).Msg("Error fetching mutes history")
+		return reporter.BotReply(c, fmt.Sprintf("Error fetching mutes history: %s", err))
+	}
+
+	destination := reporter.GetMuteDestination(c)
+	filteredHistory := utils.Filter(history, func(e *types.MuteHistoryEntry) bool {
+		return e.MatchesDestination(destination)
+	})
+
+	return reporter.ReplyRender(c, "mutes_history", filteredHistory)
+}
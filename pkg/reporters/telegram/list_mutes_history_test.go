@@ -0,0 +1,120 @@
+package telegram
+
+import (
+	"errors"
+	"main/assets"
+	databasePkg "main/pkg/database"
+	"main/pkg/types"
+	"testing"
+	"time"
+
+	"github.com/guregu/null/v5"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/require"
+	tele "gopkg.in/telebot.v3"
+)
+
+//nolint:paralleltest // disabled
+func TestTelegramReporterListMutesHistoryError(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder(
+		"POST",
+		"https://api.telegram.org/botxxx:yyy/getMe",
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("telegram-bot-ok.json")))
+
+	httpmock.RegisterMatcherResponder(
+		"POST",
+		"https://api.telegram.org/botxxx:yyy/sendMessage",
+		types.TelegramResponseHasText("Error fetching mutes history: custom error"),
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("telegram-send-message-ok.json")),
+	)
+
+	reporter := getTestReporterWithChats(t, &databasePkg.StubDatabase{
+		GetMutesHistoryError: errors.New("custom error"),
+	}, []types.TelegramChat{{ID: 100}})
+
+	ctx := reporter.TelegramBot.NewContext(tele.Update{
+		ID: 1,
+		Message: &tele.Message{
+			Sender: &tele.User{Username: "testuser"},
+			Text:   "/proposals_mutes_history",
+			Chat:   &tele.Chat{ID: 100},
+		},
+	})
+
+	err := reporter.HandleListMutesHistory(ctx)
+	require.NoError(t, err)
+}
+
+//nolint:paralleltest // disabled
+func TestTelegramReporterListMutesHistoryOk(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder(
+		"POST",
+		"https://api.telegram.org/botxxx:yyy/getMe",
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("telegram-bot-ok.json")))
+
+	httpmock.RegisterMatcherResponder(
+		"POST",
+		"https://api.telegram.org/botxxx:yyy/sendMessage",
+		types.TelegramResponseHasBytes(assets.GetBytesOrPanic("responses/list-mutes-history.html")),
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("telegram-send-message-ok.json")),
+	)
+
+	removedAt, err := time.Parse(time.RFC3339, "2024-12-01T16:56:01Z")
+	require.NoError(t, err)
+
+	database := &databasePkg.StubDatabase{
+		MutesHistory: []*types.MuteHistoryEntry{
+			{
+				Mute: types.Mute{
+					Chain:      null.StringFrom("chain"),
+					ProposalID: null.StringFrom("proposal"),
+					Expires:    removedAt.Add(time.Hour),
+					Author:     null.StringFrom("@user"),
+					Source:     null.StringFrom(types.MuteSourceTelegram),
+					CreatedAt:  null.TimeFrom(removedAt.Add(-time.Hour)),
+				},
+				Reason:    types.MuteRemovedReasonDeleted,
+				RemovedAt: removedAt,
+				RemovedBy: null.StringFrom("@admin"),
+			},
+			{
+				Mute: types.Mute{
+					Destination: null.StringFrom("telegram:100"),
+					Expires:     removedAt,
+				},
+				Reason:    types.MuteRemovedReasonExpired,
+				RemovedAt: removedAt,
+			},
+			{
+				Mute: types.Mute{
+					Destination: null.StringFrom("telegram:200"),
+					Expires:     removedAt,
+				},
+				Reason:    types.MuteRemovedReasonExpired,
+				RemovedAt: removedAt,
+			},
+		},
+	}
+
+	reporter := getTestReporterWithChats(t, database, []types.TelegramChat{
+		{ID: 100, DestinationFilter: types.DestinationFilter{MuteScope: types.MuteScopeDestination}},
+	})
+
+	ctx := reporter.TelegramBot.NewContext(tele.Update{
+		ID: 1,
+		Message: &tele.Message{
+			Sender: &tele.User{Username: "testuser"},
+			Text:   "/proposals_mutes_history",
+			Chat:   &tele.Chat{ID: 100},
+		},
+	})
+
+	err = reporter.HandleListMutesHistory(ctx)
+	require.NoError(t, err)
+}
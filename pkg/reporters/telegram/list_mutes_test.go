@@ -3,10 +3,16 @@ package telegram
 import (
 	"errors"
 	"main/assets"
+	"main/pkg/alerts"
+	"main/pkg/dashboard"
 	"main/pkg/data"
 	databasePkg "main/pkg/database"
 	loggerPkg "main/pkg/logger"
+	"main/pkg/metrics"
 	mutesmanager "main/pkg/mutes"
+	"main/pkg/outbox"
+	"main/pkg/routes"
+	"main/pkg/spam"
 	"main/pkg/state"
 	"main/pkg/tracing"
 	"main/pkg/types"
@@ -39,11 +45,17 @@ func TestTelegramReporterListMutesError(t *testing.T) {
 	config := types.TelegramConfig{TelegramToken: "xxx:yyy", TelegramChat: 123}
 	chains := types.Chains{{Name: "chain", LCDEndpoints: []string{"https://example.com"}}}
 	logger := loggerPkg.GetNopLogger()
+	metricsManager := metrics.NewManager(logger, types.MetricsConfig{})
 	tracer := tracing.InitNoopTracer()
 	database := &databasePkg.StubDatabase{GetAllMutesError: errors.New("custom error")}
 	mutesManager := mutesmanager.NewMutesManager(logger, database)
-	stateGenerator := state.NewStateGenerator(logger, tracer, chains)
-	dataManager := data.NewManager(logger, chains, tracer)
+	spamManager := spam.NewManager(logger, types.SpamConfig{}, database)
+	outboxManager := outbox.NewManager(logger, types.OutboxConfig{}, database)
+	alertsManager := alerts.NewManager(logger, database, []types.Duration{})
+	dashboardManager := dashboard.NewManager(logger, database)
+	routesManager := routes.NewManager(logger, types.Routes{})
+	stateGenerator := state.NewStateGenerator(logger, metricsManager, tracer, chains)
+	dataManager := data.NewManager(logger, chains, metricsManager, tracer)
 
 	timezone, err := time.LoadLocation("Etc/GMT")
 	require.NoError(t, err)
@@ -51,6 +63,11 @@ func TestTelegramReporterListMutesError(t *testing.T) {
 	reporter := NewTelegramReporter(
 		config,
 		mutesManager,
+		routesManager,
+		spamManager,
+		outboxManager,
+		alertsManager,
+		dashboardManager,
 		stateGenerator,
 		dataManager,
 		logger,
@@ -94,11 +111,17 @@ func TestTelegramReporterListMutesOk(t *testing.T) {
 	config := types.TelegramConfig{TelegramToken: "xxx:yyy", TelegramChat: 123}
 	chains := types.Chains{{Name: "chain", LCDEndpoints: []string{"https://example.com"}}}
 	logger := loggerPkg.GetNopLogger()
+	metricsManager := metrics.NewManager(logger, types.MetricsConfig{})
 	tracer := tracing.InitNoopTracer()
 	database := &databasePkg.StubDatabase{}
 	mutesManager := mutesmanager.NewMutesManager(logger, database)
-	stateGenerator := state.NewStateGenerator(logger, tracer, chains)
-	dataManager := data.NewManager(logger, chains, tracer)
+	spamManager := spam.NewManager(logger, types.SpamConfig{}, database)
+	outboxManager := outbox.NewManager(logger, types.OutboxConfig{}, database)
+	alertsManager := alerts.NewManager(logger, database, []types.Duration{})
+	dashboardManager := dashboard.NewManager(logger, database)
+	routesManager := routes.NewManager(logger, types.Routes{})
+	stateGenerator := state.NewStateGenerator(logger, metricsManager, tracer, chains)
+	dataManager := data.NewManager(logger, chains, metricsManager, tracer)
 
 	timezone, err := time.LoadLocation("Etc/GMT")
 	require.NoError(t, err)
@@ -106,6 +129,11 @@ func TestTelegramReporterListMutesOk(t *testing.T) {
 	reporter := NewTelegramReporter(
 		config,
 		mutesManager,
+		routesManager,
+		spamManager,
+		outboxManager,
+		alertsManager,
+		dashboardManager,
 		stateGenerator,
 		dataManager,
 		logger,
@@ -153,11 +181,17 @@ func TestTelegramReporterListMutesRenderOkEmpty(t *testing.T) {
 	config := types.TelegramConfig{TelegramToken: "xxx:yyy", TelegramChat: 123}
 	chains := types.Chains{{Name: "chain", LCDEndpoints: []string{"https://example.com"}}}
 	logger := loggerPkg.GetNopLogger()
+	metricsManager := metrics.NewManager(logger, types.MetricsConfig{})
 	tracer := tracing.InitNoopTracer()
 	database := &databasePkg.StubDatabase{}
 	mutesManager := mutesmanager.NewMutesManager(logger, database)
-	stateGenerator := state.NewStateGenerator(logger, tracer, chains)
-	dataManager := data.NewManager(logger, chains, tracer)
+	spamManager := spam.NewManager(logger, types.SpamConfig{}, database)
+	outboxManager := outbox.NewManager(logger, types.OutboxConfig{}, database)
+	alertsManager := alerts.NewManager(logger, database, []types.Duration{})
+	dashboardManager := dashboard.NewManager(logger, database)
+	routesManager := routes.NewManager(logger, types.Routes{})
+	stateGenerator := state.NewStateGenerator(logger, metricsManager, tracer, chains)
+	dataManager := data.NewManager(logger, chains, metricsManager, tracer)
 
 	timezone, err := time.LoadLocation("Etc/GMT")
 	require.NoError(t, err)
@@ -165,6 +199,11 @@ func TestTelegramReporterListMutesRenderOkEmpty(t *testing.T) {
 	reporter := NewTelegramReporter(
 		config,
 		mutesManager,
+		routesManager,
+		spamManager,
+		outboxManager,
+		alertsManager,
+		dashboardManager,
 		stateGenerator,
 		dataManager,
 		logger,
@@ -209,11 +248,17 @@ func TestTelegramReporterListMutesRenderOkNotEmpty(t *testing.T) {
 	config := types.TelegramConfig{TelegramToken: "xxx:yyy", TelegramChat: 123}
 	chains := types.Chains{{Name: "chain", LCDEndpoints: []string{"https://example.com"}}}
 	logger := loggerPkg.GetNopLogger()
+	metricsManager := metrics.NewManager(logger, types.MetricsConfig{})
 	tracer := tracing.InitNoopTracer()
 	database := &databasePkg.StubDatabase{}
 	mutesManager := mutesmanager.NewMutesManager(logger, database)
-	stateGenerator := state.NewStateGenerator(logger, tracer, chains)
-	dataManager := data.NewManager(logger, chains, tracer)
+	spamManager := spam.NewManager(logger, types.SpamConfig{}, database)
+	outboxManager := outbox.NewManager(logger, types.OutboxConfig{}, database)
+	alertsManager := alerts.NewManager(logger, database, []types.Duration{})
+	dashboardManager := dashboard.NewManager(logger, database)
+	routesManager := routes.NewManager(logger, types.Routes{})
+	stateGenerator := state.NewStateGenerator(logger, metricsManager, tracer, chains)
+	dataManager := data.NewManager(logger, chains, metricsManager, tracer)
 
 	timezone, err := time.LoadLocation("Etc/GMT")
 	require.NoError(t, err)
@@ -221,6 +266,11 @@ func TestTelegramReporterListMutesRenderOkNotEmpty(t *testing.T) {
 	reporter := NewTelegramReporter(
 		config,
 		mutesManager,
+		routesManager,
+		spamManager,
+		outboxManager,
+		alertsManager,
+		dashboardManager,
 		stateGenerator,
 		dataManager,
 		logger,
@@ -13,7 +13,11 @@ func (reporter *Reporter) HandleProposals(c tele.Context) error {
 		Str("text", c.Text()).
 		Msg("Got proposals list query")
 
-	state := reporter.StateGenerator.GetState(statePkg.NewState(), context.Background())
+	state := reporter.StateGenerator.GetState(
+		statePkg.NewState(),
+		context.Background(),
+		reporter.GetChatChains(c)...,
+	)
 	renderedState := state.ToRenderedState()
 
 	return reporter.ReplyRender(c, "proposals", renderedState)
@@ -0,0 +1,14 @@
+package telegram
+
+import (
+	tele "gopkg.in/telebot.v3"
+)
+
+func (reporter *Reporter) HandleRoutes(c tele.Context) error {
+	reporter.Logger.Info().
+		Str("sender", c.Sender().Username).
+		Str("text", c.Text()).
+		Msg("Got routes query")
+
+	return reporter.ReplyRender(c, "routes", reporter.RoutesManager.GetRoutes())
+}
@@ -0,0 +1,102 @@
+package telegram
+
+import (
+	"main/assets"
+	"main/pkg/alerts"
+	"main/pkg/dashboard"
+	"main/pkg/data"
+	databasePkg "main/pkg/database"
+	loggerPkg "main/pkg/logger"
+	"main/pkg/metrics"
+	mutesmanager "main/pkg/mutes"
+	"main/pkg/outbox"
+	"main/pkg/routes"
+	"main/pkg/spam"
+	"main/pkg/state"
+	"main/pkg/tracing"
+	"main/pkg/types"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/require"
+	tele "gopkg.in/telebot.v3"
+)
+
+//nolint:paralleltest // disabled
+func TestTelegramReporterListRoutesOk(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder(
+		"POST",
+		"https://api.telegram.org/botxxx:yyy/getMe",
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("telegram-bot-ok.json")))
+
+	httpmock.RegisterMatcherResponder(
+		"POST",
+		"https://api.telegram.org/botxxx:yyy/sendMessage",
+		types.TelegramResponseHasBytes(assets.GetBytesOrPanic("responses/list-routes.html")),
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("telegram-send-message-ok.json")),
+	)
+
+	config := types.TelegramConfig{TelegramToken: "xxx:yyy", TelegramChat: 123}
+	chains := types.Chains{{Name: "chain", LCDEndpoints: []string{"https://example.com"}}}
+	logger := loggerPkg.GetNopLogger()
+	metricsManager := metrics.NewManager(logger, types.MetricsConfig{})
+	tracer := tracing.InitNoopTracer()
+	database := &databasePkg.StubDatabase{}
+	mutesManager := mutesmanager.NewMutesManager(logger, database)
+	spamManager := spam.NewManager(logger, types.SpamConfig{}, database)
+	outboxManager := outbox.NewManager(logger, types.OutboxConfig{}, database)
+	alertsManager := alerts.NewManager(logger, database, []types.Duration{})
+	dashboardManager := dashboard.NewManager(logger, database)
+	routesManager := routes.NewManager(logger, types.Routes{
+		{
+			Reporter: "pagerduty-reporter",
+			Events:   []string{"not_voted"},
+			TimeLeft: types.Duration{Duration: 24 * time.Hour},
+		},
+		{
+			Reporter: "discord-reporter",
+			Chains:   []string{"chain1", "chain2"},
+			Wallets:  []string{"wallet"},
+		},
+	})
+	stateGenerator := state.NewStateGenerator(logger, metricsManager, tracer, chains)
+	dataManager := data.NewManager(logger, chains, metricsManager, tracer)
+
+	timezone, err := time.LoadLocation("Etc/GMT")
+	require.NoError(t, err)
+
+	reporter := NewTelegramReporter(
+		config,
+		mutesManager,
+		routesManager,
+		spamManager,
+		outboxManager,
+		alertsManager,
+		dashboardManager,
+		stateGenerator,
+		dataManager,
+		logger,
+		"1.2.3",
+		timezone,
+		tracer,
+	)
+
+	err = reporter.InitBot()
+	require.NoError(t, err)
+
+	ctx := reporter.TelegramBot.NewContext(tele.Update{
+		ID: 1,
+		Message: &tele.Message{
+			Sender: &tele.User{Username: "testuser"},
+			Text:   "/routes",
+			Chat:   &tele.Chat{ID: 2},
+		},
+	})
+
+	err = reporter.HandleRoutes(ctx)
+	require.NoError(t, err)
+}
@@ -0,0 +1,22 @@
+package telegram
+
+import (
+	"fmt"
+
+	tele "gopkg.in/telebot.v3"
+)
+
+func (reporter *Reporter) HandleListUndelivered(c tele.Context) error {
+	reporter.Logger.Info().
+		Str("sender", c.Sender().Username).
+		Str("text", c.Text()).
+		Msg("Got list undelivered notifications query")
+
+	outboxEntries, err := reporter.OutboxManager.GetUndelivered()
+	if err != nil {
+		reporter.Logger.Error().Err(err).Msg("Error fetching undelivered notifications")
+		return reporter.BotReply(c, fmt.Sprintf("Error fetching undelivered notifications: %s", err))
+	}
+
+	return reporter.ReplyRender(c, "undelivered", outboxEntries)
+}
@@ -0,0 +1,110 @@
+package telegram
+
+import (
+	"errors"
+	"main/assets"
+	databasePkg "main/pkg/database"
+	"main/pkg/types"
+	"testing"
+	"time"
+
+	"github.com/guregu/null/v5"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/require"
+	tele "gopkg.in/telebot.v3"
+)
+
+//nolint:paralleltest // disabled
+func TestTelegramReporterListUndeliveredError(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder(
+		"POST",
+		"https://api.telegram.org/botxxx:yyy/getMe",
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("telegram-bot-ok.json")))
+
+	httpmock.RegisterMatcherResponder(
+		"POST",
+		"https://api.telegram.org/botxxx:yyy/sendMessage",
+		types.TelegramResponseHasText("Error fetching undelivered notifications: custom error"),
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("telegram-send-message-ok.json")),
+	)
+
+	reporter := getTestReporterWithChats(t, &databasePkg.StubDatabase{
+		GetOutboxError: errors.New("custom error"),
+	}, []types.TelegramChat{{ID: 100}})
+
+	ctx := reporter.TelegramBot.NewContext(tele.Update{
+		ID: 1,
+		Message: &tele.Message{
+			Sender: &tele.User{Username: "testuser"},
+			Text:   "/proposals_undelivered",
+			Chat:   &tele.Chat{ID: 100},
+		},
+	})
+
+	err := reporter.HandleListUndelivered(ctx)
+	require.NoError(t, err)
+}
+
+//nolint:paralleltest // disabled
+func TestTelegramReporterListUndeliveredOk(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder(
+		"POST",
+		"https://api.telegram.org/botxxx:yyy/getMe",
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("telegram-bot-ok.json")))
+
+	httpmock.RegisterMatcherResponder(
+		"POST",
+		"https://api.telegram.org/botxxx:yyy/sendMessage",
+		types.TelegramResponseHasBytes(assets.GetBytesOrPanic("responses/list-undelivered.html")),
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("telegram-send-message-ok.json")),
+	)
+
+	createdAt, err := time.Parse(time.RFC3339, "2024-12-01T16:56:01Z")
+	require.NoError(t, err)
+
+	reporter := getTestReporterWithChats(t, &databasePkg.StubDatabase{
+		Outbox: []*types.OutboxEntry{
+			{
+				ID:            1,
+				Reporter:      "telegram-reporter",
+				Event:         "not_voted",
+				Chain:         null.StringFrom("chain"),
+				ProposalID:    null.StringFrom("proposal"),
+				Wallet:        null.StringFrom("wallet"),
+				Status:        types.OutboxStatusPending,
+				Attempts:      2,
+				LastError:     null.StringFrom("too many requests"),
+				NextAttemptAt: createdAt.Add(4 * time.Minute),
+				CreatedAt:     createdAt,
+			},
+			{
+				ID:            2,
+				Reporter:      "pagerduty-reporter",
+				Event:         "generic_error",
+				Status:        types.OutboxStatusDead,
+				Attempts:      10,
+				LastError:     null.StringFrom("timeout"),
+				NextAttemptAt: createdAt,
+				CreatedAt:     createdAt,
+			},
+		},
+	}, []types.TelegramChat{{ID: 100}})
+
+	ctx := reporter.TelegramBot.NewContext(tele.Update{
+		ID: 1,
+		Message: &tele.Message{
+			Sender: &tele.User{Username: "testuser"},
+			Text:   "/proposals_undelivered",
+			Chat:   &tele.Chat{ID: 100},
+		},
+	})
+
+	err = reporter.HandleListUndelivered(ctx)
+	require.NoError(t, err)
+}
@@ -0,0 +1,57 @@
+package telegram
+
+import (
+	"fmt"
+
+	tele "gopkg.in/telebot.v3"
+)
+
+func (reporter *Reporter) HandleNotSpam(c tele.Context) error {
+	reporter.Logger.Info().
+		Str("sender", c.Sender().Username).
+		Str("text", c.Text()).
+		Msg("Got not spam query")
+
+	args := c.Args()
+	if len(args) != 2 {
+		return c.Reply("Usage: /proposals_not_spam <chain> <proposal ID>")
+	}
+
+	found, err := reporter.SpamManager.Unflag(args[0], args[1], GetSenderName(c.Sender()))
+	if err != nil {
+		return c.Reply(fmt.Sprintf("Error un-flagging proposal: %s!", err))
+	} else if !found {
+		return c.Reply("Could not find the proposal flagged as spam!")
+	}
+
+	return c.Reply(fmt.Sprintf("Proposal %s on %s is not considered spam anymore.", args[1], args[0]))
+}
+
+func (reporter *Reporter) HandleNotSpamButton(c tele.Context) error {
+	reporter.Logger.Info().
+		Str("sender", c.Sender().Username).
+		Str("data", c.Callback().Data).
+		Msg("Got not spam button click")
+
+	args := c.Args()
+	if len(args) != 2 {
+		return c.Respond(&tele.CallbackResponse{Text: "Invalid button data!"})
+	}
+
+	found, err := reporter.SpamManager.Unflag(args[0], args[1], GetSenderName(c.Sender()))
+	if err != nil {
+		reporter.Logger.Error().Err(err).Msg("Error un-flagging proposal")
+		return c.Respond(&tele.CallbackResponse{Text: "Error un-flagging proposal!"})
+	} else if !found {
+		return c.Respond(&tele.CallbackResponse{Text: "Proposal is not flagged as spam!"})
+	}
+
+	if err := reporter.EditAlertWithAction(
+		c,
+		"✅ Marked as not spam by "+GetSenderName(c.Sender()),
+	); err != nil {
+		return err
+	}
+
+	return c.Respond(&tele.CallbackResponse{Text: "Marked as not spam!"})
+}
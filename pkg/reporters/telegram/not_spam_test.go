@@ -0,0 +1,84 @@
+package telegram
+
+import (
+	"main/assets"
+	databasePkg "main/pkg/database"
+	"main/pkg/types"
+	"strings"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/require"
+	tele "gopkg.in/telebot.v3"
+)
+
+func getNotSpamContext(reporter *Reporter, payload string) tele.Context {
+	return reporter.TelegramBot.NewContext(tele.Update{
+		ID: 1,
+		Message: &tele.Message{
+			Sender:  &tele.User{Username: "testuser"},
+			Text:    strings.TrimSpace("/proposals_not_spam " + payload),
+			Payload: payload,
+			Chat:    &tele.Chat{ID: 2},
+		},
+	})
+}
+
+func registerNotSpamResponders(t *testing.T, text string) {
+	t.Helper()
+
+	httpmock.RegisterResponder(
+		"POST",
+		"https://api.telegram.org/botxxx:yyy/getMe",
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("telegram-bot-ok.json")))
+
+	httpmock.RegisterMatcherResponder(
+		"POST",
+		"https://api.telegram.org/botxxx:yyy/sendMessage",
+		types.TelegramResponseHasText(text),
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("telegram-send-message-ok.json")),
+	)
+}
+
+//nolint:paralleltest // disabled
+func TestTelegramReporterNotSpamInvalidArgs(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	registerNotSpamResponders(t, "Usage: /proposals_not_spam <chain> <proposal ID>")
+
+	reporter := getTestReporterWithChats(t, &databasePkg.StubDatabase{}, []types.TelegramChat{{ID: 2}})
+
+	err := reporter.HandleNotSpam(getNotSpamContext(reporter, "chain"))
+	require.NoError(t, err)
+}
+
+//nolint:paralleltest // disabled
+func TestTelegramReporterNotSpamNotFound(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	registerNotSpamResponders(t, "Could not find the proposal flagged as spam!")
+
+	reporter := getTestReporterWithChats(t, &databasePkg.StubDatabase{}, []types.TelegramChat{{ID: 2}})
+
+	err := reporter.HandleNotSpam(getNotSpamContext(reporter, "chain 1"))
+	require.NoError(t, err)
+}
+
+//nolint:paralleltest // disabled
+func TestTelegramReporterNotSpamOk(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	registerNotSpamResponders(t, "Proposal 1 on chain is not considered spam anymore.")
+
+	database := &databasePkg.StubDatabase{
+		SpamFlags: []*types.SpamFlag{{Chain: "chain", ProposalID: "1", Flagged: true}},
+	}
+	reporter := getTestReporterWithChats(t, database, []types.TelegramChat{{ID: 2}})
+
+	err := reporter.HandleNotSpam(getNotSpamContext(reporter, "chain 1"))
+	require.NoError(t, err)
+	require.False(t, database.SpamFlags[0].Flagged)
+}
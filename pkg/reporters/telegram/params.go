@@ -13,7 +13,7 @@ func (reporter *Reporter) HandleParams(c tele.Context) error {
 		Str("text", c.Text()).
 		Msg("Got params query")
 
-	params, err := reporter.DataManager.GetParams(context.Background())
+	params, err := reporter.DataManager.GetParams(context.Background(), reporter.GetChatChains(c)...)
 	if err != nil {
 		return reporter.BotReply(c, fmt.Sprintf("Error getting chain params: %s", err))
 	}
@@ -18,7 +18,7 @@ func (reporter *Reporter) HandleTally(c tele.Context) error {
 		return err
 	}
 
-	tallies, err := reporter.DataManager.GetTallies(context.Background())
+	tallies, err := reporter.DataManager.GetTallies(context.Background(), reporter.GetChatChains(c)...)
 	if err != nil {
 		return reporter.BotReply(c, fmt.Sprintf("Error getting tallies info: %s", err))
 	}
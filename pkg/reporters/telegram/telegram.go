@@ -3,11 +3,19 @@ package telegram
 import (
 	"context"
 	"fmt"
+	"main/pkg/alerts"
+	"main/pkg/dashboard"
 	"main/pkg/data"
+	"main/pkg/events"
 	mutes "main/pkg/mutes"
+	"main/pkg/outbox"
 	"main/pkg/report/entry"
+	reportersPkg "main/pkg/reporters"
+	"main/pkg/routes"
+	"main/pkg/spam"
 	"main/pkg/state"
 	"main/pkg/templates"
+	"main/pkg/utils"
 	"strings"
 	"time"
 
@@ -23,8 +31,14 @@ import (
 
 type Reporter struct {
 	TelegramToken    string
-	TelegramChat     int64
+	Chats            []types.TelegramChat
+	Config           types.TelegramConfig
 	MutesManager     *mutes.Manager
+	RoutesManager    *routes.Manager
+	SpamManager      *spam.Manager
+	OutboxManager    *outbox.Manager
+	AlertsManager    *alerts.Manager
+	DashboardManager *dashboard.Manager
 	StateGenerator   *state.Generator
 	DataManager      *data.Manager
 	TemplatesManager templates.Manager
@@ -43,6 +57,11 @@ const (
 func NewTelegramReporter(
 	config types.TelegramConfig,
 	mutesManager *mutes.Manager,
+	routesManager *routes.Manager,
+	spamManager *spam.Manager,
+	outboxManager *outbox.Manager,
+	alertsManager *alerts.Manager,
+	dashboardManager *dashboard.Manager,
 	stateGenerator *state.Generator,
 	dataManager *data.Manager,
 	logger *zerolog.Logger,
@@ -52,8 +71,14 @@ func NewTelegramReporter(
 ) *Reporter {
 	return &Reporter{
 		TelegramToken:    config.TelegramToken,
-		TelegramChat:     config.TelegramChat,
+		Chats:            config.GetChats(),
+		Config:           config,
 		MutesManager:     mutesManager,
+		RoutesManager:    routesManager,
+		SpamManager:      spamManager,
+		OutboxManager:    outboxManager,
+		AlertsManager:    alertsManager,
+		DashboardManager: dashboardManager,
 		StateGenerator:   stateGenerator,
 		DataManager:      dataManager,
 		Logger:           logger.With().Str("component", "telegram_reporter").Logger(),
@@ -64,7 +89,7 @@ func NewTelegramReporter(
 }
 
 func (reporter *Reporter) Init() error {
-	if reporter.TelegramToken == "" || reporter.TelegramChat == 0 {
+	if !reporter.Enabled() {
 		reporter.Logger.Debug().Msg("Telegram credentials not set, not creating Telegram reporter.")
 		return nil
 	}
@@ -89,14 +114,26 @@ func (reporter *Reporter) InitBot() error {
 		return err
 	}
 
-	bot.Handle("/start", reporter.HandleHelp)
-	bot.Handle("/help", reporter.HandleHelp)
-	bot.Handle("/proposals_mute", reporter.HandleAddMute)
-	bot.Handle("/proposals_unmute", reporter.HandleDeleteMute)
-	bot.Handle("/proposals_mutes", reporter.HandleListMutes)
-	bot.Handle("/proposals", reporter.HandleProposals)
-	bot.Handle("/tally", reporter.HandleTally)
-	bot.Handle("/params", reporter.HandleParams)
+	read := reporter.RequireAccess(types.AccessLevelRead)
+	write := reporter.RequireAccess(types.AccessLevelWrite)
+
+	bot.Handle("/start", reporter.HandleHelp, read)
+	bot.Handle("/help", reporter.HandleHelp, read)
+	bot.Handle("/proposals_mute", reporter.HandleAddMute, write)
+	bot.Handle("/proposals_unmute", reporter.HandleDeleteMute, write)
+	bot.Handle("/proposals_mutes", reporter.HandleListMutes, read)
+	bot.Handle("/proposals_mutes_history", reporter.HandleListMutesHistory, read)
+	bot.Handle("/proposals", reporter.HandleProposals, read)
+	bot.Handle("/tally", reporter.HandleTally, read)
+	bot.Handle("/params", reporter.HandleParams, read)
+	bot.Handle("/routes", reporter.HandleRoutes, read)
+	bot.Handle("/proposals_not_spam", reporter.HandleNotSpam, write)
+	bot.Handle("/proposals_undelivered", reporter.HandleListUndelivered, read)
+	bot.Handle(&tele.Btn{Unique: ButtonMuteProposal}, reporter.HandleMuteProposalButton, write)
+	bot.Handle(&tele.Btn{Unique: ButtonMuteUntilEnd}, reporter.HandleMuteUntilEndButton, write)
+	bot.Handle(&tele.Btn{Unique: ButtonMuteChain}, reporter.HandleMuteChainButton, write)
+	bot.Handle(&tele.Btn{Unique: ButtonAcknowledge}, reporter.HandleAcknowledgeButton, write)
+	bot.Handle(&tele.Btn{Unique: ButtonNotSpam}, reporter.HandleNotSpamButton, write)
 
 	reporter.TelegramBot = bot
 
@@ -104,7 +141,7 @@ func (reporter *Reporter) InitBot() error {
 }
 
 func (reporter *Reporter) Enabled() bool {
-	return reporter.TelegramToken != "" && reporter.TelegramChat != 0
+	return reporter.TelegramToken != "" && len(reporter.Chats) > 0
 }
 
 func (reporter *Reporter) SerializeReportEntry(e entry.ReportEntry) (string, error) {
@@ -115,28 +152,223 @@ func (reporter *Reporter) SendReportEntry(reportEntry entry.ReportEntry, ctx con
 	_, span := reporter.Tracer.Start(ctx, "Sending Telegram report entry")
 	defer span.End()
 
-	serializedEntry, err := reporter.SerializeReportEntry(reportEntry)
-	if err != nil {
-		reporter.Logger.Err(err).Msg("Could not serialize report entry")
-		return err
+	return reporter.sendReportEntryToChats(reportEntry, reporter.Chats)
+}
+
+// SendReportEntryToDestinations sends the entry only to the chats it failed to be sent to before.
+func (reporter *Reporter) SendReportEntryToDestinations(
+	reportEntry entry.ReportEntry,
+	destinations []string,
+	ctx context.Context,
+) error {
+	_, span := reporter.Tracer.Start(ctx, "Sending Telegram report entry to destinations")
+	defer span.End()
+
+	chats := utils.Filter(reporter.Chats, func(chat types.TelegramChat) bool {
+		return utils.Contains(destinations, chat.Destination())
+	})
+
+	return reporter.sendReportEntryToChats(reportEntry, chats)
+}
+
+func (reporter *Reporter) sendReportEntryToChats(reportEntry entry.ReportEntry, chats []types.TelegramChat) error {
+	markup := reporter.GetAlertMarkup(reportEntry)
+	errs := make(map[string]error)
+
+	for _, chat := range chats {
+		chatEntry, shouldSend := reporter.GetEntryForChat(reportEntry, chat)
+		if !shouldSend {
+			continue
+		}
+
+		serializedEntry, err := reporter.SerializeReportEntry(chatEntry)
+		if err != nil {
+			reporter.Logger.Err(err).Msg("Could not serialize report entry")
+			errs[chat.Destination()] = err
+			continue
+		}
+
+		if reporter.Config.EditAlerts &&
+			reporter.AlertsManager.ShouldEdit(chatEntry) &&
+			reporter.EditAlertMessage(chat, chatEntry, serializedEntry, markup) {
+			continue
+		}
+
+		// long entries, like digests, do not fit into a single message,
+		// the buttons are attached to the last one
+		chunks := utils.SplitStringIntoChunks(strings.TrimSpace(serializedEntry), MaxMessageSize)
+
+		for index, chunk := range chunks {
+			options := &tele.SendOptions{
+				ParseMode:             tele.ModeHTML,
+				DisableWebPagePreview: true,
+				ThreadID:              chat.ThreadID,
+			}
+			if index == len(chunks)-1 {
+				options.ReplyMarkup = markup
+			}
+
+			message, sendErr := reporter.TelegramBot.Send(
+				&tele.Chat{ID: chat.ID},
+				strings.TrimSpace(chunk),
+				options,
+			)
+			if sendErr != nil {
+				reporter.Logger.Err(sendErr).
+					Str("destination", chat.Destination()).
+					Msg("Could not send Telegram message")
+				errs[chat.Destination()] = sendErr
+				break
+			}
+
+			if reporter.Config.EditAlerts && len(chunks) == 1 {
+				reporter.SaveAlertMessage(chat, chatEntry, message)
+			}
+		}
 	}
 
-	_, err = reporter.TelegramBot.Send(
-		&tele.User{
-			ID: reporter.TelegramChat,
-		},
-		strings.TrimSpace(serializedEntry),
-		tele.ModeHTML,
-		tele.NoPreview,
-	)
+	return reportersPkg.NewDestinationsError(errs)
+}
+
+func (reporter *Reporter) EditsAlerts() bool {
+	return reporter.Config.EditAlerts
+}
+
+// EditSentAlert edits the messages sent before for the entry with its newer state,
+// without sending new messages to the chats that have none.
+func (reporter *Reporter) EditSentAlert(reportEntry entry.ReportEntry, ctx context.Context) error {
+	_, span := reporter.Tracer.Start(ctx, "Editing Telegram alert")
+	defer span.End()
+
+	markup := reporter.GetAlertMarkup(reportEntry)
+	errs := make(map[string]error)
+
+	for _, chat := range reporter.Chats {
+		chatEntry, shouldSend := reporter.GetEntryForChat(reportEntry, chat)
+		if !shouldSend {
+			continue
+		}
+
+		serializedEntry, err := reporter.SerializeReportEntry(chatEntry)
+		if err != nil {
+			reporter.Logger.Err(err).Msg("Could not serialize report entry")
+			errs[chat.Destination()] = err
+			continue
+		}
+
+		reporter.EditAlertMessage(chat, chatEntry, serializedEntry, markup)
+	}
+
+	return reportersPkg.NewDestinationsError(errs)
+}
+
+func (reporter *Reporter) GroupsAlerts() bool {
+	return reporter.Config.GroupAlerts
+}
+
+// GetEntryForChat returns the entry to send to the chat, which for grouped alerts
+// only has the alerts the chat should receive, and whether anything should be sent.
+func (reporter *Reporter) GetEntryForChat(
+	reportEntry entry.ReportEntry,
+	chat types.TelegramChat,
+) (entry.ReportEntry, bool) {
+	if groupedEvent, ok := reportEntry.(events.GroupedEvent); ok {
+		filtered := groupedEvent.Filter(func(groupedEntry entry.ReportEntry) bool {
+			return reporter.ShouldSendToChat(groupedEntry, chat)
+		})
+		return filtered, len(filtered.Entries) > 0
+	}
+
+	// the digest is not related to a single chain, so it's always sent,
+	// but only with the chains and wallets this chat receives notifications about
+	if digestEvent, ok := reportEntry.(events.DigestEvent); ok {
+		return digestEvent.Filter(&chat.DestinationFilter), true
+	}
+
+	return reportEntry, reporter.ShouldSendToChat(reportEntry, chat)
+}
+
+func (reporter *Reporter) ShouldSendToChat(reportEntry entry.ReportEntry, chat types.TelegramChat) bool {
+	chain, wallet, _ := events.GetEntryLabels(reportEntry)
+	if !chat.Matches(chain, wallet) {
+		return false
+	}
+
+	if !chat.HasOwnMutes() {
+		return true
+	}
+
+	isMuted, err := reporter.MutesManager.IsEntryMutedForDestination(reportEntry, chat.Destination())
 	if err != nil {
-		reporter.Logger.Err(err).Msg("Could not send Telegram message")
-		return err
+		reporter.Logger.Warn().
+			Err(err).
+			Str("destination", chat.Destination()).
+			Msg("Error checking whether the entry was muted")
+		return true
+	}
+
+	if isMuted {
+		reporter.Logger.Debug().
+			Str("destination", chat.Destination()).
+			Str("entry", reportEntry.Name()).
+			Msg("Notifications are muted for this chat, not sending.")
+	}
+
+	return !isMuted
+}
+
+// GetChat returns the configured chat the command was sent from, or nil
+// if it was sent from elsewhere, like from a direct message to the bot.
+func (reporter *Reporter) GetChat(c tele.Context) *types.TelegramChat {
+	if c.Chat() == nil {
+		return nil
+	}
+
+	threadID := 0
+	if c.Message() != nil {
+		threadID = c.Message().ThreadID
+	}
+
+	var found *types.TelegramChat
+
+	for index := range reporter.Chats {
+		chat := &reporter.Chats[index]
+		if chat.ID != c.Chat().ID {
+			continue
+		}
+
+		if chat.ThreadID == threadID {
+			return chat
+		}
+
+		if chat.ThreadID == 0 && found == nil {
+			found = chat
+		}
+	}
+
+	return found
+}
+
+// GetChatChains returns the chains the commands sent from this chat should display,
+// or nil, meaning all the chains, if the chat is not configured or is not filtered by chains.
+func (reporter *Reporter) GetChatChains(c tele.Context) []string {
+	if chat := reporter.GetChat(c); chat != nil {
+		return chat.Chains
 	}
 
 	return nil
 }
 
+// GetMuteDestination returns the destination the mutes added from this chat apply to,
+// or an empty string if they should apply to everything.
+func (reporter *Reporter) GetMuteDestination(c tele.Context) string {
+	if chat := reporter.GetChat(c); chat != nil && chat.HasOwnMutes() {
+		return chat.Destination()
+	}
+
+	return ""
+}
+
 func (reporter *Reporter) Name() string {
 	return "telegram-reporter"
 }
@@ -178,12 +410,12 @@ func (reporter *Reporter) BotReply(c tele.Context, msg string) error {
 func ParseMuteOptions(query string, c tele.Context) (*types.Mute, string) {
 	args := strings.Split(query, " ")
 	if len(args) < 2 {
-		return nil, "Usage: /proposals_mute <duration> [params]"
+		return nil, "Usage: /proposals_mute <duration|until-end> [params]"
 	}
 
 	_, durationString, args := args[0], args[1], args[2:] // removing first argument as it's always /proposals_mute
 
-	duration, err := time.ParseDuration(durationString)
+	expires, durationText, err := types.ParseMuteExpiry(durationString)
 	if err != nil {
 		return nil, fmt.Sprintf("Invalid duration provided: %s", durationString)
 	}
@@ -191,12 +423,14 @@ func ParseMuteOptions(query string, c tele.Context) (*types.Mute, string) {
 	mute := &types.Mute{
 		Chain:      null.NewString("", false),
 		ProposalID: null.NewString("", false),
-		Expires:    time.Now().Add(duration),
+		Expires:    expires,
 		Comment: fmt.Sprintf(
-			"Muted using cosmos-proposals-checker for %s by %s",
-			duration,
+			"Muted using cosmos-proposals-checker %s by %s",
+			durationText,
 			c.Sender().FirstName,
 		),
+		Author: null.StringFrom(GetSenderName(c.Sender())),
+		Source: null.StringFrom(types.MuteSourceTelegram),
 	}
 
 	for index, arg := range args {
@@ -214,6 +448,27 @@ func ParseMuteOptions(query string, c tele.Context) (*types.Mute, string) {
 			mute.Chain = null.StringFrom(argSplit[1])
 		case "proposal":
 			mute.ProposalID = null.StringFrom(argSplit[1])
+		case "wallet":
+			mute.Wallet = null.StringFrom(argSplit[1])
+		case "event":
+			if err := types.ValidateMuteEvent(argSplit[1]); err != nil {
+				return nil, fmt.Sprintf("Invalid event provided: %s", err)
+			}
+
+			mute.Event = null.StringFrom(argSplit[1])
+		case "title":
+			if err := types.ValidateMuteTitlePattern(argSplit[1]); err != nil {
+				return nil, fmt.Sprintf("Invalid title pattern provided: %s", err)
+			}
+
+			mute.TitlePattern = null.StringFrom(argSplit[1])
+		default:
+			// a typo in a param would otherwise create a broader mute than intended
+			return nil, fmt.Sprintf(
+				"Unknown param at position %d: %s, expected one of chain, proposal, wallet, event or title",
+				index+1,
+				argSplit[0],
+			)
 		}
 	}
 
@@ -221,7 +476,7 @@ func ParseMuteOptions(query string, c tele.Context) (*types.Mute, string) {
 }
 
 func ParseMuteDeleteOptions(c tele.Context) (*types.Mute, string) {
-	// we only construct mute with chain/proposal to compare, no need to take care
+	// we only construct mute with its labels to compare, no need to take care
 	// about the expiration/comment
 	mute := &types.Mute{
 		Chain:      null.NewString("", false),
@@ -245,6 +500,27 @@ func ParseMuteDeleteOptions(c tele.Context) (*types.Mute, string) {
 			mute.Chain = null.StringFrom(argSplit[1])
 		case "proposal":
 			mute.ProposalID = null.StringFrom(argSplit[1])
+		case "wallet":
+			mute.Wallet = null.StringFrom(argSplit[1])
+		case "event":
+			if err := types.ValidateMuteEvent(argSplit[1]); err != nil {
+				return nil, fmt.Sprintf("Invalid event provided: %s", err)
+			}
+
+			mute.Event = null.StringFrom(argSplit[1])
+		case "title":
+			if err := types.ValidateMuteTitlePattern(argSplit[1]); err != nil {
+				return nil, fmt.Sprintf("Invalid title pattern provided: %s", err)
+			}
+
+			mute.TitlePattern = null.StringFrom(argSplit[1])
+		default:
+			// a typo in a param would otherwise create a broader mute than intended
+			return nil, fmt.Sprintf(
+				"Unknown param at position %d: %s, expected one of chain, proposal, wallet, event or title",
+				index+1,
+				argSplit[0],
+			)
 		}
 	}
 
@@ -4,12 +4,19 @@ import (
 	"context"
 	"errors"
 	"main/assets"
+	"main/pkg/alerts"
+	"main/pkg/dashboard"
 	"main/pkg/data"
 	databasePkg "main/pkg/database"
 	"main/pkg/events"
 	loggerPkg "main/pkg/logger"
+	"main/pkg/metrics"
 	mutesmanager "main/pkg/mutes"
+	"main/pkg/outbox"
 	"main/pkg/report/entry"
+	reportersPkg "main/pkg/reporters"
+	"main/pkg/routes"
+	"main/pkg/spam"
 	"main/pkg/state"
 	"main/pkg/tracing"
 	"main/pkg/types"
@@ -29,11 +36,17 @@ func TestReporterInitNotEnabled(t *testing.T) {
 	config := types.TelegramConfig{}
 	chains := types.Chains{{Name: "chain", LCDEndpoints: []string{"https://example.com"}}}
 	logger := loggerPkg.GetNopLogger()
+	metricsManager := metrics.NewManager(logger, types.MetricsConfig{})
 	tracer := tracing.InitNoopTracer()
 	database := &databasePkg.StubDatabase{}
 	mutesManager := mutesmanager.NewMutesManager(logger, database)
-	stateGenerator := state.NewStateGenerator(logger, tracer, chains)
-	dataManager := data.NewManager(logger, chains, tracer)
+	spamManager := spam.NewManager(logger, types.SpamConfig{}, database)
+	outboxManager := outbox.NewManager(logger, types.OutboxConfig{}, database)
+	alertsManager := alerts.NewManager(logger, database, []types.Duration{})
+	dashboardManager := dashboard.NewManager(logger, database)
+	routesManager := routes.NewManager(logger, types.Routes{})
+	stateGenerator := state.NewStateGenerator(logger, metricsManager, tracer, chains)
+	dataManager := data.NewManager(logger, chains, metricsManager, tracer)
 
 	timezone, err := time.LoadLocation("Etc/GMT")
 	require.NoError(t, err)
@@ -41,6 +54,11 @@ func TestReporterInitNotEnabled(t *testing.T) {
 	reporter := NewTelegramReporter(
 		config,
 		mutesManager,
+		routesManager,
+		spamManager,
+		outboxManager,
+		alertsManager,
+		dashboardManager,
 		stateGenerator,
 		dataManager,
 		logger,
@@ -60,11 +78,17 @@ func TestReporterInitCannotFetchBot(t *testing.T) {
 	config := types.TelegramConfig{TelegramToken: "xxx:yyy", TelegramChat: 123}
 	chains := types.Chains{{Name: "chain", LCDEndpoints: []string{"https://example.com"}}}
 	logger := loggerPkg.GetNopLogger()
+	metricsManager := metrics.NewManager(logger, types.MetricsConfig{})
 	tracer := tracing.InitNoopTracer()
 	database := &databasePkg.StubDatabase{}
 	mutesManager := mutesmanager.NewMutesManager(logger, database)
-	stateGenerator := state.NewStateGenerator(logger, tracer, chains)
-	dataManager := data.NewManager(logger, chains, tracer)
+	spamManager := spam.NewManager(logger, types.SpamConfig{}, database)
+	outboxManager := outbox.NewManager(logger, types.OutboxConfig{}, database)
+	alertsManager := alerts.NewManager(logger, database, []types.Duration{})
+	dashboardManager := dashboard.NewManager(logger, database)
+	routesManager := routes.NewManager(logger, types.Routes{})
+	stateGenerator := state.NewStateGenerator(logger, metricsManager, tracer, chains)
+	dataManager := data.NewManager(logger, chains, metricsManager, tracer)
 
 	timezone, err := time.LoadLocation("Etc/GMT")
 	require.NoError(t, err)
@@ -72,6 +96,11 @@ func TestReporterInitCannotFetchBot(t *testing.T) {
 	reporter := NewTelegramReporter(
 		config,
 		mutesManager,
+		routesManager,
+		spamManager,
+		outboxManager,
+		alertsManager,
+		dashboardManager,
 		stateGenerator,
 		dataManager,
 		logger,
@@ -96,11 +125,17 @@ func TestReporterInitOkay(t *testing.T) {
 	config := types.TelegramConfig{TelegramToken: "xxx:yyy", TelegramChat: 123}
 	chains := types.Chains{{Name: "chain", LCDEndpoints: []string{"https://example.com"}}}
 	logger := loggerPkg.GetNopLogger()
+	metricsManager := metrics.NewManager(logger, types.MetricsConfig{})
 	tracer := tracing.InitNoopTracer()
 	database := &databasePkg.StubDatabase{}
 	mutesManager := mutesmanager.NewMutesManager(logger, database)
-	stateGenerator := state.NewStateGenerator(logger, tracer, chains)
-	dataManager := data.NewManager(logger, chains, tracer)
+	spamManager := spam.NewManager(logger, types.SpamConfig{}, database)
+	outboxManager := outbox.NewManager(logger, types.OutboxConfig{}, database)
+	alertsManager := alerts.NewManager(logger, database, []types.Duration{})
+	dashboardManager := dashboard.NewManager(logger, database)
+	routesManager := routes.NewManager(logger, types.Routes{})
+	stateGenerator := state.NewStateGenerator(logger, metricsManager, tracer, chains)
+	dataManager := data.NewManager(logger, chains, metricsManager, tracer)
 
 	timezone, err := time.LoadLocation("Etc/GMT")
 	require.NoError(t, err)
@@ -108,6 +143,11 @@ func TestReporterInitOkay(t *testing.T) {
 	reporter := NewTelegramReporter(
 		config,
 		mutesManager,
+		routesManager,
+		spamManager,
+		outboxManager,
+		alertsManager,
+		dashboardManager,
 		stateGenerator,
 		dataManager,
 		logger,
@@ -156,11 +196,17 @@ func TestAppBotSendMultilineFail(t *testing.T) {
 	config := types.TelegramConfig{TelegramToken: "xxx:yyy", TelegramChat: 123}
 	chains := types.Chains{{Name: "chain", LCDEndpoints: []string{"https://example.com"}}}
 	logger := loggerPkg.GetNopLogger()
+	metricsManager := metrics.NewManager(logger, types.MetricsConfig{})
 	tracer := tracing.InitNoopTracer()
 	database := &databasePkg.StubDatabase{}
 	mutesManager := mutesmanager.NewMutesManager(logger, database)
-	stateGenerator := state.NewStateGenerator(logger, tracer, chains)
-	dataManager := data.NewManager(logger, chains, tracer)
+	spamManager := spam.NewManager(logger, types.SpamConfig{}, database)
+	outboxManager := outbox.NewManager(logger, types.OutboxConfig{}, database)
+	alertsManager := alerts.NewManager(logger, database, []types.Duration{})
+	dashboardManager := dashboard.NewManager(logger, database)
+	routesManager := routes.NewManager(logger, types.Routes{})
+	stateGenerator := state.NewStateGenerator(logger, metricsManager, tracer, chains)
+	dataManager := data.NewManager(logger, chains, metricsManager, tracer)
 
 	timezone, err := time.LoadLocation("Etc/GMT")
 	require.NoError(t, err)
@@ -168,6 +214,11 @@ func TestAppBotSendMultilineFail(t *testing.T) {
 	reporter := NewTelegramReporter(
 		config,
 		mutesManager,
+		routesManager,
+		spamManager,
+		outboxManager,
+		alertsManager,
+		dashboardManager,
 		stateGenerator,
 		dataManager,
 		logger,
@@ -213,11 +264,17 @@ func TestAppBotSendMultilineOk(t *testing.T) {
 	config := types.TelegramConfig{TelegramToken: "xxx:yyy", TelegramChat: 123}
 	chains := types.Chains{{Name: "chain", LCDEndpoints: []string{"https://example.com"}}}
 	logger := loggerPkg.GetNopLogger()
+	metricsManager := metrics.NewManager(logger, types.MetricsConfig{})
 	tracer := tracing.InitNoopTracer()
 	database := &databasePkg.StubDatabase{}
 	mutesManager := mutesmanager.NewMutesManager(logger, database)
-	stateGenerator := state.NewStateGenerator(logger, tracer, chains)
-	dataManager := data.NewManager(logger, chains, tracer)
+	spamManager := spam.NewManager(logger, types.SpamConfig{}, database)
+	outboxManager := outbox.NewManager(logger, types.OutboxConfig{}, database)
+	alertsManager := alerts.NewManager(logger, database, []types.Duration{})
+	dashboardManager := dashboard.NewManager(logger, database)
+	routesManager := routes.NewManager(logger, types.Routes{})
+	stateGenerator := state.NewStateGenerator(logger, metricsManager, tracer, chains)
+	dataManager := data.NewManager(logger, chains, metricsManager, tracer)
 
 	timezone, err := time.LoadLocation("Etc/GMT")
 	require.NoError(t, err)
@@ -225,6 +282,11 @@ func TestAppBotSendMultilineOk(t *testing.T) {
 	reporter := NewTelegramReporter(
 		config,
 		mutesManager,
+		routesManager,
+		spamManager,
+		outboxManager,
+		alertsManager,
+		dashboardManager,
 		stateGenerator,
 		dataManager,
 		logger,
@@ -262,11 +324,17 @@ func TestAppBotSendReportEntryFailedToSerialize(t *testing.T) {
 	config := types.TelegramConfig{TelegramToken: "xxx:yyy", TelegramChat: 123}
 	chains := types.Chains{{Name: "chain", LCDEndpoints: []string{"https://example.com"}}}
 	logger := loggerPkg.GetNopLogger()
+	metricsManager := metrics.NewManager(logger, types.MetricsConfig{})
 	tracer := tracing.InitNoopTracer()
 	database := &databasePkg.StubDatabase{}
 	mutesManager := mutesmanager.NewMutesManager(logger, database)
-	stateGenerator := state.NewStateGenerator(logger, tracer, chains)
-	dataManager := data.NewManager(logger, chains, tracer)
+	spamManager := spam.NewManager(logger, types.SpamConfig{}, database)
+	outboxManager := outbox.NewManager(logger, types.OutboxConfig{}, database)
+	alertsManager := alerts.NewManager(logger, database, []types.Duration{})
+	dashboardManager := dashboard.NewManager(logger, database)
+	routesManager := routes.NewManager(logger, types.Routes{})
+	stateGenerator := state.NewStateGenerator(logger, metricsManager, tracer, chains)
+	dataManager := data.NewManager(logger, chains, metricsManager, tracer)
 
 	timezone, err := time.LoadLocation("Etc/GMT")
 	require.NoError(t, err)
@@ -274,6 +342,11 @@ func TestAppBotSendReportEntryFailedToSerialize(t *testing.T) {
 	reporter := NewTelegramReporter(
 		config,
 		mutesManager,
+		routesManager,
+		spamManager,
+		outboxManager,
+		alertsManager,
+		dashboardManager,
 		stateGenerator,
 		dataManager,
 		logger,
@@ -307,11 +380,17 @@ func TestAppBotSendReportEntryFailedToSend(t *testing.T) {
 	config := types.TelegramConfig{TelegramToken: "xxx:yyy", TelegramChat: 123}
 	chains := types.Chains{{Name: "chain", LCDEndpoints: []string{"https://example.com"}}}
 	logger := loggerPkg.GetNopLogger()
+	metricsManager := metrics.NewManager(logger, types.MetricsConfig{})
 	tracer := tracing.InitNoopTracer()
 	database := &databasePkg.StubDatabase{}
 	mutesManager := mutesmanager.NewMutesManager(logger, database)
-	stateGenerator := state.NewStateGenerator(logger, tracer, chains)
-	dataManager := data.NewManager(logger, chains, tracer)
+	spamManager := spam.NewManager(logger, types.SpamConfig{}, database)
+	outboxManager := outbox.NewManager(logger, types.OutboxConfig{}, database)
+	alertsManager := alerts.NewManager(logger, database, []types.Duration{})
+	dashboardManager := dashboard.NewManager(logger, database)
+	routesManager := routes.NewManager(logger, types.Routes{})
+	stateGenerator := state.NewStateGenerator(logger, metricsManager, tracer, chains)
+	dataManager := data.NewManager(logger, chains, metricsManager, tracer)
 
 	timezone, err := time.LoadLocation("Etc/GMT")
 	require.NoError(t, err)
@@ -319,6 +398,11 @@ func TestAppBotSendReportEntryFailedToSend(t *testing.T) {
 	reporter := NewTelegramReporter(
 		config,
 		mutesManager,
+		routesManager,
+		spamManager,
+		outboxManager,
+		alertsManager,
+		dashboardManager,
 		stateGenerator,
 		dataManager,
 		logger,
@@ -338,6 +422,167 @@ func TestAppBotSendReportEntryFailedToSend(t *testing.T) {
 	require.ErrorContains(t, err, "custom error")
 }
 
+//nolint:paralleltest // disabled
+func TestAppBotSendReportEntryFailedToSendToSomeChats(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder(
+		"POST",
+		"https://api.telegram.org/botxxx:yyy/getMe",
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("telegram-bot-ok.json")))
+
+	httpmock.RegisterResponder(
+		"POST",
+		"https://api.telegram.org/botxxx:yyy/sendMessage",
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("telegram-send-message-ok.json")))
+
+	httpmock.RegisterMatcherResponder(
+		"POST",
+		"https://api.telegram.org/botxxx:yyy/sendMessage",
+		httpmock.BodyContainsString(`"chat_id":"456"`),
+		httpmock.NewErrorResponder(errors.New("custom error")))
+
+	config := types.TelegramConfig{
+		TelegramToken: "xxx:yyy",
+		Chats:         []types.TelegramChat{{ID: 123}, {ID: 456}},
+	}
+	chains := types.Chains{{Name: "chain", LCDEndpoints: []string{"https://example.com"}}}
+	logger := loggerPkg.GetNopLogger()
+	metricsManager := metrics.NewManager(logger, types.MetricsConfig{})
+	tracer := tracing.InitNoopTracer()
+	database := &databasePkg.StubDatabase{}
+	mutesManager := mutesmanager.NewMutesManager(logger, database)
+	spamManager := spam.NewManager(logger, types.SpamConfig{}, database)
+	outboxManager := outbox.NewManager(logger, types.OutboxConfig{}, database)
+	alertsManager := alerts.NewManager(logger, database, []types.Duration{})
+	dashboardManager := dashboard.NewManager(logger, database)
+	routesManager := routes.NewManager(logger, types.Routes{})
+	stateGenerator := state.NewStateGenerator(logger, metricsManager, tracer, chains)
+	dataManager := data.NewManager(logger, chains, metricsManager, tracer)
+
+	timezone, err := time.LoadLocation("Etc/GMT")
+	require.NoError(t, err)
+
+	reporter := NewTelegramReporter(
+		config,
+		mutesManager,
+		routesManager,
+		spamManager,
+		outboxManager,
+		alertsManager,
+		dashboardManager,
+		stateGenerator,
+		dataManager,
+		logger,
+		"1.2.3",
+		timezone,
+		tracer,
+	)
+
+	err = reporter.InitBot()
+	require.NoError(t, err)
+
+	reportEntry := &events.ProposalsQueryErrorEvent{
+		Chain: &types.Chain{Name: "chain"},
+		Error: &types.QueryError{QueryError: errors.New("query error")},
+	}
+
+	err = reporter.SendReportEntry(reportEntry, context.Background())
+	require.Error(t, err)
+	require.ErrorContains(t, err, "custom error")
+
+	var destinationsErr *reportersPkg.DestinationsError
+	require.ErrorAs(t, err, &destinationsErr)
+	require.Equal(t, []string{"telegram:456"}, destinationsErr.Destinations())
+
+	httpmock.ZeroCallCounters()
+
+	err = reporter.SendReportEntryToDestinations(reportEntry, []string{"telegram:123"}, context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 1, httpmock.GetCallCountInfo()["POST https://api.telegram.org/botxxx:yyy/sendMessage"])
+}
+
+//nolint:paralleltest // disabled
+func TestAppBotSendDigestToChainFilteredChat(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder(
+		"POST",
+		"https://api.telegram.org/botxxx:yyy/getMe",
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("telegram-bot-ok.json")))
+
+	httpmock.RegisterResponder(
+		"POST",
+		"https://api.telegram.org/botxxx:yyy/sendMessage",
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("telegram-send-message-ok.json")))
+
+	httpmock.RegisterMatcherResponder(
+		"POST",
+		"https://api.telegram.org/botxxx:yyy/sendMessage",
+		httpmock.BodyContainsString("other-chain"),
+		httpmock.NewErrorResponder(errors.New("digest is not filtered")))
+
+	config := types.TelegramConfig{
+		TelegramToken: "xxx:yyy",
+		Chats: []types.TelegramChat{{
+			ID:                123,
+			DestinationFilter: types.DestinationFilter{Chains: []string{"chain"}},
+		}},
+	}
+	chains := types.Chains{
+		{Name: "chain", LCDEndpoints: []string{"https://example.com"}},
+		{Name: "other-chain", LCDEndpoints: []string{"https://example.com"}},
+	}
+	logger := loggerPkg.GetNopLogger()
+	metricsManager := metrics.NewManager(logger, types.MetricsConfig{})
+	tracer := tracing.InitNoopTracer()
+	database := &databasePkg.StubDatabase{}
+	mutesManager := mutesmanager.NewMutesManager(logger, database)
+	spamManager := spam.NewManager(logger, types.SpamConfig{}, database)
+	outboxManager := outbox.NewManager(logger, types.OutboxConfig{}, database)
+	alertsManager := alerts.NewManager(logger, database, []types.Duration{})
+	dashboardManager := dashboard.NewManager(logger, database)
+	routesManager := routes.NewManager(logger, types.Routes{})
+	stateGenerator := state.NewStateGenerator(logger, metricsManager, tracer, chains)
+	dataManager := data.NewManager(logger, chains, metricsManager, tracer)
+
+	timezone, err := time.LoadLocation("Etc/GMT")
+	require.NoError(t, err)
+
+	reporter := NewTelegramReporter(
+		config,
+		mutesManager,
+		routesManager,
+		spamManager,
+		outboxManager,
+		alertsManager,
+		dashboardManager,
+		stateGenerator,
+		dataManager,
+		logger,
+		"1.2.3",
+		timezone,
+		tracer,
+	)
+
+	err = reporter.InitBot()
+	require.NoError(t, err)
+
+	httpmock.ZeroCallCounters()
+
+	err = reporter.SendReportEntry(events.DigestEvent{
+		Since: time.Now().Add(-time.Hour),
+		FinishedProposals: []events.DigestProposal{
+			{Chain: chains[0], Proposal: types.Proposal{ID: "1", Title: "Proposal"}},
+			{Chain: chains[1], Proposal: types.Proposal{ID: "2", Title: "Other proposal"}},
+		},
+	}, context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 1, httpmock.GetTotalCallCount())
+}
+
 //nolint:paralleltest // disabled
 func TestAppBotSendReportEntryOk(t *testing.T) {
 	httpmock.Activate()
@@ -350,11 +595,17 @@ func TestAppBotSendReportEntryOk(t *testing.T) {
 	config := types.TelegramConfig{TelegramToken: "xxx:yyy", TelegramChat: 123}
 	chains := types.Chains{{Name: "chain", LCDEndpoints: []string{"https://example.com"}}}
 	logger := loggerPkg.GetNopLogger()
+	metricsManager := metrics.NewManager(logger, types.MetricsConfig{})
 	tracer := tracing.InitNoopTracer()
 	database := &databasePkg.StubDatabase{}
 	mutesManager := mutesmanager.NewMutesManager(logger, database)
-	stateGenerator := state.NewStateGenerator(logger, tracer, chains)
-	dataManager := data.NewManager(logger, chains, tracer)
+	spamManager := spam.NewManager(logger, types.SpamConfig{}, database)
+	outboxManager := outbox.NewManager(logger, types.OutboxConfig{}, database)
+	alertsManager := alerts.NewManager(logger, database, []types.Duration{})
+	dashboardManager := dashboard.NewManager(logger, database)
+	routesManager := routes.NewManager(logger, types.Routes{})
+	stateGenerator := state.NewStateGenerator(logger, metricsManager, tracer, chains)
+	dataManager := data.NewManager(logger, chains, metricsManager, tracer)
 
 	timezone, err := time.LoadLocation("Etc/GMT")
 	require.NoError(t, err)
@@ -362,6 +613,11 @@ func TestAppBotSendReportEntryOk(t *testing.T) {
 	reporter := NewTelegramReporter(
 		config,
 		mutesManager,
+		routesManager,
+		spamManager,
+		outboxManager,
+		alertsManager,
+		dashboardManager,
 		stateGenerator,
 		dataManager,
 		logger,
@@ -410,6 +666,57 @@ func TestAppBotSendReportEntryOk(t *testing.T) {
 			},
 			resultFile: "responses/telegram-voting-finished.html",
 		},
+		{
+			event: events.SuspectedSpamEvent{
+				Chain: &types.Chain{Name: "chain"},
+				Proposal: types.Proposal{
+					ID:    "proposal",
+					Title: "proposal title",
+				},
+				Reasons: []string{"contains blocked keyword 'airdrop'"},
+			},
+			resultFile: "responses/telegram-suspected-spam.html",
+		},
+		{
+			event: events.ProposalEnteredVotingEvent{
+				Chain: &types.Chain{Name: "chain"},
+				Proposal: types.Proposal{
+					ID:        "proposal",
+					Title:     "proposal title",
+					Status:    types.ProposalStatusVoting,
+					EndTime:   proposalEndTime,
+					Expedited: true,
+				},
+				Wallets: []*types.Wallet{{Address: "address"}, {Address: "another-address"}},
+			},
+			resultFile: "responses/telegram-proposal-entered-voting.html",
+		},
+		{
+			event: events.ProposalStatusChangedEvent{
+				Chain: &types.Chain{Name: "chain"},
+				Proposal: types.Proposal{
+					ID:      "proposal",
+					Title:   "proposal title",
+					Status:  types.ProposalStatusVoting,
+					EndTime: proposalEndTime,
+				},
+				OldStatus:      types.ProposalStatusVoting,
+				OldIsExpedited: true,
+			},
+			resultFile: "responses/telegram-proposal-no-longer-expedited.html",
+		},
+		{
+			event: events.ProposalStatusChangedEvent{
+				Chain: &types.Chain{Name: "chain"},
+				Proposal: types.Proposal{
+					ID:     "proposal",
+					Title:  "proposal title",
+					Status: types.ProposalStatusRejected,
+				},
+				OldStatus: types.ProposalStatusVoting,
+			},
+			resultFile: "responses/telegram-proposal-status-changed.html",
+		},
 		{
 			event: events.VoteQueryError{
 				Chain: &types.Chain{Name: "chain"},
@@ -474,6 +781,101 @@ func TestAppBotSendReportEntryOk(t *testing.T) {
 			},
 			resultFile: "responses/telegram-revoted.html",
 		},
+		{
+			event: events.GroupedEvent{
+				Chain: &types.Chain{Name: "chain"},
+				Entries: []entry.ReportEntry{
+					events.NotVotedEvent{
+						RenderTime: renderTime,
+						Chain:      &types.Chain{Name: "chain"},
+						Wallet:     &types.Wallet{Address: "address"},
+						Proposal: types.Proposal{
+							ID:      "proposal",
+							Title:   "proposal title",
+							EndTime: proposalEndTime,
+						},
+					},
+					events.VotedEvent{
+						RenderTime: renderTime,
+						Chain:      &types.Chain{Name: "chain"},
+						Wallet:     &types.Wallet{Address: "another-address"},
+						Vote: &types.Vote{
+							Options: types.VoteOptions{
+								{Option: "Yes", Weight: 1},
+							},
+						},
+						Proposal: types.Proposal{
+							ID:    "proposal",
+							Title: "proposal title",
+						},
+					},
+					events.RevotedEvent{
+						RenderTime: renderTime,
+						Chain:      &types.Chain{Name: "chain"},
+						Wallet:     &types.Wallet{Address: "address"},
+						Vote: &types.Vote{
+							Options: types.VoteOptions{
+								{Option: "Yes", Weight: 1},
+							},
+						},
+						OldVote: &types.Vote{
+							Options: types.VoteOptions{
+								{Option: "No", Weight: 1},
+							},
+						},
+						Proposal: types.Proposal{
+							ID:    "another-proposal",
+							Title: "another proposal title",
+						},
+					},
+				},
+			},
+			resultFile: "responses/telegram-grouped.html",
+		},
+		{
+			event: events.DigestEvent{
+				Since: renderTime.Add(-24 * time.Hour),
+				State: state.RenderedState{
+					RenderTime: renderTime,
+					ChainInfos: []state.RenderedChainInfo{{
+						Chain: &types.Chain{Name: "chain"},
+						ProposalVotes: []state.RenderedProposalVotes{{
+							Proposal: types.Proposal{
+								ID:      "proposal",
+								Title:   "proposal title",
+								EndTime: proposalEndTime,
+							},
+							Votes: []state.RenderedWalletVote{
+								{Wallet: &types.Wallet{Address: "address"}},
+								{
+									Wallet: &types.Wallet{Address: "another-address"},
+									Error:  &types.QueryError{QueryError: errors.New("query error")},
+								},
+							},
+						}},
+					}},
+				},
+				NewVotes: []events.DigestVote{{
+					Chain:    &types.Chain{Name: "chain"},
+					Wallet:   &types.Wallet{Address: "voted-address"},
+					Proposal: types.Proposal{ID: "proposal"},
+					Vote: &types.Vote{
+						Options: types.VoteOptions{
+							{Option: "Yes", Weight: 1},
+						},
+					},
+				}},
+				FinishedProposals: []events.DigestProposal{{
+					Chain: &types.Chain{Name: "chain"},
+					Proposal: types.Proposal{
+						ID:     "finished",
+						Title:  "finished title",
+						Status: types.ProposalStatusPassed,
+					},
+				}},
+			},
+			resultFile: "responses/telegram-digest.html",
+		},
 	}
 
 	for _, input := range inputs {
@@ -37,9 +37,13 @@ func (reporter *Reporter) EditRender(
 		return c.Reply(fmt.Sprintf("Error rendering template: %s", renderErr))
 	}
 
-	if _, editErr := reporter.TelegramBot.Edit(message, strings.TrimSpace(template), opts...); editErr != nil {
-		reporter.Logger.Error().Err(editErr).Msg("Error editing message")
-		return editErr
+	return reporter.EditMessage(message, template, opts...)
+}
+
+func (reporter *Reporter) EditMessage(message tele.Editable, text string, opts ...interface{}) error {
+	if _, err := reporter.TelegramBot.Edit(message, strings.TrimSpace(text), opts...); err != nil {
+		reporter.Logger.Error().Err(err).Msg("Error editing message")
+		return err
 	}
 
 	return nil
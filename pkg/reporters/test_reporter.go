@@ -4,12 +4,27 @@ import (
 	"context"
 	"errors"
 	"main/pkg/report/entry"
+	"main/pkg/state"
+	"main/pkg/utils"
 )
 
 type TestReporter struct {
 	WithInitFail     bool
 	WithDisabled     bool
 	WithErrorSending bool
+	WithGrouping     bool
+	WithDashboard    bool
+	WithEditing      bool
+	// FailingDestinations are the destinations sending to which fails.
+	FailingDestinations []string
+	// ResolvedEvents are the names of the events resolving alerts opened by this reporter.
+	ResolvedEvents []string
+
+	SentEntries      []entry.ReportEntry
+	SentDestinations [][]string
+	EditedEntries    []entry.ReportEntry
+	DeletedAlerts    []entry.ReportEntry
+	Dashboards       []state.RenderedState
 }
 
 func (r *TestReporter) Init() error {
@@ -29,6 +44,76 @@ func (r *TestReporter) SendReportEntry(entry entry.ReportEntry, ctx context.Cont
 		return errors.New("fail")
 	}
 
+	if len(r.FailingDestinations) > 0 {
+		errs := make(map[string]error)
+		for _, destination := range r.FailingDestinations {
+			errs[destination] = errors.New("fail")
+		}
+
+		return NewDestinationsError(errs)
+	}
+
+	r.SentEntries = append(r.SentEntries, entry)
+	return nil
+}
+
+func (r *TestReporter) SendReportEntryToDestinations(
+	entry entry.ReportEntry,
+	destinations []string,
+	ctx context.Context,
+) error {
+	r.SentDestinations = append(r.SentDestinations, destinations)
+
+	errs := make(map[string]error)
+	for _, destination := range destinations {
+		if utils.Contains(r.FailingDestinations, destination) {
+			errs[destination] = errors.New("fail")
+		}
+	}
+
+	if len(errs) > 0 {
+		return NewDestinationsError(errs)
+	}
+
+	r.SentEntries = append(r.SentEntries, entry)
+	return nil
+}
+
+func (r *TestReporter) ResolvesAlerts(entry entry.ReportEntry) bool {
+	return utils.Contains(r.ResolvedEvents, entry.Name())
+}
+
+func (r *TestReporter) GroupsAlerts() bool {
+	return r.WithGrouping
+}
+
+func (r *TestReporter) EditsAlerts() bool {
+	return r.WithEditing
+}
+
+func (r *TestReporter) EditSentAlert(entry entry.ReportEntry, ctx context.Context) error {
+	if r.WithErrorSending {
+		return errors.New("fail")
+	}
+
+	r.EditedEntries = append(r.EditedEntries, entry)
+	return nil
+}
+
+func (r *TestReporter) DeleteAlertMessages(entry entry.ReportEntry) {
+	r.DeletedAlerts = append(r.DeletedAlerts, entry)
+}
+
+func (r *TestReporter) DashboardEnabled() bool {
+	return r.WithDashboard
+}
+
+func (r *TestReporter) UpdateDashboard(state state.RenderedState, ctx context.Context) error {
+	if r.WithErrorSending {
+		return errors.New("fail")
+	}
+
+	r.Dashboards = append(r.Dashboards, state)
 	return nil
 }
 
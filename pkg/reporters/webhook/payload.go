@@ -0,0 +1,227 @@
+package webhook
+
+import (
+	"main/pkg/events"
+	"main/pkg/report/entry"
+	"main/pkg/types"
+	"time"
+)
+
+// PayloadVersion should be bumped on every breaking change in the payload,
+// so consumers can distinguish between the formats.
+const PayloadVersion = 1
+
+type Payload struct {
+	Version   int       `json:"version"`
+	Event     string    `json:"event"`
+	IsAlert   bool      `json:"is_alert"`
+	Timestamp time.Time `json:"timestamp"`
+	Chain     *Chain    `json:"chain,omitempty"`
+	Proposal  *Proposal `json:"proposal,omitempty"`
+	Wallet    *Wallet   `json:"wallet,omitempty"`
+	Vote      *Vote     `json:"vote,omitempty"`
+	OldVote   *Vote     `json:"old_vote,omitempty"`
+	Error     string    `json:"error,omitempty"`
+	// Wallets are only set for proposals that have entered voting.
+	Wallets []*Wallet `json:"wallets,omitempty"`
+	// OldStatus and OldExpedited are only set for proposals that have changed their status.
+	OldStatus    string `json:"old_status,omitempty"`
+	OldExpedited bool   `json:"old_expedited,omitempty"`
+	// SpamReasons are only set for suspected spam proposals.
+	SpamReasons []string `json:"spam_reasons,omitempty"`
+	// Digest is only set for digests.
+	Digest *Digest `json:"digest,omitempty"`
+}
+
+type Digest struct {
+	Since             *time.Time       `json:"since,omitempty"`
+	Proposals         []DigestProposal `json:"proposals"`
+	NewVotes          []DigestVote     `json:"new_votes"`
+	FinishedProposals []DigestProposal `json:"finished_proposals"`
+}
+
+type DigestProposal struct {
+	Chain    *Chain    `json:"chain"`
+	Proposal *Proposal `json:"proposal"`
+	// NotVoted is only set for proposals in voting.
+	NotVoted []*Wallet `json:"not_voted,omitempty"`
+}
+
+type DigestVote struct {
+	Chain    *Chain    `json:"chain"`
+	Proposal *Proposal `json:"proposal"`
+	Wallet   *Wallet   `json:"wallet"`
+	Vote     *Vote     `json:"vote"`
+}
+
+type Chain struct {
+	Name       string `json:"name"`
+	PrettyName string `json:"pretty_name"`
+}
+
+type Proposal struct {
+	ID          string    `json:"id"`
+	Title       string    `json:"title"`
+	Description string    `json:"description"`
+	Status      string    `json:"status"`
+	EndTime     time.Time `json:"end_time"`
+	Expedited   bool      `json:"expedited"`
+}
+
+type Wallet struct {
+	Address string `json:"address"`
+	Alias   string `json:"alias,omitempty"`
+}
+
+type VoteOption struct {
+	Option string  `json:"option"`
+	Weight float64 `json:"weight"`
+}
+
+type Vote struct {
+	Options []VoteOption `json:"options"`
+}
+
+func NewChain(chain *types.Chain) *Chain {
+	if chain == nil {
+		return nil
+	}
+
+	return &Chain{Name: chain.Name, PrettyName: chain.GetName()}
+}
+
+func NewProposal(proposal types.Proposal) *Proposal {
+	return &Proposal{
+		ID:          proposal.ID,
+		Title:       proposal.Title,
+		Description: proposal.Description,
+		Status:      proposal.Status.String(),
+		EndTime:     proposal.EndTime,
+		Expedited:   proposal.Expedited,
+	}
+}
+
+func NewWallet(wallet *types.Wallet) *Wallet {
+	if wallet == nil {
+		return nil
+	}
+
+	return &Wallet{Address: wallet.Address, Alias: wallet.Alias}
+}
+
+func NewVote(vote *types.Vote) *Vote {
+	if vote == nil {
+		return nil
+	}
+
+	options := make([]VoteOption, len(vote.Options))
+	for index, option := range vote.Options {
+		options[index] = VoteOption{Option: option.Option, Weight: option.Weight}
+	}
+
+	return &Vote{Options: options}
+}
+
+func NewDigest(digest events.DigestEvent) *Digest {
+	payload := &Digest{
+		Proposals:         make([]DigestProposal, 0),
+		NewVotes:          make([]DigestVote, 0),
+		FinishedProposals: make([]DigestProposal, 0),
+	}
+
+	if !digest.IsFirst() {
+		payload.Since = &digest.Since
+	}
+
+	for _, chainInfo := range digest.State.ChainInfos {
+		for _, proposalVotes := range chainInfo.ProposalVotes {
+			proposal := DigestProposal{
+				Chain:    NewChain(chainInfo.Chain),
+				Proposal: NewProposal(proposalVotes.Proposal),
+				NotVoted: make([]*Wallet, 0),
+			}
+
+			for _, vote := range proposalVotes.Votes {
+				if !vote.HasVoted() && !vote.IsError() {
+					proposal.NotVoted = append(proposal.NotVoted, NewWallet(vote.Wallet))
+				}
+			}
+
+			payload.Proposals = append(payload.Proposals, proposal)
+		}
+	}
+
+	for _, vote := range digest.NewVotes {
+		payload.NewVotes = append(payload.NewVotes, DigestVote{
+			Chain:    NewChain(vote.Chain),
+			Proposal: NewProposal(vote.Proposal),
+			Wallet:   NewWallet(vote.Wallet),
+			Vote:     NewVote(vote.Vote),
+		})
+	}
+
+	for _, proposal := range digest.FinishedProposals {
+		payload.FinishedProposals = append(payload.FinishedProposals, DigestProposal{
+			Chain:    NewChain(proposal.Chain),
+			Proposal: NewProposal(proposal.Proposal),
+		})
+	}
+
+	return payload
+}
+
+func NewPayload(reportEntry entry.ReportEntry, timestamp time.Time) Payload {
+	payload := Payload{
+		Version:   PayloadVersion,
+		Event:     reportEntry.Name(),
+		IsAlert:   reportEntry.IsAlert(),
+		Timestamp: timestamp,
+	}
+
+	if entryNotError, ok := reportEntry.(entry.ReportEntryNotError); ok {
+		payload.Chain = NewChain(entryNotError.GetChain())
+		payload.Proposal = NewProposal(entryNotError.GetProposal())
+		payload.Wallet = NewWallet(entryNotError.GetWallet())
+	}
+
+	switch typedEntry := reportEntry.(type) {
+	case events.FinishedVotingEvent:
+		payload.Chain = NewChain(typedEntry.Chain)
+		payload.Proposal = NewProposal(typedEntry.Proposal)
+	case events.ProposalEnteredVotingEvent:
+		payload.Chain = NewChain(typedEntry.Chain)
+		payload.Proposal = NewProposal(typedEntry.Proposal)
+		payload.Wallets = make([]*Wallet, len(typedEntry.Wallets))
+		for index, wallet := range typedEntry.Wallets {
+			payload.Wallets[index] = NewWallet(wallet)
+		}
+	case events.ProposalStatusChangedEvent:
+		payload.Chain = NewChain(typedEntry.Chain)
+		payload.Proposal = NewProposal(typedEntry.Proposal)
+		payload.OldStatus = typedEntry.OldStatus.String()
+		payload.OldExpedited = typedEntry.OldIsExpedited
+	case events.SuspectedSpamEvent:
+		payload.Chain = NewChain(typedEntry.Chain)
+		payload.Proposal = NewProposal(typedEntry.Proposal)
+		payload.SpamReasons = typedEntry.Reasons
+	case events.DigestEvent:
+		payload.Digest = NewDigest(typedEntry)
+	case events.VotedEvent:
+		payload.Vote = NewVote(typedEntry.Vote)
+	case events.RevotedEvent:
+		payload.Vote = NewVote(typedEntry.Vote)
+		payload.OldVote = NewVote(typedEntry.OldVote)
+	case events.GenericErrorEvent:
+		payload.Chain = NewChain(typedEntry.Chain)
+		payload.Error = typedEntry.Error.Error()
+	case events.ProposalsQueryErrorEvent:
+		payload.Chain = NewChain(typedEntry.Chain)
+		payload.Error = typedEntry.Error.Error()
+	case events.VoteQueryError:
+		payload.Chain = NewChain(typedEntry.Chain)
+		payload.Proposal = NewProposal(typedEntry.Proposal)
+		payload.Error = typedEntry.Error.Error()
+	}
+
+	return payload
+}
@@ -0,0 +1,186 @@
+package webhook
+
+import (
+	"errors"
+	"main/pkg/events"
+	"main/pkg/state"
+	"main/pkg/types"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewPayloadNotVoted(t *testing.T) {
+	t.Parallel()
+
+	timestamp := time.Unix(1700000000, 0)
+	payload := NewPayload(events.NotVotedEvent{
+		Chain:    &types.Chain{Name: "chain", PrettyName: "Chain"},
+		Wallet:   &types.Wallet{Address: "wallet", Alias: "alias"},
+		Proposal: types.Proposal{ID: "proposal", Title: "title", Status: types.ProposalStatusVoting},
+	}, timestamp)
+
+	require.Equal(t, PayloadVersion, payload.Version)
+	require.Equal(t, "not_voted", payload.Event)
+	require.True(t, payload.IsAlert)
+	require.Equal(t, timestamp, payload.Timestamp)
+	require.Equal(t, &Chain{Name: "chain", PrettyName: "Chain"}, payload.Chain)
+	require.Equal(t, &Wallet{Address: "wallet", Alias: "alias"}, payload.Wallet)
+	require.Equal(t, "proposal", payload.Proposal.ID)
+	require.Nil(t, payload.Vote)
+	require.Empty(t, payload.Error)
+}
+
+func TestNewPayloadRevoted(t *testing.T) {
+	t.Parallel()
+
+	payload := NewPayload(events.RevotedEvent{
+		Chain:    &types.Chain{Name: "chain"},
+		Wallet:   &types.Wallet{Address: "wallet"},
+		Proposal: types.Proposal{ID: "proposal"},
+		Vote:     &types.Vote{Options: types.VoteOptions{{Option: "YES", Weight: 1}}},
+		OldVote:  &types.Vote{Options: types.VoteOptions{{Option: "NO", Weight: 1}}},
+	}, time.Now())
+
+	require.Equal(t, &Vote{Options: []VoteOption{{Option: "YES", Weight: 1}}}, payload.Vote)
+	require.Equal(t, &Vote{Options: []VoteOption{{Option: "NO", Weight: 1}}}, payload.OldVote)
+}
+
+func TestNewPayloadFinishedVoting(t *testing.T) {
+	t.Parallel()
+
+	payload := NewPayload(events.FinishedVotingEvent{
+		Chain:    &types.Chain{Name: "chain"},
+		Proposal: types.Proposal{ID: "proposal"},
+	}, time.Now())
+
+	require.Equal(t, "chain", payload.Chain.Name)
+	require.Equal(t, "proposal", payload.Proposal.ID)
+	require.Nil(t, payload.Wallet)
+}
+
+func TestNewPayloadProposalEnteredVoting(t *testing.T) {
+	t.Parallel()
+
+	payload := NewPayload(events.ProposalEnteredVotingEvent{
+		Chain:    &types.Chain{Name: "chain"},
+		Proposal: types.Proposal{ID: "proposal", Expedited: true},
+		Wallets:  []*types.Wallet{{Address: "wallet", Alias: "alias"}},
+	}, time.Now())
+
+	require.Equal(t, "chain", payload.Chain.Name)
+	require.Equal(t, "proposal", payload.Proposal.ID)
+	require.True(t, payload.Proposal.Expedited)
+	require.Equal(t, []*Wallet{{Address: "wallet", Alias: "alias"}}, payload.Wallets)
+}
+
+func TestNewPayloadProposalStatusChanged(t *testing.T) {
+	t.Parallel()
+
+	payload := NewPayload(events.ProposalStatusChangedEvent{
+		Chain:          &types.Chain{Name: "chain"},
+		Proposal:       types.Proposal{ID: "proposal", Status: types.ProposalStatusVoting},
+		OldStatus:      types.ProposalStatusDeposit,
+		OldIsExpedited: true,
+	}, time.Now())
+
+	require.Equal(t, "chain", payload.Chain.Name)
+	require.Equal(t, "proposal", payload.Proposal.ID)
+	require.Equal(t, types.ProposalStatusDeposit.String(), payload.OldStatus)
+	require.True(t, payload.OldExpedited)
+}
+
+func TestNewPayloadSuspectedSpam(t *testing.T) {
+	t.Parallel()
+
+	payload := NewPayload(events.SuspectedSpamEvent{
+		Chain:    &types.Chain{Name: "chain"},
+		Proposal: types.Proposal{ID: "proposal"},
+		Reasons:  []string{"contains blocked keyword 'airdrop'"},
+	}, time.Now())
+
+	require.Equal(t, "chain", payload.Chain.Name)
+	require.Equal(t, "proposal", payload.Proposal.ID)
+	require.Equal(t, []string{"contains blocked keyword 'airdrop'"}, payload.SpamReasons)
+}
+
+func TestNewPayloadDigest(t *testing.T) {
+	t.Parallel()
+
+	chain := &types.Chain{Name: "chain"}
+	since := time.Unix(1700000000, 0)
+
+	payload := NewPayload(events.DigestEvent{
+		Since: since,
+		State: state.RenderedState{
+			ChainInfos: []state.RenderedChainInfo{{
+				Chain: chain,
+				ProposalVotes: []state.RenderedProposalVotes{{
+					Proposal: types.Proposal{ID: "1"},
+					Votes: []state.RenderedWalletVote{
+						{Wallet: &types.Wallet{Address: "voted"}, Vote: &types.Vote{}},
+						{Wallet: &types.Wallet{Address: "not-voted"}},
+						{Wallet: &types.Wallet{Address: "error"}, Error: &types.QueryError{}},
+					},
+				}},
+			}},
+		},
+		NewVotes: []events.DigestVote{{
+			Chain:    chain,
+			Proposal: types.Proposal{ID: "1"},
+			Wallet:   &types.Wallet{Address: "voted"},
+			Vote:     &types.Vote{Options: types.VoteOptions{{Option: "YES", Weight: 1}}},
+		}},
+		FinishedProposals: []events.DigestProposal{{
+			Chain:    chain,
+			Proposal: types.Proposal{ID: "2", Status: types.ProposalStatusPassed},
+		}},
+	}, time.Now())
+
+	require.Equal(t, "digest", payload.Event)
+	require.False(t, payload.IsAlert)
+	require.Nil(t, payload.Chain)
+	require.NotNil(t, payload.Digest)
+	require.Equal(t, &since, payload.Digest.Since)
+
+	require.Len(t, payload.Digest.Proposals, 1)
+	require.Equal(t, "1", payload.Digest.Proposals[0].Proposal.ID)
+	require.Equal(t, []*Wallet{{Address: "not-voted"}}, payload.Digest.Proposals[0].NotVoted)
+
+	require.Len(t, payload.Digest.NewVotes, 1)
+	require.Equal(t, "voted", payload.Digest.NewVotes[0].Wallet.Address)
+	require.Equal(t, &Vote{Options: []VoteOption{{Option: "YES", Weight: 1}}}, payload.Digest.NewVotes[0].Vote)
+
+	require.Len(t, payload.Digest.FinishedProposals, 1)
+	require.Equal(t, "2", payload.Digest.FinishedProposals[0].Proposal.ID)
+
+	firstDigest := NewPayload(events.DigestEvent{}, time.Now())
+	require.Nil(t, firstDigest.Digest.Since)
+	require.Empty(t, firstDigest.Digest.Proposals)
+}
+
+func TestNewPayloadErrors(t *testing.T) {
+	t.Parallel()
+
+	genericError := NewPayload(events.GenericErrorEvent{
+		Error: errors.New("generic error"),
+	}, time.Now())
+	require.Nil(t, genericError.Chain)
+	require.Equal(t, "generic error", genericError.Error)
+
+	proposalsError := NewPayload(events.ProposalsQueryErrorEvent{
+		Chain: &types.Chain{Name: "chain"},
+		Error: &types.QueryError{QueryError: errors.New("proposals error")},
+	}, time.Now())
+	require.Equal(t, "chain", proposalsError.Chain.Name)
+	require.Equal(t, "proposals error", proposalsError.Error)
+
+	voteError := NewPayload(events.VoteQueryError{
+		Chain:    &types.Chain{Name: "chain"},
+		Proposal: types.Proposal{ID: "proposal"},
+		Error:    &types.QueryError{QueryError: errors.New("vote error")},
+	}, time.Now())
+	require.Equal(t, "proposal", voteError.Proposal.ID)
+	require.Equal(t, "vote error", voteError.Error)
+}
@@ -0,0 +1,227 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"main/pkg/report/entry"
+	reportersPkg "main/pkg/reporters"
+	"main/pkg/types"
+	"main/pkg/utils"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	EventHeader     = "X-Proposals-Checker-Event"
+	TimestampHeader = "X-Proposals-Checker-Timestamp"
+	SignatureHeader = "X-Proposals-Checker-Signature"
+)
+
+type Reporter struct {
+	URLs         []string
+	Secret       string
+	Retries      int
+	RetryDelay   time.Duration
+	MaxRetryTime time.Duration
+
+	Client *http.Client
+	Logger zerolog.Logger
+	Tracer trace.Tracer
+}
+
+func NewReporter(
+	config types.WebhookConfig,
+	logger *zerolog.Logger,
+	tracer trace.Tracer,
+) *Reporter {
+	return &Reporter{
+		URLs:         config.URLs,
+		Secret:       config.Secret,
+		Retries:      config.Retries,
+		RetryDelay:   config.RetryDelay.Duration,
+		MaxRetryTime: config.MaxRetryTime.Duration,
+		Client:       &http.Client{Timeout: config.Timeout.Duration},
+		Logger:       logger.With().Str("component", "webhook_reporter").Logger(),
+		Tracer:       tracer,
+	}
+}
+
+func (r *Reporter) Init() error {
+	return nil
+}
+
+func (r *Reporter) Enabled() bool {
+	return len(r.URLs) > 0
+}
+
+func (r *Reporter) Name() string {
+	return "webhook-reporter"
+}
+
+func (r *Reporter) SendReportEntry(reportEntry entry.ReportEntry, ctx context.Context) error {
+	childCtx, span := r.Tracer.Start(ctx, "Sending webhook report entry")
+	defer span.End()
+
+	return r.sendReportEntryToURLs(reportEntry, r.URLs, childCtx)
+}
+
+// SendReportEntryToDestinations sends the entry only to the URLs it failed to be sent to before.
+func (r *Reporter) SendReportEntryToDestinations(
+	reportEntry entry.ReportEntry,
+	destinations []string,
+	ctx context.Context,
+) error {
+	childCtx, span := r.Tracer.Start(ctx, "Sending webhook report entry to destinations")
+	defer span.End()
+
+	urls := utils.Filter(r.URLs, func(url string) bool {
+		return utils.Contains(destinations, Destination(url))
+	})
+
+	return r.sendReportEntryToURLs(reportEntry, urls, childCtx)
+}
+
+func (r *Reporter) sendReportEntryToURLs(
+	reportEntry entry.ReportEntry,
+	urls []string,
+	ctx context.Context,
+) error {
+	now := time.Now()
+	payload := NewPayload(reportEntry, now)
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		r.Logger.Error().Err(err).Msg("Error marshalling webhook payload")
+		return err
+	}
+
+	errs := make(map[string]error)
+
+	for _, url := range urls {
+		if sendErr := r.SendWithRetries(url, payload.Event, now, body, ctx); sendErr != nil {
+			errs[Destination(url)] = fmt.Errorf("error sending webhook to %s: %w", url, sendErr)
+		}
+	}
+
+	return reportersPkg.NewDestinationsError(errs)
+}
+
+// Destination returns the destination to store in the outbox for the URL. The commas are escaped,
+// as the outbox stores the destinations as a comma-separated list.
+func Destination(url string) string {
+	return "webhook:" + strings.ReplaceAll(url, ",", "%2C")
+}
+
+// SendWithRetries sends the request, retrying it with exponential backoff until it succeeds,
+// the retries are exhausted, the next retry would exceed the max retry time, or the context is cancelled.
+// Entries that still failed to be sent are retried later by the outbox, if it's enabled.
+func (r *Reporter) SendWithRetries(
+	url string,
+	event string,
+	timestamp time.Time,
+	body []byte,
+	ctx context.Context,
+) error {
+	var err error
+
+	delay := r.RetryDelay
+	deadline := time.Now().Add(r.MaxRetryTime)
+
+	for attempt := 0; attempt <= r.Retries; attempt++ {
+		if attempt > 0 {
+			if time.Now().Add(delay).After(deadline) {
+				r.Logger.Debug().
+					Str("url", url).
+					Int("attempt", attempt).
+					Msg("Max retry time reached, not retrying webhook request")
+				return err
+			}
+
+			r.Logger.Debug().
+				Str("url", url).
+				Int("attempt", attempt).
+				Dur("delay", delay).
+				Msg("Retrying webhook request")
+
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(delay):
+			}
+
+			delay *= 2
+		}
+
+		var retryable bool
+		if retryable, err = r.Send(url, event, timestamp, body, ctx); err == nil || !retryable {
+			return err
+		}
+
+		r.Logger.Warn().
+			Err(err).
+			Str("url", url).
+			Int("attempt", attempt+1).
+			Msg("Error sending webhook")
+	}
+
+	return err
+}
+
+// Send sends a single webhook request, returning an error if it failed and whether
+// it's worth retrying it.
+func (r *Reporter) Send(
+	url string,
+	event string,
+	timestamp time.Time,
+	body []byte,
+	ctx context.Context,
+) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+
+	serializedTimestamp := strconv.FormatInt(timestamp.Unix(), 10)
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "cosmos-proposals-checker")
+	req.Header.Set(EventHeader, event)
+	req.Header.Set(TimestampHeader, serializedTimestamp)
+	req.Header.Set(SignatureHeader, "sha256="+Sign(r.Secret, serializedTimestamp, body))
+
+	res, err := r.Client.Do(req)
+	if err != nil {
+		return true, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode >= 200 && res.StatusCode < 300 {
+		return false, nil
+	}
+
+	// client errors are not going to go away on retries, except for timeouts and rate limits
+	retryable := res.StatusCode >= 500 ||
+		res.StatusCode == http.StatusTooManyRequests ||
+		res.StatusCode == http.StatusRequestTimeout
+
+	return retryable, fmt.Errorf("got unexpected status code: %d", res.StatusCode)
+}
+
+// Sign returns the HMAC-SHA256 of "<timestamp>.<body>", so the timestamp is signed as well
+// and receivers can reject the replayed requests.
+func Sign(secret string, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,265 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"io"
+	"main/pkg/events"
+	loggerPkg "main/pkg/logger"
+	reportersPkg "main/pkg/reporters"
+	"main/pkg/tracing"
+	"main/pkg/types"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/require"
+)
+
+func getTestReporter(urls ...string) *Reporter {
+	return NewReporter(
+		types.WebhookConfig{
+			URLs:         urls,
+			Secret:       "secret",
+			Retries:      2,
+			RetryDelay:   types.Duration{Duration: time.Millisecond},
+			MaxRetryTime: types.Duration{Duration: time.Second},
+			Timeout:      types.Duration{Duration: time.Second},
+		},
+		loggerPkg.GetNopLogger(),
+		tracing.InitNoopTracer(),
+	)
+}
+
+func getTestEntry() events.VotedEvent {
+	return events.VotedEvent{
+		Chain:    &types.Chain{Name: "chain"},
+		Wallet:   &types.Wallet{Address: "wallet"},
+		Proposal: types.Proposal{ID: "proposal"},
+		Vote:     &types.Vote{Options: types.VoteOptions{{Option: "YES", Weight: 1}}},
+	}
+}
+
+func TestWebhookReporterBase(t *testing.T) {
+	t.Parallel()
+
+	reporter := getTestReporter()
+	require.NoError(t, reporter.Init())
+	require.False(t, reporter.Enabled())
+	require.Equal(t, "webhook-reporter", reporter.Name())
+
+	reporter2 := getTestReporter("https://example.com")
+	require.True(t, reporter2.Enabled())
+}
+
+func TestWebhookSign(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(
+		t,
+		"d59d7af613dcc6b398fe444b38acc98b9bb9a6c22fa53a9348e9f03dfc637798",
+		Sign("secret", "1700000000", []byte("message")),
+	)
+}
+
+//nolint:paralleltest // disabled
+func TestWebhookReporterSendOk(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder(
+		"POST",
+		"https://example.com/webhook",
+		func(req *http.Request) (*http.Response, error) {
+			body, err := io.ReadAll(req.Body)
+			require.NoError(t, err)
+
+			require.Equal(t, "application/json", req.Header.Get("Content-Type"))
+			require.Equal(t, "voted", req.Header.Get(EventHeader))
+			require.NotEmpty(t, req.Header.Get(TimestampHeader))
+			require.Equal(
+				t,
+				"sha256="+Sign("secret", req.Header.Get(TimestampHeader), body),
+				req.Header.Get(SignatureHeader),
+			)
+			require.Contains(t, string(body), `"version":1`)
+			require.Contains(t, string(body), `"event":"voted"`)
+
+			return httpmock.NewStringResponse(200, "ok"), nil
+		},
+	)
+
+	reporter := getTestReporter("https://example.com/webhook")
+	err := reporter.SendReportEntry(getTestEntry(), context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 1, httpmock.GetTotalCallCount())
+}
+
+//nolint:paralleltest // disabled
+func TestWebhookReporterSendRetriesAndSucceeds(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder(
+		"POST",
+		"https://example.com/webhook",
+		httpmock.NewStringResponder(500, "error").
+			Then(httpmock.NewStringResponder(200, "ok")),
+	)
+
+	reporter := getTestReporter("https://example.com/webhook")
+	err := reporter.SendReportEntry(getTestEntry(), context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 2, httpmock.GetTotalCallCount())
+}
+
+//nolint:paralleltest // disabled
+func TestWebhookReporterSendRetriesExhausted(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder(
+		"POST",
+		"https://example.com/webhook",
+		httpmock.NewErrorResponder(errors.New("custom error")),
+	)
+
+	reporter := getTestReporter("https://example.com/webhook")
+	err := reporter.SendReportEntry(getTestEntry(), context.Background())
+	require.Error(t, err)
+	require.ErrorContains(t, err, "custom error")
+	require.Equal(t, 3, httpmock.GetTotalCallCount())
+}
+
+//nolint:paralleltest // disabled
+func TestWebhookReporterSendMaxRetryTimeReached(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder(
+		"POST",
+		"https://example.com/webhook",
+		httpmock.NewStringResponder(500, "error"),
+	)
+
+	// the first retry would be after 1 second, which exceeds the max retry time
+	reporter := getTestReporter("https://example.com/webhook")
+	reporter.RetryDelay = time.Second
+	reporter.MaxRetryTime = 500 * time.Millisecond
+
+	err := reporter.SendReportEntry(getTestEntry(), context.Background())
+	require.Error(t, err)
+	require.ErrorContains(t, err, "got unexpected status code: 500")
+	require.Equal(t, 1, httpmock.GetTotalCallCount())
+}
+
+//nolint:paralleltest // disabled
+func TestWebhookReporterSendContextCancelled(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	httpmock.RegisterResponder(
+		"POST",
+		"https://example.com/webhook",
+		func(req *http.Request) (*http.Response, error) {
+			cancel()
+			return httpmock.NewStringResponse(500, "error"), nil
+		},
+	)
+
+	reporter := getTestReporter("https://example.com/webhook")
+	reporter.RetryDelay = time.Minute
+	reporter.MaxRetryTime = time.Hour
+
+	err := reporter.SendReportEntry(getTestEntry(), ctx)
+	require.Error(t, err)
+	require.ErrorContains(t, err, context.Canceled.Error())
+	require.Equal(t, 1, httpmock.GetTotalCallCount())
+}
+
+//nolint:paralleltest // disabled
+func TestWebhookReporterSendClientErrorNotRetried(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder(
+		"POST",
+		"https://example.com/webhook",
+		httpmock.NewStringResponder(400, "bad request"),
+	)
+
+	reporter := getTestReporter("https://example.com/webhook")
+	err := reporter.SendReportEntry(getTestEntry(), context.Background())
+	require.Error(t, err)
+	require.ErrorContains(t, err, "got unexpected status code: 400")
+	require.Equal(t, 1, httpmock.GetTotalCallCount())
+}
+
+//nolint:paralleltest // disabled
+func TestWebhookReporterSendMultipleURLs(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder(
+		"POST",
+		"https://example.com/first",
+		httpmock.NewStringResponder(404, "not found"),
+	)
+	httpmock.RegisterResponder(
+		"POST",
+		"https://example.com/second",
+		httpmock.NewStringResponder(200, "ok"),
+	)
+
+	reporter := getTestReporter("https://example.com/first", "https://example.com/second")
+	err := reporter.SendReportEntry(getTestEntry(), context.Background())
+	require.Error(t, err)
+	require.ErrorContains(t, err, "https://example.com/first")
+	require.NotContains(t, err.Error(), "https://example.com/second")
+	require.Equal(t, 2, httpmock.GetTotalCallCount())
+
+	var destinationsErr *reportersPkg.DestinationsError
+	require.ErrorAs(t, err, &destinationsErr)
+	require.Equal(t, []string{"webhook:https://example.com/first"}, destinationsErr.Destinations())
+}
+
+//nolint:paralleltest // disabled
+func TestWebhookReporterSendToDestinations(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder(
+		"POST",
+		"https://example.com/first",
+		httpmock.NewStringResponder(200, "ok"),
+	)
+	httpmock.RegisterResponder(
+		"POST",
+		"https://example.com/second?a=1,2",
+		httpmock.NewStringResponder(200, "ok"),
+	)
+
+	reporter := getTestReporter("https://example.com/first", "https://example.com/second?a=1,2")
+	require.Equal(t, "webhook:https://example.com/second?a=1%2C2", Destination("https://example.com/second?a=1,2"))
+
+	// only the URLs the entry failed to be sent to before are retried
+	err := reporter.SendReportEntryToDestinations(
+		getTestEntry(),
+		[]string{Destination("https://example.com/second?a=1,2")},
+		context.Background(),
+	)
+	require.NoError(t, err)
+	require.Equal(t, 0, httpmock.GetCallCountInfo()["POST https://example.com/first"])
+	require.Equal(t, 1, httpmock.GetCallCountInfo()["POST https://example.com/second?a=1,2"])
+}
+
+func TestWebhookReporterSendInvalidURL(t *testing.T) {
+	t.Parallel()
+
+	reporter := getTestReporter("://invalid")
+	err := reporter.SendReportEntry(getTestEntry(), context.Background())
+	require.Error(t, err)
+}
@@ -0,0 +1,55 @@
+package routes
+
+import (
+	"main/pkg/events"
+	"main/pkg/report/entry"
+	"main/pkg/types"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+type Manager struct {
+	Logger zerolog.Logger
+	Routes types.Routes
+}
+
+func NewManager(logger *zerolog.Logger, routes types.Routes) *Manager {
+	return &Manager{
+		Logger: logger.With().Str("component", "routes_manager").Logger(),
+		Routes: routes,
+	}
+}
+
+func (m *Manager) GetRoutes() types.Routes {
+	return m.Routes
+}
+
+// ShouldSend returns whether the entry should be sent to the given reporter.
+// Reporters without any routes receive everything, otherwise at least one
+// of the reporter's routes should match the entry.
+func (m *Manager) ShouldSend(reporter string, reportEntry entry.ReportEntry) bool {
+	routes := m.Routes.ForReporter(reporter)
+	if len(routes) == 0 {
+		return true
+	}
+
+	chain, wallet, proposal := events.GetEntryLabels(reportEntry)
+
+	for _, route := range routes {
+		if !route.MatchesEvent(reportEntry.Name()) ||
+			!route.MatchesChain(chain) ||
+			!route.MatchesWallet(wallet) {
+			continue
+		}
+
+		if route.TimeLeft.Duration > 0 &&
+			(proposal == nil || time.Until(proposal.EndTime) > route.TimeLeft.Duration) {
+			continue
+		}
+
+		return true
+	}
+
+	return false
+}
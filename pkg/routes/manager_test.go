@@ -0,0 +1,98 @@
+package routes
+
+import (
+	"errors"
+	"main/pkg/events"
+	"main/pkg/logger"
+	"main/pkg/types"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRoutesManagerNoRoutes(t *testing.T) {
+	t.Parallel()
+
+	manager := NewManager(logger.GetNopLogger(), types.Routes{})
+	assert.Empty(t, manager.GetRoutes())
+	assert.True(t, manager.ShouldSend("telegram-reporter", events.VotedEvent{}))
+	assert.True(t, manager.ShouldSend("telegram-reporter", events.GenericErrorEvent{}))
+}
+
+func TestRoutesManagerOtherReporter(t *testing.T) {
+	t.Parallel()
+
+	manager := NewManager(logger.GetNopLogger(), types.Routes{
+		{Reporter: "pagerduty-reporter", Events: []string{"not_voted"}},
+	})
+	assert.True(t, manager.ShouldSend("telegram-reporter", events.VotedEvent{}))
+	assert.False(t, manager.ShouldSend("pagerduty-reporter", events.VotedEvent{}))
+}
+
+func TestRoutesManagerTimeLeft(t *testing.T) {
+	t.Parallel()
+
+	manager := NewManager(logger.GetNopLogger(), types.Routes{
+		{
+			Reporter: "pagerduty-reporter",
+			Events:   []string{"not_voted"},
+			TimeLeft: types.Duration{Duration: 24 * time.Hour},
+		},
+	})
+
+	assert.True(t, manager.ShouldSend("pagerduty-reporter", events.NotVotedEvent{
+		Chain:    &types.Chain{Name: "chain"},
+		Wallet:   &types.Wallet{Address: "wallet"},
+		Proposal: types.Proposal{ID: "proposal", EndTime: time.Now().Add(time.Hour)},
+	}))
+	assert.False(t, manager.ShouldSend("pagerduty-reporter", events.NotVotedEvent{
+		Chain:    &types.Chain{Name: "chain"},
+		Wallet:   &types.Wallet{Address: "wallet"},
+		Proposal: types.Proposal{ID: "proposal", EndTime: time.Now().Add(48 * time.Hour)},
+	}))
+}
+
+func TestRoutesManagerTimeLeftNoProposal(t *testing.T) {
+	t.Parallel()
+
+	manager := NewManager(logger.GetNopLogger(), types.Routes{
+		{Reporter: "pagerduty-reporter", TimeLeft: types.Duration{Duration: 24 * time.Hour}},
+	})
+
+	assert.False(t, manager.ShouldSend("pagerduty-reporter", events.GenericErrorEvent{
+		Chain: &types.Chain{Name: "chain"},
+		Error: errors.New("error"),
+	}))
+}
+
+func TestRoutesManagerChainsAndWallets(t *testing.T) {
+	t.Parallel()
+
+	manager := NewManager(logger.GetNopLogger(), types.Routes{
+		{Reporter: "discord-reporter", Chains: []string{"chain1"}},
+		{Reporter: "discord-reporter", Wallets: []string{"wallet2"}},
+	})
+
+	assert.True(t, manager.ShouldSend("discord-reporter", events.FinishedVotingEvent{
+		Chain: &types.Chain{Name: "chain1"},
+	}))
+	assert.True(t, manager.ShouldSend("discord-reporter", events.VoteQueryError{
+		Chain: &types.Chain{Name: "chain1"},
+	}))
+	assert.True(t, manager.ShouldSend("discord-reporter", events.ProposalsQueryErrorEvent{
+		Chain: &types.Chain{Name: "chain1"},
+	}))
+	assert.True(t, manager.ShouldSend("discord-reporter", events.RevotedEvent{
+		Chain:  &types.Chain{Name: "chain2"},
+		Wallet: &types.Wallet{Address: "wallet2"},
+	}))
+	assert.False(t, manager.ShouldSend("discord-reporter", events.RevotedEvent{
+		Chain:  &types.Chain{Name: "chain2"},
+		Wallet: &types.Wallet{Address: "wallet3"},
+	}))
+	assert.False(t, manager.ShouldSend("discord-reporter", events.GenericErrorEvent{
+		Chain: &types.Chain{Name: "chain2"},
+	}))
+	assert.False(t, manager.ShouldSend("discord-reporter", events.NotExistingEvent{}))
+}
@@ -0,0 +1,110 @@
+package spam
+
+import (
+	databasePkg "main/pkg/database"
+	"main/pkg/types"
+	"time"
+
+	"github.com/guregu/null/v5"
+	"github.com/rs/zerolog"
+)
+
+type Manager struct {
+	Config   types.SpamConfig
+	Database databasePkg.Database
+	Logger   zerolog.Logger
+}
+
+func NewManager(
+	logger *zerolog.Logger,
+	config types.SpamConfig,
+	database databasePkg.Database,
+) *Manager {
+	return &Manager{
+		Config:   config,
+		Database: database,
+		Logger:   logger.With().Str("component", "spam_manager").Logger(),
+	}
+}
+
+// NeedsMinDeposit returns whether the chain's min deposit has to be fetched to classify proposals.
+func (m *Manager) NeedsMinDeposit() bool {
+	return m.Config.Enabled.Bool && m.Config.MinDepositRatio > 0
+}
+
+// CheckProposal returns the spam flag for a proposal, classifying and storing it
+// if it's not classified yet. isNew is true only when the proposal was just flagged,
+// so it's reported once. Proposals that are not spam are not stored and are
+// re-checked each time, as the spam config might change.
+func (m *Manager) CheckProposal(
+	chain *types.Chain,
+	proposal types.Proposal,
+	minDeposit []types.Amount,
+) (*types.SpamFlag, bool, error) {
+	if !m.Config.Enabled.Bool {
+		return nil, false, nil
+	}
+
+	flag, err := m.Database.GetSpamFlag(chain.Name, proposal.ID)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if flag != nil {
+		return flag, false, nil
+	}
+
+	reasons := proposal.GetSpamReasons(m.Config, minDeposit)
+	if len(reasons) == 0 {
+		return nil, false, nil
+	}
+
+	flag = &types.SpamFlag{
+		Chain:      chain.Name,
+		ProposalID: proposal.ID,
+		Reasons:    reasons,
+		Flagged:    true,
+		UpdatedAt:  time.Now(),
+	}
+
+	if err := m.Database.UpsertSpamFlag(flag); err != nil {
+		return nil, false, err
+	}
+
+	m.Logger.Info().
+		Str("chain", chain.Name).
+		Str("proposal", proposal.ID).
+		Strs("reasons", reasons).
+		Msg("Proposal is suspected to be spam")
+
+	return flag, true, nil
+}
+
+// Unflag marks a proposal as not spam, so the not voted alerts on it are sent again.
+// It returns false if the proposal was not flagged.
+func (m *Manager) Unflag(chain, proposalID, author string) (bool, error) {
+	flag, err := m.Database.GetSpamFlag(chain, proposalID)
+	if err != nil {
+		return false, err
+	}
+
+	if flag == nil || !flag.Flagged {
+		return false, nil
+	}
+
+	flag.Flagged = false
+	flag.UpdatedAt = time.Now()
+	flag.UpdatedBy = null.StringFrom(author)
+
+	if err := m.Database.UpsertSpamFlag(flag); err != nil {
+		return false, err
+	}
+
+	m.Logger.Info().
+		Str("chain", chain).
+		Str("proposal", proposalID).
+		Str("author", author).
+		Msg("Proposal was un-flagged as spam")
+
+	return true, nil
+}
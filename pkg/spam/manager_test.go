@@ -0,0 +1,128 @@
+package spam
+
+import (
+	"errors"
+	databasePkg "main/pkg/database"
+	loggerPkg "main/pkg/logger"
+	"main/pkg/types"
+	"testing"
+
+	"github.com/guregu/null/v5"
+	"github.com/stretchr/testify/require"
+)
+
+func getTestManager(database databasePkg.Database) *Manager {
+	return NewManager(loggerPkg.GetNopLogger(), types.SpamConfig{
+		Enabled:         null.BoolFrom(true),
+		Keywords:        []string{"airdrop"},
+		MinDepositRatio: 0.5,
+	}, database)
+}
+
+func TestSpamManagerNeedsMinDeposit(t *testing.T) {
+	t.Parallel()
+
+	database := &databasePkg.StubDatabase{}
+	require.True(t, getTestManager(database).NeedsMinDeposit())
+	require.False(t, NewManager(loggerPkg.GetNopLogger(), types.SpamConfig{}, database).NeedsMinDeposit())
+}
+
+func TestSpamManagerCheckProposalDisabled(t *testing.T) {
+	t.Parallel()
+
+	database := &databasePkg.StubDatabase{}
+	manager := NewManager(loggerPkg.GetNopLogger(), types.SpamConfig{}, database)
+
+	flag, isNew, err := manager.CheckProposal(
+		&types.Chain{Name: "chain"},
+		types.Proposal{ID: "1", Title: "Airdrop"},
+		nil,
+	)
+	require.NoError(t, err)
+	require.Nil(t, flag)
+	require.False(t, isNew)
+}
+
+func TestSpamManagerCheckProposal(t *testing.T) {
+	t.Parallel()
+
+	database := &databasePkg.StubDatabase{}
+	manager := getTestManager(database)
+	chain := &types.Chain{Name: "chain"}
+
+	flag, isNew, err := manager.CheckProposal(chain, types.Proposal{ID: "1", Title: "Upgrade"}, nil)
+	require.NoError(t, err)
+	require.Nil(t, flag)
+	require.False(t, isNew)
+
+	flag, isNew, err = manager.CheckProposal(chain, types.Proposal{ID: "2", Title: "Airdrop"}, nil)
+	require.NoError(t, err)
+	require.NotNil(t, flag)
+	require.True(t, isNew)
+	require.True(t, flag.Flagged)
+	require.Equal(t, []string{"contains blocked keyword 'airdrop'"}, flag.Reasons)
+
+	flag, isNew, err = manager.CheckProposal(chain, types.Proposal{ID: "2", Title: "Airdrop"}, nil)
+	require.NoError(t, err)
+	require.NotNil(t, flag)
+	require.False(t, isNew)
+}
+
+func TestSpamManagerCheckProposalErrors(t *testing.T) {
+	t.Parallel()
+
+	chain := &types.Chain{Name: "chain"}
+	proposal := types.Proposal{ID: "1", Title: "Airdrop"}
+
+	manager := getTestManager(&databasePkg.StubDatabase{GetSpamFlagError: errors.New("get error")})
+	_, _, err := manager.CheckProposal(chain, proposal, nil)
+	require.ErrorContains(t, err, "get error")
+
+	manager = getTestManager(&databasePkg.StubDatabase{UpsertSpamFlagError: errors.New("upsert error")})
+	_, _, err = manager.CheckProposal(chain, proposal, nil)
+	require.ErrorContains(t, err, "upsert error")
+}
+
+func TestSpamManagerUnflag(t *testing.T) {
+	t.Parallel()
+
+	database := &databasePkg.StubDatabase{}
+	manager := getTestManager(database)
+	chain := &types.Chain{Name: "chain"}
+
+	found, err := manager.Unflag("chain", "1", "user")
+	require.NoError(t, err)
+	require.False(t, found)
+
+	_, _, err = manager.CheckProposal(chain, types.Proposal{ID: "1", Title: "Airdrop"}, nil)
+	require.NoError(t, err)
+
+	found, err = manager.Unflag("chain", "1", "user")
+	require.NoError(t, err)
+	require.True(t, found)
+
+	flag, isNew, err := manager.CheckProposal(chain, types.Proposal{ID: "1", Title: "Airdrop"}, nil)
+	require.NoError(t, err)
+	require.False(t, isNew)
+	require.False(t, flag.Flagged)
+	require.Equal(t, "user", flag.UpdatedBy.String)
+
+	found, err = manager.Unflag("chain", "1", "user")
+	require.NoError(t, err)
+	require.False(t, found)
+}
+
+func TestSpamManagerUnflagErrors(t *testing.T) {
+	t.Parallel()
+
+	manager := getTestManager(&databasePkg.StubDatabase{GetSpamFlagError: errors.New("get error")})
+	_, err := manager.Unflag("chain", "1", "user")
+	require.ErrorContains(t, err, "get error")
+
+	manager = getTestManager(&databasePkg.StubDatabase{
+		SpamFlags:           []*types.SpamFlag{{Chain: "chain", ProposalID: "1", Flagged: true}},
+		UpsertSpamFlagError: errors.New("upsert error"),
+	})
+	_, err = manager.Unflag("chain", "1", "user")
+	require.ErrorContains(t, err, "upsert error")
+}
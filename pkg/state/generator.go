@@ -3,6 +3,7 @@ package state
 import (
 	"context"
 	fetchersPkg "main/pkg/fetchers"
+	"main/pkg/metrics"
 	"main/pkg/types"
 	"sync"
 
@@ -20,11 +21,16 @@ type Generator struct {
 	Mutex    sync.Mutex
 }
 
-func NewStateGenerator(logger *zerolog.Logger, tracer trace.Tracer, chains types.Chains) *Generator {
+func NewStateGenerator(
+	logger *zerolog.Logger,
+	metricsManager *metrics.Manager,
+	tracer trace.Tracer,
+	chains types.Chains,
+) *Generator {
 	fetchers := make(map[string]fetchersPkg.Fetcher, len(chains))
 
 	for _, chain := range chains {
-		fetchers[chain.Name] = fetchersPkg.GetFetcher(chain, logger, tracer)
+		fetchers[chain.Name] = fetchersPkg.GetFetcher(chain, logger, metricsManager, tracer)
 	}
 
 	return &Generator{
@@ -35,16 +41,19 @@ func NewStateGenerator(logger *zerolog.Logger, tracer trace.Tracer, chains types
 	}
 }
 
-func (g *Generator) GetState(oldState State, ctx context.Context) State {
+// GetState fetches the state of the chains with the given names, or of all chains
+// if no names are provided.
+func (g *Generator) GetState(oldState State, ctx context.Context, chainNames ...string) State {
 	childCtx, span := g.Tracer.Start(ctx, "State generation")
 	defer span.End()
 
 	state := NewState()
+	chains := g.Chains.FilterByNames(chainNames)
 
 	var wg sync.WaitGroup
-	wg.Add(len(g.Chains))
+	wg.Add(len(chains))
 
-	for _, chain := range g.Chains {
+	for _, chain := range chains {
 		g.Logger.Info().Str("name", chain.Name).Msg("Processing a chain")
 
 		fetcher := g.Fetchers[chain.Name]
@@ -4,6 +4,7 @@ import (
 	"context"
 	"main/pkg/fetchers"
 	"main/pkg/logger"
+	"main/pkg/metrics"
 	"main/pkg/tracing"
 	"main/pkg/types"
 	"testing"
@@ -17,10 +18,11 @@ func TestReportGeneratorNew(t *testing.T) {
 	t.Parallel()
 
 	log := logger.GetNopLogger()
+	metricsManager := metrics.NewManager(log, types.MetricsConfig{})
 	chain := &types.Chain{Name: "chain", Type: "cosmos"}
 	chains := types.Chains{chain}
 
-	generator := NewStateGenerator(log, tracing.InitNoopTracer(), chains)
+	generator := NewStateGenerator(log, metricsManager, tracing.InitNoopTracer(), chains)
 	assert.NotNil(t, generator)
 }
 
@@ -45,6 +47,30 @@ func TestReportGeneratorProcessChain(t *testing.T) {
 	assert.Len(t, newState.ChainInfos, 1)
 }
 
+func TestReportGeneratorProcessChainFiltered(t *testing.T) {
+	t.Parallel()
+
+	log := logger.GetNopLogger()
+	chains := types.Chains{
+		{Name: "chain", Type: "cosmos"},
+		{Name: "another", Type: "cosmos"},
+	}
+
+	generator := Generator{
+		Logger: *log,
+		Chains: chains,
+		Fetchers: map[string]fetchers.Fetcher{
+			"chain":   &fetchers.TestFetcher{WithPassedProposals: true},
+			"another": &fetchers.TestFetcher{WithPassedProposals: true},
+		},
+		Tracer: tracing.InitNoopTracer(),
+	}
+
+	newState := generator.GetState(NewState(), context.Background(), "another")
+	assert.Len(t, newState.ChainInfos, 1)
+	assert.Contains(t, newState.ChainInfos, "another")
+}
+
 func TestReportGeneratorProcessProposalsWithPassed(t *testing.T) {
 	t.Parallel()
 
@@ -43,3 +43,35 @@ func (c RenderedChainInfo) HasProposalsError() bool {
 func (s RenderedState) GetProposalTimeLeft(p types.Proposal) string {
 	return utils.FormatDuration(p.EndTime.Sub(s.RenderTime).Round(time.Second))
 }
+
+// Filter returns the state with only the chains and wallets the chat or channel
+// with this filter receives notifications about.
+func (s RenderedState) Filter(filter *types.DestinationFilter) RenderedState {
+	chainInfos := make([]RenderedChainInfo, 0, len(s.ChainInfos))
+
+	for _, chainInfo := range s.ChainInfos {
+		if !filter.MatchesChain(chainInfo.Chain) {
+			continue
+		}
+
+		proposalVotes := make([]RenderedProposalVotes, len(chainInfo.ProposalVotes))
+		for index, proposalVote := range chainInfo.ProposalVotes {
+			votes := make([]RenderedWalletVote, 0, len(proposalVote.Votes))
+			for _, vote := range proposalVote.Votes {
+				if filter.Matches(chainInfo.Chain, vote.Wallet) {
+					votes = append(votes, vote)
+				}
+			}
+
+			proposalVotes[index] = RenderedProposalVotes{Proposal: proposalVote.Proposal, Votes: votes}
+		}
+
+		chainInfos = append(chainInfos, RenderedChainInfo{
+			Chain:          chainInfo.Chain,
+			ProposalVotes:  proposalVotes,
+			ProposalsError: chainInfo.ProposalsError,
+		})
+	}
+
+	return RenderedState{RenderTime: s.RenderTime, ChainInfos: chainInfos}
+}
@@ -3,8 +3,10 @@ package state
 import (
 	"main/pkg/types"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestToRenderedStateFilteredChain(t *testing.T) {
@@ -163,3 +165,41 @@ func TestRenderedChainInfoHasError(t *testing.T) {
 	assert.False(t, RenderedChainInfo{}.HasProposalsError())
 	assert.True(t, RenderedChainInfo{ProposalsError: &types.QueryError{}}.HasProposalsError())
 }
+
+func TestRenderedStateFilter(t *testing.T) {
+	t.Parallel()
+
+	chain := &types.Chain{Name: "chain"}
+	anotherChain := &types.Chain{Name: "another"}
+	renderTime := time.Now()
+
+	state := RenderedState{
+		RenderTime: renderTime,
+		ChainInfos: []RenderedChainInfo{
+			{
+				Chain: chain,
+				ProposalVotes: []RenderedProposalVotes{{
+					Proposal: types.Proposal{ID: "1"},
+					Votes: []RenderedWalletVote{
+						{Wallet: &types.Wallet{Address: "wallet"}},
+						{Wallet: &types.Wallet{Address: "another", Alias: "alias"}},
+					},
+				}},
+			},
+			{Chain: anotherChain, ProposalVotes: []RenderedProposalVotes{}, ProposalsError: &types.QueryError{}},
+		},
+	}
+
+	assert.Equal(t, state, state.Filter(&types.DestinationFilter{}))
+
+	filtered := state.Filter(&types.DestinationFilter{Chains: []string{"chain"}, Wallets: []string{"alias"}})
+	assert.Equal(t, renderTime, filtered.RenderTime)
+	require.Len(t, filtered.ChainInfos, 1)
+	assert.Equal(t, "chain", filtered.ChainInfos[0].Chain.Name)
+	require.Len(t, filtered.ChainInfos[0].ProposalVotes, 1)
+	require.Len(t, filtered.ChainInfos[0].ProposalVotes[0].Votes, 1)
+	assert.Equal(t, "another", filtered.ChainInfos[0].ProposalVotes[0].Votes[0].Wallet.Address)
+
+	// the original state is not changed
+	require.Len(t, state.ChainInfos[0].ProposalVotes[0].Votes, 2)
+}
@@ -0,0 +1,88 @@
+package templates
+
+import (
+	"bytes"
+	"fmt"
+	"main/pkg/types"
+	"main/pkg/utils"
+	"main/templates"
+	"text/template"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+type SlackTemplatesManager struct {
+	Templates map[string]*template.Template
+	Logger    zerolog.Logger
+	Timezone  *time.Location
+}
+
+func NewSlackTemplatesManager(
+	logger *zerolog.Logger,
+	timezone *time.Location,
+) *SlackTemplatesManager {
+	return &SlackTemplatesManager{
+		Templates: map[string]*template.Template{},
+		Logger:    logger.With().Str("component", "slack_templates_manager").Logger(),
+		Timezone:  timezone,
+	}
+}
+
+func (m *SlackTemplatesManager) Render(templateName string, data interface{}) (string, error) {
+	templateToRender, err := m.GetTemplate(templateName)
+	if err != nil {
+		m.Logger.Error().
+			Err(err).
+			Str("name", templateName).
+			Msg("Error getting template")
+		return "", err
+	}
+
+	var buffer bytes.Buffer
+	if err := templateToRender.Execute(&buffer, data); err != nil {
+		m.Logger.Error().
+			Err(err).
+			Str("name", templateName).
+			Msg("Error rendering template")
+		return "", err
+	}
+
+	return buffer.String(), nil
+}
+
+func (m *SlackTemplatesManager) GetTemplate(templateName string) (*template.Template, error) {
+	if cachedTemplate, ok := m.Templates[templateName]; ok {
+		m.Logger.Trace().Str("type", templateName).Msg("Using cached template")
+		return cachedTemplate, nil
+	}
+
+	m.Logger.Trace().Str("type", templateName).Msg("Loading template")
+
+	filename := templateName + ".md"
+
+	t, err := template.New(filename).Funcs(template.FuncMap{
+		"SerializeLink":  m.SerializeLink,
+		"SerializeDate":  m.SerializeDate,
+		"FormatDuration": utils.FormatDuration,
+	}).ParseFS(templates.TemplatesFs, "slack/"+filename)
+	if err != nil {
+		return nil, err
+	}
+
+	m.Templates[templateName] = t
+
+	return t, nil
+}
+
+func (m *SlackTemplatesManager) SerializeLink(link types.Link) string {
+	if link.Href != "" {
+		return fmt.Sprintf("<%s|%s>", link.Href, link.Name)
+	}
+
+	return link.Name
+}
+
+func (m *SlackTemplatesManager) SerializeDate(date time.Time) string {
+	return date.In(m.Timezone).Format(time.RFC1123)
+}
@@ -0,0 +1,108 @@
+package templates
+
+import (
+	"errors"
+	"main/pkg/events"
+	loggerPkg "main/pkg/logger"
+	"main/pkg/types"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSlackGetTemplateNotExisting(t *testing.T) {
+	t.Parallel()
+
+	logger := loggerPkg.GetNopLogger()
+	timezone, _ := time.LoadLocation("Europe/Moscow")
+	manager := NewSlackTemplatesManager(logger, timezone)
+
+	template, err := manager.GetTemplate("not-existing")
+	require.Error(t, err)
+	assert.Nil(t, template)
+}
+
+func TestSlackGetTemplateExistingAndCached(t *testing.T) {
+	t.Parallel()
+
+	logger := loggerPkg.GetNopLogger()
+	timezone, _ := time.LoadLocation("Europe/Moscow")
+	manager := NewSlackTemplatesManager(logger, timezone)
+
+	template, err := manager.GetTemplate("voted")
+	require.NoError(t, err)
+	assert.NotNil(t, template)
+
+	// this time it should be loaded from cache
+	template2, err2 := manager.GetTemplate("voted")
+	require.NoError(t, err2)
+	assert.NotNil(t, template2)
+}
+
+func TestSlackRenderTemplateError(t *testing.T) {
+	t.Parallel()
+
+	logger := loggerPkg.GetNopLogger()
+	timezone, _ := time.LoadLocation("Europe/Moscow")
+	manager := NewSlackTemplatesManager(logger, timezone)
+
+	template, err := manager.Render("not-existing", nil)
+	require.Error(t, err)
+	assert.Empty(t, template)
+}
+
+func TestSlackRenderTemplateRenderError(t *testing.T) {
+	t.Parallel()
+
+	logger := loggerPkg.GetNopLogger()
+	timezone, _ := time.LoadLocation("Europe/Moscow")
+	manager := NewSlackTemplatesManager(logger, timezone)
+
+	value := map[string]interface{}{}
+
+	template, err := manager.Render("voted", value)
+	require.Error(t, err)
+	assert.Empty(t, template)
+}
+
+func TestSlackRenderTemplateRenderSuccess(t *testing.T) {
+	t.Parallel()
+
+	logger := loggerPkg.GetNopLogger()
+	timezone, _ := time.LoadLocation("Etc/GMT")
+	manager := NewSlackTemplatesManager(logger, timezone)
+
+	template, err := manager.Render("proposals_query_error", events.ProposalsQueryErrorEvent{
+		Chain: &types.Chain{Name: "chain"},
+		Error: &types.QueryError{QueryError: errors.New("custom error")},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "❌ There was an error querying proposals on chain.\n*Error text:* custom error\nSent by <https://github.com/QuokkaStake/cosmos-proposals-checker|cosmos-proposals-checker>.", template)
+}
+
+func TestSlackRenderSerializeLink(t *testing.T) {
+	t.Parallel()
+
+	logger := loggerPkg.GetNopLogger()
+	timezone, _ := time.LoadLocation("Europe/Moscow")
+	manager := NewSlackTemplatesManager(logger, timezone)
+
+	assert.Equal(t, "test", manager.SerializeLink(types.Link{Name: "test"}))
+	assert.Equal(t, "<href|test>", manager.SerializeLink(types.Link{Name: "test", Href: "href"}))
+}
+
+func TestSlackRenderSerializeDate(t *testing.T) {
+	t.Parallel()
+
+	logger := loggerPkg.GetNopLogger()
+	timezone, _ := time.LoadLocation("Europe/Moscow")
+	manager := NewSlackTemplatesManager(logger, timezone)
+
+	dateStr := manager.SerializeDate(
+		time.Date(2000, 1, 1, 0, 0, 0, 0, timezone),
+	)
+	assert.Equal(t, "Sat, 01 Jan 2000 00:00:00 MSK", dateStr)
+}
@@ -0,0 +1,108 @@
+package templates
+
+import (
+	"errors"
+	"main/pkg/events"
+	loggerPkg "main/pkg/logger"
+	"main/pkg/types"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTextGetTemplateNotExisting(t *testing.T) {
+	t.Parallel()
+
+	logger := loggerPkg.GetNopLogger()
+	timezone, _ := time.LoadLocation("Europe/Moscow")
+	manager := NewTextTemplatesManager(logger, timezone)
+
+	template, err := manager.GetTemplate("not-existing")
+	require.Error(t, err)
+	assert.Nil(t, template)
+}
+
+func TestTextGetTemplateExistingAndCached(t *testing.T) {
+	t.Parallel()
+
+	logger := loggerPkg.GetNopLogger()
+	timezone, _ := time.LoadLocation("Europe/Moscow")
+	manager := NewTextTemplatesManager(logger, timezone)
+
+	template, err := manager.GetTemplate("voted")
+	require.NoError(t, err)
+	assert.NotNil(t, template)
+
+	// this time it should be loaded from cache
+	template2, err2 := manager.GetTemplate("voted")
+	require.NoError(t, err2)
+	assert.NotNil(t, template2)
+}
+
+func TestTextRenderTemplateError(t *testing.T) {
+	t.Parallel()
+
+	logger := loggerPkg.GetNopLogger()
+	timezone, _ := time.LoadLocation("Europe/Moscow")
+	manager := NewTextTemplatesManager(logger, timezone)
+
+	template, err := manager.Render("not-existing", nil)
+	require.Error(t, err)
+	assert.Empty(t, template)
+}
+
+func TestTextRenderTemplateRenderError(t *testing.T) {
+	t.Parallel()
+
+	logger := loggerPkg.GetNopLogger()
+	timezone, _ := time.LoadLocation("Europe/Moscow")
+	manager := NewTextTemplatesManager(logger, timezone)
+
+	value := map[string]interface{}{}
+
+	template, err := manager.Render("voted", value)
+	require.Error(t, err)
+	assert.Empty(t, template)
+}
+
+func TestTextRenderTemplateRenderSuccess(t *testing.T) {
+	t.Parallel()
+
+	logger := loggerPkg.GetNopLogger()
+	timezone, _ := time.LoadLocation("Etc/GMT")
+	manager := NewTextTemplatesManager(logger, timezone)
+
+	template, err := manager.Render("proposals_query_error", events.ProposalsQueryErrorEvent{
+		Chain: &types.Chain{Name: "chain"},
+		Error: &types.QueryError{QueryError: errors.New("custom error")},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "There was an error querying proposals on chain: custom error\n", template)
+}
+
+func TestTextRenderSerializeLink(t *testing.T) {
+	t.Parallel()
+
+	logger := loggerPkg.GetNopLogger()
+	timezone, _ := time.LoadLocation("Europe/Moscow")
+	manager := NewTextTemplatesManager(logger, timezone)
+
+	assert.Equal(t, "test", manager.SerializeLink(types.Link{Name: "test"}))
+	assert.Equal(t, "test (href)", manager.SerializeLink(types.Link{Name: "test", Href: "href"}))
+}
+
+func TestTextRenderSerializeDate(t *testing.T) {
+	t.Parallel()
+
+	logger := loggerPkg.GetNopLogger()
+	timezone, _ := time.LoadLocation("Europe/Moscow")
+	manager := NewTextTemplatesManager(logger, timezone)
+
+	dateStr := manager.SerializeDate(
+		time.Date(2000, 1, 1, 0, 0, 0, 0, timezone),
+	)
+	assert.Equal(t, "Sat, 01 Jan 2000 00:00:00 MSK", dateStr)
+}
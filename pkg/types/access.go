@@ -0,0 +1,48 @@
+package types
+
+import "main/pkg/utils"
+
+type AccessLevel int
+
+const (
+	// AccessLevelRead is required for commands that only display data.
+	AccessLevelRead AccessLevel = iota
+	// AccessLevelWrite is required for commands that change something, like adding mutes.
+	AccessLevelWrite
+)
+
+func (l AccessLevel) String() string {
+	if l == AccessLevelWrite {
+		return "write"
+	}
+
+	return "read"
+}
+
+// HasAccess returns whether any of the identities (a Telegram or Slack user ID, or Discord member roles)
+// can run commands of the given level. If both allowlists are empty, everyone can run everything.
+// Admins can run all commands, users can only run read-only ones, unless the admins list is empty,
+// in which case users can also run mutating commands.
+func HasAccess[T comparable](users, admins, identities []T, level AccessLevel) bool {
+	isAdmin := containsAny(admins, identities)
+
+	if level == AccessLevelWrite && len(admins) > 0 {
+		return isAdmin
+	}
+
+	if len(users) == 0 && len(admins) == 0 {
+		return true
+	}
+
+	return isAdmin || containsAny(users, identities)
+}
+
+func containsAny[T comparable](slice []T, values []T) bool {
+	for _, value := range values {
+		if utils.Contains(slice, value) {
+			return true
+		}
+	}
+
+	return false
+}
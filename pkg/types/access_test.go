@@ -0,0 +1,72 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccessLevelString(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "read", AccessLevelRead.String())
+	assert.Equal(t, "write", AccessLevelWrite.String())
+}
+
+func TestHasAccessNoAllowlists(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, HasAccess([]int64{}, []int64{}, []int64{1}, AccessLevelRead))
+	assert.True(t, HasAccess([]int64{}, []int64{}, []int64{1}, AccessLevelWrite))
+}
+
+func TestHasAccessOnlyUsers(t *testing.T) {
+	t.Parallel()
+
+	users := []int64{1}
+	assert.True(t, HasAccess(users, []int64{}, []int64{1}, AccessLevelRead))
+	assert.True(t, HasAccess(users, []int64{}, []int64{1}, AccessLevelWrite))
+	assert.False(t, HasAccess(users, []int64{}, []int64{2}, AccessLevelRead))
+	assert.False(t, HasAccess(users, []int64{}, []int64{2}, AccessLevelWrite))
+}
+
+func TestHasAccessOnlyAdmins(t *testing.T) {
+	t.Parallel()
+
+	admins := []int64{1}
+	assert.True(t, HasAccess([]int64{}, admins, []int64{1}, AccessLevelRead))
+	assert.True(t, HasAccess([]int64{}, admins, []int64{1}, AccessLevelWrite))
+	assert.False(t, HasAccess([]int64{}, admins, []int64{2}, AccessLevelRead))
+	assert.False(t, HasAccess([]int64{}, admins, []int64{2}, AccessLevelWrite))
+}
+
+func TestHasAccessUsersAndAdmins(t *testing.T) {
+	t.Parallel()
+
+	config := DiscordConfig{UserRoles: []string{"user"}, AdminRoles: []string{"admin"}}
+	assert.True(t, config.HasAccess([]string{"user"}, AccessLevelRead))
+	assert.False(t, config.HasAccess([]string{"user"}, AccessLevelWrite))
+	assert.True(t, config.HasAccess([]string{"other", "admin"}, AccessLevelRead))
+	assert.True(t, config.HasAccess([]string{"other", "admin"}, AccessLevelWrite))
+	assert.False(t, config.HasAccess([]string{}, AccessLevelRead))
+}
+
+func TestTelegramConfigHasAccess(t *testing.T) {
+	t.Parallel()
+
+	config := TelegramConfig{Users: []int64{1}, Admins: []int64{2}}
+	assert.True(t, config.HasAccess(1, AccessLevelRead))
+	assert.False(t, config.HasAccess(1, AccessLevelWrite))
+	assert.True(t, config.HasAccess(2, AccessLevelWrite))
+	assert.False(t, config.HasAccess(3, AccessLevelRead))
+}
+
+func TestSlackConfigHasAccess(t *testing.T) {
+	t.Parallel()
+
+	config := SlackConfig{Users: []string{"U1"}, Admins: []string{"U2"}}
+	assert.True(t, config.HasAccess("U1", AccessLevelRead))
+	assert.False(t, config.HasAccess("U1", AccessLevelWrite))
+	assert.True(t, config.HasAccess("U2", AccessLevelWrite))
+	assert.False(t, config.HasAccess("U3", AccessLevelRead))
+}
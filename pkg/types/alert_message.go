@@ -0,0 +1,22 @@
+package types
+
+import (
+	"time"
+
+	"github.com/guregu/null/v5"
+)
+
+// AlertMessage is a wallet-related alert sent to a chat or channel, stored so later
+// alerts on the same wallet and proposal edit this message instead of sending a new one.
+type AlertMessage struct {
+	Destination string
+	Chain       string
+	ProposalID  string
+	Wallet      string
+	MessageID   string
+	// Action is the action taken from the alert buttons, like acknowledging it,
+	// which is kept in the message when it's edited.
+	Action    null.String
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
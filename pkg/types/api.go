@@ -0,0 +1,21 @@
+package types
+
+import (
+	"errors"
+
+	"github.com/guregu/null/v5"
+)
+
+type APIConfig struct {
+	Enabled    null.Bool `default:"false" toml:"enabled"`
+	ListenAddr string    `default:":9581" toml:"listen-address"`
+	Token      string    `toml:"token"`
+}
+
+func (c *APIConfig) Validate() error {
+	if c.Enabled.Bool && c.ListenAddr == "" {
+		return errors.New("API is enabled, but listen-address is not provided")
+	}
+
+	return nil
+}
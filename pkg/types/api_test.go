@@ -0,0 +1,25 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/guregu/null/v5"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIConfigInvalid(t *testing.T) {
+	t.Parallel()
+
+	api := APIConfig{Enabled: null.BoolFrom(true)}
+	err := api.Validate()
+	assert.Error(t, err)
+}
+
+func TestAPIConfigValid(t *testing.T) {
+	t.Parallel()
+
+	api := APIConfig{Enabled: null.BoolFrom(true), ListenAddr: ":9581"}
+	err := api.Validate()
+	assert.NoError(t, err)
+}
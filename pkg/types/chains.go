@@ -1,5 +1,7 @@
 package types
 
+import "main/pkg/utils"
+
 type Chains []*Chain
 
 func (c Chains) FindByName(name string) *Chain {
@@ -11,3 +13,26 @@ func (c Chains) FindByName(name string) *Chain {
 
 	return nil
 }
+
+func (c Chains) HasWallet(addressOrAlias string) bool {
+	for _, chain := range c {
+		for _, wallet := range chain.Wallets {
+			if wallet.Address == addressOrAlias || (wallet.Alias != "" && wallet.Alias == addressOrAlias) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// FilterByNames returns the chains with the given names, or all chains if no names are provided.
+func (c Chains) FilterByNames(names []string) Chains {
+	if len(names) == 0 {
+		return c
+	}
+
+	return utils.Filter(c, func(chain *Chain) bool {
+		return utils.Contains(names, chain.Name)
+	})
+}
@@ -29,3 +29,29 @@ func TestFindChainByNameIfNotPresent(t *testing.T) {
 	chain := chains.FindByName("chain3")
 	assert.Nil(t, chain, "Chain should not be presented!")
 }
+
+func TestChainsHasWallet(t *testing.T) {
+	t.Parallel()
+
+	chains := Chains{
+		{Name: "chain1", Wallets: []*Wallet{{Address: "wallet1"}}},
+		{Name: "chain2", Wallets: []*Wallet{{Address: "wallet2", Alias: "alias"}}},
+	}
+
+	assert.True(t, chains.HasWallet("wallet1"))
+	assert.True(t, chains.HasWallet("wallet2"))
+	assert.True(t, chains.HasWallet("alias"))
+	assert.False(t, chains.HasWallet("wallet3"))
+	assert.False(t, chains.HasWallet(""))
+}
+
+func TestChainsFilterByNames(t *testing.T) {
+	t.Parallel()
+
+	chains := Chains{{Name: "chain1"}, {Name: "chain2"}}
+
+	assert.Len(t, chains.FilterByNames(nil), 2)
+	filtered := chains.FilterByNames([]string{"chain2", "chain3"})
+	assert.Len(t, filtered, 1)
+	assert.Equal(t, "chain2", filtered[0].Name)
+}
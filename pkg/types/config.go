@@ -10,29 +10,154 @@ import (
 type Config struct {
 	DatabaseConfig  DatabaseConfig  `toml:"database"`
 	PagerDutyConfig PagerDutyConfig `toml:"pagerduty"`
+	OpsgenieConfig  OpsgenieConfig  `toml:"opsgenie"`
 	TelegramConfig  TelegramConfig  `toml:"telegram"`
 	DiscordConfig   DiscordConfig   `toml:"discord"`
+	SlackConfig     SlackConfig     `toml:"slack"`
+	WebhookConfig   WebhookConfig   `toml:"webhook"`
 	LogConfig       LogConfig       `toml:"log"`
 	TracingConfig   TracingConfig   `toml:"tracing"`
+	MetricsConfig   MetricsConfig   `toml:"metrics"`
+	APIConfig       APIConfig       `toml:"api"`
 	Chains          Chains          `toml:"chains"`
 	Timezone        string          `toml:"timezone"`
 	Interval        string          `default:"* * * * *" toml:"interval"`
+	Reminders       []Duration      `toml:"reminders"`
+	Routes          Routes          `toml:"routes"`
+	SpamConfig      SpamConfig      `toml:"spam"`
+	DigestConfig    DigestConfig    `toml:"digest"`
+	OutboxConfig    OutboxConfig    `toml:"outbox"`
 }
 
 type PagerDutyConfig struct {
-	PagerDutyURL string `default:"https://events.pagerduty.com" toml:"url"`
-	APIKey       string `toml:"api-key"`
+	PagerDutyURL      string   `default:"https://events.pagerduty.com" toml:"url"`
+	APIKey            string   `toml:"api-key"`
+	ErrorThreshold    Duration `default:"24h"                          toml:"error-threshold"`
+	CriticalThreshold Duration `default:"1h"                           toml:"critical-threshold"`
+}
+
+func (c *PagerDutyConfig) Validate() error {
+	if c.CriticalThreshold.Duration > c.ErrorThreshold.Duration {
+		return fmt.Errorf(
+			"expected critical-threshold to be less than or equal to error-threshold, but got %s and %s",
+			c.CriticalThreshold,
+			c.ErrorThreshold,
+		)
+	}
+
+	return nil
 }
 
 type TelegramConfig struct {
-	TelegramChat  int64  `toml:"chat"`
-	TelegramToken string `toml:"token"`
+	TelegramChat  int64          `toml:"chat"`
+	TelegramToken string         `toml:"token"`
+	Chats         []TelegramChat `toml:"chats"`
+	Users         []int64        `toml:"users"`
+	Admins        []int64        `toml:"admins"`
+	// GroupAlerts sends the wallet-related alerts for a chain as a single message per run.
+	GroupAlerts bool `default:"false" toml:"group-alerts"`
+	// EditAlerts edits the message previously sent about a wallet and a proposal
+	// instead of sending a new one.
+	EditAlerts bool `default:"false" toml:"edit-alerts"`
+	// Dashboard keeps a pinned message with the proposals in voting in each chat,
+	// updated after every report run.
+	Dashboard bool `default:"false" toml:"dashboard"`
+}
+
+func (c *TelegramConfig) HasAccess(userID int64, level AccessLevel) bool {
+	return HasAccess(c.Users, c.Admins, []int64{userID}, level)
+}
+
+// GetChats returns all the chats to send notifications to, including the one
+// set with the `chat` option, which has no filters.
+func (c *TelegramConfig) GetChats() []TelegramChat {
+	chats := make([]TelegramChat, 0, len(c.Chats)+1)
+
+	if c.TelegramChat != 0 {
+		chats = append(chats, TelegramChat{
+			ID:                c.TelegramChat,
+			DestinationFilter: DestinationFilter{MuteScope: MuteScopeGlobal},
+		})
+	}
+
+	return append(chats, c.Chats...)
+}
+
+func (c *TelegramConfig) Validate(chains Chains) error {
+	for index, chat := range c.Chats {
+		if chat.ID == 0 {
+			return fmt.Errorf("chat %d: empty chat ID", index)
+		}
+
+		if err := chat.Validate(chains); err != nil {
+			return fmt.Errorf("chat %d: %s", index, err)
+		}
+	}
+
+	return nil
 }
 
 type DiscordConfig struct {
-	Guild   string `toml:"guild"`
-	Token   string `toml:"token"`
-	Channel string `toml:"channel"`
+	Guild      string           `toml:"guild"`
+	Token      string           `toml:"token"`
+	Channel    string           `toml:"channel"`
+	Channels   []DiscordChannel `toml:"channels"`
+	UserRoles  []string         `toml:"user-roles"`
+	AdminRoles []string         `toml:"admin-roles"`
+	// GroupAlerts posts the wallet-related alerts for a chain to each channel as a single message
+	// per run, split into several ones if it's longer than the Discord message limit.
+	GroupAlerts bool `default:"false" toml:"group-alerts"`
+	// EditAlerts edits the message previously posted to a channel about a wallet and a proposal
+	// instead of posting a new one. Discord does not notify about edited messages.
+	EditAlerts bool `default:"false" toml:"edit-alerts"`
+	// Dashboard keeps a pinned message with the proposals in voting in each channel,
+	// edited after every report run.
+	Dashboard bool `default:"false" toml:"dashboard"`
+}
+
+func (c *DiscordConfig) HasAccess(roles []string, level AccessLevel) bool {
+	return HasAccess(c.UserRoles, c.AdminRoles, roles, level)
+}
+
+// GetChannels returns all the channels to send notifications to, including the one
+// set with the `channel` option, which has no filters.
+func (c *DiscordConfig) GetChannels() []DiscordChannel {
+	channels := make([]DiscordChannel, 0, len(c.Channels)+1)
+
+	if c.Channel != "" {
+		channels = append(channels, DiscordChannel{
+			ID:                c.Channel,
+			DestinationFilter: DestinationFilter{MuteScope: MuteScopeGlobal},
+		})
+	}
+
+	return append(channels, c.Channels...)
+}
+
+func (c *DiscordConfig) Validate(chains Chains) error {
+	for index, channel := range c.Channels {
+		if channel.ID == "" {
+			return fmt.Errorf("channel %d: empty channel ID", index)
+		}
+
+		if err := channel.Validate(chains); err != nil {
+			return fmt.Errorf("channel %d: %s", index, err)
+		}
+	}
+
+	return nil
+}
+
+type SlackConfig struct {
+	Token    string   `toml:"token"`
+	AppToken string   `toml:"app-token"`
+	Channel  string   `toml:"channel"`
+	Users    []string `toml:"users"`
+	Admins   []string `toml:"admins"`
+}
+
+func (c *SlackConfig) HasAccess(userID string, level AccessLevel) bool {
+	return HasAccess(c.Users, c.Admins, []string{userID}, level)
 }
 
 func (c *Config) Validate() error {
@@ -40,6 +165,26 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("invalid database config: %s", err)
 	}
 
+	if err := c.MetricsConfig.Validate(); err != nil {
+		return fmt.Errorf("invalid metrics config: %s", err)
+	}
+
+	if err := c.APIConfig.Validate(); err != nil {
+		return fmt.Errorf("invalid API config: %s", err)
+	}
+
+	if err := c.WebhookConfig.Validate(); err != nil {
+		return fmt.Errorf("invalid webhook config: %s", err)
+	}
+
+	if err := c.PagerDutyConfig.Validate(); err != nil {
+		return fmt.Errorf("invalid PagerDuty config: %s", err)
+	}
+
+	if err := c.OpsgenieConfig.Validate(); err != nil {
+		return fmt.Errorf("invalid Opsgenie config: %s", err)
+	}
+
 	if len(c.Chains) == 0 {
 		return fmt.Errorf("no chains provided")
 	}
@@ -50,10 +195,40 @@ func (c *Config) Validate() error {
 		}
 	}
 
+	if err := c.TelegramConfig.Validate(c.Chains); err != nil {
+		return fmt.Errorf("invalid Telegram config: %s", err)
+	}
+
+	if err := c.DiscordConfig.Validate(c.Chains); err != nil {
+		return fmt.Errorf("invalid Discord config: %s", err)
+	}
+
+	if err := c.Routes.Validate(c.Chains); err != nil {
+		return fmt.Errorf("invalid routes config: %s", err)
+	}
+
+	if err := c.SpamConfig.Validate(); err != nil {
+		return fmt.Errorf("invalid spam config: %s", err)
+	}
+
+	if err := c.DigestConfig.Validate(); err != nil {
+		return fmt.Errorf("invalid digest config: %s", err)
+	}
+
+	if err := c.OutboxConfig.Validate(); err != nil {
+		return fmt.Errorf("invalid outbox config: %s", err)
+	}
+
 	if _, err := time.LoadLocation(c.Timezone); err != nil {
 		return fmt.Errorf("error parsing timezone: %s", err)
 	}
 
+	for index, reminder := range c.Reminders {
+		if reminder.Duration <= 0 {
+			return fmt.Errorf("reminder %d: expected a positive duration, but got %s", index, reminder)
+		}
+	}
+
 	return nil
 }
 
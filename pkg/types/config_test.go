@@ -2,7 +2,9 @@ package types
 
 import (
 	"testing"
+	"time"
 
+	"github.com/guregu/null/v5"
 	"github.com/rs/zerolog"
 
 	"github.com/stretchr/testify/require"
@@ -97,6 +99,69 @@ func TestValidateConfigNoDatabase(t *testing.T) {
 	require.Error(t, err, nil, "Error should be presented!")
 }
 
+func TestValidateConfigInvalidMetrics(t *testing.T) {
+	t.Parallel()
+
+	config := Config{
+		DatabaseConfig: DatabaseConfig{Path: "database.sqlite"},
+		MetricsConfig:  MetricsConfig{Enabled: null.BoolFrom(true)},
+		Chains:         []*Chain{},
+	}
+	err := config.Validate()
+	require.Error(t, err, nil, "Error should be presented!")
+}
+
+func TestValidateConfigInvalidAPI(t *testing.T) {
+	t.Parallel()
+
+	config := Config{
+		DatabaseConfig: DatabaseConfig{Path: "database.sqlite"},
+		APIConfig:      APIConfig{Enabled: null.BoolFrom(true)},
+		Chains:         []*Chain{},
+	}
+	err := config.Validate()
+	require.Error(t, err, nil, "Error should be presented!")
+}
+
+func TestValidateConfigInvalidWebhook(t *testing.T) {
+	t.Parallel()
+
+	config := Config{
+		DatabaseConfig: DatabaseConfig{Path: "database.sqlite"},
+		WebhookConfig:  WebhookConfig{URLs: []string{"https://example.com"}},
+		Chains:         []*Chain{},
+	}
+	err := config.Validate()
+	require.ErrorContains(t, err, "invalid webhook config")
+}
+
+func TestValidateConfigInvalidOpsgenie(t *testing.T) {
+	t.Parallel()
+
+	config := Config{
+		DatabaseConfig: DatabaseConfig{Path: "database.sqlite"},
+		OpsgenieConfig: OpsgenieConfig{APIKey: "key", Priority: "invalid"},
+		Chains:         []*Chain{},
+	}
+	err := config.Validate()
+	require.ErrorContains(t, err, "invalid Opsgenie config")
+}
+
+func TestValidateConfigInvalidPagerDuty(t *testing.T) {
+	t.Parallel()
+
+	config := Config{
+		DatabaseConfig: DatabaseConfig{Path: "database.sqlite"},
+		PagerDutyConfig: PagerDutyConfig{
+			ErrorThreshold:    Duration{Duration: time.Hour},
+			CriticalThreshold: Duration{Duration: 24 * time.Hour},
+		},
+		Chains: []*Chain{},
+	}
+	err := config.Validate()
+	require.ErrorContains(t, err, "invalid PagerDuty config")
+}
+
 func TestValidateConfigNoChains(t *testing.T) {
 	t.Parallel()
 
@@ -221,6 +286,90 @@ func TestValidateConfigValidChain(t *testing.T) {
 	require.NoError(t, err, "Error should not be presented!")
 }
 
+func TestValidateConfigInvalidReminder(t *testing.T) {
+	t.Parallel()
+
+	config := Config{
+		Timezone:       "Europe/Moscow",
+		DatabaseConfig: DatabaseConfig{Path: "database.sqlite"},
+		Chains: []*Chain{
+			{
+				Name:          "chain",
+				LCDEndpoints:  []string{"endpoint"},
+				Wallets:       []*Wallet{{Address: "wallet"}},
+				ProposalsType: "v1",
+				Type:          "cosmos",
+			},
+		},
+		Reminders: []Duration{{Duration: time.Hour}, {Duration: -time.Hour}},
+	}
+	err := config.Validate()
+	require.Error(t, err, nil, "Error should be presented!")
+}
+
+func TestValidateConfigInvalidTelegram(t *testing.T) {
+	t.Parallel()
+
+	config := Config{
+		Timezone:       "Europe/Moscow",
+		DatabaseConfig: DatabaseConfig{Path: "database.sqlite"},
+		Chains: []*Chain{
+			{
+				Name:          "chain",
+				LCDEndpoints:  []string{"endpoint"},
+				Wallets:       []*Wallet{{Address: "wallet"}},
+				ProposalsType: "v1",
+				Type:          "cosmos",
+			},
+		},
+		TelegramConfig: TelegramConfig{Chats: []TelegramChat{{ID: 0}}},
+	}
+	err := config.Validate()
+	require.Error(t, err, nil, "Error should be presented!")
+}
+
+func TestValidateConfigInvalidDiscord(t *testing.T) {
+	t.Parallel()
+
+	config := Config{
+		Timezone:       "Europe/Moscow",
+		DatabaseConfig: DatabaseConfig{Path: "database.sqlite"},
+		Chains: []*Chain{
+			{
+				Name:          "chain",
+				LCDEndpoints:  []string{"endpoint"},
+				Wallets:       []*Wallet{{Address: "wallet"}},
+				ProposalsType: "v1",
+				Type:          "cosmos",
+			},
+		},
+		DiscordConfig: DiscordConfig{Channels: []DiscordChannel{{ID: ""}}},
+	}
+	err := config.Validate()
+	require.Error(t, err, nil, "Error should be presented!")
+}
+
+func TestValidateConfigInvalidRoutes(t *testing.T) {
+	t.Parallel()
+
+	config := Config{
+		Timezone:       "Europe/Moscow",
+		DatabaseConfig: DatabaseConfig{Path: "database.sqlite"},
+		Chains: []*Chain{
+			{
+				Name:          "chain",
+				LCDEndpoints:  []string{"endpoint"},
+				Wallets:       []*Wallet{{Address: "wallet"}},
+				ProposalsType: "v1",
+				Type:          "cosmos",
+			},
+		},
+		Routes: Routes{{Reporter: "telegram-reporter", Chains: []string{"another-chain"}}},
+	}
+	err := config.Validate()
+	require.Error(t, err, nil, "Error should be presented!")
+}
+
 func TestConfigDisplayWarningInvalidChain(t *testing.T) {
 	t.Parallel()
 
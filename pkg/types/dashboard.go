@@ -0,0 +1,11 @@
+package types
+
+import "time"
+
+// DashboardMessage is the pinned message in a chat or channel showing the proposals
+// in voting, which is edited after every report run.
+type DashboardMessage struct {
+	Destination string
+	MessageID   string
+	UpdatedAt   time.Time
+}
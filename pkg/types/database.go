@@ -2,15 +2,37 @@ package types
 
 import (
 	"errors"
+	"fmt"
+)
+
+const (
+	DatabaseTypeSqlite   = "sqlite"
+	DatabaseTypePostgres = "postgres"
 )
 
 type DatabaseConfig struct {
+	Type string `default:"sqlite" toml:"type"`
 	Path string `toml:"path"`
+	URL  string `toml:"url"`
 }
 
 func (c *DatabaseConfig) Validate() error {
-	if c.Path == "" {
-		return errors.New("database path not specified")
+	switch c.Type {
+	case DatabaseTypeSqlite, "":
+		if c.Path == "" {
+			return errors.New("database path not specified")
+		}
+	case DatabaseTypePostgres:
+		if c.URL == "" {
+			return errors.New("database url not specified")
+		}
+	default:
+		return fmt.Errorf(
+			"expected database type to be one of '%s', '%s', but got '%s'",
+			DatabaseTypeSqlite,
+			DatabaseTypePostgres,
+			c.Type,
+		)
 	}
 
 	return nil
@@ -21,3 +21,27 @@ func TestValidateDatabaseConfigOk(t *testing.T) {
 	err := config.Validate()
 	require.NoError(t, err, "Error should not be present!")
 }
+
+func TestValidateDatabaseConfigInvalidType(t *testing.T) {
+	t.Parallel()
+
+	config := &DatabaseConfig{Type: "mysql", Path: "path"}
+	err := config.Validate()
+	require.Error(t, err, "Error should be present!")
+}
+
+func TestValidateDatabaseConfigPostgresWithoutURL(t *testing.T) {
+	t.Parallel()
+
+	config := &DatabaseConfig{Type: "postgres", Path: "path"}
+	err := config.Validate()
+	require.Error(t, err, "Error should be present!")
+}
+
+func TestValidateDatabaseConfigPostgresOk(t *testing.T) {
+	t.Parallel()
+
+	config := &DatabaseConfig{Type: "postgres", URL: "postgres://localhost:5432/db"}
+	err := config.Validate()
+	require.NoError(t, err, "Error should not be present!")
+}
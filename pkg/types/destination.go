@@ -0,0 +1,91 @@
+package types
+
+import (
+	"fmt"
+	"main/pkg/utils"
+)
+
+const (
+	MuteScopeGlobal      = "global"
+	MuteScopeDestination = "destination"
+)
+
+// DestinationFilter limits the notifications sent to a chat or channel and the chains
+// its bot commands are showing, and sets whether the mutes added from it apply to everything
+// or only to this chat or channel.
+type DestinationFilter struct {
+	Chains    []string `toml:"chains"`
+	Wallets   []string `toml:"wallets"`
+	MuteScope string   `default:"global" toml:"mute-scope"`
+}
+
+func (f *DestinationFilter) Validate(chains Chains) error {
+	for _, chainName := range f.Chains {
+		if chains.FindByName(chainName) == nil {
+			return fmt.Errorf("chain '%s' is not configured", chainName)
+		}
+	}
+
+	for _, wallet := range f.Wallets {
+		if !chains.HasWallet(wallet) {
+			return fmt.Errorf("wallet '%s' is not configured for any chain", wallet)
+		}
+	}
+
+	if f.MuteScope != "" && !utils.Contains([]string{MuteScopeGlobal, MuteScopeDestination}, f.MuteScope) {
+		return fmt.Errorf(
+			"expected mute-scope to be one of '%s', '%s', but got '%s'",
+			MuteScopeGlobal,
+			MuteScopeDestination,
+			f.MuteScope,
+		)
+	}
+
+	return nil
+}
+
+func (f *DestinationFilter) MatchesChain(chain *Chain) bool {
+	return len(f.Chains) == 0 || (chain != nil && utils.Contains(f.Chains, chain.Name))
+}
+
+func (f *DestinationFilter) Matches(chain *Chain, wallet *Wallet) bool {
+	if !f.MatchesChain(chain) {
+		return false
+	}
+
+	if len(f.Wallets) > 0 &&
+		(wallet == nil || !(utils.Contains(f.Wallets, wallet.Address) || utils.Contains(f.Wallets, wallet.Alias))) {
+		return false
+	}
+
+	return true
+}
+
+func (f *DestinationFilter) HasOwnMutes() bool {
+	return f.MuteScope == MuteScopeDestination
+}
+
+type TelegramChat struct {
+	ID       int64 `toml:"id"`
+	ThreadID int   `toml:"thread-id"`
+	DestinationFilter
+}
+
+// Destination returns the ID of this chat used for mutes applying to this chat only.
+func (c *TelegramChat) Destination() string {
+	if c.ThreadID != 0 {
+		return fmt.Sprintf("telegram:%d:%d", c.ID, c.ThreadID)
+	}
+
+	return fmt.Sprintf("telegram:%d", c.ID)
+}
+
+type DiscordChannel struct {
+	ID string `toml:"id"`
+	DestinationFilter
+}
+
+// Destination returns the ID of this channel used for mutes applying to this channel only.
+func (c *DiscordChannel) Destination() string {
+	return "discord:" + c.ID
+}
@@ -0,0 +1,99 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDestinationFilterValidate(t *testing.T) {
+	t.Parallel()
+
+	chains := Chains{{Name: "chain", Wallets: []*Wallet{{Address: "wallet"}}}}
+
+	require.Error(t, (&DestinationFilter{Chains: []string{"unknown"}}).Validate(chains))
+	require.Error(t, (&DestinationFilter{Wallets: []string{"unknown"}}).Validate(chains))
+	require.Error(t, (&DestinationFilter{MuteScope: "unknown"}).Validate(chains))
+	require.NoError(t, (&DestinationFilter{
+		Chains:    []string{"chain"},
+		Wallets:   []string{"wallet"},
+		MuteScope: MuteScopeDestination,
+	}).Validate(chains))
+}
+
+func TestDestinationFilterMatches(t *testing.T) {
+	t.Parallel()
+
+	emptyFilter := DestinationFilter{}
+	assert.True(t, emptyFilter.Matches(nil, nil))
+
+	filter := DestinationFilter{Chains: []string{"chain"}, Wallets: []string{"alias"}}
+	assert.True(t, filter.Matches(&Chain{Name: "chain"}, &Wallet{Address: "wallet", Alias: "alias"}))
+	assert.False(t, filter.Matches(&Chain{Name: "another"}, &Wallet{Address: "wallet", Alias: "alias"}))
+	assert.False(t, filter.Matches(&Chain{Name: "chain"}, &Wallet{Address: "wallet"}))
+	assert.False(t, filter.Matches(nil, nil))
+	assert.True(t, filter.MatchesChain(&Chain{Name: "chain"}))
+	assert.False(t, filter.MatchesChain(&Chain{Name: "another"}))
+	assert.False(t, filter.MatchesChain(nil))
+	assert.True(t, emptyFilter.MatchesChain(nil))
+}
+
+func TestTelegramConfigGetChats(t *testing.T) {
+	t.Parallel()
+
+	config := TelegramConfig{
+		TelegramChat: 123,
+		Chats:        []TelegramChat{{ID: 456, ThreadID: 7}},
+	}
+
+	chats := config.GetChats()
+	require.Len(t, chats, 2)
+	assert.Equal(t, "telegram:123", chats[0].Destination())
+	assert.False(t, chats[0].HasOwnMutes())
+	assert.Equal(t, "telegram:456:7", chats[1].Destination())
+
+	assert.Empty(t, (&TelegramConfig{}).GetChats())
+}
+
+func TestTelegramConfigValidate(t *testing.T) {
+	t.Parallel()
+
+	chains := Chains{{Name: "chain"}}
+
+	require.NoError(t, (&TelegramConfig{TelegramChat: 123}).Validate(chains))
+	require.Error(t, (&TelegramConfig{Chats: []TelegramChat{{}}}).Validate(chains))
+	require.Error(t, (&TelegramConfig{Chats: []TelegramChat{{
+		ID:                123,
+		DestinationFilter: DestinationFilter{Chains: []string{"unknown"}},
+	}}}).Validate(chains))
+}
+
+func TestDiscordConfigGetChannels(t *testing.T) {
+	t.Parallel()
+
+	config := DiscordConfig{
+		Channel:  "123",
+		Channels: []DiscordChannel{{ID: "456"}},
+	}
+
+	channels := config.GetChannels()
+	require.Len(t, channels, 2)
+	assert.Equal(t, "discord:123", channels[0].Destination())
+	assert.Equal(t, "discord:456", channels[1].Destination())
+
+	assert.Empty(t, (&DiscordConfig{}).GetChannels())
+}
+
+func TestDiscordConfigValidate(t *testing.T) {
+	t.Parallel()
+
+	chains := Chains{{Name: "chain"}}
+
+	require.NoError(t, (&DiscordConfig{Channel: "123"}).Validate(chains))
+	require.Error(t, (&DiscordConfig{Channels: []DiscordChannel{{}}}).Validate(chains))
+	require.Error(t, (&DiscordConfig{Channels: []DiscordChannel{{
+		ID:                "123",
+		DestinationFilter: DestinationFilter{Chains: []string{"unknown"}},
+	}}}).Validate(chains))
+}
@@ -0,0 +1,55 @@
+package types
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/guregu/null/v5"
+	"github.com/robfig/cron/v3"
+)
+
+type DigestConfig struct {
+	Enabled null.Bool `default:"false" toml:"enabled"`
+	// Interval is a cron pattern, configured separately from the alerts interval.
+	Interval string `default:"0 9 * * *" toml:"interval"`
+	// DisableAlerts sends only the digest instead of the per-event alerts.
+	DisableAlerts null.Bool `default:"false" toml:"disable-alerts"`
+}
+
+func (c *DigestConfig) Validate() error {
+	if !c.Enabled.Bool {
+		return nil
+	}
+
+	if _, err := cron.ParseStandard(c.Interval); err != nil {
+		return fmt.Errorf("error parsing interval: %s", err)
+	}
+
+	return nil
+}
+
+// DigestSnapshot is what was sent in the last digest, so the next one
+// only includes what has changed since then.
+type DigestSnapshot struct {
+	SentAt time.Time
+	Votes  []DigestSnapshotVote
+}
+
+type DigestSnapshotVote struct {
+	Chain      string
+	ProposalID string
+	Wallet     string
+	Vote       string
+}
+
+// GetVote returns the wallet's vote as it was in the last digest, and whether
+// the wallet has voted back then.
+func (s *DigestSnapshot) GetVote(chain, proposalID, wallet string) (string, bool) {
+	for _, vote := range s.Votes {
+		if vote.Chain == chain && vote.ProposalID == proposalID && vote.Wallet == wallet {
+			return vote.Vote, true
+		}
+	}
+
+	return "", false
+}
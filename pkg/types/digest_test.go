@@ -0,0 +1,33 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/guregu/null/v5"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDigestConfigValidate(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, (&DigestConfig{Interval: "invalid"}).Validate())
+	require.NoError(t, (&DigestConfig{Enabled: null.BoolFrom(true), Interval: "0 9 * * *"}).Validate())
+	require.NoError(t, (&DigestConfig{Enabled: null.BoolFrom(true), Interval: "@daily"}).Validate())
+	require.Error(t, (&DigestConfig{Enabled: null.BoolFrom(true), Interval: "invalid"}).Validate())
+}
+
+func TestDigestSnapshotGetVote(t *testing.T) {
+	t.Parallel()
+
+	snapshot := &DigestSnapshot{
+		Votes: []DigestSnapshotVote{{Chain: "chain", ProposalID: "1", Wallet: "wallet", Vote: "Yes"}},
+	}
+
+	vote, found := snapshot.GetVote("chain", "1", "wallet")
+	require.True(t, found)
+	require.Equal(t, "Yes", vote)
+
+	vote, found = snapshot.GetVote("chain", "2", "wallet")
+	require.False(t, found)
+	require.Empty(t, vote)
+}
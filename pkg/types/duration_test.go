@@ -49,3 +49,20 @@ func TestDurationValid(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, 30*time.Second, duration.Duration)
 }
+
+func TestDurationUnmarshalTextInvalid(t *testing.T) {
+	t.Parallel()
+
+	duration := Duration{}
+	err := duration.UnmarshalText([]byte("asd"))
+	require.Error(t, err)
+}
+
+func TestDurationUnmarshalTextValid(t *testing.T) {
+	t.Parallel()
+
+	duration := Duration{}
+	err := duration.UnmarshalText([]byte("72h"))
+	require.NoError(t, err)
+	require.Equal(t, 72*time.Hour, duration.Duration)
+}
@@ -2,6 +2,7 @@ package types
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"strings"
 )
@@ -51,3 +52,20 @@ func (q QueryError) Error() string {
 
 	return sb.String()
 }
+
+// MarshalJSON only keeps the error message, as the underlying errors cannot be
+// unmarshalled back. Unmarshalling it results in an error with the same message.
+func (q QueryError) MarshalJSON() ([]byte, error) {
+	return json.Marshal(q.Error())
+}
+
+func (q *QueryError) UnmarshalJSON(data []byte) error {
+	var message string
+	if err := json.Unmarshal(data, &message); err != nil {
+		return err
+	}
+
+	q.QueryError = errors.New(message)
+	q.NodeErrors = nil
+	return nil
+}
@@ -1,6 +1,7 @@
 package types
 
 import (
+	"encoding/json"
 	"errors"
 	"testing"
 
@@ -39,6 +40,26 @@ func TestQueryErrorSerializeWithoutQueryError(t *testing.T) {
 	)
 }
 
+func TestQueryErrorMarshalUnmarshalJson(t *testing.T) {
+	t.Parallel()
+
+	queryError := &QueryError{
+		NodeErrors: []NodeError{
+			{Node: "test", Error: NewJSONError(errors.New("test error"))},
+		},
+	}
+
+	value, err := json.Marshal(queryError)
+	require.NoError(t, err)
+	assert.Equal(t, `"All LCD requests failed:\n#1: test -\u003e test error\n"`, string(value))
+
+	unmarshalled := &QueryError{}
+	require.NoError(t, json.Unmarshal(value, unmarshalled))
+	assert.Equal(t, queryError.Error(), unmarshalled.Error())
+
+	require.Error(t, json.Unmarshal([]byte("123"), unmarshalled))
+}
+
 func TestJsonErrorMarshalJson(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,14 @@
+package types
+
+import "time"
+
+// Incident is an alert opened in an external incident management system
+// (like PagerDuty), stored so it can be resolved later, even after a restart.
+type Incident struct {
+	Reporter   string
+	Chain      string
+	ProposalID string
+	Wallet     string
+	DedupKey   string
+	CreatedAt  time.Time
+}
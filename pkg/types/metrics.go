@@ -0,0 +1,20 @@
+package types
+
+import (
+	"errors"
+
+	"github.com/guregu/null/v5"
+)
+
+type MetricsConfig struct {
+	Enabled    null.Bool `default:"false" toml:"enabled"`
+	ListenAddr string    `default:":9580" toml:"listen-address"`
+}
+
+func (c *MetricsConfig) Validate() error {
+	if c.Enabled.Bool && c.ListenAddr == "" {
+		return errors.New("metrics are enabled, but listen-address is not provided")
+	}
+
+	return nil
+}
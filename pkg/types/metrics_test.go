@@ -0,0 +1,25 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/guregu/null/v5"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetricsInvalid(t *testing.T) {
+	t.Parallel()
+
+	metrics := MetricsConfig{Enabled: null.BoolFrom(true)}
+	err := metrics.Validate()
+	assert.Error(t, err)
+}
+
+func TestMetricsValid(t *testing.T) {
+	t.Parallel()
+
+	metrics := MetricsConfig{Enabled: null.BoolFrom(true), ListenAddr: ":9580"}
+	err := metrics.Validate()
+	assert.NoError(t, err)
+}
@@ -1,36 +1,163 @@
 package types
 
 import (
+	"fmt"
+	"main/pkg/utils"
+	"regexp"
+	"strings"
 	"time"
 
 	"github.com/guregu/null/v5"
 )
 
+const (
+	MuteSourceTelegram = "telegram"
+	MuteSourceDiscord  = "discord"
+	MuteSourceSlack    = "slack"
+
+	MuteRemovedReasonDeleted = "deleted"
+	MuteRemovedReasonExpired = "expired"
+
+	// MuteUntilVotingEnds can be passed to the mute commands instead of a duration.
+	MuteUntilVotingEnds = "until-end"
+)
+
 type Mute struct {
 	Chain      null.String
 	ProposalID null.String
-	Expires    time.Time
-	Comment    string
+	// Wallet can be either the wallet address or its alias.
+	Wallet null.String
+	// Event is the report entry type, like "not_voted" or "vote_query_error".
+	Event null.String
+	// TitlePattern is a regular expression matched against the proposal title.
+	TitlePattern null.String
+	// Destination is set for mutes only applying to a specific chat or channel,
+	// like "telegram:<chat ID>", and is empty for mutes applying to everything.
+	Destination null.String
+	Expires     time.Time
+	Comment     string
+	// Author, Source and CreatedAt are empty for mutes added before they were stored.
+	Author    null.String
+	Source    null.String
+	CreatedAt null.Time
+}
+
+// MuteQuery describes a report entry checked against the mutes. Each field is empty
+// if the entry is not related to it, like the wallet for an error querying proposals.
+type MuteQuery struct {
+	Chain         string
+	ProposalID    string
+	WalletAddress string
+	WalletAlias   string
+	ProposalTitle string
+	Event         string
+	Destination   string
+}
+
+// MuteHistoryEntry is a mute that was deleted or has expired.
+type MuteHistoryEntry struct {
+	Mute
+	Reason    string
+	RemovedAt time.Time
+	RemovedBy null.String
+}
+
+// ValidateMuteEvent returns an error if the event is not one of the known report entry types.
+func ValidateMuteEvent(event string) error {
+	if !utils.Contains(RouteEvents, event) {
+		return fmt.Errorf(
+			"expected event to be one of %s, but got '%s'",
+			strings.Join(RouteEvents, ", "),
+			event,
+		)
+	}
+
+	return nil
+}
+
+// ParseMuteExpiry returns when a mute for the given duration expires and how to describe it.
+// The expiration time is left empty for mutes until the voting ends, as mutes.Manager
+// takes it from the stored proposal.
+func ParseMuteExpiry(durationString string) (time.Time, string, error) {
+	if durationString == MuteUntilVotingEnds {
+		return time.Time{}, "until voting ends", nil
+	}
+
+	duration, err := time.ParseDuration(durationString)
+	if err != nil {
+		return time.Time{}, "", err
+	}
+
+	return time.Now().Add(duration), "for " + duration.String(), nil
+}
+
+func ValidateMuteTitlePattern(pattern string) error {
+	if _, err := regexp.Compile(pattern); err != nil {
+		return fmt.Errorf("invalid title pattern '%s': %s", pattern, err)
+	}
+
+	return nil
 }
 
 func (m *Mute) IsExpired() bool {
 	return m.Expires.Before(time.Now())
 }
 
-func (m *Mute) Matches(chain string, proposalID string) bool {
+func (m *Mute) Matches(query MuteQuery) bool {
 	match := true
 
 	if !m.Chain.IsZero() {
-		match = match && chain == m.Chain.String
+		match = match && query.Chain == m.Chain.String
 	}
 
 	if !m.ProposalID.IsZero() {
-		match = match && proposalID == m.ProposalID.String
+		match = match && query.ProposalID == m.ProposalID.String
+	}
+
+	if !m.Wallet.IsZero() {
+		match = match && (query.WalletAddress == m.Wallet.String ||
+			(query.WalletAlias != "" && query.WalletAlias == m.Wallet.String))
+	}
+
+	if !m.Event.IsZero() {
+		match = match && query.Event == m.Event.String
+	}
+
+	if !m.TitlePattern.IsZero() {
+		match = match && m.MatchesTitle(query.ProposalTitle)
 	}
 
 	return match
 }
 
+// MatchesTitle returns whether the proposal title matches the mute title pattern,
+// entries without a proposal title never match.
+func (m *Mute) MatchesTitle(title string) bool {
+	if title == "" {
+		return false
+	}
+
+	pattern, err := regexp.Compile(m.TitlePattern.String)
+	if err != nil {
+		return false
+	}
+
+	return pattern.MatchString(title)
+}
+
+func (m *Mute) MatchesDestination(destination string) bool {
+	return m.Destination.IsZero() || m.Destination.String == destination
+}
+
+func (m *Mute) IsGlobal() bool {
+	return m.Destination.IsZero()
+}
+
 func (m *Mute) LabelsEqual(another *Mute) bool {
-	return m.Chain == another.Chain && m.ProposalID == another.ProposalID
+	return m.Chain == another.Chain &&
+		m.ProposalID == another.ProposalID &&
+		m.Wallet == another.Wallet &&
+		m.Event == another.Event &&
+		m.TitlePattern == another.TitlePattern &&
+		m.Destination == another.Destination
 }
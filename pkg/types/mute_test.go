@@ -2,16 +2,18 @@ package types
 
 import (
 	"testing"
+	"time"
 
 	"github.com/guregu/null/v5"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestMuteMatchesNoParams(t *testing.T) {
 	t.Parallel()
 
 	mute := &Mute{}
-	muted := mute.Matches("chain", "proposal")
+	muted := mute.Matches(MuteQuery{Chain: "chain", ProposalID: "proposal"})
 	assert.True(t, muted, "Mute should match!")
 }
 
@@ -19,9 +21,9 @@ func TestMuteMatchesWithChainSpecified(t *testing.T) {
 	t.Parallel()
 
 	mute := &Mute{Chain: null.StringFrom("chain")}
-	muted := mute.Matches("chain", "proposal")
+	muted := mute.Matches(MuteQuery{Chain: "chain", ProposalID: "proposal"})
 	assert.True(t, muted, "Mute should match!")
-	muted2 := mute.Matches("chain2", "proposal")
+	muted2 := mute.Matches(MuteQuery{Chain: "chain2", ProposalID: "proposal"})
 	assert.False(t, muted2, "Mute should not match!")
 }
 
@@ -29,9 +31,9 @@ func TestMuteMatchesWithProposalSpecified(t *testing.T) {
 	t.Parallel()
 
 	mute := &Mute{ProposalID: null.StringFrom("proposal")}
-	muted := mute.Matches("chain", "proposal")
+	muted := mute.Matches(MuteQuery{Chain: "chain", ProposalID: "proposal"})
 	assert.True(t, muted, "Mute should match!")
-	muted2 := mute.Matches("chain", "proposal2")
+	muted2 := mute.Matches(MuteQuery{Chain: "chain", ProposalID: "proposal2"})
 	assert.False(t, muted2, "Mute should not match!")
 }
 
@@ -39,10 +41,90 @@ func TestMuteMatchesWithAllSpecified(t *testing.T) {
 	t.Parallel()
 
 	mute := &Mute{Chain: null.StringFrom("chain"), ProposalID: null.StringFrom("proposal")}
-	muted := mute.Matches("chain", "proposal")
+	muted := mute.Matches(MuteQuery{Chain: "chain", ProposalID: "proposal"})
 	assert.True(t, muted, "Mute should match!")
-	muted2 := mute.Matches("chain", "proposal2")
+	muted2 := mute.Matches(MuteQuery{Chain: "chain", ProposalID: "proposal2"})
 	assert.False(t, muted2, "Mute should not match!")
-	muted3 := mute.Matches("chain2", "proposal")
+	muted3 := mute.Matches(MuteQuery{Chain: "chain2", ProposalID: "proposal"})
 	assert.False(t, muted3, "Mute should not match!")
 }
+
+func TestMuteMatchesWithWalletSpecified(t *testing.T) {
+	t.Parallel()
+
+	mute := &Mute{Chain: null.StringFrom("chain"), Wallet: null.StringFrom("alias")}
+	assert.True(t, mute.Matches(MuteQuery{Chain: "chain", WalletAddress: "address", WalletAlias: "alias"}))
+	assert.False(t, mute.Matches(MuteQuery{Chain: "chain", WalletAddress: "address"}))
+	assert.False(t, mute.Matches(MuteQuery{Chain: "chain2", WalletAddress: "address", WalletAlias: "alias"}))
+
+	addressMute := &Mute{Wallet: null.StringFrom("address")}
+	assert.True(t, addressMute.Matches(MuteQuery{Chain: "chain", WalletAddress: "address", WalletAlias: "alias"}))
+	assert.False(t, addressMute.Matches(MuteQuery{Chain: "chain"}))
+}
+
+func TestMuteMatchesWithEventSpecified(t *testing.T) {
+	t.Parallel()
+
+	mute := &Mute{Event: null.StringFrom("vote_query_error")}
+	assert.True(t, mute.Matches(MuteQuery{Chain: "chain", ProposalID: "1", Event: "vote_query_error"}))
+	assert.False(t, mute.Matches(MuteQuery{Chain: "chain", ProposalID: "1", Event: "not_voted"}))
+}
+
+func TestMuteMatchesDestination(t *testing.T) {
+	t.Parallel()
+
+	globalMute := Mute{}
+	assert.True(t, globalMute.IsGlobal())
+	assert.True(t, globalMute.MatchesDestination(""))
+	assert.True(t, globalMute.MatchesDestination("telegram:123"))
+
+	destinationMute := Mute{Destination: null.StringFrom("telegram:123")}
+	assert.False(t, destinationMute.IsGlobal())
+	assert.False(t, destinationMute.MatchesDestination(""))
+	assert.True(t, destinationMute.MatchesDestination("telegram:123"))
+	assert.False(t, destinationMute.MatchesDestination("telegram:456"))
+}
+
+func TestValidateMuteEvent(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, ValidateMuteEvent("vote_query_error"))
+	require.Error(t, ValidateMuteEvent("invalid"))
+}
+
+func TestMuteMatchesWithTitlePatternSpecified(t *testing.T) {
+	t.Parallel()
+
+	mute := &Mute{Chain: null.StringFrom("chain"), TitlePattern: null.StringFrom("(?i)airdrop")}
+	assert.True(t, mute.Matches(MuteQuery{Chain: "chain", ProposalTitle: "Free AIRDROP here"}))
+	assert.False(t, mute.Matches(MuteQuery{Chain: "chain", ProposalTitle: "Software upgrade"}))
+	assert.False(t, mute.Matches(MuteQuery{Chain: "chain2", ProposalTitle: "Free airdrop"}))
+	assert.False(t, mute.Matches(MuteQuery{Chain: "chain"}))
+
+	invalidMute := &Mute{TitlePattern: null.StringFrom("(")}
+	assert.False(t, invalidMute.Matches(MuteQuery{ProposalTitle: "("}))
+}
+
+func TestValidateMuteTitlePattern(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, ValidateMuteTitlePattern("(?i)airdrop"))
+	require.Error(t, ValidateMuteTitlePattern("("))
+}
+
+func TestParseMuteExpiry(t *testing.T) {
+	t.Parallel()
+
+	expires, text, err := ParseMuteExpiry("1h")
+	require.NoError(t, err)
+	assert.Equal(t, "for 1h0m0s", text)
+	assert.WithinDuration(t, time.Now().Add(time.Hour), expires, time.Minute)
+
+	expires2, text2, err := ParseMuteExpiry(MuteUntilVotingEnds)
+	require.NoError(t, err)
+	assert.Equal(t, "until voting ends", text2)
+	assert.True(t, expires2.IsZero())
+
+	_, _, err = ParseMuteExpiry("invalid")
+	require.Error(t, err)
+}
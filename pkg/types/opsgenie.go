@@ -0,0 +1,26 @@
+package types
+
+import (
+	"fmt"
+	"slices"
+)
+
+var OpsgeniePriorities = []string{"P1", "P2", "P3", "P4", "P5"}
+
+type OpsgenieConfig struct {
+	OpsgenieURL string `default:"https://api.opsgenie.com" toml:"url"`
+	APIKey      string `toml:"api-key"`
+	Priority    string `default:"P3"                       toml:"priority"`
+}
+
+func (c *OpsgenieConfig) Validate() error {
+	if c.APIKey == "" {
+		return nil
+	}
+
+	if !slices.Contains(OpsgeniePriorities, c.Priority) {
+		return fmt.Errorf("expected priority to be one of %v, but got %s", OpsgeniePriorities, c.Priority)
+	}
+
+	return nil
+}
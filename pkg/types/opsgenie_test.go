@@ -0,0 +1,28 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpsgenieConfigDisabled(t *testing.T) {
+	t.Parallel()
+
+	config := OpsgenieConfig{Priority: "invalid"}
+	require.NoError(t, config.Validate())
+}
+
+func TestOpsgenieConfigInvalidPriority(t *testing.T) {
+	t.Parallel()
+
+	config := OpsgenieConfig{APIKey: "key", Priority: "P6"}
+	require.ErrorContains(t, config.Validate(), "expected priority to be one of")
+}
+
+func TestOpsgenieConfigValid(t *testing.T) {
+	t.Parallel()
+
+	config := OpsgenieConfig{APIKey: "key", Priority: "P1"}
+	require.NoError(t, config.Validate())
+}
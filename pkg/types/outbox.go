@@ -0,0 +1,135 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/guregu/null/v5"
+	"github.com/robfig/cron/v3"
+)
+
+type OutboxConfig struct {
+	Enabled null.Bool `default:"true" toml:"enabled"`
+	// RetryInterval is a cron pattern for checking for entries due to be retried.
+	RetryInterval  string   `default:"* * * * *" toml:"retry-interval"`
+	MaxAttempts    int      `default:"10"        toml:"max-attempts"`
+	InitialBackoff Duration `default:"1m"        toml:"initial-backoff"`
+	MaxBackoff     Duration `default:"1h"        toml:"max-backoff"`
+}
+
+func (c *OutboxConfig) Validate() error {
+	if !c.Enabled.Bool {
+		return nil
+	}
+
+	if _, err := cron.ParseStandard(c.RetryInterval); err != nil {
+		return fmt.Errorf("error parsing retry-interval: %s", err)
+	}
+
+	if c.MaxAttempts <= 0 {
+		return fmt.Errorf("expected max-attempts to be positive, but got %d", c.MaxAttempts)
+	}
+
+	if c.InitialBackoff.Duration <= 0 {
+		return fmt.Errorf("expected initial-backoff to be positive, but got %s", c.InitialBackoff)
+	}
+
+	if c.MaxBackoff.Duration < c.InitialBackoff.Duration {
+		return fmt.Errorf(
+			"expected max-backoff to be greater than or equal to initial-backoff, but got %s and %s",
+			c.MaxBackoff,
+			c.InitialBackoff,
+		)
+	}
+
+	return nil
+}
+
+// GetBackoff returns how long to wait before the next attempt after the given
+// number of failed ones, doubling each time up to the max backoff.
+func (c *OutboxConfig) GetBackoff(attempts int) time.Duration {
+	backoff := c.InitialBackoff.Duration
+
+	for i := 1; i < attempts; i++ {
+		backoff *= 2
+		if backoff >= c.MaxBackoff.Duration {
+			return c.MaxBackoff.Duration
+		}
+	}
+
+	return backoff
+}
+
+type OutboxStatus string
+
+const (
+	OutboxStatusPending OutboxStatus = "pending"
+	// OutboxStatusDead is for entries that failed to be sent too many times
+	// and are not retried anymore.
+	OutboxStatusDead OutboxStatus = "dead"
+)
+
+// OutboxEntry is a report entry to be sent by a reporter. It is stored before sending
+// and deleted once delivered, so failed sends can be retried, even after a restart.
+type OutboxEntry struct {
+	ID       int64
+	Reporter string
+	Event    string
+	// Chain, ProposalID and Wallet are only set for entries related to them,
+	// and are only used for displaying the entry.
+	Chain      null.String
+	ProposalID null.String
+	Wallet     null.String
+	// Payload is the report entry serialized as JSON.
+	Payload string
+	// Destinations are the comma-separated chats or channels the entry failed to be sent to,
+	// for reporters sending to several of them, so only these are retried.
+	// If not set, the entry is sent to all of them.
+	Destinations  null.String
+	Status        OutboxStatus
+	Attempts      int
+	LastError     null.String
+	NextAttemptAt time.Time
+	CreatedAt     time.Time
+}
+
+func (e *OutboxEntry) IsDead() bool {
+	return e.Status == OutboxStatusDead
+}
+
+func (e *OutboxEntry) GetDestinations() []string {
+	if e.Destinations.String == "" {
+		return []string{}
+	}
+
+	return strings.Split(e.Destinations.String, ",")
+}
+
+func (e *OutboxEntry) SetDestinations(destinations []string) {
+	if len(destinations) == 0 {
+		e.Destinations = null.String{}
+		return
+	}
+
+	e.Destinations = null.StringFrom(strings.Join(destinations, ","))
+}
+
+// MarkFailed records a failed attempt, scheduling the next one or giving up
+// if there were too many of them.
+func (e *OutboxEntry) MarkFailed(err error, config OutboxConfig, now time.Time) {
+	e.Attempts++
+	e.LastError = null.StringFrom(err.Error())
+
+	if e.Attempts >= config.MaxAttempts {
+		e.Status = OutboxStatusDead
+		return
+	}
+
+	e.NextAttemptAt = now.Add(config.GetBackoff(e.Attempts))
+}
+
+func (e *OutboxEntry) MarkDead(err error) {
+	e.LastError = null.StringFrom(err.Error())
+	e.Status = OutboxStatusDead
+}
@@ -0,0 +1,89 @@
+package types
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/guregu/null/v5"
+	"github.com/stretchr/testify/require"
+)
+
+func getValidOutboxConfig() OutboxConfig {
+	return OutboxConfig{
+		Enabled:        null.BoolFrom(true),
+		RetryInterval:  "* * * * *",
+		MaxAttempts:    3,
+		InitialBackoff: Duration{Duration: time.Minute},
+		MaxBackoff:     Duration{Duration: 5 * time.Minute},
+	}
+}
+
+func TestOutboxConfigValidate(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, (&OutboxConfig{}).Validate())
+
+	config := getValidOutboxConfig()
+	require.NoError(t, config.Validate())
+
+	invalidInterval := getValidOutboxConfig()
+	invalidInterval.RetryInterval = "invalid"
+	require.Error(t, invalidInterval.Validate())
+
+	invalidAttempts := getValidOutboxConfig()
+	invalidAttempts.MaxAttempts = 0
+	require.Error(t, invalidAttempts.Validate())
+
+	invalidInitialBackoff := getValidOutboxConfig()
+	invalidInitialBackoff.InitialBackoff = Duration{}
+	require.Error(t, invalidInitialBackoff.Validate())
+
+	invalidMaxBackoff := getValidOutboxConfig()
+	invalidMaxBackoff.MaxBackoff = Duration{Duration: time.Second}
+	require.Error(t, invalidMaxBackoff.Validate())
+}
+
+func TestOutboxConfigGetBackoff(t *testing.T) {
+	t.Parallel()
+
+	config := getValidOutboxConfig()
+	require.Equal(t, time.Minute, config.GetBackoff(1))
+	require.Equal(t, 2*time.Minute, config.GetBackoff(2))
+	require.Equal(t, 4*time.Minute, config.GetBackoff(3))
+	require.Equal(t, 5*time.Minute, config.GetBackoff(4))
+	require.Equal(t, 5*time.Minute, config.GetBackoff(100))
+}
+
+func TestOutboxEntryMarkFailed(t *testing.T) {
+	t.Parallel()
+
+	config := getValidOutboxConfig()
+	now := time.Now()
+	entry := &OutboxEntry{Status: OutboxStatusPending}
+
+	entry.MarkFailed(errors.New("error 1"), config, now)
+	require.Equal(t, 1, entry.Attempts)
+	require.Equal(t, "error 1", entry.LastError.String)
+	require.Equal(t, now.Add(time.Minute), entry.NextAttemptAt)
+	require.False(t, entry.IsDead())
+
+	entry.MarkFailed(errors.New("error 2"), config, now)
+	require.Equal(t, now.Add(2*time.Minute), entry.NextAttemptAt)
+	require.False(t, entry.IsDead())
+
+	entry.MarkFailed(errors.New("error 3"), config, now)
+	require.Equal(t, 3, entry.Attempts)
+	require.Equal(t, "error 3", entry.LastError.String)
+	require.True(t, entry.IsDead())
+}
+
+func TestOutboxEntryMarkDead(t *testing.T) {
+	t.Parallel()
+
+	entry := &OutboxEntry{Status: OutboxStatusPending}
+	entry.MarkDead(errors.New("error"))
+	require.True(t, entry.IsDead())
+	require.Equal(t, "error", entry.LastError.String)
+	require.Zero(t, entry.Attempts)
+}
@@ -8,11 +8,24 @@ import (
 	"main/pkg/utils"
 )
 
+const MinDepositParamDescription = "Min deposit amount"
+
 type ChainWithVotingParams struct {
 	Chain  *Chain
 	Params []ChainParam
 }
 
+// GetMinDeposit returns the chain's min deposit, or nothing if the chain does not have it.
+func (p ChainWithVotingParams) GetMinDeposit() []Amount {
+	for _, param := range p.Params {
+		if amounts, ok := param.(AmountsParam); ok && amounts.Description == MinDepositParamDescription {
+			return amounts.Value
+		}
+	}
+
+	return []Amount{}
+}
+
 type ChainParam interface {
 	GetDescription() string
 	Serialize() string
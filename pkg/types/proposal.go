@@ -21,6 +21,11 @@ type Proposal struct {
 	Description string
 	EndTime     time.Time
 	Status      ProposalStatus
+	// Expedited is only set on chains with the v1 gov module supporting expedited proposals.
+	Expedited bool
+	// Proposer and Deposit are not stored, and are empty if the chain does not return them.
+	Proposer string
+	Deposit  []Amount
 }
 
 func (p Proposal) GetTimeLeft() string {
@@ -53,5 +58,6 @@ func (p Proposal) Equals(other Proposal) bool {
 		p.Title == other.Title &&
 		p.Description == other.Description &&
 		p.Status == other.Status &&
+		p.Expedited == other.Expedited &&
 		p.EndTime == other.EndTime
 }
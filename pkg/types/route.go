@@ -0,0 +1,118 @@
+package types
+
+import (
+	"fmt"
+	"main/pkg/utils"
+	"strings"
+)
+
+var (
+	RouteReporters = []string{
+		"telegram-reporter",
+		"discord-reporter",
+		"slack-reporter",
+		"pagerduty-reporter",
+		"opsgenie-reporter",
+		"webhook-reporter",
+	}
+	RouteEvents = []string{
+		"not_voted",
+		"voted",
+		"revoted",
+		"finished_voting",
+		"proposal_entered_voting",
+		"proposal_status_changed",
+		"suspected_spam",
+		"digest",
+		"generic_error",
+		"proposals_query_error",
+		"vote_query_error",
+	}
+)
+
+// Route limits which report entries are sent to a reporter. Empty filters match
+// everything, and a reporter without any routes receives all entries.
+type Route struct {
+	Reporter string   `toml:"reporter"`
+	Events   []string `toml:"events"`
+	Chains   []string `toml:"chains"`
+	Wallets  []string `toml:"wallets"`
+	TimeLeft Duration `toml:"time-left"`
+}
+
+type Routes []Route
+
+func (r *Route) Validate(chains Chains) error {
+	if !utils.Contains(RouteReporters, r.Reporter) {
+		return fmt.Errorf(
+			"expected reporter to be one of %s, but got '%s'",
+			strings.Join(RouteReporters, ", "),
+			r.Reporter,
+		)
+	}
+
+	for _, event := range r.Events {
+		if !utils.Contains(RouteEvents, event) {
+			return fmt.Errorf(
+				"expected event to be one of %s, but got '%s'",
+				strings.Join(RouteEvents, ", "),
+				event,
+			)
+		}
+	}
+
+	for _, chainName := range r.Chains {
+		if chains.FindByName(chainName) == nil {
+			return fmt.Errorf("chain '%s' is not configured", chainName)
+		}
+	}
+
+	for _, wallet := range r.Wallets {
+		if !chains.HasWallet(wallet) {
+			return fmt.Errorf("wallet '%s' is not configured for any chain", wallet)
+		}
+	}
+
+	if r.TimeLeft.Duration < 0 {
+		return fmt.Errorf("expected time-left to be non-negative, but got %s", r.TimeLeft)
+	}
+
+	return nil
+}
+
+func (r *Route) MatchesEvent(event string) bool {
+	return len(r.Events) == 0 || utils.Contains(r.Events, event)
+}
+
+func (r *Route) MatchesChain(chain *Chain) bool {
+	if len(r.Chains) == 0 {
+		return true
+	}
+
+	return chain != nil && utils.Contains(r.Chains, chain.Name)
+}
+
+func (r *Route) MatchesWallet(wallet *Wallet) bool {
+	if len(r.Wallets) == 0 {
+		return true
+	}
+
+	return wallet != nil &&
+		(utils.Contains(r.Wallets, wallet.Address) || utils.Contains(r.Wallets, wallet.Alias))
+}
+
+func (r Routes) Validate(chains Chains) error {
+	for index, route := range r {
+		if err := route.Validate(chains); err != nil {
+			return fmt.Errorf("error in route %d: %s", index, err)
+		}
+	}
+
+	return nil
+}
+
+func (r Routes) ForReporter(reporter string) Routes {
+	return utils.Filter(r, func(route Route) bool {
+		return route.Reporter == reporter
+	})
+}
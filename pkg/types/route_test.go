@@ -0,0 +1,79 @@
+package types
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRouteValidate(t *testing.T) {
+	t.Parallel()
+
+	chains := Chains{{Name: "chain", Wallets: []*Wallet{{Address: "wallet", Alias: "alias"}}}}
+
+	require.Error(t, (&Route{Reporter: "unknown"}).Validate(chains))
+	require.Error(t, (&Route{Reporter: "telegram-reporter", Events: []string{"unknown"}}).Validate(chains))
+	require.Error(t, (&Route{Reporter: "telegram-reporter", Chains: []string{"unknown"}}).Validate(chains))
+	require.Error(t, (&Route{Reporter: "telegram-reporter", Wallets: []string{"unknown"}}).Validate(chains))
+	require.Error(t, (&Route{
+		Reporter: "telegram-reporter",
+		TimeLeft: Duration{Duration: -time.Hour},
+	}).Validate(chains))
+	require.NoError(t, (&Route{
+		Reporter: "pagerduty-reporter",
+		Events:   []string{"not_voted"},
+		Chains:   []string{"chain"},
+		Wallets:  []string{"wallet", "alias"},
+		TimeLeft: Duration{Duration: 24 * time.Hour},
+	}).Validate(chains))
+}
+
+func TestRoutesValidate(t *testing.T) {
+	t.Parallel()
+
+	chains := Chains{{Name: "chain", Wallets: []*Wallet{{Address: "wallet"}}}}
+
+	require.NoError(t, Routes{}.Validate(chains))
+	require.NoError(t, Routes{{Reporter: "telegram-reporter"}}.Validate(chains))
+	require.Error(t, Routes{{Reporter: "telegram-reporter"}, {Reporter: "unknown"}}.Validate(chains))
+}
+
+func TestRouteMatches(t *testing.T) {
+	t.Parallel()
+
+	route := Route{
+		Events:  []string{"not_voted"},
+		Chains:  []string{"chain"},
+		Wallets: []string{"alias"},
+	}
+
+	assert.True(t, route.MatchesEvent("not_voted"))
+	assert.False(t, route.MatchesEvent("voted"))
+	assert.True(t, route.MatchesChain(&Chain{Name: "chain"}))
+	assert.False(t, route.MatchesChain(&Chain{Name: "another"}))
+	assert.False(t, route.MatchesChain(nil))
+	assert.True(t, route.MatchesWallet(&Wallet{Address: "wallet", Alias: "alias"}))
+	assert.False(t, route.MatchesWallet(&Wallet{Address: "wallet"}))
+	assert.False(t, route.MatchesWallet(nil))
+
+	emptyRoute := Route{}
+	assert.True(t, emptyRoute.MatchesEvent("voted"))
+	assert.True(t, emptyRoute.MatchesChain(nil))
+	assert.True(t, emptyRoute.MatchesWallet(nil))
+}
+
+func TestRoutesForReporter(t *testing.T) {
+	t.Parallel()
+
+	routes := Routes{
+		{Reporter: "telegram-reporter"},
+		{Reporter: "discord-reporter"},
+		{Reporter: "telegram-reporter", Events: []string{"voted"}},
+	}
+
+	assert.Len(t, routes.ForReporter("telegram-reporter"), 2)
+	assert.Len(t, routes.ForReporter("discord-reporter"), 1)
+	assert.Empty(t, routes.ForReporter("slack-reporter"))
+}
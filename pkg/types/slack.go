@@ -0,0 +1,27 @@
+package types
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/jarcoal/httpmock"
+)
+
+func SlackRequestHasText(text string) httpmock.Matcher {
+	return httpmock.NewMatcher("SlackRequestHasText",
+		func(req *http.Request) bool {
+			if err := req.ParseForm(); err != nil {
+				return false
+			}
+
+			if !req.PostForm.Has("text") {
+				return false
+			}
+
+			if requestText := req.PostForm.Get("text"); requestText != text {
+				panic(fmt.Sprintf("expected %q but got %q", text, requestText))
+			}
+
+			return true
+		})
+}
@@ -0,0 +1,50 @@
+package types
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newSlackRequest(t *testing.T, values url.Values) *http.Request {
+	t.Helper()
+
+	req, err := http.NewRequest(http.MethodPost, "https://slack.com/api/chat.postMessage", strings.NewReader(values.Encode()))
+	require.NoError(t, err)
+
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	return req
+}
+
+func TestSlackRequestHasTextNoText(t *testing.T) {
+	t.Parallel()
+
+	req := newSlackRequest(t, url.Values{"channel": []string{"channel"}})
+	matcher := SlackRequestHasText("text")
+	require.False(t, matcher.Check(req))
+}
+
+func TestSlackRequestHasTextDoesNotMatch(t *testing.T) {
+	t.Parallel()
+
+	defer func() {
+		if r := recover(); r == nil {
+			require.Fail(t, "Expected to have a panic here!")
+		}
+	}()
+
+	req := newSlackRequest(t, url.Values{"text": []string{"text"}})
+	matcher := SlackRequestHasText("wrong text")
+	matcher.Check(req)
+}
+
+func TestSlackRequestHasTextMatches(t *testing.T) {
+	t.Parallel()
+
+	req := newSlackRequest(t, url.Values{"text": []string{"text"}})
+	matcher := SlackRequestHasText("text")
+	require.True(t, matcher.Check(req))
+}
@@ -0,0 +1,152 @@
+package types
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"cosmossdk.io/math"
+	"github.com/guregu/null/v5"
+)
+
+var urlRegexp = regexp.MustCompile(`(?i)\bhttps?://[^\s<>"'()\[\]]+`)
+
+type SpamConfig struct {
+	Enabled null.Bool `default:"false" toml:"enabled"`
+	// Keywords are matched case-insensitively against the proposal title and description.
+	Keywords []string `toml:"keywords"`
+	// BlockedDomains are matched against the links in the proposal title and description,
+	// including their subdomains.
+	BlockedDomains []string `toml:"blocked-domains"`
+	Proposers      []string `toml:"proposers"`
+	// MinDepositRatio flags proposals with a deposit lower than this share of the chain's
+	// min deposit, 0 disables this check.
+	MinDepositRatio float64 `default:"0" toml:"min-deposit-ratio"`
+}
+
+func (c *SpamConfig) Validate() error {
+	if c.MinDepositRatio < 0 {
+		return fmt.Errorf("expected min-deposit-ratio to be non-negative, but got %f", c.MinDepositRatio)
+	}
+
+	for index, keyword := range c.Keywords {
+		if keyword == "" {
+			return fmt.Errorf("keyword %d is empty", index)
+		}
+	}
+
+	for index, domain := range c.BlockedDomains {
+		if domain == "" {
+			return fmt.Errorf("blocked domain %d is empty", index)
+		}
+	}
+
+	return nil
+}
+
+// SpamFlag is stored for each proposal suspected to be spam. Flagged is set to false
+// once someone un-flags the proposal, so it's not flagged again.
+type SpamFlag struct {
+	Chain      string
+	ProposalID string
+	Reasons    []string
+	Flagged    bool
+	UpdatedAt  time.Time
+	UpdatedBy  null.String
+}
+
+// GetSpamReasons returns why the proposal is suspected to be spam, or nothing if it's not.
+// The min deposit is not checked if it's not known for the chain.
+func (p Proposal) GetSpamReasons(config SpamConfig, minDeposit []Amount) []string {
+	if !config.Enabled.Bool {
+		return []string{}
+	}
+
+	reasons := make([]string, 0)
+	text := strings.ToLower(p.Title + "\n" + p.Description)
+
+	for _, keyword := range config.Keywords {
+		if strings.Contains(text, strings.ToLower(keyword)) {
+			reasons = append(reasons, fmt.Sprintf("contains blocked keyword '%s'", keyword))
+		}
+	}
+
+	for _, domain := range config.BlockedDomains {
+		if p.LinksToDomain(domain) {
+			reasons = append(reasons, fmt.Sprintf("links to blocked domain '%s'", domain))
+		}
+	}
+
+	for _, proposer := range config.Proposers {
+		if p.Proposer != "" && p.Proposer == proposer {
+			reasons = append(reasons, fmt.Sprintf("submitted by blocked proposer '%s'", proposer))
+		}
+	}
+
+	if config.MinDepositRatio > 0 {
+		if reason := p.GetLowDepositReason(config.MinDepositRatio, minDeposit); reason != "" {
+			reasons = append(reasons, reason)
+		}
+	}
+
+	return reasons
+}
+
+func (p Proposal) LinksToDomain(domain string) bool {
+	domain = strings.ToLower(domain)
+
+	for _, link := range urlRegexp.FindAllString(p.Title+"\n"+p.Description, -1) {
+		parsed, err := url.Parse(link)
+		if err != nil {
+			continue
+		}
+
+		host := strings.ToLower(parsed.Hostname())
+		if host == domain || strings.HasSuffix(host, "."+domain) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (p Proposal) GetLowDepositReason(ratio float64, minDeposit []Amount) string {
+	ratioDec, err := math.LegacyNewDecFromStr(fmt.Sprintf("%f", ratio))
+	if err != nil {
+		return ""
+	}
+
+	for _, minAmount := range minDeposit {
+		minAmountDec, err := math.LegacyNewDecFromStr(minAmount.Amount)
+		if err != nil {
+			continue
+		}
+
+		deposit := math.LegacyZeroDec()
+
+		for _, amount := range p.Deposit {
+			if amount.Denom != minAmount.Denom {
+				continue
+			}
+
+			if amountDec, err := math.LegacyNewDecFromStr(amount.Amount); err == nil {
+				deposit = deposit.Add(amountDec)
+			}
+		}
+
+		if deposit.LT(minAmountDec.Mul(ratioDec)) {
+			return fmt.Sprintf(
+				"deposit of %s%s is below %.0f%% of the min deposit of %s%s",
+				deposit.TruncateInt().String(),
+				minAmount.Denom,
+				ratio*100,
+				minAmount.Amount,
+				minAmount.Denom,
+			)
+		}
+	}
+
+	return ""
+}
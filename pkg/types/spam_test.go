@@ -0,0 +1,67 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/guregu/null/v5"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSpamConfigValidate(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, (&SpamConfig{}).Validate())
+	require.NoError(t, (&SpamConfig{
+		Keywords:        []string{"airdrop"},
+		BlockedDomains:  []string{"example.com"},
+		MinDepositRatio: 0.5,
+	}).Validate())
+	require.Error(t, (&SpamConfig{MinDepositRatio: -1}).Validate())
+	require.Error(t, (&SpamConfig{Keywords: []string{""}}).Validate())
+	require.Error(t, (&SpamConfig{BlockedDomains: []string{""}}).Validate())
+}
+
+func TestProposalGetSpamReasonsDisabled(t *testing.T) {
+	t.Parallel()
+
+	proposal := Proposal{Title: "Airdrop"}
+	require.Empty(t, proposal.GetSpamReasons(SpamConfig{Keywords: []string{"airdrop"}}, nil))
+}
+
+func TestProposalGetSpamReasons(t *testing.T) {
+	t.Parallel()
+
+	config := SpamConfig{
+		Enabled:         null.BoolFrom(true),
+		Keywords:        []string{"AIRDROP"},
+		BlockedDomains:  []string{"scam.com"},
+		Proposers:       []string{"proposer"},
+		MinDepositRatio: 0.5,
+	}
+	minDeposit := []Amount{{Denom: "uatom", Amount: "1000"}}
+
+	proposal := Proposal{
+		Title:       "Claim your airdrop",
+		Description: "Visit https://app.scam.com/claim now",
+		Proposer:    "proposer",
+		Deposit:     []Amount{{Denom: "uatom", Amount: "10"}},
+	}
+	require.Equal(t, []string{
+		"contains blocked keyword 'AIRDROP'",
+		"links to blocked domain 'scam.com'",
+		"submitted by blocked proposer 'proposer'",
+		"deposit of 10uatom is below 50% of the min deposit of 1000uatom",
+	}, proposal.GetSpamReasons(config, minDeposit))
+
+	proposal = Proposal{
+		Title:       "Upgrade",
+		Description: "See https://notscam.com and https://scam.com.example.org",
+		Proposer:    "other",
+		Deposit:     []Amount{{Denom: "uatom", Amount: "500"}},
+	}
+	require.Empty(t, proposal.GetSpamReasons(config, minDeposit))
+
+	// min deposit is unknown
+	proposal = Proposal{Title: "Upgrade"}
+	require.Empty(t, proposal.GetSpamReasons(config, []Amount{}))
+}
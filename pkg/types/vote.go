@@ -1,7 +1,10 @@
 package types
 
 import (
+	"fmt"
 	"main/pkg/utils"
+	"math"
+	"strconv"
 	"strings"
 )
 
@@ -11,6 +14,11 @@ type VoteOption struct {
 	Option string
 	Weight float64
 }
+
+func (o VoteOption) GetWeightPercent() string {
+	return strconv.FormatFloat(math.Round(o.Weight*10000)/100, 'f', -1, 64) + "%"
+}
+
 type VoteOptions []VoteOption
 
 type Vote struct {
@@ -19,12 +27,24 @@ type Vote struct {
 	Options    VoteOptions
 }
 
+func (v Vote) IsWeighted() bool {
+	return len(v.Options) > 1
+}
+
 func (v Vote) ResolveVote() string {
+	if !v.IsWeighted() {
+		optionsStrings := utils.Map(v.Options, func(v VoteOption) string {
+			return v.Option
+		})
+
+		return strings.Join(optionsStrings, ", ")
+	}
+
 	optionsStrings := utils.Map(v.Options, func(v VoteOption) string {
-		return v.Option
+		return fmt.Sprintf("%s %s", v.Option, v.GetWeightPercent())
 	})
 
-	return strings.Join(optionsStrings, ", ")
+	return strings.Join(optionsStrings, " / ")
 }
 
 func (v Vote) VotesEquals(other *Vote) bool {
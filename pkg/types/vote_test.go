@@ -12,11 +12,33 @@ func TestVoteResolveVote(t *testing.T) {
 	vote := Vote{
 		Options: []VoteOption{
 			{Option: "Yes", Weight: 1},
-			{Option: "No", Weight: 2},
 		},
 	}
 
-	assert.Equal(t, "Yes, No", vote.ResolveVote(), "Wrong value!")
+	assert.False(t, vote.IsWeighted())
+	assert.Equal(t, "Yes", vote.ResolveVote(), "Wrong value!")
+}
+
+func TestVoteResolveVoteWeighted(t *testing.T) {
+	t.Parallel()
+
+	vote := Vote{
+		Options: []VoteOption{
+			{Option: "Yes", Weight: 0.7},
+			{Option: "Abstain", Weight: 0.3},
+		},
+	}
+
+	assert.True(t, vote.IsWeighted())
+	assert.Equal(t, "Yes 70% / Abstain 30%", vote.ResolveVote(), "Wrong value!")
+}
+
+func TestVoteOptionGetWeightPercent(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "100%", VoteOption{Weight: 1}.GetWeightPercent())
+	assert.Equal(t, "33.33%", VoteOption{Weight: 0.333333333333333333}.GetWeightPercent())
+	assert.Equal(t, "0.5%", VoteOption{Weight: 0.005}.GetWeightPercent())
 }
 
 func TestVoteEqualsDifferentLength(t *testing.T) {
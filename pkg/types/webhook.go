@@ -0,0 +1,53 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+)
+
+type WebhookConfig struct {
+	URLs       []string `toml:"urls"`
+	Secret     string   `toml:"secret"`
+	Retries    int      `default:"3"  toml:"retries"`
+	RetryDelay Duration `default:"1s" toml:"retry-delay"`
+	// MaxRetryTime limits how long a request is retried for, so a slow URL
+	// does not hold up the other reporters for too long.
+	MaxRetryTime Duration `default:"30s" toml:"max-retry-time"`
+	Timeout      Duration `default:"10s" toml:"timeout"`
+}
+
+func (c *WebhookConfig) Validate() error {
+	if len(c.URLs) == 0 {
+		return nil
+	}
+
+	if c.Secret == "" {
+		return errors.New("webhook URLs are provided, but secret is not")
+	}
+
+	for index, webhookURL := range c.URLs {
+		parsedURL, err := url.ParseRequestURI(webhookURL)
+		if err != nil {
+			return fmt.Errorf("invalid URL at position %d: %s", index, err)
+		}
+
+		if parsedURL.Scheme != "http" && parsedURL.Scheme != "https" {
+			return fmt.Errorf("invalid URL at position %d: expected http or https scheme, but got %s", index, parsedURL.Scheme)
+		}
+	}
+
+	if c.Retries < 0 {
+		return fmt.Errorf("expected retries to be non-negative, but got %d", c.Retries)
+	}
+
+	if c.RetryDelay.Duration < 0 {
+		return fmt.Errorf("expected retry-delay to be non-negative, but got %s", c.RetryDelay)
+	}
+
+	if c.MaxRetryTime.Duration < 0 {
+		return fmt.Errorf("expected max-retry-time to be non-negative, but got %s", c.MaxRetryTime)
+	}
+
+	return nil
+}
@@ -0,0 +1,77 @@
+package types
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhookConfigEmptyValid(t *testing.T) {
+	t.Parallel()
+
+	config := WebhookConfig{}
+	require.NoError(t, config.Validate())
+}
+
+func TestWebhookConfigNoSecret(t *testing.T) {
+	t.Parallel()
+
+	config := WebhookConfig{URLs: []string{"https://example.com"}}
+	require.ErrorContains(t, config.Validate(), "secret is not")
+}
+
+func TestWebhookConfigInvalidURL(t *testing.T) {
+	t.Parallel()
+
+	config := WebhookConfig{URLs: []string{"not a url"}, Secret: "secret"}
+	require.ErrorContains(t, config.Validate(), "invalid URL at position 0")
+}
+
+func TestWebhookConfigInvalidScheme(t *testing.T) {
+	t.Parallel()
+
+	config := WebhookConfig{URLs: []string{"ftp://example.com"}, Secret: "secret"}
+	require.ErrorContains(t, config.Validate(), "expected http or https scheme")
+}
+
+func TestWebhookConfigInvalidRetries(t *testing.T) {
+	t.Parallel()
+
+	config := WebhookConfig{URLs: []string{"https://example.com"}, Secret: "secret", Retries: -1}
+	require.ErrorContains(t, config.Validate(), "expected retries to be non-negative")
+}
+
+func TestWebhookConfigInvalidRetryDelay(t *testing.T) {
+	t.Parallel()
+
+	config := WebhookConfig{
+		URLs:       []string{"https://example.com"},
+		Secret:     "secret",
+		RetryDelay: Duration{Duration: -time.Second},
+	}
+	require.ErrorContains(t, config.Validate(), "expected retry-delay to be non-negative")
+}
+
+func TestWebhookConfigInvalidMaxRetryTime(t *testing.T) {
+	t.Parallel()
+
+	config := WebhookConfig{
+		URLs:         []string{"https://example.com"},
+		Secret:       "secret",
+		MaxRetryTime: Duration{Duration: -time.Second},
+	}
+	require.ErrorContains(t, config.Validate(), "expected max-retry-time to be non-negative")
+}
+
+func TestWebhookConfigValid(t *testing.T) {
+	t.Parallel()
+
+	config := WebhookConfig{
+		URLs:       []string{"https://example.com", "http://localhost:8080/hook"},
+		Secret:     "secret",
+		Retries:    3,
+		RetryDelay: Duration{Duration: time.Second},
+	}
+	require.NoError(t, config.Validate())
+}